@@ -29,6 +29,8 @@ const (
 	ActivityPipelineTaskStatementUpdate ActivityType = "bb.pipeline.task.statement.update"
 	// ActivityPipelineTaskEarliestAllowedTimeUpdate is the type for updating pipeline task the earliest allowed time.
 	ActivityPipelineTaskEarliestAllowedTimeUpdate ActivityType = "bb.pipeline.task.general.earliest-allowed-time.update"
+	// ActivityPipelineTaskMigrationModified is the type for modifying the VCS migration file of the version applied by the pipeline task.
+	ActivityPipelineTaskMigrationModified ActivityType = "bb.pipeline.task.migration.modified"
 
 	// Member related.
 
@@ -150,6 +152,23 @@ type ActivityPipelineTaskEarliestAllowedTimeUpdatePayload struct {
 	TaskName  string `json:"taskName"`
 }
 
+// ActivityPipelineTaskMigrationModifiedPayload is the API message payloads for modifying the migration file of the version applied by the pipeline task.
+type ActivityPipelineTaskMigrationModifiedPayload struct {
+	TaskID             int    `json:"taskId"`
+	InstanceID         int    `json:"instanceId"`
+	MigrationHistoryID int    `json:"migrationHistoryId"`
+	SchemaVersion      string `json:"schemaVersion"`
+	FilePath           string `json:"filePath"`
+	// OldStatementChecksum is the statement checksum recorded in the migration history.
+	OldStatementChecksum string `json:"oldStatementChecksum"`
+	// NewStatementChecksum is the statement checksum of the modified file, which is recorded in the migration history once accepted.
+	NewStatementChecksum string `json:"newStatementChecksum"`
+	NewStatement         string `json:"newStatement"`
+	// Used by inbox to display info without paying the join cost
+	IssueName string `json:"issueName"`
+	TaskName  string `json:"taskName"`
+}
+
 // ActivityMemberCreatePayload is the API message payloads for creating members.
 type ActivityMemberCreatePayload struct {
 	PrincipalID    int          `json:"principalId"`
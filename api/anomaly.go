@@ -16,6 +16,8 @@ const (
 	AnomalyDatabaseBackupPolicyViolation AnomalyType = "bb.anomaly.database.backup.policy-violation"
 	// AnomalyDatabaseBackupMissing is the anomaly type for missing backups.
 	AnomalyDatabaseBackupMissing AnomalyType = "bb.anomaly.database.backup.missing"
+	// AnomalyDatabaseBackupVerificationFailure is the anomaly type for backups failing verification.
+	AnomalyDatabaseBackupVerificationFailure AnomalyType = "bb.anomaly.database.backup.verification-failure"
 	// AnomalyDatabaseConnection is the anomaly type for database connections.
 	AnomalyDatabaseConnection AnomalyType = "bb.anomaly.database.connection"
 	// AnomalyDatabaseSchemaDrift is the anomaly type for database schema drifts.
@@ -41,6 +43,8 @@ func AnomalySeverityFromType(anomalyType AnomalyType) AnomalySeverity {
 		return AnomalySeverityMedium
 	case AnomalyDatabaseBackupMissing:
 		return AnomalySeverityHigh
+	case AnomalyDatabaseBackupVerificationFailure:
+		return AnomalySeverityHigh
 	case AnomalyInstanceConnection:
 	case AnomalyInstanceMigrationSchema:
 	case AnomalyDatabaseConnection:
@@ -70,6 +74,16 @@ type AnomalyDatabaseBackupMissingPayload struct {
 	LastBackupTs int64 `json:"lastBackupTs,omitempty"`
 }
 
+// AnomalyDatabaseBackupVerificationFailurePayload is the API message for backup verification failure payloads.
+type AnomalyDatabaseBackupVerificationFailurePayload struct {
+	BackupID   int    `json:"backupId,omitempty"`
+	BackupName string `json:"backupName,omitempty"`
+	// Time of the failed verification
+	VerifiedTs int64 `json:"verifiedTs,omitempty"`
+	// Verification failure detail
+	Detail string `json:"detail,omitempty"`
+}
+
 // AnomalyDatabaseConnectionPayload is the API message for database connection payloads.
 type AnomalyDatabaseConnectionPayload struct {
 	// Connection failure detail
@@ -84,6 +98,10 @@ type AnomalyDatabaseSchemaDriftPayload struct {
 	Expect string `json:"expect,omitempty"`
 	// The actual schema dumped from the database
 	Actual string `json:"actual,omitempty"`
+	// The DDL generated by the schema differ to turn the expected schema into the actual schema, i.e. the drift
+	Diff string `json:"diff,omitempty"`
+	// The DDL generated by the schema differ to revert the database to the expected schema
+	RevertStatement string `json:"revertStatement,omitempty"`
 }
 
 // Anomaly is the API message for an anomaly.
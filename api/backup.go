@@ -42,14 +42,37 @@ type BackupStorageBackend string
 const (
 	// BackupStorageBackendLocal is the local storage backend for a backup.
 	BackupStorageBackendLocal BackupStorageBackend = "LOCAL"
-	// BackupStorageBackendS3 is the AWS S3 storage backend for a backup. Not used yet.
+	// BackupStorageBackendS3 is the AWS S3 storage backend for a backup.
 	BackupStorageBackendS3 BackupStorageBackend = "S3"
-	// BackupStorageBackendGCS is the Google Cloud Storage (GCS) storage backend for a backup. Not used yet.
+	// BackupStorageBackendGCS is the Google Cloud Storage (GCS) storage backend for a backup.
 	BackupStorageBackendGCS BackupStorageBackend = "GCS"
-	// BackupStorageBackendOSS is the AliCloud Object Storage Service (OSS) storage backend for a backup. Not used yet.
+	// BackupStorageBackendOSS is the AliCloud Object Storage Service (OSS) storage backend for a backup.
 	BackupStorageBackendOSS BackupStorageBackend = "OSS"
 )
 
+// BackupVerificationStatus is the status of a backup verification.
+type BackupVerificationStatus string
+
+const (
+	// BackupVerificationStatusPassed is the status for PASSED.
+	BackupVerificationStatusPassed BackupVerificationStatus = "PASSED"
+	// BackupVerificationStatusFailed is the status for FAILED.
+	BackupVerificationStatusFailed BackupVerificationStatus = "FAILED"
+)
+
+// BackupVerification is the result of restoring a backup into a scratch database and checking the restored database.
+type BackupVerification struct {
+	Status     BackupVerificationStatus `json:"status"`
+	VerifiedTs int64                    `json:"verifiedTs"`
+	// InstanceID is the instance where the backup is restored.
+	InstanceID int `json:"instanceId"`
+	// TableCount and RowCount are the number of tables and rows in the restored database.
+	TableCount int   `json:"tableCount"`
+	RowCount   int64 `json:"rowCount"`
+	// Detail is the reason of the failure.
+	Detail string `json:"detail,omitempty"`
+}
+
 // BinlogInfo is the binlog coordination for MySQL.
 type BinlogInfo struct {
 	FileName string `json:"fileName"`
@@ -69,6 +92,14 @@ type BackupPayload struct {
 	// It is recorded within the same transaction as the dump so that the binlog position is consistent with the dump.
 	// Please refer to https://github.com/bytebase/bytebase/blob/main/docs/design/pitr-mysql.md#full-backup for details.
 	BinlogInfo BinlogInfo `json:"binlogInfo"`
+
+	// Compression is the compression algorithm of the backup file, such as GZIP and ZSTD, and empty if the backup isn't compressed.
+	Compression string `json:"compression,omitempty"`
+	// Encrypted is true if the backup file is encrypted with the workspace backup encryption key.
+	Encrypted bool `json:"encrypted,omitempty"`
+
+	// Verification is the result of the latest verification, and nil if the backup hasn't been verified.
+	Verification *BackupVerification `json:"verification,omitempty"`
 }
 
 // Backup is the API message for a backup.
@@ -69,6 +69,15 @@ type DataSource struct {
 	// HostOverride and PortOverride are only used for read-only data sources for user's read-replica instances.
 	HostOverride string `jsonapi:"attr,hostOverride"`
 	PortOverride string `jsonapi:"attr,portOverride"`
+	// SSHHost, SSHPort and SSHUser are the bastion host to connect the database through an SSH tunnel, which isn't used if SSHHost is empty.
+	SSHHost string `jsonapi:"attr,sshHost"`
+	SSHPort string `jsonapi:"attr,sshPort"`
+	SSHUser string `jsonapi:"attr,sshUser"`
+	// Do not return the SSH password and private key to client
+	SSHPassword   string
+	SSHPrivateKey string
+	// SSHHostKey is the public key or the SHA256 fingerprint of the SSH host to verify it.
+	SSHHostKey string `jsonapi:"attr,sshHostKey"`
 }
 
 // DataSourceCreate is the API message for creating a data source.
@@ -82,15 +91,21 @@ type DataSourceCreate struct {
 	DatabaseID int `jsonapi:"attr,databaseId"`
 
 	// Domain specific fields
-	Name         string         `jsonapi:"attr,name"`
-	Type         DataSourceType `jsonapi:"attr,type"`
-	Username     string         `jsonapi:"attr,username"`
-	Password     string         `jsonapi:"attr,password"`
-	SslCa        string         `jsonapi:"attr,sslCa"`
-	SslCert      string         `jsonapi:"attr,sslCert"`
-	SslKey       string         `jsonapi:"attr,sslKey"`
-	HostOverride string         `jsonapi:"attr,hostOverride"`
-	PortOverride string         `jsonapi:"attr,portOverride"`
+	Name          string         `jsonapi:"attr,name"`
+	Type          DataSourceType `jsonapi:"attr,type"`
+	Username      string         `jsonapi:"attr,username"`
+	Password      string         `jsonapi:"attr,password"`
+	SslCa         string         `jsonapi:"attr,sslCa"`
+	SslCert       string         `jsonapi:"attr,sslCert"`
+	SslKey        string         `jsonapi:"attr,sslKey"`
+	HostOverride  string         `jsonapi:"attr,hostOverride"`
+	PortOverride  string         `jsonapi:"attr,portOverride"`
+	SSHHost       string         `jsonapi:"attr,sshHost"`
+	SSHPort       string         `jsonapi:"attr,sshPort"`
+	SSHUser       string         `jsonapi:"attr,sshUser"`
+	SSHPassword   string         `jsonapi:"attr,sshPassword"`
+	SSHPrivateKey string         `jsonapi:"attr,sshPrivateKey"`
+	SSHHostKey    string         `jsonapi:"attr,sshHostKey"`
 }
 
 // DataSourceFind is the API message for finding data sources.
@@ -130,6 +145,12 @@ type DataSourcePatch struct {
 	SslKey           *string `jsonapi:"attr,sslKey"`
 	HostOverride     *string `jsonapi:"attr,hostOverride"`
 	PortOverride     *string `jsonapi:"attr,portOverride"`
+	SSHHost          *string `jsonapi:"attr,sshHost"`
+	SSHPort          *string `jsonapi:"attr,sshPort"`
+	SSHUser          *string `jsonapi:"attr,sshUser"`
+	SSHPassword      *string `jsonapi:"attr,sshPassword"`
+	SSHPrivateKey    *string `jsonapi:"attr,sshPrivateKey"`
+	SSHHostKey       *string `jsonapi:"attr,sshHostKey"`
 }
 
 // DataSourceDelete is the API message for deleting data sources.
@@ -0,0 +1,25 @@
+package api
+
+// ERDTable is a table node in the entity-relationship diagram of a database.
+type ERDTable struct {
+	Name       string   `json:"name"`
+	ColumnList []string `json:"columnList"`
+}
+
+// ERDRelationship is a foreign key edge from the referencing table to the referenced table.
+// ToTable may not be in the table list if it belongs to another database.
+type ERDRelationship struct {
+	Name           string   `json:"name"`
+	FromTable      string   `json:"fromTable"`
+	FromColumnList []string `json:"fromColumnList"`
+	ToTable        string   `json:"toTable"`
+	ToColumnList   []string `json:"toColumnList"`
+	OnUpdate       string   `json:"onUpdate"`
+	OnDelete       string   `json:"onDelete"`
+}
+
+// ERD is the API message for the entity-relationship diagram of a database.
+type ERD struct {
+	TableList        []*ERDTable        `json:"tableList"`
+	RelationshipList []*ERDRelationship `json:"relationshipList"`
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"encoding/json"
+)
+
+// DBFunction is the API message for a database function.
+type DBFunction struct {
+	ID int `jsonapi:"primary,dbFunction"`
+
+	// Standard fields
+	CreatorID int
+	Creator   *Principal `jsonapi:"relation,creator"`
+	CreatedTs int64      `jsonapi:"attr,createdTs"`
+	UpdaterID int
+	Updater   *Principal `jsonapi:"relation,updater"`
+	UpdatedTs int64      `jsonapi:"attr,updatedTs"`
+
+	// Related fields
+	DatabaseID int
+	Database   *Database `jsonapi:"relation,database"`
+
+	// Domain specific fields
+	Name       string `jsonapi:"attr,name"`
+	Arguments  string `jsonapi:"attr,arguments"`
+	ReturnType string `jsonapi:"attr,returnType"`
+	Language   string `jsonapi:"attr,language"`
+	Definition string `jsonapi:"attr,definition"`
+	Comment    string `jsonapi:"attr,comment"`
+}
+
+// DBFunctionCreate is the API message for creating a database function.
+type DBFunctionCreate struct {
+	// Standard fields
+	// Value is assigned from the jwt subject field passed by the client.
+	CreatorID int
+	CreatedTs int64
+	UpdatedTs int64
+
+	// Related fields
+	DatabaseID int
+
+	// Domain specific fields
+	Name       string
+	Arguments  string
+	ReturnType string
+	Language   string
+	Definition string
+	Comment    string
+}
+
+// DBFunctionFind is the API message for finding functions.
+type DBFunctionFind struct {
+	ID *int
+
+	// Related fields
+	DatabaseID *int
+
+	// Domain specific fields
+}
+
+func (find *DBFunctionFind) String() string {
+	str, err := json.Marshal(*find)
+	if err != nil {
+		return err.Error()
+	}
+	return string(str)
+}
+
+// DBFunctionDelete is the API message for deleting a database function.
+type DBFunctionDelete struct {
+	ID int
+}
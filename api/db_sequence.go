@@ -0,0 +1,78 @@
+package api
+
+import (
+	"encoding/json"
+)
+
+// DBSequence is the API message for a database sequence.
+type DBSequence struct {
+	ID int `jsonapi:"primary,dbSequence"`
+
+	// Standard fields
+	CreatorID int
+	Creator   *Principal `jsonapi:"relation,creator"`
+	CreatedTs int64      `jsonapi:"attr,createdTs"`
+	UpdaterID int
+	Updater   *Principal `jsonapi:"relation,updater"`
+	UpdatedTs int64      `jsonapi:"attr,updatedTs"`
+
+	// Related fields
+	DatabaseID int
+	Database   *Database `jsonapi:"relation,database"`
+
+	// Domain specific fields
+	Name       string `jsonapi:"attr,name"`
+	DataType   string `jsonapi:"attr,dataType"`
+	StartValue int64  `jsonapi:"attr,startValue"`
+	MinValue   int64  `jsonapi:"attr,minValue"`
+	MaxValue   int64  `jsonapi:"attr,maxValue"`
+	Increment  int64  `jsonapi:"attr,increment"`
+	Cycle      bool   `jsonapi:"attr,cycle"`
+	// OwnedBy is the owning column in the format of "schema.table.column", empty if the sequence isn't owned.
+	OwnedBy string `jsonapi:"attr,ownedBy"`
+}
+
+// DBSequenceCreate is the API message for creating a database sequence.
+type DBSequenceCreate struct {
+	// Standard fields
+	// Value is assigned from the jwt subject field passed by the client.
+	CreatorID int
+	CreatedTs int64
+	UpdatedTs int64
+
+	// Related fields
+	DatabaseID int
+
+	// Domain specific fields
+	Name       string
+	DataType   string
+	StartValue int64
+	MinValue   int64
+	MaxValue   int64
+	Increment  int64
+	Cycle      bool
+	OwnedBy    string
+}
+
+// DBSequenceFind is the API message for finding sequences.
+type DBSequenceFind struct {
+	ID *int
+
+	// Related fields
+	DatabaseID *int
+
+	// Domain specific fields
+}
+
+func (find *DBSequenceFind) String() string {
+	str, err := json.Marshal(*find)
+	if err != nil {
+		return err.Error()
+	}
+	return string(str)
+}
+
+// DBSequenceDelete is the API message for deleting a database sequence.
+type DBSequenceDelete struct {
+	ID int
+}
@@ -0,0 +1,73 @@
+package api
+
+import (
+	"encoding/json"
+)
+
+// DBTrigger is the API message for a database trigger.
+type DBTrigger struct {
+	ID int `jsonapi:"primary,dbTrigger"`
+
+	// Standard fields
+	CreatorID int
+	Creator   *Principal `jsonapi:"relation,creator"`
+	CreatedTs int64      `jsonapi:"attr,createdTs"`
+	UpdaterID int
+	Updater   *Principal `jsonapi:"relation,updater"`
+	UpdatedTs int64      `jsonapi:"attr,updatedTs"`
+
+	// Related fields
+	DatabaseID int
+	Database   *Database `jsonapi:"relation,database"`
+
+	// Domain specific fields
+	Name       string `jsonapi:"attr,name"`
+	TableName  string `jsonapi:"attr,tableName"`
+	Timing     string `jsonapi:"attr,timing"`
+	Event      string `jsonapi:"attr,event"`
+	Definition string `jsonapi:"attr,definition"`
+	Comment    string `jsonapi:"attr,comment"`
+}
+
+// DBTriggerCreate is the API message for creating a database trigger.
+type DBTriggerCreate struct {
+	// Standard fields
+	// Value is assigned from the jwt subject field passed by the client.
+	CreatorID int
+	CreatedTs int64
+	UpdatedTs int64
+
+	// Related fields
+	DatabaseID int
+
+	// Domain specific fields
+	Name       string
+	TableName  string
+	Timing     string
+	Event      string
+	Definition string
+	Comment    string
+}
+
+// DBTriggerFind is the API message for finding triggers.
+type DBTriggerFind struct {
+	ID *int
+
+	// Related fields
+	DatabaseID *int
+
+	// Domain specific fields
+}
+
+func (find *DBTriggerFind) String() string {
+	str, err := json.Marshal(*find)
+	if err != nil {
+		return err.Error()
+	}
+	return string(str)
+}
+
+// DBTriggerDelete is the API message for deleting a database trigger.
+type DBTriggerDelete struct {
+	ID int
+}
@@ -56,6 +56,13 @@ type InstanceCreate struct {
 	SslCa        string  `jsonapi:"attr,sslCa"`
 	SslCert      string  `jsonapi:"attr,sslCert"`
 	SslKey       string  `jsonapi:"attr,sslKey"`
+	// The SSH tunnel of the admin data source.
+	SSHHost       string `jsonapi:"attr,sshHost"`
+	SSHPort       string `jsonapi:"attr,sshPort"`
+	SSHUser       string `jsonapi:"attr,sshUser"`
+	SSHPassword   string `jsonapi:"attr,sshPassword"`
+	SSHPrivateKey string `jsonapi:"attr,sshPrivateKey"`
+	SSHHostKey    string `jsonapi:"attr,sshHostKey"`
 }
 
 // InstanceFind is the API message for finding instances.
@@ -155,3 +162,14 @@ type MigrationHistory struct {
 	IssueID string `jsonapi:"attr,issueId"`
 	Payload string `jsonapi:"attr,payload"`
 }
+
+// MigrationHistoryStatementChecksumAccept is the API message for accepting the modified statement of an applied migration.
+type MigrationHistoryStatementChecksumAccept struct {
+	// Standard fields
+	// Value is assigned from the jwt subject field passed by the client.
+	UpdaterID int
+
+	// Domain specific fields
+	// ActivityID is the ID of the activity flagging the modified migration file, whose statement checksum is accepted.
+	ActivityID int `jsonapi:"attr,activityId"`
+}
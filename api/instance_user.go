@@ -49,3 +49,80 @@ func (find *InstanceUserFind) String() string {
 type InstanceUserDelete struct {
 	ID int
 }
+
+// InstanceUserGrantStatus is the status of an instance user grant.
+type InstanceUserGrantStatus string
+
+const (
+	// InstanceUserGrantActive is the status for the grants whose privileges are in effect.
+	InstanceUserGrantActive InstanceUserGrantStatus = "ACTIVE"
+	// InstanceUserGrantRevoked is the status for the grants whose privileges have been revoked.
+	InstanceUserGrantRevoked InstanceUserGrantStatus = "REVOKED"
+)
+
+// InstanceUserGrant is the API message for the database privileges granted to an instance user by a database grant issue.
+type InstanceUserGrant struct {
+	ID int `jsonapi:"primary,instanceUserGrant"`
+
+	// Standard fields
+	CreatorID int
+	CreatedTs int64 `jsonapi:"attr,createdTs"`
+	UpdaterID int
+	UpdatedTs int64 `jsonapi:"attr,updatedTs"`
+
+	// Related fields
+	InstanceID int `jsonapi:"attr,instanceId"`
+	IssueID    int `jsonapi:"attr,issueId"`
+
+	// Domain specific fields
+	DatabaseName string `jsonapi:"attr,databaseName"`
+	UserName     string `jsonapi:"attr,userName"`
+	// Host is the host of the MySQL and TiDB user, it's empty for the other engines.
+	Host          string                  `jsonapi:"attr,host"`
+	PrivilegeList []string                `jsonapi:"attr,privilegeList"`
+	Status        InstanceUserGrantStatus `jsonapi:"attr,status"`
+	// ExpireTs is the time to revoke the privileges in Unix timestamp in seconds, the privileges never expire if it's 0.
+	ExpireTs int64 `jsonapi:"attr,expireTs"`
+}
+
+// InstanceUserGrantCreate is the API message for creating an instance user grant.
+type InstanceUserGrantCreate struct {
+	// Standard fields
+	CreatorID int
+
+	// Related fields
+	InstanceID int
+	IssueID    int
+
+	// Domain specific fields
+	DatabaseName  string
+	UserName      string
+	Host          string
+	PrivilegeList []string
+	ExpireTs      int64
+}
+
+// InstanceUserGrantFind is the API message for finding instance user grants.
+type InstanceUserGrantFind struct {
+	ID *int
+
+	// Related fields
+	InstanceID *int
+	IssueID    *int
+
+	// Domain specific fields
+	Status *InstanceUserGrantStatus
+	// ExpireTsBefore finds the grants expiring at or before the time, excluding the grants which never expire.
+	ExpireTsBefore *int64
+}
+
+// InstanceUserGrantPatch is the API message for patching an instance user grant.
+type InstanceUserGrantPatch struct {
+	ID int
+
+	// Standard fields
+	UpdaterID int
+
+	// Domain specific fields
+	Status *InstanceUserGrantStatus
+}
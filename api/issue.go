@@ -37,6 +37,8 @@ const (
 	IssueDataSourceRequest IssueType = "bb.issue.data-source.request"
 	// IssueDatabaseRestorePITR is the issue type for performing a Point-in-time Recovery.
 	IssueDatabaseRestorePITR IssueType = "bb.issue.database.restore.pitr"
+	// IssueDatabaseRollback is the issue type for rolling back databases to a migration version with the undo statements.
+	IssueDatabaseRollback IssueType = "bb.issue.database.rollback"
 )
 
 // IssueFieldID is the field ID for an issue.
@@ -144,6 +146,31 @@ type CreateDatabaseContext struct {
 	Labels string `jsonapi:"attr,labels,omitempty"`
 }
 
+// DatabaseGrantContext is the issue create context for granting database privileges to a database user.
+type DatabaseGrantContext struct {
+	// DatabaseID is the ID of the database to grant the privileges on.
+	DatabaseID int `json:"databaseId"`
+	// UserName is the name of the database user, or the role for Postgres. The user is created if it doesn't exist.
+	UserName string `json:"userName"`
+	// Host is the host of the user. This is only applicable to MySQL and TiDB, and defaults to "%".
+	Host string `json:"host"`
+	// Password is the password of the user. It's required to create the user, and must be empty for an existing user.
+	Password string `json:"password"`
+	// PrivilegeList is the privileges to grant, such as SELECT and INSERT.
+	PrivilegeList []string `json:"privilegeList"`
+	// ExpireTs is the time to revoke the privileges in Unix timestamp in seconds, the privileges never expire if it's 0.
+	ExpireTs int64 `json:"expireTs"`
+}
+
+// DatabaseRollbackContext is the issue create context for rolling back a database to a migration version.
+// The migrations applied after the version are reversed by their undo statements, the most recent first.
+type DatabaseRollbackContext struct {
+	// DatabaseID is the ID of the database to roll back.
+	DatabaseID int `json:"databaseId"`
+	// Version is the migration version to roll back to.
+	Version string `json:"version"`
+}
+
 // MigrationDetail is the detail for database migration such as Migrate, Data.
 type MigrationDetail struct {
 	// MigrationType is the type of a migration.
@@ -161,6 +188,9 @@ type MigrationDetail struct {
 	// SchemaVersion is parsed from VCS file name.
 	// It is automatically generated in the UI workflow.
 	SchemaVersion string `json:"schemaVersion"`
+	// UndoStatement is the statement to reverse the migration, which is only applicable to Migrate and Data.
+	// It's read from the paired undo file of the same version in the VCS workflow.
+	UndoStatement string `json:"undoStatement"`
 }
 
 // MigrationContext is the issue create context for database migration such as Migrate, Data.
@@ -35,6 +35,8 @@ const (
 	PolicyTypeSQLReview PolicyType = "bb.policy.sql-review"
 	// PolicyTypeEnvironmentTier is the tier of an environment.
 	PolicyTypeEnvironmentTier PolicyType = "bb.policy.environment-tier"
+	// PolicyTypeSQLQueryTimeout is the SQL editor query timeout policy type.
+	PolicyTypeSQLQueryTimeout PolicyType = "bb.policy.sql-query-timeout"
 
 	// PipelineApprovalValueManualNever means the pipeline will automatically be approved without user intervention.
 	PipelineApprovalValueManualNever PipelineApprovalValue = "MANUAL_APPROVAL_NEVER"
@@ -66,6 +68,7 @@ var (
 		PolicyTypeBackupPlan:       true,
 		PolicyTypeSQLReview:        true,
 		PolicyTypeEnvironmentTier:  true,
+		PolicyTypeSQLQueryTimeout:  true,
 	}
 )
 
@@ -229,6 +232,29 @@ func UnmarshalEnvironmentTierPolicy(payload string) (*EnvironmentTierPolicy, err
 	return &p, nil
 }
 
+// SQLQueryTimeoutPolicy is the policy configuration for the SQL editor query timeout.
+type SQLQueryTimeoutPolicy struct {
+	// TimeoutSec is the max seconds a SQL editor query can run for, queries are not timed out if it's 0.
+	TimeoutSec int `json:"timeoutSec"`
+}
+
+func (p *SQLQueryTimeoutPolicy) String() (string, error) {
+	s, err := json.Marshal(p)
+	if err != nil {
+		return "", err
+	}
+	return string(s), nil
+}
+
+// UnmarshalSQLQueryTimeoutPolicy will unmarshal payload to SQL query timeout policy.
+func UnmarshalSQLQueryTimeoutPolicy(payload string) (*SQLQueryTimeoutPolicy, error) {
+	var p SQLQueryTimeoutPolicy
+	if err := json.Unmarshal([]byte(payload), &p); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal SQL query timeout policy %q", payload)
+	}
+	return &p, nil
+}
+
 // ValidatePolicy will validate the policy type and payload values.
 func ValidatePolicy(pType PolicyType, payload string) error {
 	if !PolicyTypes[pType] {
@@ -283,6 +309,14 @@ func ValidatePolicy(pType PolicyType, payload string) error {
 		if p.EnvironmentTier != EnvironmentTierValueProtected && p.EnvironmentTier != EnvironmentTierValueUnprotected {
 			return errors.Errorf("invalid environment tier value %q", p.EnvironmentTier)
 		}
+	case PolicyTypeSQLQueryTimeout:
+		p, err := UnmarshalSQLQueryTimeoutPolicy(payload)
+		if err != nil {
+			return err
+		}
+		if p.TimeoutSec < 0 {
+			return errors.Errorf("invalid SQL query timeout %d, it must not be negative", p.TimeoutSec)
+		}
 	}
 	return nil
 }
@@ -309,6 +343,11 @@ func GetDefaultPolicy(pType PolicyType) (string, error) {
 			EnvironmentTier: EnvironmentTierValueUnprotected,
 		}
 		return policy.String()
+	case PolicyTypeSQLQueryTimeout:
+		policy := SQLQueryTimeoutPolicy{
+			TimeoutSec: 0,
+		}
+		return policy.String()
 	}
 	return "", nil
 }
@@ -20,6 +20,8 @@ const (
 	SettingEnterpriseTrial SettingName = "bb.enterprise.trial"
 	// SettingAppIM is the setting name for IM applications.
 	SettingAppIM SettingName = "bb.app.im"
+	// SettingBackupEncryptionKey is the setting name for the key to encrypt backups.
+	SettingBackupEncryptionKey SettingName = "bb.backup.encryption-key"
 )
 
 // IMType is the type of IM.
@@ -19,6 +19,12 @@ type ConnectionInfo struct {
 	SslCa            *string `jsonapi:"attr,sslCa"`
 	SslCert          *string `jsonapi:"attr,sslCert"`
 	SslKey           *string `jsonapi:"attr,sslKey"`
+	SSHHost          string  `jsonapi:"attr,sshHost"`
+	SSHPort          string  `jsonapi:"attr,sshPort"`
+	SSHUser          string  `jsonapi:"attr,sshUser"`
+	SSHPassword      string  `jsonapi:"attr,sshPassword"`
+	SSHPrivateKey    string  `jsonapi:"attr,sshPrivateKey"`
+	SSHHostKey       string  `jsonapi:"attr,sshHostKey"`
 }
 
 // SQLSyncSchema is the API message for sync schemas.
@@ -45,12 +51,48 @@ type SQLExecute struct {
 type SQLResultSet struct {
 	// A list of rows marshalled into a JSON.
 	Data string `jsonapi:"attr,data"`
+	// Truncated is true if the rows exceed the row limit or the size limit and only part of them are returned.
+	Truncated bool `jsonapi:"attr,truncated"`
 	// SQL operation may fail for connection issue and there is no proper http status code for it, so we return error in the response body.
 	Error string `jsonapi:"attr,error"`
 	// A list of SQL check advice.
 	AdviceList []advisor.Advice `jsonapi:"attr,adviceList"`
 }
 
+// SQLExplain is the API message for explaining a SQL statement.
+type SQLExplain struct {
+	InstanceID int `jsonapi:"attr,instanceId"`
+	// For engines such as MySQL, databaseName can be empty.
+	DatabaseName string `jsonapi:"attr,databaseName"`
+	Statement    string `jsonapi:"attr,statement"`
+}
+
+// SQLExplainResult is the API message for the query plan of a SQL statement.
+type SQLExplainResult struct {
+	// The query plan tree marshalled into a JSON, see db.ExplainNode.
+	Plan string `jsonapi:"attr,plan"`
+	// Explaining may fail for connection issue and there is no proper http status code for it, so we return error in the response body.
+	Error string `jsonapi:"attr,error"`
+	// A list of advice on the query plan, such as full table scans.
+	AdviceList []advisor.Advice `jsonapi:"attr,adviceList"`
+}
+
+// SQLQuery is the API message for a running SQL editor query.
+type SQLQuery struct {
+	ID int `jsonapi:"primary,sqlQuery"`
+
+	// Standard fields
+	CreatorID int
+	CreatedTs int64 `jsonapi:"attr,createdTs"`
+
+	// Domain specific fields
+	InstanceID   int    `jsonapi:"attr,instanceId"`
+	DatabaseName string `jsonapi:"attr,databaseName"`
+	Statement    string `jsonapi:"attr,statement"`
+	// TimeoutTs is the time when the query times out, it's 0 if the query doesn't time out.
+	TimeoutTs int64 `jsonapi:"attr,timeoutTs"`
+}
+
 // SQLService is the service for SQL.
 type SQLService interface {
 	Ping(ctx context.Context, config *ConnectionInfo) (*SQLResultSet, error)
@@ -21,18 +21,23 @@ type Table struct {
 	Database   *Database `jsonapi:"relation,database"`
 
 	// Domain specific fields
-	Name          string    `jsonapi:"attr,name"`
-	Type          string    `jsonapi:"attr,type"`
-	Engine        string    `jsonapi:"attr,engine"`
-	Collation     string    `jsonapi:"attr,collation"`
-	RowCount      int64     `jsonapi:"attr,rowCount"`
-	DataSize      int64     `jsonapi:"attr,dataSize"`
-	IndexSize     int64     `jsonapi:"attr,indexSize"`
-	DataFree      int64     `jsonapi:"attr,dataFree"`
-	CreateOptions string    `jsonapi:"attr,createOptions"`
-	Comment       string    `jsonapi:"attr,comment"`
-	ColumnList    []*Column `jsonapi:"attr,columnList"`
-	IndexList     []*Index  `jsonapi:"attr,indexList"`
+	Name           string        `jsonapi:"attr,name"`
+	Type           string        `jsonapi:"attr,type"`
+	Engine         string        `jsonapi:"attr,engine"`
+	Collation      string        `jsonapi:"attr,collation"`
+	RowCount       int64         `jsonapi:"attr,rowCount"`
+	DataSize       int64         `jsonapi:"attr,dataSize"`
+	IndexSize      int64         `jsonapi:"attr,indexSize"`
+	DataFree       int64         `jsonapi:"attr,dataFree"`
+	CreateOptions  string        `jsonapi:"attr,createOptions"`
+	Comment        string        `jsonapi:"attr,comment"`
+	ColumnList     []*Column     `jsonapi:"attr,columnList"`
+	IndexList      []*Index      `jsonapi:"attr,indexList"`
+	ForeignKeyList []*ForeignKey `jsonapi:"attr,foreignKeyList"`
+	// PartitionKey, PartitionParent and PartitionBound are only set for partitioned tables and partitions.
+	PartitionKey    string `jsonapi:"attr,partitionKey"`
+	PartitionParent string `jsonapi:"attr,partitionParent"`
+	PartitionBound  string `jsonapi:"attr,partitionBound"`
 }
 
 // TableCreate is the API message for creating a table.
@@ -47,16 +52,19 @@ type TableCreate struct {
 	DatabaseID int
 
 	// Domain specific fields
-	Name          string
-	Type          string
-	Engine        string
-	Collation     string
-	RowCount      int64
-	DataSize      int64
-	IndexSize     int64
-	DataFree      int64
-	CreateOptions string
-	Comment       string
+	Name            string
+	Type            string
+	Engine          string
+	Collation       string
+	RowCount        int64
+	DataSize        int64
+	IndexSize       int64
+	DataFree        int64
+	CreateOptions   string
+	Comment         string
+	PartitionKey    string
+	PartitionParent string
+	PartitionBound  string
 }
 
 // TableFind is the API message for finding tables.
@@ -86,15 +94,18 @@ type TablePatch struct {
 	UpdaterID int
 
 	// Domain specific fields
-	Type          string
-	Engine        string
-	Collation     string
-	RowCount      int64
-	DataSize      int64
-	IndexSize     int64
-	DataFree      int64
-	CreateOptions string
-	Comment       string
+	Type            string
+	Engine          string
+	Collation       string
+	RowCount        int64
+	DataSize        int64
+	IndexSize       int64
+	DataFree        int64
+	CreateOptions   string
+	Comment         string
+	PartitionKey    string
+	PartitionParent string
+	PartitionBound  string
 }
 
 // TableDelete is the API message for deleting a table.
@@ -33,6 +33,8 @@ const (
 	TaskGeneral TaskType = "bb.task.general"
 	// TaskDatabaseCreate is the task type for creating databases.
 	TaskDatabaseCreate TaskType = "bb.task.database.create"
+	// TaskDatabaseGrant is the task type for granting database privileges to a database user.
+	TaskDatabaseGrant TaskType = "bb.task.database.grant"
 	// TaskDatabaseSchemaBaseline is the task type for database schema baseline.
 	TaskDatabaseSchemaBaseline TaskType = "bb.task.database.schema.baseline"
 	// TaskDatabaseSchemaUpdate is the task type for updating database schemas.
@@ -45,6 +47,8 @@ const (
 	TaskDatabaseSchemaUpdateGhostCutover TaskType = "bb.task.database.schema.update.ghost.cutover"
 	// TaskDatabaseDataUpdate is the task type for updating database data.
 	TaskDatabaseDataUpdate TaskType = "bb.task.database.data.update"
+	// TaskDatabaseUndo is the task type for reversing an applied migration with its undo statement.
+	TaskDatabaseUndo TaskType = "bb.task.database.undo"
 	// TaskDatabaseBackup is the task type for creating database backups.
 	TaskDatabaseBackup TaskType = "bb.task.database.backup"
 	// TaskDatabaseRestorePITRRestore is the task type for restoring databases using PITR.
@@ -96,6 +100,14 @@ type TaskDatabaseCreatePayload struct {
 	SchemaVersion string `json:"schemaVersion,omitempty"`
 }
 
+// TaskDatabaseGrantPayload is the task payload for granting database privileges.
+type TaskDatabaseGrantPayload struct {
+	UserName      string   `json:"userName,omitempty"`
+	Host          string   `json:"host,omitempty"`
+	PrivilegeList []string `json:"privilegeList,omitempty"`
+	ExpireTs      int64    `json:"expireTs,omitempty"`
+}
+
 // TaskDatabaseSchemaBaselinePayload is the task payload for database schema baseline.
 type TaskDatabaseSchemaBaselinePayload struct {
 	Statement     string `json:"statement,omitempty"`
@@ -109,6 +121,8 @@ type TaskDatabaseSchemaUpdatePayload struct {
 	Statement     string         `json:"statement,omitempty"`
 	SchemaVersion string         `json:"schemaVersion,omitempty"`
 	VCSPushEvent  *vcs.PushEvent `json:"pushEvent,omitempty"`
+	// UndoStatement is recorded in the migration history to reverse the migration.
+	UndoStatement string `json:"undoStatement,omitempty"`
 }
 
 // TaskDatabaseSchemaUpdateSDLPayload is the task payload for database schema update (SDL).
@@ -137,6 +151,8 @@ type TaskDatabaseDataUpdatePayload struct {
 	Statement     string         `json:"statement,omitempty"`
 	SchemaVersion string         `json:"schemaVersion,omitempty"`
 	VCSPushEvent  *vcs.PushEvent `json:"pushEvent,omitempty"`
+	// UndoStatement is recorded in the migration history to reverse the migration.
+	UndoStatement string `json:"undoStatement,omitempty"`
 
 	// MySQL rollback SQL related.
 
@@ -151,6 +167,16 @@ type TaskDatabaseDataUpdatePayload struct {
 	BinlogPosEnd    int64  `json:"binlogPosEnd,omitempty"`
 }
 
+// TaskDatabaseUndoPayload is the task payload for reversing an applied migration.
+type TaskDatabaseUndoPayload struct {
+	// Statement is the undo statement of the migration.
+	Statement string `json:"statement,omitempty"`
+	// SchemaVersion is the version of the UNDO migration.
+	SchemaVersion string `json:"schemaVersion,omitempty"`
+	// UndoVersion is the version of the migration to reverse.
+	UndoVersion string `json:"undoVersion,omitempty"`
+}
+
 // TaskDatabaseBackupPayload is the task payload for database backup.
 type TaskDatabaseBackupPayload struct {
 	BackupID int `json:"backupId,omitempty"`
@@ -208,6 +234,12 @@ type Progress struct {
 	Payload string `json:"payload"`
 }
 
+// ProgressStatementPayload is the progress payload of the tasks executing the statements one by one.
+type ProgressStatementPayload struct {
+	// CurrentStatement is the statement being executed
+	CurrentStatement string `json:"currentStatement,omitempty"`
+}
+
 // TaskCreate is the API message for creating a task.
 type TaskCreate struct {
 	// Standard fields
@@ -235,6 +267,8 @@ type TaskCreate struct {
 	Labels            string `jsonapi:"attr,labels"`
 	BackupID          *int   `jsonapi:"attr,backupId"`
 	VCSPushEvent      *vcs.PushEvent
+	// Secret is stored apart from the payload and is never returned by the API, e.g. the password of the database grant task.
+	Secret string
 }
 
 // TaskFind is the API message for finding tasks.
@@ -249,6 +283,8 @@ type TaskFind struct {
 	// Domain specific fields
 	StatusList *[]TaskStatus
 	TypeList   *[]TaskType
+	// SchemaVersion is the schema version in the payload.
+	SchemaVersion *string
 	// Payload contains JSONB expressions
 	// Ref: https://www.postgresql.org/docs/current/functions-json.html
 	Payload string
@@ -69,6 +69,8 @@ const (
 	TaskCheckDatabaseStatementAdvise TaskCheckType = "bb.task-check.database.statement.advise"
 	// TaskCheckDatabaseStatementType is the task check type for statement type.
 	TaskCheckDatabaseStatementType TaskCheckType = "bb.task-check.database.statement.type"
+	// TaskCheckDatabaseStatementDryRun is the task check type for running the statement in a transaction which is always rolled back.
+	TaskCheckDatabaseStatementDryRun TaskCheckType = "bb.task-check.database.statement.dry-run"
 	// TaskCheckDatabaseConnect is the task check type for database connection.
 	TaskCheckDatabaseConnect TaskCheckType = "bb.task-check.database.connect"
 	// TaskCheckInstanceMigrationSchema is the task check type for migrating schemas.
@@ -107,6 +109,12 @@ type TaskCheckDatabaseStatementTypePayload struct {
 	Collation string `json:"collation,omitempty"`
 }
 
+// TaskCheckDatabaseStatementDryRunPayload is the task check payload for statement dry run.
+type TaskCheckDatabaseStatementDryRunPayload struct {
+	Statement string  `json:"statement,omitempty"`
+	DbType    db.Type `json:"dbType,omitempty"`
+}
+
 // Namespace is the namespace for task check result.
 type Namespace string
 
@@ -243,3 +251,9 @@ func IsStatementTypeCheckSupported(dbType db.Type) bool {
 		return false
 	}
 }
+
+// IsStatementDryRunSupported checks the engine type if statement dry run supports it.
+// Only PostgreSQL is supported because it has transactional DDL, so that the statement can be rolled back.
+func IsStatementDryRunSupported(dbType db.Type) bool {
+	return dbType == db.Postgres
+}
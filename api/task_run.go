@@ -27,6 +27,16 @@ type TaskRunResultPayload struct {
 	Detail      string `json:"detail,omitempty"`
 	MigrationID int64  `json:"migrationId,omitempty"`
 	Version     string `json:"version,omitempty"`
+	// StatementResultList is the execution result of each statement for the tasks executing the statements one by one.
+	StatementResultList []TaskRunStatementResult `json:"statementResultList,omitempty"`
+}
+
+// TaskRunStatementResult is the execution result of a single statement in a task run.
+type TaskRunStatementResult struct {
+	// Statement may be truncated if it's too long.
+	Statement string `json:"statement"`
+	// DurationMs is the execution duration of the statement in milliseconds.
+	DurationMs int64 `json:"durationMs"`
 }
 
 // TaskRun is the API message for a task run.
@@ -24,6 +24,8 @@ type View struct {
 	Name       string `jsonapi:"attr,name"`
 	Definition string `jsonapi:"attr,definition"`
 	Comment    string `jsonapi:"attr,comment"`
+	// Materialized is only supported for Postgres.
+	Materialized bool `jsonapi:"attr,materialized"`
 }
 
 // ViewCreate is the API message for creating a view.
@@ -38,9 +40,10 @@ type ViewCreate struct {
 	DatabaseID int
 
 	// Domain specific fields
-	Name       string
-	Definition string
-	Comment    string
+	Name         string
+	Definition   string
+	Comment      string
+	Materialized bool
 }
 
 // ViewFind is the API message for finding views.
@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/xo/dburl"
+
+	"github.com/bytebase/bytebase/plugin/parser"
+	"github.com/bytebase/bytebase/plugin/parser/differ"
+
+	// Register the mysql differ.
+	_ "github.com/bytebase/bytebase/plugin/parser/differ/mysql"
+	// Register the pg differ.
+	_ "github.com/bytebase/bytebase/plugin/parser/differ/pg"
+	// Register the pg parser engine used by the pg differ.
+	_ "github.com/bytebase/bytebase/plugin/parser/engine/pg"
+)
+
+const (
+	// diffExitCode is the exit code of bb diff with --exit-code if the schemas differ.
+	diffExitCode = 2
+)
+
+func newDiffCmd() *cobra.Command {
+	var (
+		sourceDSN  string
+		sourceFile string
+		targetDSN  string
+		targetFile string
+		engine     string
+		file       string
+		exitCode   bool
+	)
+	diffCmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Generates the migration DDL from the source schema to the target schema.",
+		Long: `Generates the migration DDL from the source schema to the target schema.
+
+The source and the target can be either a database specified by the DSN or a schema file.
+The schemas of the databases are dumped through the database drivers.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := context.Background()
+			source, err := newDiffSchema(sourceDSN, sourceFile)
+			if err != nil {
+				return errors.Wrap(err, "invalid source")
+			}
+			target, err := newDiffSchema(targetDSN, targetFile)
+			if err != nil {
+				return errors.Wrap(err, "invalid target")
+			}
+			engineType, err := getDiffEngineType(engine, source, target)
+			if err != nil {
+				return err
+			}
+
+			sourceSchema, err := source.load(ctx)
+			if err != nil {
+				return err
+			}
+			targetSchema, err := target.load(ctx)
+			if err != nil {
+				return err
+			}
+			diff, err := differ.SchemaDiff(engineType, sourceSchema, targetSchema)
+			if err != nil {
+				return errors.Wrap(err, "failed to compute the diff between the source and target schemas")
+			}
+
+			out := cmd.OutOrStdout()
+			if file != "" {
+				f, err := os.Create(file)
+				if err != nil {
+					return errors.Wrapf(err, "failed to create file %s", file)
+				}
+				defer f.Close()
+				out = f
+			}
+			if _, err := io.WriteString(out, diff); err != nil {
+				return errors.Wrap(err, "failed to write the diff")
+			}
+
+			if exitCode && diff != "" {
+				// The schema drift isn't a failure of the command, so we don't print the error and the usage.
+				cmd.SilenceErrors = true
+				cmd.SilenceUsage = true
+				return &ExitError{Code: diffExitCode, Err: errors.New("the source and target schemas differ")}
+			}
+			return nil
+		},
+	}
+
+	diffCmd.Flags().StringVar(&sourceDSN, "source-dsn", "", "Connection string of the source database, see --dsn of bb dump for the format.")
+	diffCmd.Flags().StringVar(&sourceFile, "source-file", "", "Schema file of the source.")
+	diffCmd.Flags().StringVar(&targetDSN, "target-dsn", "", "Connection string of the target database, see --dsn of bb dump for the format.")
+	diffCmd.Flags().StringVar(&targetFile, "target-file", "", "Schema file of the target.")
+	diffCmd.Flags().StringVar(&engine, "engine", "", "Database engine of the schema files, mysql or postgres. Required if both the source and target are files.")
+	diffCmd.Flags().StringVar(&file, "file", "", "File to store the migration DDL. Output to stdout if unspecified.")
+	diffCmd.Flags().BoolVar(&exitCode, "exit-code", false, fmt.Sprintf("Exit with %d if the schemas differ and 0 if they are the same. Errors always exit with 1.", diffExitCode))
+	return diffCmd
+}
+
+// diffSchema is the source or target schema of bb diff, either a database or a schema file.
+type diffSchema struct {
+	// u is nil if the schema is read from the file.
+	u    *dburl.URL
+	file string
+}
+
+func newDiffSchema(dsn, file string) (*diffSchema, error) {
+	if (dsn == "") == (file == "") {
+		return nil, errors.New("exactly one of the dsn and the file must be specified")
+	}
+	if file != "" {
+		return &diffSchema{file: file}, nil
+	}
+	u, err := dburl.Parse(dsn)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse dsn")
+	}
+	return &diffSchema{u: u}, nil
+}
+
+// load loads the schema by dumping the database or reading the file.
+func (s *diffSchema) load(ctx context.Context) (string, error) {
+	if s.u == nil {
+		content, err := os.ReadFile(s.file)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to read schema file %q", s.file)
+		}
+		return string(content), nil
+	}
+
+	driver, err := open(ctx, s.u)
+	if err != nil {
+		return "", err
+	}
+	defer driver.Close(ctx)
+	var buf bytes.Buffer
+	if _, err := driver.Dump(ctx, getDatabase(s.u), &buf, true /* schemaOnly */); err != nil {
+		return "", errors.Wrapf(err, "failed to dump the schema of %s", s.u.Redacted())
+	}
+	return buf.String(), nil
+}
+
+// getDiffEngineType gets the engine from the DSNs, or the engine flag if both the source and target are files.
+func getDiffEngineType(engine string, schemaList ...*diffSchema) (parser.EngineType, error) {
+	var engineType parser.EngineType
+	if engine != "" {
+		t, err := getEngineTypeByDriver(engine)
+		if err != nil {
+			return "", err
+		}
+		engineType = t
+	}
+	for _, s := range schemaList {
+		if s.u == nil {
+			continue
+		}
+		t, err := getEngineTypeByDriver(s.u.Driver)
+		if err != nil {
+			return "", err
+		}
+		if engineType != "" && engineType != t {
+			return "", errors.Errorf("the source and target must be the same database engine, but got %s and %s", engineType, t)
+		}
+		engineType = t
+	}
+	if engineType == "" {
+		return "", errors.New("--engine is required if both the source and target are schema files")
+	}
+	return engineType, nil
+}
+
+func getEngineTypeByDriver(driver string) (parser.EngineType, error) {
+	// dburl.Parse() parses 'pg', 'postgresql' and 'pgsql' to 'postgres'.
+	switch driver {
+	case "mysql":
+		return parser.MySQL, nil
+	case "postgres", "postgresql", "pg":
+		return parser.Postgres, nil
+	}
+	return "", errors.Errorf("database type %q not supported; supported types: mysql, postgres", driver)
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/plugin/parser"
+)
+
+func TestGetDiffEngineType(t *testing.T) {
+	newSchema := func(dsn, file string) *diffSchema {
+		s, err := newDiffSchema(dsn, file)
+		require.NoError(t, err)
+		return s
+	}
+	mysqlSchema := newSchema("mysql://root@localhost:3306/db", "")
+	pgSchema := newSchema("pgsql://postgres@localhost:5432/db", "")
+	fileSchema := newSchema("", "schema.sql")
+
+	tests := []struct {
+		name       string
+		engine     string
+		schemaList []*diffSchema
+		want       parser.EngineType
+		wantErr    bool
+	}{
+		{name: "same engine", schemaList: []*diffSchema{mysqlSchema, mysqlSchema}, want: parser.MySQL},
+		{name: "engine from the dsn", schemaList: []*diffSchema{fileSchema, pgSchema}, want: parser.Postgres},
+		{name: "engine flag", engine: "postgresql", schemaList: []*diffSchema{fileSchema, fileSchema}, want: parser.Postgres},
+		{name: "engine flag matching the dsn", engine: "mysql", schemaList: []*diffSchema{mysqlSchema, fileSchema}, want: parser.MySQL},
+		{name: "engine mismatch", schemaList: []*diffSchema{mysqlSchema, pgSchema}, wantErr: true},
+		{name: "engine flag mismatch", engine: "mysql", schemaList: []*diffSchema{fileSchema, pgSchema}, wantErr: true},
+		{name: "files without the engine flag", schemaList: []*diffSchema{fileSchema, fileSchema}, wantErr: true},
+		{name: "unsupported engine", engine: "oracle", schemaList: []*diffSchema{fileSchema, fileSchema}, wantErr: true},
+	}
+
+	for _, test := range tests {
+		got, err := getDiffEngineType(test.engine, test.schemaList...)
+		if test.wantErr {
+			require.Error(t, err, test.name)
+			continue
+		}
+		require.NoError(t, err, test.name)
+		require.Equal(t, test.want, got, test.name)
+	}
+}
+
+func TestNewDiffSchema(t *testing.T) {
+	_, err := newDiffSchema("", "")
+	require.Error(t, err)
+	_, err = newDiffSchema("mysql://root@localhost:3306/db", "schema.sql")
+	require.Error(t, err)
+}
+
+func TestDiffExitCode(t *testing.T) {
+	dir := t.TempDir()
+	writeSchema := func(name, schema string) string {
+		file := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(file, []byte(schema), 0644))
+		return file
+	}
+	source := writeSchema("source.sql", "CREATE TABLE t (id INT);\n")
+	target := writeSchema("target.sql", "CREATE TABLE t (id INT, name VARCHAR(10));\n")
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantDiff bool
+		wantCode int
+	}{
+		{name: "same schemas", args: []string{"--source-file", source, "--target-file", source, "--exit-code"}},
+		{name: "diff without --exit-code", args: []string{"--source-file", source, "--target-file", target}, wantDiff: true},
+		{name: "diff with --exit-code", args: []string{"--source-file", source, "--target-file", target, "--exit-code"}, wantDiff: true, wantCode: diffExitCode},
+	}
+
+	for _, test := range tests {
+		cmd := newDiffCmd()
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetErr(&out)
+		cmd.SetArgs(append([]string{"--engine", "mysql"}, test.args...))
+		err := cmd.Execute()
+		if test.wantCode == 0 {
+			require.NoError(t, err, test.name)
+		} else {
+			exitErr, ok := err.(*ExitError)
+			require.True(t, ok, test.name)
+			require.Equal(t, test.wantCode, exitErr.Code, test.name)
+		}
+		if test.wantDiff {
+			require.Contains(t, out.String(), "ALTER TABLE", test.name)
+		} else {
+			require.Empty(t, out.String(), test.name)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+// Package cmd is the command surface of Bytebase bb tool provided by bytebase.com.
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/xo/dburl"
+
+	"github.com/bytebase/bytebase/plugin/db"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+
+	outputUsage = "Output format, table or json."
+)
+
+func newHistoryCmd() *cobra.Command {
+	var (
+		dsn    string
+		limit  int
+		output string
+	)
+	historyCmd := &cobra.Command{
+		Use:   "history",
+		Short: "Show the migration history of a database.",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := validateOutput(output); err != nil {
+				return err
+			}
+			u, err := dburl.Parse(dsn)
+			if err != nil {
+				return errors.Wrap(err, "failed to parse dsn")
+			}
+			return showMigrationHistory(context.Background(), u, limit, output, cmd.OutOrStdout())
+		},
+	}
+
+	historyCmd.Flags().StringVar(&dsn, "dsn", "", dsnUsage)
+	historyCmd.Flags().IntVar(&limit, "limit", 0, "Show the most recent migrations only. Show all migrations if unspecified.")
+	historyCmd.Flags().StringVarP(&output, "output", "o", outputTable, outputUsage)
+	return historyCmd
+}
+
+// migrationHistory is the output of a migration history entry.
+type migrationHistory struct {
+	Version             string `json:"version"`
+	Type                string `json:"type"`
+	Status              string `json:"status"`
+	ExecutionDurationNs int64  `json:"executionDurationNs"`
+	IssueID             string `json:"issueId"`
+	Description         string `json:"description"`
+	Creator             string `json:"creator"`
+	CreatedTs           int64  `json:"createdTs"`
+}
+
+func convertMigrationHistory(history *db.MigrationHistory) *migrationHistory {
+	return &migrationHistory{
+		Version:             history.Version,
+		Type:                string(history.Type),
+		Status:              string(history.Status),
+		ExecutionDurationNs: history.ExecutionDurationNs,
+		IssueID:             history.IssueID,
+		Description:         history.Description,
+		Creator:             history.Creator,
+		CreatedTs:           history.CreatedTs,
+	}
+}
+
+// showMigrationHistory prints the migration history of the database, most recent first.
+func showMigrationHistory(ctx context.Context, u *dburl.URL, limit int, output string, out io.Writer) error {
+	driver, err := open(ctx, u)
+	if err != nil {
+		return err
+	}
+	defer driver.Close(ctx)
+
+	historyList, err := findMigrationHistoryList(ctx, driver, getDatabase(u), limit)
+	if err != nil {
+		return err
+	}
+	var list []*migrationHistory
+	for _, history := range historyList {
+		list = append(list, convertMigrationHistory(history))
+	}
+
+	if output == outputJSON {
+		if list == nil {
+			list = []*migrationHistory{}
+		}
+		return writeJSON(out, list)
+	}
+	if len(list) == 0 {
+		_, err := fmt.Fprintln(out, "No migration history.")
+		return err
+	}
+	return writeMigrationHistoryTable(out, list)
+}
+
+// findMigrationHistoryList finds the migration history of the database, most recent first.
+// It returns an empty list if the migration history hasn't been set up.
+func findMigrationHistoryList(ctx context.Context, driver db.Driver, database string, limit int) ([]*db.MigrationHistory, error) {
+	setup, err := driver.NeedsSetupMigration(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to check migration setup")
+	}
+	if setup {
+		return nil, nil
+	}
+	find := &db.MigrationHistoryFind{
+		Database: &database,
+	}
+	if limit > 0 {
+		find.Limit = &limit
+	}
+	historyList, err := driver.FindMigrationHistoryList(ctx, find)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find migration history")
+	}
+	return historyList, nil
+}
+
+func writeMigrationHistoryTable(out io.Writer, list []*migrationHistory) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(w, "VERSION\tTYPE\tSTATUS\tDURATION\tISSUE\tCREATED\tDESCRIPTION"); err != nil {
+		return err
+	}
+	for _, history := range list {
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			history.Version,
+			history.Type,
+			history.Status,
+			time.Duration(history.ExecutionDurationNs).Round(time.Millisecond),
+			history.IssueID,
+			time.Unix(history.CreatedTs, 0).Format("2006-01-02 15:04:05"),
+			history.Description,
+		); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+func validateOutput(output string) error {
+	if output != outputTable && output != outputJSON {
+		return errors.Errorf("invalid output format %q, supported formats: %s, %s", output, outputTable, outputJSON)
+	}
+	return nil
+}
+
+func writeJSON(out io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/plugin/db"
+)
+
+func TestConvertMigrationHistory(t *testing.T) {
+	history := &db.MigrationHistory{
+		Version:             "0001",
+		Type:                db.Migrate,
+		Status:              db.Done,
+		ExecutionDurationNs: 42,
+		IssueID:             "7",
+		Description:         "create table",
+		Creator:             "alice",
+		CreatedTs:           1668000000,
+	}
+	require.Equal(t, &migrationHistory{
+		Version:             "0001",
+		Type:                "MIGRATE",
+		Status:              "DONE",
+		ExecutionDurationNs: 42,
+		IssueID:             "7",
+		Description:         "create table",
+		Creator:             "alice",
+		CreatedTs:           1668000000,
+	}, convertMigrationHistory(history))
+}
+
+func TestWriteMigrationHistory(t *testing.T) {
+	createdTs := int64(1668000000)
+	createdAt := time.Unix(createdTs, 0).Format("2006-01-02 15:04:05")
+	list := []*migrationHistory{
+		{Version: "0002", Type: "DATA", Status: "PENDING", ExecutionDurationNs: int64(2 * time.Second), IssueID: "12", CreatedTs: createdTs, Description: "insert rows"},
+		{Version: "0001", Type: "MIGRATE", Status: "DONE", ExecutionDurationNs: int64(1234567 * time.Nanosecond), CreatedTs: createdTs, Description: "create table"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, writeMigrationHistoryTable(&buf, list))
+	require.Equal(t, "VERSION  TYPE     STATUS   DURATION  ISSUE  CREATED              DESCRIPTION\n"+
+		fmt.Sprintf("0002     DATA     PENDING  2s        12     %s  insert rows\n", createdAt)+
+		fmt.Sprintf("0001     MIGRATE  DONE     1ms              %s  create table\n", createdAt),
+		buf.String())
+
+	buf.Reset()
+	require.NoError(t, writeJSON(&buf, list[1:]))
+	require.Equal(t, `[
+  {
+    "version": "0001",
+    "type": "MIGRATE",
+    "status": "DONE",
+    "executionDurationNs": 1234567,
+    "issueId": "",
+    "description": "create table",
+    "creator": "",
+    "createdTs": 1668000000
+  }
+]
+`, buf.String())
+}
+
+func TestValidateOutput(t *testing.T) {
+	require.NoError(t, validateOutput(outputTable))
+	require.NoError(t, validateOutput(outputJSON))
+	require.Error(t, validateOutput("yaml"))
+}
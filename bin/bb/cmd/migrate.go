@@ -3,10 +3,16 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"os/user"
+	"path/filepath"
+	"sort"
 	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -14,25 +20,56 @@ import (
 
 	"github.com/bytebase/bytebase/common"
 	"github.com/bytebase/bytebase/plugin/db"
+	"github.com/bytebase/bytebase/plugin/db/util"
 )
 
+// defaultMigrationFileTemplate is the default file path template of the migration files, the same as the default of the GitOps workflow.
+const defaultMigrationFileTemplate = "{{DB_NAME}}##{{VERSION}}##{{TYPE}}##{{DESCRIPTION}}.sql"
+
 func newMigrateCmd() *cobra.Command {
 	var (
-		dsn         string
-		fileList    []string
-		commandList []string
-		description string
-		issueID     string
+		dsn           string
+		fileList      []string
+		commandList   []string
+		description   string
+		issueID       string
+		dir           string
+		template      string
+		targetVersion string
+		dryRun        bool
+		lockTimeout   time.Duration
 	)
 	migrateCmd := &cobra.Command{
 		Use:   "migrate",
 		Short: "Migrate the database schema.",
-		RunE: func(_ *cobra.Command, _ []string) error {
+		Long: `Migrate the database schema.
+
+The SQL files and commands are executed as one migration.
+With --dir, the migration files in the directory are applied in the order of the versions parsed by the file path template.
+The versions already applied are skipped, so the directory can be applied repeatedly.`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
 			u, err := dburl.Parse(dsn)
 			if err != nil {
 				return errors.Wrap(err, "failed to parse dsn")
 			}
 
+			if dir != "" {
+				if len(fileList) > 0 || len(commandList) > 0 {
+					return errors.New("--dir cannot be used with --file or --command")
+				}
+				return migrateDirectory(context.Background(), u, &migrationDirectory{
+					dir:           dir,
+					template:      template,
+					targetVersion: targetVersion,
+					issueID:       issueID,
+					dryRun:        dryRun,
+					lockTimeout:   lockTimeout,
+				}, cmd.OutOrStdout())
+			}
+			if targetVersion != "" || dryRun {
+				return errors.New("--target-version and --dry-run can only be used with --dir")
+			}
+
 			var sqlReaders []io.Reader
 
 			// TODO(qsliu): support file and command combined as the passed order.
@@ -52,7 +89,7 @@ func newMigrateCmd() *cobra.Command {
 			}
 
 			sqlReader := io.MultiReader(sqlReaders...)
-			return migrateDatabase(context.Background(), u, description, issueID, false /*createDatabase*/, sqlReader)
+			return migrateDatabase(context.Background(), u, description, issueID, false /*createDatabase*/, lockTimeout, sqlReader)
 		}}
 
 	migrateCmd.Flags().StringVar(&dsn, "dsn", "", dsnUsage)
@@ -60,24 +97,235 @@ func newMigrateCmd() *cobra.Command {
 	migrateCmd.Flags().StringSliceVarP(&commandList, "command", "c", []string{}, "SQL command to execute.")
 	migrateCmd.Flags().StringVar(&description, "description", "", "Description of migration.")
 	migrateCmd.Flags().StringVar(&issueID, "issue-id", "", "Issue ID of migration.")
+	migrateCmd.Flags().StringVar(&dir, "dir", "", "Directory of the versioned migration files to apply.")
+	migrateCmd.Flags().StringVar(&template, "template", defaultMigrationFileTemplate, "File path template of the migration files in --dir, the same as the file path template of the GitOps workflow. The {{DB_NAME}} is optional, files for the other databases are skipped.")
+	migrateCmd.Flags().StringVar(&targetVersion, "target-version", "", "Apply the migration files in --dir up to the version. Apply all migration files if unspecified.")
+	migrateCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the migration files in --dir to apply without applying them.")
+	migrateCmd.Flags().DurationVar(&lockTimeout, "lock-timeout", db.DefaultMigrationLockTimeout, "Maximum time to wait for the migration lock of the database, which is held by the other migrations of the database, e.g. from the Bytebase server.")
 	return migrateCmd
 }
 
-func migrateDatabase(ctx context.Context, u *dburl.URL, description, issueID string, createDatabase bool, sqlReader io.Reader) error {
+// migrationDirectory is the directory of the versioned migration files.
+type migrationDirectory struct {
+	dir      string
+	template string
+	// targetVersion is the version to migrate to, empty means the latest version.
+	targetVersion string
+	issueID       string
+	dryRun        bool
+	lockTimeout   time.Duration
+}
+
+// migrationFile is a migration file in the migration directory.
+type migrationFile struct {
+	path          string
+	migrationInfo *db.MigrationInfo
+}
+
+// migrateDirectory applies the migration files in the directory which haven't been applied, in the order of the versions.
+func migrateDirectory(ctx context.Context, u *dburl.URL, d *migrationDirectory, out io.Writer) error {
+	database := getDatabase(u)
+	if database == "" {
+		return errors.New("the database must be specified in the dsn to migrate a directory")
+	}
+	fileList, err := findMigrationFileList(d.dir, d.template, database)
+	if err != nil {
+		return err
+	}
+
 	driver, err := open(ctx, u)
 	if err != nil {
 		return err
 	}
 	defer driver.Close(ctx)
 
+	historyList, err := findMigrationHistoryList(ctx, driver, database, 0 /* limit */)
+	if err != nil {
+		return err
+	}
+	appliedVersions := make(map[string]*db.MigrationHistory)
+	for _, history := range historyList {
+		if history.Status == db.Done {
+			appliedVersions[history.Version] = history
+		}
+	}
+	if d.targetVersion != "" && len(fileList) > 0 {
+		if err := checkVersionLength(d.targetVersion, fileList[0].migrationInfo.Version); err != nil {
+			return errors.Wrap(err, "invalid target version")
+		}
+	}
+	var pendingList []*migrationFile
+	targetFound := d.targetVersion == "" || appliedVersions[d.targetVersion] != nil
+	for _, file := range fileList {
+		version := file.migrationInfo.Version
+		if d.targetVersion != "" && version > d.targetVersion {
+			break
+		}
+		if version == d.targetVersion {
+			targetFound = true
+		}
+		if history, ok := appliedVersions[version]; ok {
+			// The applied migration files are not applied again even if they are modified, so we warn about it.
+			modified, err := isMigrationFileModified(file.path, history)
+			if err != nil {
+				return err
+			}
+			if modified {
+				if _, err := fmt.Fprintf(out, "Warning: %s has been modified after version %s was applied, the modification is not applied.\n", file.path, version); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		pendingList = append(pendingList, file)
+	}
+	if !targetFound {
+		return errors.Errorf("target version %s is not found in directory %q", d.targetVersion, d.dir)
+	}
+
+	if len(pendingList) == 0 {
+		_, err := fmt.Fprintln(out, "The database is up to date.")
+		return err
+	}
+	if d.dryRun {
+		if _, err := fmt.Fprintf(out, "%d migration(s) to apply:\n", len(pendingList)); err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		if _, err := fmt.Fprintln(w, "VERSION\tTYPE\tFILE\tDESCRIPTION"); err != nil {
+			return err
+		}
+		for _, file := range pendingList {
+			if _, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", file.migrationInfo.Version, file.migrationInfo.Type, file.path, file.migrationInfo.Description); err != nil {
+				return err
+			}
+		}
+		return w.Flush()
+	}
+
 	if err := driver.SetupMigrationIfNeeded(ctx); err != nil {
 		return errors.Wrap(err, "failed to setup migration")
 	}
+	creator := getMigrationCreator()
+	for _, file := range pendingList {
+		statement, err := os.ReadFile(file.path)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read migration file %q", file.path)
+		}
+		if _, _, err := driver.ExecuteMigration(ctx, &db.MigrationInfo{
+			ReleaseVersion: version,
+			Version:        file.migrationInfo.Version,
+			Namespace:      database,
+			Database:       database,
+			Source:         db.LIBRARY,
+			Type:           file.migrationInfo.Type,
+			Description:    file.migrationInfo.Description,
+			Creator:        creator,
+			IssueID:        d.issueID,
+			LockTimeout:    d.lockTimeout,
+		}, string(statement)); err != nil {
+			return errors.Wrapf(err, "failed to apply migration file %q", file.path)
+		}
+		if _, err := fmt.Fprintf(out, "Applied version %s from %s\n", file.migrationInfo.Version, file.path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isMigrationFileModified returns whether the statement of the migration file differs from the applied one.
+func isMigrationFileModified(path string, history *db.MigrationHistory) (bool, error) {
+	statement, err := os.ReadFile(path)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to read migration file %q", path)
+	}
+	checksum, err := util.GetMigrationHistoryStatementChecksum(history)
+	if err != nil {
+		return false, err
+	}
+	return checksum != util.GetStatementChecksum(string(statement)), nil
+}
+
+// findMigrationFileList finds the migration files of the database in the directory, sorted by the versions.
+// The versions are compared as strings, the same as the migration history, so all versions must have the same length.
+func findMigrationFileList(dir, template, database string) ([]*migrationFile, error) {
+	var fileList []*migrationFile
+	versionFiles := make(map[string]string)
+	if err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		relativePath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		mi, err := db.ParseMigrationInfo(filepath.ToSlash(relativePath), template, true /* allowOmitDatabaseName */)
+		if err != nil {
+			return err
+		}
+		// Skip the files not matching the template and the files for the other databases.
+		if mi == nil || (mi.Database != "" && mi.Database != database) {
+			return nil
+		}
+		// Skip the undo files, which revert their paired migration files of the same version instead of migrating forward.
+		if mi.Type == db.Undo {
+			return nil
+		}
+		if mi.Type != db.Migrate && mi.Type != db.Data {
+			return errors.Errorf("migration file %q has unsupported migration type %s", path, mi.Type)
+		}
+		if existing, ok := versionFiles[mi.Version]; ok {
+			return errors.Errorf("migration files %q and %q have the same version %s", existing, path, mi.Version)
+		}
+		versionFiles[mi.Version] = path
+		fileList = append(fileList, &migrationFile{path: path, migrationInfo: mi})
+		return nil
+	}); err != nil {
+		return nil, errors.Wrapf(err, "failed to read migration directory %q", dir)
+	}
+	sort.Slice(fileList, func(i, j int) bool {
+		return fileList[i].migrationInfo.Version < fileList[j].migrationInfo.Version
+	})
+	// The versions of different lengths are ordered wrongly as strings, e.g. 10 is ordered before 9,
+	// and the server would reject the migrations as out of order, so we require the versions to have the same length.
+	for _, file := range fileList {
+		if err := checkVersionLength(fileList[0].migrationInfo.Version, file.migrationInfo.Version); err != nil {
+			return nil, errors.Wrapf(err, "migration files %q and %q", fileList[0].path, file.path)
+		}
+	}
+	return fileList, nil
+}
 
-	migrationCreator := "bb-unknown-creator"
+// checkVersionLength returns error if the versions have different lengths, because the versions are compared as strings.
+func checkVersionLength(version, other string) error {
+	if len(version) != len(other) {
+		return errors.Errorf("versions %s and %s have different lengths and can't be ordered as strings, please pad the versions with leading zeros to the same length, e.g. 0009 and 0010", version, other)
+	}
+	return nil
+}
+
+func getMigrationCreator() string {
 	if currentUser, err := user.Current(); err == nil {
-		migrationCreator = currentUser.Username
+		return currentUser.Username
 	}
+	return "bb-unknown-creator"
+}
+
+func migrateDatabase(ctx context.Context, u *dburl.URL, description, issueID string, createDatabase bool, lockTimeout time.Duration, sqlReader io.Reader) error {
+	driver, err := open(ctx, u)
+	if err != nil {
+		return err
+	}
+	defer driver.Close(ctx)
+
+	if err := driver.SetupMigrationIfNeeded(ctx); err != nil {
+		return errors.Wrap(err, "failed to setup migration")
+	}
+
+	migrationCreator := getMigrationCreator()
 
 	var buf bytes.Buffer
 	if _, err := io.Copy(&buf, sqlReader); err != nil {
@@ -87,6 +335,7 @@ func migrateDatabase(ctx context.Context, u *dburl.URL, description, issueID str
 	if _, _, err := driver.ExecuteMigration(ctx, &db.MigrationInfo{
 		ReleaseVersion: version,
 		Version:        common.DefaultMigrationVersion(),
+		Namespace:      getDatabase(u),
 		Database:       getDatabase(u),
 		Source:         db.LIBRARY,
 		Type:           db.Migrate,
@@ -94,6 +343,7 @@ func migrateDatabase(ctx context.Context, u *dburl.URL, description, issueID str
 		Creator:        migrationCreator,
 		IssueID:        issueID,
 		CreateDatabase: createDatabase,
+		LockTimeout:    lockTimeout,
 	}, buf.String()); err != nil {
 		return errors.Wrap(err, "failed to migrate database")
 	}
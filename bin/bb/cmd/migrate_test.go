@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/plugin/db"
+)
+
+func writeMigrationFiles(t *testing.T, nameList ...string) string {
+	dir := t.TempDir()
+	for _, name := range nameList {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("SELECT 1;"), 0644))
+	}
+	return dir
+}
+
+func TestFindMigrationFileList(t *testing.T) {
+	a := require.New(t)
+	dir := writeMigrationFiles(t,
+		"db##0002##migrate##add_index.sql",
+		"db##0002##undo##add_index.sql",
+		"db##0001##ddl##create_table.sql",
+		"db##0003##data##insert_rows.sql",
+		"other##0001##migrate##create_table.sql",
+		"README.md",
+	)
+	fileList, err := findMigrationFileList(dir, defaultMigrationFileTemplate, "db")
+	a.NoError(err)
+	// The undo files and the files for the other databases are skipped.
+	a.Len(fileList, 3)
+	a.Equal("0001", fileList[0].migrationInfo.Version)
+	a.Equal(db.Migrate, fileList[0].migrationInfo.Type)
+	a.Equal("0002", fileList[1].migrationInfo.Version)
+	a.Equal(db.Migrate, fileList[1].migrationInfo.Type)
+	a.Equal("0003", fileList[2].migrationInfo.Version)
+	a.Equal(db.Data, fileList[2].migrationInfo.Type)
+
+	dir = writeMigrationFiles(t,
+		"db##0001##migrate##create_table.sql",
+		"db##0001##data##insert_rows.sql",
+	)
+	_, err = findMigrationFileList(dir, defaultMigrationFileTemplate, "db")
+	a.ErrorContains(err, "have the same version 0001")
+
+	// The version 10 would be ordered before the version 9 as strings.
+	dir = writeMigrationFiles(t,
+		"db##9##migrate##create_table.sql",
+		"db##10##migrate##add_index.sql",
+	)
+	_, err = findMigrationFileList(dir, defaultMigrationFileTemplate, "db")
+	a.ErrorContains(err, "versions 10 and 9 have different lengths")
+	a.NoError(checkVersionLength("0009", "0010"))
+}
@@ -8,12 +8,15 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/xo/dburl"
+
+	"github.com/bytebase/bytebase/common"
 )
 
 func newRestoreCmd() *cobra.Command {
 	var (
-		dsn  string
-		file string
+		dsn           string
+		file          string
+		encryptionKey string
 	)
 	restoreCmd := &cobra.Command{
 		Use:   "restore",
@@ -23,11 +26,12 @@ func newRestoreCmd() *cobra.Command {
 			if err != nil {
 				return errors.Wrap(err, "failed to parse dsn")
 			}
-			return restoreDatabase(context.Background(), u, file)
+			return restoreDatabase(context.Background(), u, file, encryptionKey)
 		},
 	}
 	restoreCmd.Flags().StringVar(&dsn, "dsn", "", dsnUsage)
 	restoreCmd.Flags().StringVar(&file, "file", "", "File to store the dump.")
+	restoreCmd.Flags().StringVar(&encryptionKey, "encryption-key", "", "Key to decrypt the backup if it's encrypted, which is the value of the bb.backup.encryption-key setting of the workspace.")
 	if err := restoreCmd.MarkFlagRequired("file"); err != nil {
 		panic(err)
 	}
@@ -36,12 +40,18 @@ func newRestoreCmd() *cobra.Command {
 }
 
 // restoreDatabase restores the schema of a database instance.
-func restoreDatabase(ctx context.Context, u *dburl.URL, file string) error {
+// Compressed and encrypted backups are decompressed and decrypted transparently.
+func restoreDatabase(ctx context.Context, u *dburl.URL, file, encryptionKey string) error {
 	f, err := os.Open(file)
 	if err != nil {
 		return errors.Wrapf(err, "failed to open file %q", file)
 	}
 	defer f.Close()
+	backup, err := common.NewBackupReader(f, encryptionKey)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read backup file %q", file)
+	}
+	defer backup.Close()
 
 	db, err := open(ctx, u)
 	if err != nil {
@@ -49,7 +59,7 @@ func restoreDatabase(ctx context.Context, u *dburl.URL, file string) error {
 	}
 	defer db.Close(ctx)
 
-	if err := db.Restore(ctx, f); err != nil {
+	if err := db.Restore(ctx, backup); err != nil {
 		return errors.Wrapf(err, "failed to restore from backup file %q", file)
 	}
 	return nil
@@ -0,0 +1,506 @@
+package cmd
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/xo/dburl"
+
+	"github.com/bytebase/bytebase/plugin/advisor"
+	"github.com/bytebase/bytebase/plugin/advisor/catalog"
+	advisorDB "github.com/bytebase/bytebase/plugin/advisor/db"
+	"github.com/bytebase/bytebase/plugin/db"
+
+	// Register pingcap parser driver.
+	_ "github.com/pingcap/tidb/types/parser_driver"
+	// Register mysql advisor.
+	_ "github.com/bytebase/bytebase/plugin/advisor/mysql"
+	// Register postgresql advisor.
+	_ "github.com/bytebase/bytebase/plugin/advisor/pg"
+)
+
+const (
+	outputText   = "text"
+	outputSARIF  = "sarif"
+	outputGitHub = "github"
+
+	// reviewExitCode is the exit code of bb review if any statement violates the ERROR level rules.
+	reviewExitCode = 2
+
+	// The default charset and collation if there's no database to review against, the same as the SQL review API.
+	defaultReviewCharset   = "utf8mb4"
+	defaultReviewCollation = "utf8mb4_general_ci"
+)
+
+func newReviewCmd() *cobra.Command {
+	var (
+		config string
+		engine string
+		dsn    string
+		output string
+	)
+	reviewCmd := &cobra.Command{
+		Use:   "review [flags] FILE...",
+		Short: "Review the SQL files with the SQL review rules.",
+		Long: `Review the SQL files with the SQL review rules.
+
+The config is a YAML file in the same format as the SQL review templates, such as
+https://github.com/bytebase/bytebase/tree/main/plugin/advisor/config/sql-review.prod.yaml.
+It can also extend a template by the template id, such as
+https://github.com/bytebase/bytebase/tree/main/plugin/advisor/config/sql-review.override.yaml.
+
+If the DSN is specified, the SQL files are reviewed against the schema of the database,
+otherwise they are reviewed against an empty database and the rules requiring the database are skipped.
+
+bb review exits with 2 if any statement violates the ERROR level rules. Other errors exit with 1.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			switch output {
+			case outputText, outputJSON, outputSARIF, outputGitHub:
+			default:
+				return errors.Errorf("invalid output format %q, supported formats: %s, %s, %s, %s", output, outputText, outputJSON, outputSARIF, outputGitHub)
+			}
+			if config == "" {
+				return errors.New("--config is required")
+			}
+			content, err := os.ReadFile(config)
+			if err != nil {
+				return errors.Wrapf(err, "failed to read config file %q", config)
+			}
+			ruleList, err := advisor.UnmarshalSQLReviewConfig(content)
+			if err != nil {
+				return errors.Wrapf(err, "invalid config file %q", config)
+			}
+			var u *dburl.URL
+			if dsn != "" {
+				if u, err = dburl.Parse(dsn); err != nil {
+					return errors.Wrap(err, "failed to parse dsn")
+				}
+			}
+			dbType, err := getReviewDBType(engine, u)
+			if err != nil {
+				return err
+			}
+
+			resultList, err := reviewFiles(context.Background(), u, dbType, ruleList, args)
+			if err != nil {
+				return err
+			}
+			if err := writeReviewResult(cmd.OutOrStdout(), output, resultList); err != nil {
+				return err
+			}
+
+			for _, result := range resultList {
+				if result.Status == advisor.Error {
+					// The violations are reported in the output, so we don't print the error and the usage.
+					cmd.SilenceErrors = true
+					cmd.SilenceUsage = true
+					return &ExitError{Code: reviewExitCode, Err: errors.New("the SQL review failed")}
+				}
+			}
+			return nil
+		},
+	}
+
+	reviewCmd.Flags().StringVar(&config, "config", "", "SQL review config file in YAML format.")
+	reviewCmd.Flags().StringVar(&engine, "engine", "", "Database engine of the SQL files, mysql, tidb or postgres. Required if the DSN is unspecified.")
+	reviewCmd.Flags().StringVar(&dsn, "dsn", "", "Connection string of the database to review against, see --dsn of bb dump for the format.")
+	reviewCmd.Flags().StringVarP(&output, "output", "o", outputText, "Output format, text, json, sarif or github. The github format is the GitHub Actions workflow commands.")
+	return reviewCmd
+}
+
+// reviewResult is the output of an advice of bb review.
+type reviewResult struct {
+	File    string         `json:"file"`
+	Line    int            `json:"line"`
+	Status  advisor.Status `json:"status"`
+	Code    advisor.Code   `json:"code"`
+	Title   string         `json:"title"`
+	Content string         `json:"content"`
+}
+
+// getReviewDBType gets the database type from the engine flag, or the DSN if the engine is unspecified.
+func getReviewDBType(engine string, u *dburl.URL) (advisorDB.Type, error) {
+	if engine == "" {
+		if u == nil {
+			return "", errors.New("--engine is required if the DSN is unspecified")
+		}
+		engine = u.Driver
+	}
+	// dburl.Parse() parses 'pg', 'postgresql' and 'pgsql' to 'postgres'.
+	switch strings.ToLower(engine) {
+	case "postgresql", "pg":
+		engine = string(advisorDB.Postgres)
+	}
+	dbType, err := advisorDB.ConvertToAdvisorDBType(engine)
+	if err != nil {
+		return "", errors.Errorf("database type %q not supported; supported types: mysql, tidb, postgres", engine)
+	}
+	return dbType, nil
+}
+
+// reviewFiles reviews the SQL files and returns the advices except the successful ones.
+func reviewFiles(ctx context.Context, u *dburl.URL, dbType advisorDB.Type, ruleList []*advisor.SQLReviewRule, fileList []string) ([]*reviewResult, error) {
+	database := &catalog.Database{
+		CharacterSet: defaultReviewCharset,
+		Collation:    defaultReviewCollation,
+		DbType:       dbType,
+	}
+	// We cannot check the integrity, such as whether the dropped tables exist, without the database.
+	finderContext := &catalog.FinderContext{CheckIntegrity: false}
+	// The connection is used by the rules that run the statements against the database, such as the DML dry run.
+	var connection *sql.DB
+	if u != nil {
+		driver, err := open(ctx, u)
+		if err != nil {
+			return nil, err
+		}
+		defer driver.Close(ctx)
+		if connection, err = driver.GetDBConnection(ctx, getDatabase(u)); err != nil {
+			return nil, errors.Wrap(err, "failed to get the database connection")
+		}
+		schema, err := driver.SyncDBSchema(ctx, getDatabase(u))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to sync the schema of %s", u.Redacted())
+		}
+		if database, err = convertCatalogDatabase(schema, dbType); err != nil {
+			return nil, err
+		}
+		finderContext.CheckIntegrity = true
+	}
+
+	resultList := []*reviewResult{}
+	for _, file := range fileList {
+		statements, err := os.ReadFile(file)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read SQL file %q", file)
+		}
+		// The finder walks through the statements, so each file needs a new one.
+		adviceList, err := advisor.SQLReviewCheck(string(statements), ruleList, advisor.SQLReviewCheckContext{
+			Charset:   database.CharacterSet,
+			Collation: database.Collation,
+			DbType:    dbType,
+			Catalog:   &reviewCatalog{finder: catalog.NewFinder(database, finderContext)},
+			Driver:    connection,
+			Context:   ctx,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to review SQL file %q", file)
+		}
+		// The advices are grouped by the rules, we sort them by the lines instead.
+		sort.SliceStable(adviceList, func(i, j int) bool {
+			return adviceList[i].Line < adviceList[j].Line
+		})
+		for _, advice := range adviceList {
+			if advice.Status == advisor.Success {
+				continue
+			}
+			resultList = append(resultList, &reviewResult{
+				File:    file,
+				Line:    advice.Line,
+				Status:  advice.Status,
+				Code:    advice.Code,
+				Title:   advice.Title,
+				Content: advice.Content,
+			})
+		}
+	}
+	return resultList, nil
+}
+
+var (
+	_ catalog.Catalog = (*reviewCatalog)(nil)
+)
+
+// reviewCatalog is the catalog of the database to review against.
+type reviewCatalog struct {
+	finder *catalog.Finder
+}
+
+// GetFinder implements the catalog.Catalog interface.
+func (c *reviewCatalog) GetFinder() *catalog.Finder {
+	return c.finder
+}
+
+// convertCatalogDatabase converts the synced schema to the catalog database, the same as the catalog from the Bytebase metadata.
+func convertCatalogDatabase(schema *db.Schema, dbType advisorDB.Type) (*catalog.Database, error) {
+	database := &catalog.Database{
+		Name:         schema.Name,
+		CharacterSet: schema.CharacterSet,
+		Collation:    schema.Collation,
+		DbType:       dbType,
+	}
+	schemaMap := make(map[string]*catalog.Schema)
+	getOrCreateSchema := func(name string) *catalog.Schema {
+		if s, ok := schemaMap[name]; ok {
+			return s
+		}
+		s := &catalog.Schema{Name: name}
+		schemaMap[name] = s
+		database.SchemaList = append(database.SchemaList, s)
+		return s
+	}
+	// The table and view names are prefixed with the schema names for Postgres.
+	splitName := func(name string) (string, string, error) {
+		if dbType != advisorDB.Postgres {
+			return "", name, nil
+		}
+		list := strings.Split(name, ".")
+		if len(list) != 2 {
+			return "", "", errors.Errorf("split failed: the expected name is schemaName.name, but get %s", name)
+		}
+		return list[0], list[1], nil
+	}
+
+	for _, table := range schema.TableList {
+		schemaName, tableName, err := splitName(table.Name)
+		if err != nil {
+			return nil, err
+		}
+		tableData := &catalog.Table{
+			Name:          tableName,
+			CreatedTs:     table.CreatedTs,
+			UpdatedTs:     table.UpdatedTs,
+			Type:          table.Type,
+			Engine:        table.Engine,
+			Collation:     table.Collation,
+			RowCount:      table.RowCount,
+			DataSize:      table.DataSize,
+			IndexSize:     table.IndexSize,
+			DataFree:      table.DataFree,
+			CreateOptions: table.CreateOptions,
+			Comment:       table.Comment,
+			IndexList:     convertCatalogIndexList(table.IndexList),
+		}
+		for _, column := range table.ColumnList {
+			tableData.ColumnList = append(tableData.ColumnList, &catalog.Column{
+				Name:         column.Name,
+				Position:     column.Position,
+				Default:      column.Default,
+				Nullable:     column.Nullable,
+				Type:         column.Type,
+				CharacterSet: column.CharacterSet,
+				Collation:    column.Collation,
+				Comment:      column.Comment,
+			})
+		}
+		s := getOrCreateSchema(schemaName)
+		s.TableList = append(s.TableList, tableData)
+	}
+	for _, view := range schema.ViewList {
+		schemaName, viewName, err := splitName(view.Name)
+		if err != nil {
+			return nil, err
+		}
+		s := getOrCreateSchema(schemaName)
+		s.ViewList = append(s.ViewList, &catalog.View{
+			Name:       viewName,
+			CreatedTs:  view.CreatedTs,
+			UpdatedTs:  view.UpdatedTs,
+			Definition: view.Definition,
+			Comment:    view.Comment,
+		})
+	}
+	for _, extension := range schema.ExtensionList {
+		s := getOrCreateSchema(extension.Schema)
+		s.ExtensionList = append(s.ExtensionList, &catalog.Extension{
+			Name:        extension.Name,
+			Version:     extension.Version,
+			Description: extension.Description,
+		})
+	}
+	return database, nil
+}
+
+// convertCatalogIndexList converts the index list, which has an entry per index expression, to the catalog indexes.
+func convertCatalogIndexList(list []db.Index) []*catalog.Index {
+	var res []*catalog.Index
+	indexMap := make(map[string]*catalog.Index)
+	sorted := append([]db.Index{}, list...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Position < sorted[j].Position
+	})
+	for _, index := range sorted {
+		catalogIndex, ok := indexMap[index.Name]
+		if !ok {
+			catalogIndex = &catalog.Index{
+				Name:    index.Name,
+				Type:    index.Type,
+				Unique:  index.Unique,
+				Primary: index.Primary,
+				Visible: index.Visible,
+				Comment: index.Comment,
+			}
+			indexMap[index.Name] = catalogIndex
+			res = append(res, catalogIndex)
+		}
+		catalogIndex.ExpressionList = append(catalogIndex.ExpressionList, index.Expression)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Name < res[j].Name
+	})
+	return res
+}
+
+func writeReviewResult(out io.Writer, output string, resultList []*reviewResult) error {
+	switch output {
+	case outputJSON:
+		return writeJSON(out, resultList)
+	case outputSARIF:
+		return writeJSON(out, convertSARIFLog(resultList))
+	case outputGitHub:
+		return writeGitHubAnnotations(out, resultList)
+	}
+	return writeReviewText(out, resultList)
+}
+
+func writeReviewText(out io.Writer, resultList []*reviewResult) error {
+	errorCount, warningCount := 0, 0
+	for _, result := range resultList {
+		if result.Status == advisor.Error {
+			errorCount++
+		} else {
+			warningCount++
+		}
+		content := result.Title
+		if result.Content != "" {
+			content = fmt.Sprintf("%s: %s", result.Title, result.Content)
+		}
+		if _, err := fmt.Fprintf(out, "%s:%d: [%s] %s (code %d)\n", result.File, result.Line, result.Status, content, result.Code); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(out, "%d error(s), %d warning(s)\n", errorCount, warningCount)
+	return err
+}
+
+// writeGitHubAnnotations writes the GitHub Actions workflow commands, which annotate the files in the pull requests.
+// See https://docs.github.com/en/actions/using-workflows/workflow-commands-for-github-actions#setting-an-error-message.
+func writeGitHubAnnotations(out io.Writer, resultList []*reviewResult) error {
+	for _, result := range resultList {
+		command := "warning"
+		if result.Status == advisor.Error {
+			command = "error"
+		}
+		properties := fmt.Sprintf("file=%s", escapeGitHubProperty(result.File))
+		if result.Line > 0 {
+			properties += fmt.Sprintf(",line=%d", result.Line)
+		}
+		properties += fmt.Sprintf(",title=%s", escapeGitHubProperty(result.Title))
+		message := result.Content
+		if message == "" {
+			message = result.Title
+		}
+		if _, err := fmt.Fprintf(out, "::%s %s::%s\n", command, properties, escapeGitHubData(message)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func escapeGitHubData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+func escapeGitHubProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
+
+// The SARIF log is the static analysis results interchange format, which is supported by GitHub code scanning.
+// See https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html.
+type sarifLog struct {
+	Schema  string      `json:"$schema"`
+	Version string      `json:"version"`
+	Runs    []*sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    *sarifTool     `json:"tool"`
+	Results []*sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver *sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string `json:"name"`
+	InformationURI string `json:"informationUri"`
+}
+
+type sarifResult struct {
+	RuleID    string           `json:"ruleId"`
+	Level     string           `json:"level"`
+	Message   *sarifMessage    `json:"message"`
+	Locations []*sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation *sarifArtifactLocation `json:"artifactLocation"`
+	// Region is nil if the line is unknown.
+	Region *sarifRegion `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+func convertSARIFLog(resultList []*reviewResult) *sarifLog {
+	run := &sarifRun{
+		Tool: &sarifTool{
+			Driver: &sarifDriver{
+				Name:           "bb",
+				InformationURI: "https://www.bytebase.com/docs/sql-review/review-rules/overview",
+			},
+		},
+		Results: []*sarifResult{},
+	}
+	for _, result := range resultList {
+		level := "warning"
+		if result.Status == advisor.Error {
+			level = "error"
+		}
+		message := result.Title
+		if result.Content != "" {
+			message = fmt.Sprintf("%s: %s", result.Title, result.Content)
+		}
+		location := &sarifPhysicalLocation{
+			ArtifactLocation: &sarifArtifactLocation{URI: result.File},
+		}
+		// The SARIF line numbers start from 1.
+		if result.Line > 0 {
+			location.Region = &sarifRegion{StartLine: result.Line}
+		}
+		run.Results = append(run.Results, &sarifResult{
+			RuleID:    strconv.Itoa(int(result.Code)),
+			Level:     level,
+			Message:   &sarifMessage{Text: message},
+			Locations: []*sarifLocation{{PhysicalLocation: location}},
+		})
+	}
+	return &sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []*sarifRun{run},
+	}
+}
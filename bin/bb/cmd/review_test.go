@@ -0,0 +1,218 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xo/dburl"
+
+	"github.com/bytebase/bytebase/plugin/advisor"
+	"github.com/bytebase/bytebase/plugin/advisor/catalog"
+	advisorDB "github.com/bytebase/bytebase/plugin/advisor/db"
+	"github.com/bytebase/bytebase/plugin/db"
+)
+
+func TestGetReviewDBType(t *testing.T) {
+	tests := []struct {
+		engine  string
+		dsn     string
+		want    advisorDB.Type
+		wantErr bool
+	}{
+		{engine: "mysql", want: advisorDB.MySQL},
+		{engine: "TiDB", want: advisorDB.TiDB},
+		{engine: "postgresql", want: advisorDB.Postgres},
+		{engine: "pg", want: advisorDB.Postgres},
+		{dsn: "mysql://root@localhost:3306/db", want: advisorDB.MySQL},
+		{dsn: "pgsql://postgres@localhost:5432/db", want: advisorDB.Postgres},
+		// The engine flag takes precedence over the DSN.
+		{engine: "tidb", dsn: "mysql://root@localhost:4000/db", want: advisorDB.TiDB},
+		{engine: "oracle", wantErr: true},
+		{dsn: "sqlite:/tmp/db.sqlite", wantErr: true},
+		// The engine is required without the DSN.
+		{wantErr: true},
+	}
+
+	for _, test := range tests {
+		var u *dburl.URL
+		if test.dsn != "" {
+			var err error
+			u, err = dburl.Parse(test.dsn)
+			require.NoError(t, err)
+		}
+		got, err := getReviewDBType(test.engine, u)
+		if test.wantErr {
+			require.Error(t, err, test)
+			continue
+		}
+		require.NoError(t, err, test)
+		require.Equal(t, test.want, got, test)
+	}
+}
+
+func TestConvertCatalogIndexList(t *testing.T) {
+	tests := []struct {
+		list []db.Index
+		want []*catalog.Index
+	}{
+		{
+			list: nil,
+			want: nil,
+		},
+		{
+			// The expressions are ordered by the positions, and the indexes are ordered by the names.
+			list: []db.Index{
+				{Name: "idx_name", Expression: "last_name", Position: 2, Type: "BTREE", Visible: true},
+				{Name: "PRIMARY", Expression: "id", Position: 1, Type: "BTREE", Unique: true, Primary: true, Visible: true},
+				{Name: "idx_name", Expression: "first_name", Position: 1, Type: "BTREE", Visible: true, Comment: "name"},
+			},
+			want: []*catalog.Index{
+				{Name: "PRIMARY", ExpressionList: []string{"id"}, Type: "BTREE", Unique: true, Primary: true, Visible: true},
+				{Name: "idx_name", ExpressionList: []string{"first_name", "last_name"}, Type: "BTREE", Visible: true, Comment: "name"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		require.Equal(t, test.want, convertCatalogIndexList(test.list))
+	}
+}
+
+func TestConvertSARIFLog(t *testing.T) {
+	tests := []struct {
+		resultList []*reviewResult
+		want       []*sarifResult
+	}{
+		{
+			resultList: nil,
+			want:       []*sarifResult{},
+		},
+		{
+			resultList: []*reviewResult{
+				{File: "a.sql", Line: 3, Status: advisor.Error, Code: advisor.StatementSelectAll, Title: "statement.select.no-select-all", Content: "\"SELECT * FROM t\" uses SELECT all"},
+				{File: "b.sql", Line: 0, Status: advisor.Warn, Code: advisor.TableNoPK, Title: "table.require-pk"},
+			},
+			want: []*sarifResult{
+				{
+					RuleID:  "203",
+					Level:   "error",
+					Message: &sarifMessage{Text: "statement.select.no-select-all: \"SELECT * FROM t\" uses SELECT all"},
+					Locations: []*sarifLocation{{PhysicalLocation: &sarifPhysicalLocation{
+						ArtifactLocation: &sarifArtifactLocation{URI: "a.sql"},
+						Region:           &sarifRegion{StartLine: 3},
+					}}},
+				},
+				{
+					// The region is omitted if the line is unknown.
+					RuleID:  "601",
+					Level:   "warning",
+					Message: &sarifMessage{Text: "table.require-pk"},
+					Locations: []*sarifLocation{{PhysicalLocation: &sarifPhysicalLocation{
+						ArtifactLocation: &sarifArtifactLocation{URI: "b.sql"},
+					}}},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		log := convertSARIFLog(test.resultList)
+		require.Equal(t, "2.1.0", log.Version)
+		require.Len(t, log.Runs, 1)
+		require.Equal(t, "bb", log.Runs[0].Tool.Driver.Name)
+		require.Equal(t, test.want, log.Runs[0].Results)
+		// The empty results are written as an empty array instead of null.
+		content, err := json.Marshal(log)
+		require.NoError(t, err)
+		require.NotContains(t, string(content), `"results":null`)
+	}
+}
+
+func TestWriteGitHubAnnotations(t *testing.T) {
+	tests := []struct {
+		resultList []*reviewResult
+		want       string
+	}{
+		{
+			resultList: nil,
+			want:       "",
+		},
+		{
+			resultList: []*reviewResult{
+				{File: "migration/a.sql", Line: 3, Status: advisor.Error, Title: "statement.select.no-select-all", Content: "uses SELECT all"},
+				{File: "b.sql", Status: advisor.Warn, Title: "table.require-pk"},
+			},
+			want: "::error file=migration/a.sql,line=3,title=statement.select.no-select-all::uses SELECT all\n" +
+				"::warning file=b.sql,title=table.require-pk::table.require-pk\n",
+		},
+		{
+			// The "%", CR and LF are escaped in the data, and ":" and "," are escaped in the properties as well.
+			resultList: []*reviewResult{
+				{File: "dir,1/a:b.sql", Line: 1, Status: advisor.Error, Title: "100%: a,b", Content: "line 1\r\nline 2 100%"},
+			},
+			want: "::error file=dir%2C1/a%3Ab.sql,line=1,title=100%25%3A a%2Cb::line 1%0D%0Aline 2 100%25\n",
+		},
+	}
+
+	for _, test := range tests {
+		var buf bytes.Buffer
+		require.NoError(t, writeGitHubAnnotations(&buf, test.resultList))
+		require.Equal(t, test.want, buf.String())
+	}
+}
+
+func TestReviewExitCode(t *testing.T) {
+	dir := t.TempDir()
+	config := filepath.Join(dir, "sql-review.yaml")
+	require.NoError(t, os.WriteFile(config, []byte(`
+ruleList:
+  - type: statement.select.no-select-all
+    level: ERROR
+  - type: statement.where.require
+    level: WARNING
+`), 0644))
+	selectAll := filepath.Join(dir, "select_all.sql")
+	require.NoError(t, os.WriteFile(selectAll, []byte("SELECT * FROM t WHERE id = 1;"), 0644))
+	deleteAll := filepath.Join(dir, "delete_all.sql")
+	require.NoError(t, os.WriteFile(deleteAll, []byte("DELETE FROM t;"), 0644))
+
+	tests := []struct {
+		args     []string
+		wantCode int
+		wantErr  bool
+	}{
+		// The warnings don't fail the review.
+		{args: []string{deleteAll}, wantCode: 0},
+		// The ERROR level violations exit with 2.
+		{args: []string{deleteAll, selectAll}, wantCode: reviewExitCode},
+		// The other errors exit with 1.
+		{args: []string{filepath.Join(dir, "not_exist.sql")}, wantErr: true},
+	}
+
+	for _, test := range tests {
+		cmd := newReviewCmd()
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetErr(&out)
+		cmd.SetArgs(append([]string{"--config", config, "--engine", "mysql"}, test.args...))
+		err := cmd.Execute()
+		switch {
+		case test.wantErr:
+			require.Error(t, err)
+			_, ok := err.(*ExitError)
+			require.False(t, ok)
+		case test.wantCode == 0:
+			require.NoError(t, err, out.String())
+			require.Contains(t, out.String(), "0 error(s), 1 warning(s)")
+		default:
+			exitErr, ok := err.(*ExitError)
+			require.True(t, ok, err)
+			require.Equal(t, test.wantCode, exitErr.Code)
+			require.Contains(t, out.String(), "1 error(s), 1 warning(s)")
+		}
+	}
+}
@@ -17,11 +17,22 @@ func NewRootCmd() *cobra.Command {
 		},
 	}
 
-	rootCmd.AddCommand(newDumpCmd(), newRestoreCmd(), newVersionCmd(), newMigrateCmd())
+	rootCmd.AddCommand(newDumpCmd(), newRestoreCmd(), newVersionCmd(), newMigrateCmd(), newHistoryCmd(), newStatusCmd(), newDiffCmd(), newReviewCmd())
 
 	return rootCmd
 }
 
+// ExitError is the error with the exit code of bb.
+// It's returned by the commands which use exit codes to report results besides failures, such as bb diff with --exit-code.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
 // Execute is the execute command for root command.
 func Execute() (err error) {
 	defer log.Sync()
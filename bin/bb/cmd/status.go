@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/xo/dburl"
+
+	"github.com/bytebase/bytebase/plugin/db"
+)
+
+func newStatusCmd() *cobra.Command {
+	var (
+		dsn    string
+		output string
+	)
+	statusCmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the migration status of a database, including the pending and failed migrations and the schema drift.",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := validateOutput(output); err != nil {
+				return err
+			}
+			u, err := dburl.Parse(dsn)
+			if err != nil {
+				return errors.Wrap(err, "failed to parse dsn")
+			}
+			return showMigrationStatus(context.Background(), u, output, cmd.OutOrStdout())
+		},
+	}
+
+	statusCmd.Flags().StringVar(&dsn, "dsn", "", dsnUsage)
+	statusCmd.Flags().StringVarP(&output, "output", "o", outputTable, outputUsage)
+	return statusCmd
+}
+
+// migrationStatus is the output of the migration status of a database.
+type migrationStatus struct {
+	Database string `json:"database"`
+	// LatestMigration is the most recent successful migration, it's nil if there isn't any.
+	LatestMigration *migrationHistory   `json:"latestMigration"`
+	PendingList     []*migrationHistory `json:"pendingList"`
+	FailedList      []*migrationHistory `json:"failedList"`
+	// SchemaDrift is true if the current schema differs from the schema recorded by the latest migration.
+	SchemaDrift bool `json:"schemaDrift"`
+}
+
+// showMigrationStatus prints the migration status of the database.
+func showMigrationStatus(ctx context.Context, u *dburl.URL, output string, out io.Writer) error {
+	driver, err := open(ctx, u)
+	if err != nil {
+		return err
+	}
+	defer driver.Close(ctx)
+
+	database := getDatabase(u)
+	historyList, err := findMigrationHistoryList(ctx, driver, database, 0 /* limit */)
+	if err != nil {
+		return err
+	}
+	status, latest := getMigrationStatus(database, historyList)
+	if latest != nil {
+		var schemaBuf bytes.Buffer
+		if _, err := driver.Dump(ctx, database, &schemaBuf, true /* schemaOnly */); err != nil {
+			return errors.Wrap(err, "failed to dump the current schema")
+		}
+		status.SchemaDrift = latest.Schema != schemaBuf.String()
+	}
+
+	if output == outputJSON {
+		return writeJSON(out, status)
+	}
+	return writeMigrationStatusTable(out, status)
+}
+
+// getMigrationStatus gets the migration status from the migration history, most recent first.
+// It also returns the latest successful migration, whose schema is used to check the schema drift.
+func getMigrationStatus(database string, historyList []*db.MigrationHistory) (*migrationStatus, *db.MigrationHistory) {
+	status := &migrationStatus{
+		Database:    database,
+		PendingList: []*migrationHistory{},
+		FailedList:  []*migrationHistory{},
+	}
+	var latest *db.MigrationHistory
+	for _, history := range historyList {
+		switch history.Status {
+		case db.Done:
+			if latest == nil {
+				latest = history
+			}
+		case db.Pending:
+			status.PendingList = append(status.PendingList, convertMigrationHistory(history))
+		case db.Failed:
+			status.FailedList = append(status.FailedList, convertMigrationHistory(history))
+		}
+	}
+	if latest != nil {
+		status.LatestMigration = convertMigrationHistory(latest)
+	}
+	return status, latest
+}
+
+func writeMigrationStatusTable(out io.Writer, status *migrationStatus) error {
+	if _, err := fmt.Fprintf(out, "Database: %s\n", status.Database); err != nil {
+		return err
+	}
+	if status.LatestMigration == nil {
+		if _, err := fmt.Fprintln(out, "Latest version: none, no migration has been applied."); err != nil {
+			return err
+		}
+	} else {
+		if _, err := fmt.Fprintf(out, "Latest version: %s, applied at %s\n", status.LatestMigration.Version, time.Unix(status.LatestMigration.CreatedTs, 0).Format("2006-01-02 15:04:05")); err != nil {
+			return err
+		}
+		drift := "no"
+		if status.SchemaDrift {
+			drift = fmt.Sprintf("yes, the current schema differs from the schema recorded by version %s", status.LatestMigration.Version)
+		}
+		if _, err := fmt.Fprintf(out, "Schema drift: %s\n", drift); err != nil {
+			return err
+		}
+	}
+
+	for _, section := range []struct {
+		title string
+		list  []*migrationHistory
+	}{
+		{title: "Pending migrations", list: status.PendingList},
+		{title: "Failed migrations", list: status.FailedList},
+	} {
+		if len(section.list) == 0 {
+			if _, err := fmt.Fprintf(out, "%s: none\n", section.title); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, err := fmt.Fprintf(out, "\n%s:\n", section.title); err != nil {
+			return err
+		}
+		if err := writeMigrationHistoryTable(out, section.list); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/plugin/db"
+)
+
+func TestGetMigrationStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		historyList []*db.MigrationHistory
+		wantLatest  string
+		wantPending []string
+		wantFailed  []string
+	}{
+		{
+			name: "no migration",
+		},
+		{
+			// The history list is most recent first, so the first done migration is the latest one.
+			name: "latest done version",
+			historyList: []*db.MigrationHistory{
+				{Version: "0003", Status: db.Done},
+				{Version: "0002", Status: db.Done},
+				{Version: "0001", Status: db.Done},
+			},
+			wantLatest: "0003",
+		},
+		{
+			name: "pending and failed buckets",
+			historyList: []*db.MigrationHistory{
+				{Version: "0005", Status: db.Pending},
+				{Version: "0004", Status: db.Failed},
+				{Version: "0003", Status: db.Pending},
+				{Version: "0002", Status: db.Done},
+				{Version: "0001", Status: db.Failed},
+			},
+			wantLatest:  "0002",
+			wantPending: []string{"0005", "0003"},
+			wantFailed:  []string{"0004", "0001"},
+		},
+		{
+			name: "no done migration",
+			historyList: []*db.MigrationHistory{
+				{Version: "0001", Status: db.Failed},
+			},
+			wantFailed: []string{"0001"},
+		},
+	}
+
+	getVersionList := func(list []*migrationHistory) []string {
+		var versionList []string
+		for _, history := range list {
+			versionList = append(versionList, history.Version)
+		}
+		return versionList
+	}
+	for _, test := range tests {
+		status, latest := getMigrationStatus("db", test.historyList)
+		require.Equal(t, "db", status.Database, test.name)
+		if test.wantLatest == "" {
+			require.Nil(t, latest, test.name)
+			require.Nil(t, status.LatestMigration, test.name)
+		} else {
+			require.Equal(t, test.wantLatest, latest.Version, test.name)
+			require.Equal(t, test.wantLatest, status.LatestMigration.Version, test.name)
+		}
+		// The empty lists are written as empty arrays instead of null in JSON.
+		require.NotNil(t, status.PendingList, test.name)
+		require.NotNil(t, status.FailedList, test.name)
+		require.Equal(t, test.wantPending, getVersionList(status.PendingList), test.name)
+		require.Equal(t, test.wantFailed, getVersionList(status.FailedList), test.name)
+	}
+}
+
+func TestWriteMigrationStatus(t *testing.T) {
+	createdTs := int64(1668000000)
+	createdAt := time.Unix(createdTs, 0).Format("2006-01-02 15:04:05")
+	status := &migrationStatus{
+		Database:        "db",
+		LatestMigration: &migrationHistory{Version: "0002", Type: "MIGRATE", Status: "DONE", CreatedTs: createdTs},
+		PendingList:     []*migrationHistory{},
+		FailedList: []*migrationHistory{
+			{Version: "0003", Type: "DATA", Status: "FAILED", ExecutionDurationNs: int64(1500 * time.Millisecond), IssueID: "12", CreatedTs: createdTs, Description: "insert rows"},
+		},
+		SchemaDrift: true,
+	}
+
+	tests := []struct {
+		status *migrationStatus
+		want   string
+	}{
+		{
+			status: &migrationStatus{Database: "db", PendingList: []*migrationHistory{}, FailedList: []*migrationHistory{}},
+			want: "Database: db\n" +
+				"Latest version: none, no migration has been applied.\n" +
+				"Pending migrations: none\n" +
+				"Failed migrations: none\n",
+		},
+		{
+			status: status,
+			want: "Database: db\n" +
+				fmt.Sprintf("Latest version: 0002, applied at %s\n", createdAt) +
+				"Schema drift: yes, the current schema differs from the schema recorded by version 0002\n" +
+				"Pending migrations: none\n" +
+				"\n" +
+				"Failed migrations:\n" +
+				"VERSION  TYPE  STATUS  DURATION  ISSUE  CREATED              DESCRIPTION\n" +
+				fmt.Sprintf("0003     DATA  FAILED  1.5s      12     %s  insert rows\n", createdAt),
+		},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		require.NoError(t, writeMigrationStatusTable(&buf, test.status))
+		require.Equal(t, test.want, buf.String())
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, writeJSON(&buf, status))
+	require.Equal(t, `{
+  "database": "db",
+  "latestMigration": {
+    "version": "0002",
+    "type": "MIGRATE",
+    "status": "DONE",
+    "executionDurationNs": 0,
+    "issueId": "",
+    "description": "",
+    "creator": "",
+    "createdTs": 1668000000
+  },
+  "pendingList": [],
+  "failedList": [
+    {
+      "version": "0003",
+      "type": "DATA",
+      "status": "FAILED",
+      "executionDurationNs": 1500000000,
+      "issueId": "12",
+      "description": "insert rows",
+      "creator": "",
+      "createdTs": 1668000000
+    }
+  ],
+  "schemaDrift": true
+}
+`, buf.String())
+}
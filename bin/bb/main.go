@@ -4,6 +4,8 @@ package main
 import (
 	"os"
 
+	"github.com/pkg/errors"
+
 	"github.com/bytebase/bytebase/bin/bb/cmd"
 
 	// Register mysql driver.
@@ -14,6 +16,10 @@ import (
 
 func main() {
 	if err := cmd.Execute(); err != nil {
+		var exitErr *cmd.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
 		os.Exit(1)
 	}
 }
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/bytebase/bytebase/api"
@@ -19,27 +20,38 @@ func getBaseProfile() server.Profile {
 		demoDataDir = fmt.Sprintf("demo/%s", demoName)
 	}
 	backupStorageBackend := api.BackupStorageBackendLocal
+	backupBucket := ""
 	if flags.backupBucket != "" {
-		backupStorageBackend = api.BackupStorageBackendS3
+		// The bucket URI has been validated by checkCloudBackupFlags.
+		backupStorageBackend, backupBucket, _ = parseBackupBucket(flags.backupBucket)
 	}
 	// Using flags.port + 1 as our datastore port
 	datastorePort := flags.port + 1
 
 	return server.Profile{
-		ExternalURL:          flags.externalURL,
-		DatastorePort:        datastorePort,
-		Readonly:             flags.readonly,
-		Debug:                flags.debug,
-		Demo:                 flags.demo,
-		DemoDataDir:          demoDataDir,
-		Version:              version,
-		GitCommit:            gitcommit,
-		PgURL:                flags.pgURL,
-		DisableMetric:        flags.disableMetric,
-		BackupStorageBackend: backupStorageBackend,
-		BackupRegion:         flags.backupRegion,
-		BackupBucket:         flags.backupBucket,
-		BackupCredentialFile: flags.backupCredential,
+		ExternalURL:                  flags.externalURL,
+		DatastorePort:                datastorePort,
+		Readonly:                     flags.readonly,
+		Debug:                        flags.debug,
+		Demo:                         flags.demo,
+		DemoDataDir:                  demoDataDir,
+		Version:                      version,
+		GitCommit:                    gitcommit,
+		PgURL:                        flags.pgURL,
+		DisableMetric:                flags.disableMetric,
+		NativePgDump:                 flags.nativePgDump,
+		MySQLDumpParallelism:         flags.mysqlDumpParallelism,
+		BackupCompression:            strings.ToUpper(flags.backupCompression),
+		BackupEncryption:             flags.backupEncryption,
+		BackupVerificationInterval:   flags.backupVerificationInterval,
+		BackupVerificationInstanceID: flags.backupVerificationInstance,
+		MaxDriversPerInstance:        flags.maxDriversPerInstance,
+		DriverIdleTimeout:            flags.driverIdleTimeout,
+		MigrationLockTimeout:         flags.migrationLockTimeout,
+		BackupStorageBackend:         backupStorageBackend,
+		BackupRegion:                 flags.backupRegion,
+		BackupBucket:                 backupBucket,
+		BackupCredentialFile:         flags.backupCredential,
 	}
 }
 
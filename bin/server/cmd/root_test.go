@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"testing"
+
+	"github.com/bytebase/bytebase/api"
 )
 
 func TestNormalizeExternalURL(t *testing.T) {
@@ -70,3 +72,54 @@ func TestNormalizeExternalURL(t *testing.T) {
 		})
 	}
 }
+
+func TestParseBackupBucket(t *testing.T) {
+	tests := []struct {
+		uri         string
+		wantBackend api.BackupStorageBackend
+		wantBucket  string
+		wantErr     bool
+	}{
+		{
+			uri:         "s3://example-bucket",
+			wantBackend: api.BackupStorageBackendS3,
+			wantBucket:  "example-bucket",
+		},
+		{
+			uri:         "gs://example-bucket",
+			wantBackend: api.BackupStorageBackendGCS,
+			wantBucket:  "example-bucket",
+		},
+		{
+			uri:         "oss://example-bucket",
+			wantBackend: api.BackupStorageBackendOSS,
+			wantBucket:  "example-bucket",
+		},
+		// Unknown scheme
+		{
+			uri:     "azure://example-bucket",
+			wantErr: true,
+		},
+		// Empty bucket name
+		{
+			uri:     "s3://",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.uri, func(t *testing.T) {
+			backend, bucket, err := parseBackupBucket(tt.uri)
+			if err != nil {
+				if !tt.wantErr {
+					t.Errorf("expect no error, got %s", err.Error())
+				}
+			} else {
+				if tt.wantErr {
+					t.Errorf("expect error")
+				} else if tt.wantBackend != backend || tt.wantBucket != bucket {
+					t.Errorf("expect %s %s, got %s %s", tt.wantBackend, tt.wantBucket, backend, bucket)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,284 @@
+package common
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pkg/errors"
+)
+
+// The compression algorithms of backup files.
+const (
+	BackupCompressionNone = ""
+	BackupCompressionGzip = "GZIP"
+	BackupCompressionZstd = "ZSTD"
+)
+
+const (
+	// encryptedBackupMagic is the header of encrypted backup files.
+	encryptedBackupMagic = "BBENC001"
+	// encryptedBackupChunkSize is the size of the plaintext in each encrypted chunk.
+	encryptedBackupChunkSize = 64 * 1024
+	// encryptedBackupNonceSize is the nonce size of AES-GCM.
+	encryptedBackupNonceSize = 12
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+
+	// lastChunkAdditionalData marks the last encrypted chunk so that a truncated backup file cannot be decrypted.
+	lastChunkAdditionalData = []byte("last")
+)
+
+// NewBackupWriter returns a writer which compresses the backup with the compression algorithm,
+// then encrypts it with AES-256-GCM if the key isn't empty. Close must be called to flush the backup, it doesn't close w.
+// The backup can be read by NewBackupReader without knowing the compression or encryption.
+func NewBackupWriter(w io.Writer, compression string, key string) (io.WriteCloser, error) {
+	var closers []io.Closer
+	if key != "" {
+		ew, err := newEncryptWriter(w, key)
+		if err != nil {
+			return nil, err
+		}
+		w = ew
+		closers = append(closers, ew)
+	}
+	switch compression {
+	case BackupCompressionNone:
+	case BackupCompressionGzip:
+		gw := gzip.NewWriter(w)
+		w = gw
+		closers = append(closers, gw)
+	case BackupCompressionZstd:
+		zw, err := zstd.NewWriter(w)
+		if err != nil {
+			return nil, err
+		}
+		w = zw
+		closers = append(closers, zw)
+	default:
+		return nil, errors.Errorf("unsupported backup compression %q", compression)
+	}
+	return &backupWriter{w: w, closers: closers}, nil
+}
+
+type backupWriter struct {
+	w       io.Writer
+	closers []io.Closer
+}
+
+func (w *backupWriter) Write(p []byte) (int, error) {
+	return w.w.Write(p)
+}
+
+// Close closes the compressor before the encryptor.
+func (w *backupWriter) Close() error {
+	for i := len(w.closers) - 1; i >= 0; i-- {
+		if err := w.closers[i].Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewBackupReader returns a reader which decrypts and decompresses a backup written by NewBackupWriter.
+// The encryption and compression are detected from the content, and plain backups are read as is.
+// The key is only required for encrypted backups.
+func NewBackupReader(r io.Reader, key string) (io.ReadCloser, error) {
+	br := bufio.NewReader(r)
+	header, err := br.Peek(len(encryptedBackupMagic))
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	if bytes.Equal(header, []byte(encryptedBackupMagic)) {
+		if key == "" {
+			return nil, errors.New("the backup is encrypted but the encryption key is not provided")
+		}
+		dr, err := newDecryptReader(br, key)
+		if err != nil {
+			return nil, err
+		}
+		br = bufio.NewReader(dr)
+		if header, err = br.Peek(len(zstdMagic)); err != nil && err != io.EOF {
+			return nil, err
+		}
+	}
+
+	switch {
+	case bytes.HasPrefix(header, gzipMagic):
+		return gzip.NewReader(br)
+	case bytes.HasPrefix(header, zstdMagic):
+		zr, err := zstd.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		return zr.IOReadCloser(), nil
+	default:
+		return io.NopCloser(br), nil
+	}
+}
+
+func newBackupCipher(key string) (cipher.AEAD, error) {
+	// Derive a 256-bit key so that the key can be any string.
+	sum := sha256.Sum256([]byte(key))
+	block, err := aes.NewCipher(sum[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// chunkNonce returns the nonce of the i-th chunk by XORing the chunk index into the base nonce.
+func chunkNonce(baseNonce []byte, i uint64) []byte {
+	nonce := make([]byte, len(baseNonce))
+	copy(nonce, baseNonce)
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], i)
+	for j := range counter {
+		nonce[len(nonce)-8+j] ^= counter[j]
+	}
+	return nonce
+}
+
+// encryptWriter encrypts the data in chunks.
+// The format is the magic header, the base nonce, followed by chunks of 4-byte big-endian ciphertext length and the ciphertext.
+type encryptWriter struct {
+	w         io.Writer
+	aead      cipher.AEAD
+	baseNonce []byte
+	index     uint64
+	buf       []byte
+}
+
+func newEncryptWriter(w io.Writer, key string) (*encryptWriter, error) {
+	aead, err := newBackupCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	baseNonce := make([]byte, encryptedBackupNonceSize)
+	if _, err := rand.Read(baseNonce); err != nil {
+		return nil, err
+	}
+	if _, err := io.WriteString(w, encryptedBackupMagic); err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(baseNonce); err != nil {
+		return nil, err
+	}
+	return &encryptWriter{w: w, aead: aead, baseNonce: baseNonce}, nil
+}
+
+func (w *encryptWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		// Keep a full chunk in the buffer, because we don't know whether it's the last chunk until Close.
+		if len(w.buf) == encryptedBackupChunkSize {
+			if err := w.writeChunk(nil); err != nil {
+				return 0, err
+			}
+		}
+		size := encryptedBackupChunkSize - len(w.buf)
+		if size > len(p) {
+			size = len(p)
+		}
+		w.buf = append(w.buf, p[:size]...)
+		p = p[size:]
+	}
+	return n, nil
+}
+
+func (w *encryptWriter) writeChunk(additionalData []byte) error {
+	ciphertext := w.aead.Seal(nil, chunkNonce(w.baseNonce, w.index), w.buf, additionalData)
+	var length [4]byte
+	binary.BigEndian.PutUint32(length[:], uint32(len(ciphertext)))
+	if _, err := w.w.Write(length[:]); err != nil {
+		return err
+	}
+	if _, err := w.w.Write(ciphertext); err != nil {
+		return err
+	}
+	w.index++
+	w.buf = w.buf[:0]
+	return nil
+}
+
+// Close writes the last chunk, which may be empty.
+func (w *encryptWriter) Close() error {
+	return w.writeChunk(lastChunkAdditionalData)
+}
+
+// decryptReader decrypts the data written by encryptWriter.
+type decryptReader struct {
+	r         io.Reader
+	aead      cipher.AEAD
+	baseNonce []byte
+	index     uint64
+	buf       []byte
+	done      bool
+}
+
+func newDecryptReader(r io.Reader, key string) (*decryptReader, error) {
+	aead, err := newBackupCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	header := make([]byte, len(encryptedBackupMagic)+encryptedBackupNonceSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, errors.Wrap(err, "failed to read the encrypted backup header")
+	}
+	return &decryptReader{r: r, aead: aead, baseNonce: header[len(encryptedBackupMagic):]}, nil
+}
+
+func (r *decryptReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		if err := r.readChunk(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func (r *decryptReader) readChunk() error {
+	var length [4]byte
+	if _, err := io.ReadFull(r.r, length[:]); err != nil {
+		if err == io.EOF {
+			return errors.New("the encrypted backup is truncated")
+		}
+		return err
+	}
+	size := binary.BigEndian.Uint32(length[:])
+	if size > encryptedBackupChunkSize+uint32(r.aead.Overhead()) {
+		return errors.Errorf("invalid encrypted chunk size %d", size)
+	}
+	ciphertext := make([]byte, size)
+	if _, err := io.ReadFull(r.r, ciphertext); err != nil {
+		return err
+	}
+	nonce := chunkNonce(r.baseNonce, r.index)
+	plaintext, err := r.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		// The last chunk is authenticated with the additional data.
+		plaintext, err = r.aead.Open(nil, nonce, ciphertext, lastChunkAdditionalData)
+		if err != nil {
+			return errors.New("failed to decrypt the backup, the encryption key may be wrong or the backup is corrupted")
+		}
+		r.done = true
+	}
+	r.index++
+	r.buf = plaintext
+	return nil
+}
@@ -0,0 +1,123 @@
+package common
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupCodec(t *testing.T) {
+	a := require.New(t)
+	// The data spans multiple encrypted chunks.
+	data := strings.Repeat("INSERT INTO `t` VALUES (1, 'hello');\n", 5000)
+	tests := []struct {
+		name        string
+		compression string
+		key         string
+	}{
+		{
+			name:        "plain",
+			compression: BackupCompressionNone,
+		},
+		{
+			name:        "gzip",
+			compression: BackupCompressionGzip,
+		},
+		{
+			name:        "zstd",
+			compression: BackupCompressionZstd,
+		},
+		{
+			name:        "encrypted",
+			compression: BackupCompressionNone,
+			key:         "secret",
+		},
+		{
+			name:        "gzip and encrypted",
+			compression: BackupCompressionGzip,
+			key:         "secret",
+		},
+		{
+			name:        "zstd and encrypted",
+			compression: BackupCompressionZstd,
+			key:         "secret",
+		},
+	}
+
+	for _, test := range tests {
+		var buf bytes.Buffer
+		w, err := NewBackupWriter(&buf, test.compression, test.key)
+		a.NoError(err, test.name)
+		_, err = io.WriteString(w, data)
+		a.NoError(err, test.name)
+		a.NoError(w.Close(), test.name)
+		switch {
+		case test.compression != BackupCompressionNone:
+			a.Less(buf.Len(), len(data)/10, test.name)
+		case test.key != "":
+			a.NotContains(buf.String(), "INSERT INTO", test.name)
+		default:
+			a.Equal(data, buf.String(), test.name)
+		}
+
+		r, err := NewBackupReader(bytes.NewReader(buf.Bytes()), test.key)
+		a.NoError(err, test.name)
+		got, err := io.ReadAll(r)
+		a.NoError(err, test.name)
+		a.NoError(r.Close(), test.name)
+		a.Equal(data, string(got), test.name)
+	}
+}
+
+func TestBackupCodecEncryptionError(t *testing.T) {
+	a := require.New(t)
+	var buf bytes.Buffer
+	w, err := NewBackupWriter(&buf, BackupCompressionNone, "secret")
+	a.NoError(err)
+	_, err = io.WriteString(w, strings.Repeat("a", 100*1024))
+	a.NoError(err)
+	a.NoError(w.Close())
+
+	readAll := func(data []byte, key string) error {
+		r, err := NewBackupReader(bytes.NewReader(data), key)
+		if err != nil {
+			return err
+		}
+		_, err = io.ReadAll(r)
+		return err
+	}
+	// Missing key.
+	a.Error(readAll(buf.Bytes(), ""))
+	// Wrong key.
+	a.Error(readAll(buf.Bytes(), "wrong"))
+	// Truncated in the last chunk.
+	a.Error(readAll(buf.Bytes()[:buf.Len()-100], "secret"))
+	// Truncated after the first chunk.
+	a.Error(readAll(buf.Bytes()[:len(encryptedBackupMagic)+encryptedBackupNonceSize+4+encryptedBackupChunkSize+16], "secret"))
+	a.NoError(readAll(buf.Bytes(), "secret"))
+}
+
+func TestBackupCodecEmpty(t *testing.T) {
+	a := require.New(t)
+	r, err := NewBackupReader(bytes.NewReader(nil), "")
+	a.NoError(err)
+	got, err := io.ReadAll(r)
+	a.NoError(err)
+	a.Empty(got)
+
+	var buf bytes.Buffer
+	w, err := NewBackupWriter(&buf, BackupCompressionGzip, "secret")
+	a.NoError(err)
+	a.NoError(w.Close())
+	r, err = NewBackupReader(bytes.NewReader(buf.Bytes()), "secret")
+	a.NoError(err)
+	got, err = io.ReadAll(r)
+	a.NoError(err)
+	a.Empty(got)
+
+	_, err = NewBackupWriter(&buf, "LZ4", "")
+	a.Error(err)
+}
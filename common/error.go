@@ -33,6 +33,7 @@ const (
 	// MigrationBaselineMissing Code = 204.
 	MigrationPending Code = 205
 	MigrationFailed  Code = 206
+	MigrationLocked  Code = 207
 
 	// 301 task error.
 	TaskTimingNotAllowed Code = 301
@@ -40,6 +41,12 @@ const (
 	// 401 task sql type error.
 	TaskTypeNotDML Code = 401
 	TaskTypeNotDDL Code = 402
+
+	// 501 task statement dry run error.
+	DryRunNotTransactional Code = 501
+	DryRunFailed           Code = 502
+	DryRunLockTimeout      Code = 503
+	DryRunTimeout          Code = 504
 )
 
 // Int returns the int type of code.
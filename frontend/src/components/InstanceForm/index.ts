@@ -1,3 +1,4 @@
 import SslCertificateForm from "./SslCertificateForm.vue";
+import SshTunnelForm from "./SshTunnelForm.vue";
 
-export { SslCertificateForm };
+export { SslCertificateForm, SshTunnelForm };
@@ -2,6 +2,7 @@ import { defineStore } from "pinia";
 import axios from "axios";
 import { computed, onBeforeMount, unref, watch } from "vue";
 import {
+  ActivityId,
   DataSource,
   empty,
   EMPTY_ID,
@@ -396,6 +397,39 @@ export const useInstanceStore = defineStore("instance", {
       });
       return migrationHistory;
     },
+    async acceptModifiedMigration({
+      instanceId,
+      migrationHistoryId,
+      activityId,
+    }: {
+      instanceId: InstanceId;
+      migrationHistoryId: MigrationHistoryId;
+      activityId: ActivityId;
+    }) {
+      const data = (
+        await axios.post(
+          `/api/instance/${instanceId}/migration/history/${migrationHistoryId}/accept-modified`,
+          {
+            data: {
+              type: "migrationHistoryStatementChecksumAccept",
+              attributes: {
+                activityId,
+              },
+            },
+          },
+          {
+            timeout: INSTANCE_OPERATION_TIMEOUT,
+          }
+        )
+      ).data;
+      const migrationHistory = convertMigrationHistory(data.data);
+
+      this.setMigrationHistoryById({
+        migrationHistoryId,
+        migrationHistory,
+      });
+      return migrationHistory;
+    },
     async fetchMigrationHistoryByVersion({
       instanceId,
       databaseName,
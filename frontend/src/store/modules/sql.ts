@@ -8,14 +8,24 @@ import {
   QueryInfo,
   ResourceObject,
   SQLResultSet,
+  SQLQuery,
+  SQLExplainResult,
   Advice,
 } from "@/types";
 import { useDatabaseStore } from "./database";
 import { useInstanceStore } from "./instance";
 
+function convertSQLQuery(query: ResourceObject): SQLQuery {
+  return {
+    ...(query.attributes as Omit<SQLQuery, "id">),
+    id: parseInt(query.id, 10),
+  };
+}
+
 function convert(resultSet: ResourceObject): SQLResultSet {
   return {
     data: JSON.parse((resultSet.attributes.data as string) || "null"),
+    truncated: (resultSet.attributes.truncated as boolean) || false,
     error: resultSet.attributes.error as string,
     adviceList: resultSet.attributes.adviceList as Advice[],
   };
@@ -144,5 +154,36 @@ export const useSQLStore = defineStore("sql", {
 
       return resultSet;
     },
+    async explain(queryInfo: QueryInfo): Promise<SQLExplainResult> {
+      const res = (
+        await axios.post(`/api/sql/explain`, {
+          data: {
+            type: "sqlExplain",
+            attributes: {
+              instanceId: queryInfo.instanceId,
+              databaseName: queryInfo.databaseName,
+              statement: queryInfo.statement,
+            },
+          },
+        })
+      ).data;
+
+      const result = res.data.attributes;
+      if (result.error) {
+        throw new Error(result.error as string);
+      }
+      return {
+        plan: JSON.parse(result.plan as string),
+        error: "",
+        adviceList: result.adviceList as Advice[],
+      };
+    },
+    async fetchRunningQueryList(): Promise<SQLQuery[]> {
+      const data = (await axios.get(`/api/sql/query`)).data.data;
+      return data.map((query: ResourceObject) => convertSQLQuery(query));
+    },
+    async cancelQuery(queryId: number) {
+      await axios.delete(`/api/sql/query/${queryId}`);
+    },
   },
 });
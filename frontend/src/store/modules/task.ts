@@ -92,9 +92,9 @@ function convertTaskProgress(attributes: any): TaskProgress {
   if (!attributes) return unknown("TASK_PROGRESS");
 
   const progress: TaskProgress = { ...attributes };
-  if (typeof attributes.comment === "string") {
+  if (typeof attributes.payload === "string" && attributes.payload !== "") {
     try {
-      progress.payload = JSON.parse(attributes.comment);
+      progress.payload = JSON.parse(attributes.payload);
     } catch {
       progress.payload = undefined;
     }
@@ -1,5 +1,12 @@
 import { FieldId } from "../plugins";
-import { ActivityId, ContainerId, PrincipalId, TaskId } from "./id";
+import {
+  ActivityId,
+  ContainerId,
+  InstanceId,
+  MigrationHistoryId,
+  PrincipalId,
+  TaskId,
+} from "./id";
 import { IssueStatus } from "./issue";
 import { MemberStatus, RoleType } from "./member";
 import { TaskStatus } from "./pipeline";
@@ -16,7 +23,8 @@ export type IssueActivityType =
   | "bb.pipeline.task.status.update"
   | "bb.pipeline.task.file.commit"
   | "bb.pipeline.task.statement.update"
-  | "bb.pipeline.task.general.earliest-allowed-time.update";
+  | "bb.pipeline.task.general.earliest-allowed-time.update"
+  | "bb.pipeline.task.migration.modified";
 
 export type MemberActivityType =
   | "bb.member.create"
@@ -60,6 +68,8 @@ export function activityName(type: ActivityType): string {
       return t("activity.type.pipeline-task-statement-update");
     case "bb.pipeline.task.general.earliest-allowed-time.update":
       return t("activity.type.pipeline-task-earliest-allowed-time-update");
+    case "bb.pipeline.task.migration.modified":
+      return t("activity.type.pipeline-task-migration-modified");
     case "bb.member.create":
       return t("activity.type.member-create");
     case "bb.member.role.update":
@@ -141,6 +151,20 @@ export type ActivityTaskEarliestAllowedTimeUpdatePayload = {
   taskName: string;
 };
 
+export type ActivityTaskMigrationModifiedPayload = {
+  taskId: TaskId;
+  instanceId: InstanceId;
+  migrationHistoryId: MigrationHistoryId;
+  schemaVersion: string;
+  filePath: string;
+  oldStatementChecksum: string;
+  // recorded in the migration history once the modification is accepted
+  newStatementChecksum: string;
+  newStatement: string;
+  issueName: string;
+  taskName: string;
+};
+
 export type ActivityMemberCreatePayload = {
   principalId: PrincipalId;
   principalName: string;
@@ -1,5 +1,6 @@
 import {
   AnomalyId,
+  BackupId,
   BackupPlanPolicySchedule,
   Database,
   DatabaseId,
@@ -15,6 +16,7 @@ export type AnomalyType =
   | "bb.anomaly.instance.migration-schema"
   | "bb.anomaly.database.backup.policy-violation"
   | "bb.anomaly.database.backup.missing"
+  | "bb.anomaly.database.backup.verification-failure"
   | "bb.anomaly.database.connection"
   | "bb.anomaly.database.schema.drift";
 
@@ -33,6 +35,13 @@ export type AnomalyDatabaseBackupMissingPayload = {
   lastBackupTs: number;
 };
 
+export type AnomalyDatabaseBackupVerificationFailurePayload = {
+  backupId: BackupId;
+  backupName: string;
+  verifiedTs: number;
+  detail: string;
+};
+
 export type AnomalyDatabaseConnectionPayload = {
   detail: string;
 };
@@ -41,11 +50,14 @@ export type AnomalyDatabaseSchemaDriftPayload = {
   version: string;
   expect: string;
   actual: string;
+  diff?: string;
+  revertStatement?: string;
 };
 
 export type AnomalyPayload =
   | AnomalyDatabaseBackupPolicyViolationPayload
   | AnomalyDatabaseBackupMissingPayload
+  | AnomalyDatabaseBackupVerificationFailurePayload
   | AnomalyDatabaseConnectionPayload
   | AnomalyDatabaseSchemaDriftPayload;
 
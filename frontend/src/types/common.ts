@@ -540,6 +540,10 @@ const makeUnknown = (type: ResourceType) => {
     createOptions: "",
     comment: "",
     columnList: [],
+    foreignKeyList: [],
+    partitionKey: "",
+    partitionParent: "",
+    partitionBound: "",
   };
 
   switch (type) {
@@ -968,6 +972,10 @@ const makeEmpty = (type: ResourceType) => {
     createOptions: "",
     comment: "",
     columnList: [],
+    foreignKeyList: [],
+    partitionKey: "",
+    partitionParent: "",
+    partitionBound: "",
   };
 
   switch (type) {
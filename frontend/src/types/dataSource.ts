@@ -40,6 +40,16 @@ export type DataSource = {
   hostOverride: string;
   portOverride: string;
 
+  // sshHost, sshPort and sshUser are the SSH tunnel to connect the database, the SSH host is empty if it's connected directly.
+  // sshPassword and sshPrivateKey are write-only.
+  sshHost: string;
+  sshPort: string;
+  sshUser: string;
+  sshPassword?: string;
+  sshPrivateKey?: string;
+  // sshHostKey is the public key or the SHA256 fingerprint of the SSH host to verify it.
+  sshHostKey: string;
+
   // UI-only fields
   updateSsl?: boolean;
 };
@@ -59,6 +69,12 @@ export type DataSourceCreate = {
   sslKey?: string;
   hostOverride: string;
   portOverride: string;
+  sshHost?: string;
+  sshPort?: string;
+  sshUser?: string;
+  sshPassword?: string;
+  sshPrivateKey?: string;
+  sshHostKey?: string;
 };
 
 export type DataSourcePatch = {
@@ -72,6 +88,12 @@ export type DataSourcePatch = {
   sslKey?: string;
   hostOverride?: string;
   portOverride?: string;
+  sshHost?: string;
+  sshPort?: string;
+  sshUser?: string;
+  sshPassword?: string;
+  sshPrivateKey?: string;
+  sshHostKey?: string;
 };
 
 export type DataSourceMember = {
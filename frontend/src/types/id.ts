@@ -55,6 +55,8 @@ export type ColumnId = IdType;
 
 export type TableIndexId = IdType;
 
+export type TableForeignKeyId = IdType;
+
 export type VCSId = IdType;
 
 export type RepositoryId = IdType;
@@ -33,6 +33,7 @@ export * from "./sql";
 export * from "./store";
 export * from "./table";
 export * from "./tableIndex";
+export * from "./tableForeignKey";
 export * from "./vcs";
 export * from "./view";
 export * from "./db_extension";
@@ -81,6 +81,12 @@ export type InstanceCreate = {
   sslCa?: string;
   sslCert?: string;
   sslKey?: string;
+  sshHost?: string;
+  sshPort?: string;
+  sshUser?: string;
+  sshPassword?: string;
+  sshPrivateKey?: string;
+  sshHostKey?: string;
 };
 
 export type InstancePatch = {
@@ -103,12 +109,20 @@ export type InstanceMigration = {
 
 export type MigrationSource = "UI" | "VCS" | "LIBRARY";
 
-export type MigrationType = "BASELINE" | "MIGRATE" | "BRANCH" | "DATA";
+export type MigrationType =
+  | "BASELINE"
+  | "MIGRATE"
+  | "BRANCH"
+  | "DATA"
+  | "UNDO";
 
 export type MigrationStatus = "PENDING" | "DONE" | "FAILED";
 
 export type MigrationHistoryPayload = {
   pushEvent?: VCSPushEvent;
+  undoStatement?: string;
+  undoVersion?: string; // the version reversed by the UNDO migration
+  statementChecksum?: string;
 };
 
 export type MigrationHistory = {
@@ -19,7 +19,8 @@ type IssueTypeDatabase =
   | "bb.issue.database.schema.update"
   | "bb.issue.database.data.update"
   | "bb.issue.database.schema.update.ghost"
-  | "bb.issue.database.restore.pitr";
+  | "bb.issue.database.restore.pitr"
+  | "bb.issue.database.rollback";
 
 type IssueTypeDataSource = "bb.issue.data-source.request";
 
@@ -43,12 +44,26 @@ export type CreateDatabaseContext = {
   labels?: string; // JSON encoded
 };
 
+export type DatabaseGrantContext = {
+  databaseId: DatabaseId;
+  userName: string;
+  // Only applicable to MySQL and TiDB, defaults to "%"
+  host?: string;
+  // Required to create the user, and must be empty for an existing user
+  password?: string;
+  privilegeList: string[];
+  // UNIX timestamp to revoke the privileges, never expires if 0
+  expireTs: number;
+};
+
 export type MigrationDetail = {
   migrationType: MigrationType;
   databaseId: DatabaseId;
   databaseName: string;
   statement: string;
   earliestAllowedTs: number;
+  // Reverses the migration, only applicable to MIGRATE and DATA
+  undoStatement?: string;
 };
 
 export type UpdateSchemaGhostDetail = MigrationDetail & {
@@ -71,14 +86,22 @@ export type PITRContext = {
   createDatabaseContext?: CreateDatabaseContext;
 };
 
+export type DatabaseRollbackContext = {
+  databaseId: DatabaseId;
+  // The version to roll back to, the later migrations are reversed by their undo statements
+  version: string;
+};
+
 // eslint-disable-next-line @typescript-eslint/ban-types
 export type EmptyContext = {};
 
 export type IssueCreateContext =
   | CreateDatabaseContext
+  | DatabaseGrantContext
   | MigrationContext
   | UpdateSchemaGhostContext
   | PITRContext
+  | DatabaseRollbackContext
   | EmptyContext;
 
 export type IssuePayload = { [key: string]: any };
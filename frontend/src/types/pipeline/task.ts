@@ -17,10 +17,12 @@ import { Stage } from "./stage";
 export type TaskType =
   | "bb.task.general"
   | "bb.task.database.create"
+  | "bb.task.database.grant"
   | "bb.task.database.schema.baseline"
   | "bb.task.database.schema.update"
   | "bb.task.database.schema.update-sdl"
   | "bb.task.database.data.update"
+  | "bb.task.database.undo"
   | "bb.task.database.restore"
   | "bb.task.database.schema.update.ghost.sync"
   | "bb.task.database.schema.update.ghost.cutover"
@@ -51,6 +53,13 @@ export type TaskDatabaseCreatePayload = {
   collation: string;
 };
 
+export type TaskDatabaseGrantPayload = {
+  userName: string;
+  host?: string; // only applicable to MySQL and TiDB
+  privilegeList: string[];
+  expireTs?: number; // UNIX timestamp, never expires if empty
+};
+
 export type TaskDatabaseSchemaBaselinePayload = {
   statement: string;
   schemaVersion: string;
@@ -60,6 +69,7 @@ export type TaskDatabaseSchemaBaselinePayload = {
 export type TaskDatabaseSchemaUpdatePayload = {
   statement: string;
   pushEvent?: VCSPushEvent;
+  undoStatement?: string;
 };
 
 export type TaskDatabaseSchemaUpdateSDLPayload = {
@@ -97,6 +107,13 @@ export type TaskDatabasePITRDeletePayload = {
 export type TaskDatabaseDataUpdatePayload = {
   statement: string;
   pushEvent?: VCSPushEvent;
+  undoStatement?: string;
+};
+
+export type TaskDatabaseUndoPayload = {
+  statement: string;
+  schemaVersion: string;
+  undoVersion: string; // the version of the migration to reverse
 };
 
 export type TaskDatabaseRestorePayload = {
@@ -107,11 +124,13 @@ export type TaskDatabaseRestorePayload = {
 export type TaskPayload =
   | TaskGeneralPayload
   | TaskDatabaseCreatePayload
+  | TaskDatabaseGrantPayload
   | TaskDatabaseSchemaBaselinePayload
   | TaskDatabaseSchemaUpdatePayload
   | TaskDatabaseSchemaUpdateGhostSyncPayload
   | TaskDatabaseSchemaUpdateGhostCutoverPayload
   | TaskDatabaseDataUpdatePayload
+  | TaskDatabaseUndoPayload
   | TaskDatabaseRestorePayload
   | TaskEarliestAllowedTimePayload
   | TaskDatabasePITRRestorePayload
@@ -119,7 +138,9 @@ export type TaskPayload =
   | TaskDatabasePITRDeletePayload;
 
 export type TaskProgressPayload = {
-  comment: string;
+  comment?: string;
+  // The statement being executed by the tasks executing the statements one by one.
+  currentStatement?: string;
 };
 
 export type TaskProgress = {
@@ -194,10 +215,16 @@ export type TaskStatusPatch = {
 // TaskRun is one run of a particular task
 export type TaskRunStatus = "RUNNING" | "DONE" | "FAILED" | "CANCELED";
 
+export type TaskRunStatementResult = {
+  statement: string;
+  durationMs: number;
+};
+
 export type TaskRunResultPayload = {
   detail: string;
   migrationId?: MigrationHistoryId;
   version?: string;
+  statementResultList?: TaskRunStatementResult[];
 };
 
 export type TaskRun = {
@@ -227,6 +254,7 @@ export type TaskCheckType =
   | "bb.task-check.database.statement.compatibility"
   | "bb.task-check.database.statement.advise"
   | "bb.task-check.database.statement.type"
+  | "bb.task-check.database.statement.dry-run"
   | "bb.task-check.database.connect"
   | "bb.task-check.instance.migration-schema"
   | "bb.task-check.database.ghost.sync"
@@ -13,7 +13,8 @@ export type PolicyType =
   | "bb.policy.pipeline-approval"
   | "bb.policy.backup-plan"
   | "bb.policy.sql-review"
-  | "bb.policy.environment-tier";
+  | "bb.policy.environment-tier"
+  | "bb.policy.sql-query-timeout";
 
 export type PipelineApprovalPolicyValue =
   | "MANUAL_APPROVAL_NEVER"
@@ -35,6 +36,11 @@ export type EnvironmentTierPolicyPayload = {
 
 export const DefaultEnvironmentTier: EnvironmentTier = "UNPROTECTED";
 
+export type SQLQueryTimeoutPolicyPayload = {
+  // 0 means the SQL editor queries don't time out.
+  timeoutSec: number;
+};
+
 export type BackupPlanPolicySchedule = "UNSET" | "DAILY" | "WEEKLY";
 
 export type BackupPlanPolicyPayload = {
@@ -72,7 +78,8 @@ export type PolicyPayload =
   | PipelineApprovalPolicyPayload
   | BackupPlanPolicyPayload
   | SQLReviewPolicyPayload
-  | EnvironmentTierPolicyPayload;
+  | EnvironmentTierPolicyPayload
+  | SQLQueryTimeoutPolicyPayload;
 
 export type Policy = {
   id: PolicyId;
@@ -17,6 +17,12 @@ export type ConnectionInfo = {
   sslCa?: string;
   sslCert?: string;
   sslKey?: string;
+  sshHost?: string;
+  sshPort?: string;
+  sshUser?: string;
+  sshPassword?: string;
+  sshPrivateKey?: string;
+  sshHostKey?: string;
 };
 
 export type QueryInfo = {
@@ -28,8 +34,38 @@ export type QueryInfo = {
 
 export type Advice = TaskCheckResult;
 
+// ExplainNode is a node of the query plan tree.
+export type ExplainNode = {
+  // The engine specific operation, e.g. "Seq Scan" for PostgreSQL and the access type "ALL" for MySQL.
+  nodeType: string;
+  relation: string;
+  index: string;
+  fullScan: boolean;
+  estimatedRows: number;
+  estimatedCost: number;
+  children: ExplainNode[] | null;
+};
+
+export type SQLExplainResult = {
+  plan: ExplainNode | undefined;
+  error: string;
+  adviceList: Advice[];
+};
+
+// SQLQuery is a running SQL editor query of the current user.
+export type SQLQuery = {
+  id: number;
+  createdTs: number;
+  instanceId: InstanceId;
+  databaseName: string;
+  statement: string;
+  // The time when the query times out, it's 0 if the query doesn't time out.
+  timeoutTs: number;
+};
+
 export type SQLResultSet = {
   data: any[];
+  truncated: boolean;
   error: string;
   adviceList: Advice[];
 };
@@ -2,6 +2,7 @@ import { Column } from "./column";
 import { Database } from "./database";
 import { TableId } from "./id";
 import { Principal } from "./principal";
+import { TableForeignKey } from "./tableForeignKey";
 import { TableIndex } from "./tableIndex";
 
 export type TableType = "BASE TABLE" | "VIEW";
@@ -33,4 +34,8 @@ export type Table = {
   createOptions: string;
   comment: string;
   columnList: Column[];
+  foreignKeyList: TableForeignKey[];
+  partitionKey: string;
+  partitionParent: string;
+  partitionBound: string;
 };
@@ -0,0 +1,25 @@
+import { DatabaseId, TableForeignKeyId, TableId } from "./id";
+
+// Foreign key
+export type TableForeignKey = {
+  id: TableForeignKeyId;
+
+  // Related fields
+  databaseId: DatabaseId;
+  tableId: TableId;
+
+  // Standard fields
+  creatorId: number;
+  createdTs: number;
+  updaterId: number;
+  updatedTs: number;
+
+  // Domain specific fields
+  name: string;
+  column: string;
+  position: number;
+  referencedTable: string;
+  referencedColumn: string;
+  onUpdate: string;
+  onDelete: string;
+};
@@ -19,4 +19,5 @@ export type View = {
   name: string;
   definition: string;
   comment: string;
+  materialized: boolean;
 };
@@ -262,6 +262,27 @@ const (
 
 	// PostgreSQLColumnTypeDisallowList is an advisor type for Postgresql column type disallow list.
 	PostgreSQLColumnTypeDisallowList Type = "bb.plugin.advisor.postgresql.column.type-disallow-list"
+
+	// PostgreSQLStatementDisallowCommit is an advisor type for PostgreSQL to disallow commit.
+	PostgreSQLStatementDisallowCommit Type = "bb.plugin.advisor.postgresql.statement.disallow-commit"
+
+	// PostgreSQLDisallowLimit is an advisor type for PostgreSQL no LIMIT clause in INSERT/UPDATE/DELETE statement.
+	PostgreSQLDisallowLimit Type = "bb.plugin.advisor.postgresql.statement.disallow-limit"
+
+	// PostgreSQLDisallowOrderBy is an advisor type for PostgreSQL no ORDER BY clause in DELETE/UPDATE statement.
+	PostgreSQLDisallowOrderBy Type = "bb.plugin.advisor.postgresql.statement.disallow-order-by"
+
+	// PostgreSQLInsertMustSpecifyColumn is an advisor type for PostgreSQL to enforce column specified.
+	PostgreSQLInsertMustSpecifyColumn Type = "bb.plugin.advisor.postgresql.insert.must-specify-column"
+
+	// PostgreSQLInsertDisallowOrderByRand is an advisor type for PostgreSQL to disallow order by random in INSERT statements.
+	PostgreSQLInsertDisallowOrderByRand Type = "bb.plugin.advisor.postgresql.insert.disallow-order-by-rand"
+
+	// PostgreSQLStatementAffectedRowLimit is an advisor type for PostgreSQL UPDATE/DELETE affected row limit.
+	PostgreSQLStatementAffectedRowLimit Type = "bb.plugin.advisor.postgresql.statement.affected-row-limit"
+
+	// PostgreSQLStatementDMLDryRun is an advisor type for PostgreSQL DML dry run.
+	PostgreSQLStatementDMLDryRun Type = "bb.plugin.advisor.postgresql.statement.dml-dry-run"
 )
 
 // Advice is the result of an advisor.
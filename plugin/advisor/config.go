@@ -74,6 +74,49 @@ func MergeSQLReviewRules(override *SQLReviewConfigOverride) ([]*SQLReviewRule, e
 	return res, nil
 }
 
+// SQLReviewConfig is the SQL review config in YAML format.
+// It's either a rule list in the same format as the templates, or an override extending the template specified by Template.
+type SQLReviewConfig struct {
+	ID       SQLReviewTemplateID  `yaml:"id"`
+	Template SQLReviewTemplateID  `yaml:"template"`
+	RuleList []*SQLReviewRuleData `yaml:"ruleList"`
+}
+
+// UnmarshalSQLReviewConfig unmarshals the YAML config into the SQL review rules.
+func UnmarshalSQLReviewConfig(content []byte) ([]*SQLReviewRule, error) {
+	config := &SQLReviewConfig{}
+	if err := yaml.Unmarshal(content, config); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal the SQL review config")
+	}
+	for _, ruleData := range config.RuleList {
+		switch ruleData.Level {
+		case SchemaRuleLevelError, SchemaRuleLevelWarning, SchemaRuleLevelDisabled:
+		default:
+			// The rule extending the template keeps the level of the template if the level is unspecified.
+			if config.Template != "" && ruleData.Level == "" {
+				continue
+			}
+			return nil, errors.Errorf("invalid level %q for rule %s, the level should be %s, %s or %s", ruleData.Level, ruleData.Type, SchemaRuleLevelError, SchemaRuleLevelWarning, SchemaRuleLevelDisabled)
+		}
+	}
+	if config.Template != "" {
+		return MergeSQLReviewRules(&SQLReviewConfigOverride{
+			Template: config.Template,
+			RuleList: config.RuleList,
+		})
+	}
+
+	var res []*SQLReviewRule
+	for _, ruleData := range config.RuleList {
+		rule, err := mergeRule(ruleData, nil)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, rule)
+	}
+	return res, nil
+}
+
 func parseSQLReviewTemplateList() ([]*SQLReviewTemplateData, error) {
 	prodTemplate := &SQLReviewTemplateData{}
 	devTemplate := &SQLReviewTemplateData{}
@@ -77,3 +77,67 @@ func TestConfigOverride(t *testing.T) {
 		}
 	}
 }
+
+func TestUnmarshalSQLReviewConfig(t *testing.T) {
+	tests := []struct {
+		config  string
+		want    []*SQLReviewRule
+		wantErr bool
+	}{
+		{
+			config: `
+id: my-review
+ruleList:
+  - type: statement.select.no-select-all
+    level: DISABLED
+  - type: naming.table
+    level: WARNING
+    payload:
+      format: "^[a-z]+$"
+      maxLength: 32
+`,
+			want: []*SQLReviewRule{
+				{Type: SchemaRuleStatementNoSelectAll, Level: SchemaRuleLevelDisabled, Payload: "null"},
+				{Type: SchemaRuleTableNaming, Level: SchemaRuleLevelWarning, Payload: `{"format":"^[a-z]+$","maxLength":32}`},
+			},
+		},
+		{
+			config: `
+ruleList:
+  - type: table.require-pk
+    level: TEST
+`,
+			wantErr: true,
+		},
+		{
+			// The invalid level is rejected instead of falling back to the level of the template.
+			config: `
+template: bb.sql-review.prod
+ruleList:
+  - type: table.require-pk
+    level: warn
+`,
+			wantErr: true,
+		},
+		{
+			config: `
+template: bb.sql-review.not-exist
+`,
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		ruleList, err := UnmarshalSQLReviewConfig([]byte(test.config))
+		if test.wantErr {
+			require.Error(t, err)
+			continue
+		}
+		require.NoError(t, err)
+		assert.Equal(t, test.want, ruleList)
+	}
+
+	// The invalid level TEST of the override is rejected.
+	_, err := UnmarshalSQLReviewConfig([]byte(mockConfigOverrideYAMLStr))
+	require.Error(t, err)
+}
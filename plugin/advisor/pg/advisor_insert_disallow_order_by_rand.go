@@ -0,0 +1,92 @@
+package pg
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bytebase/bytebase/plugin/advisor"
+	"github.com/bytebase/bytebase/plugin/advisor/db"
+	"github.com/bytebase/bytebase/plugin/parser/ast"
+)
+
+var (
+	_ advisor.Advisor = (*InsertDisallowOrderByRandAdvisor)(nil)
+	_ ast.Visitor     = (*insertDisallowOrderByRandChecker)(nil)
+)
+
+func init() {
+	advisor.Register(db.Postgres, advisor.PostgreSQLInsertDisallowOrderByRand, &InsertDisallowOrderByRandAdvisor{})
+}
+
+// InsertDisallowOrderByRandAdvisor is the advisor checking for to disallow order by random function for INSERT statement.
+type InsertDisallowOrderByRandAdvisor struct {
+}
+
+// Check checks for to disallow order by random function for INSERT statement.
+func (*InsertDisallowOrderByRandAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	stmts, errAdvice := parseStatement(statement)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	checker := &insertDisallowOrderByRandChecker{
+		level: level,
+		title: string(ctx.Rule.Type),
+	}
+
+	for _, stmt := range stmts {
+		checker.text = stmt.Text()
+		checker.line = stmt.LastLine()
+		ast.Walk(checker, stmt)
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList, nil
+}
+
+type insertDisallowOrderByRandChecker struct {
+	adviceList []advisor.Advice
+	level      advisor.Status
+	title      string
+	text       string
+	line       int
+}
+
+// Visit implements the ast.Visitor interface.
+func (checker *insertDisallowOrderByRandChecker) Visit(node ast.Node) ast.Visitor {
+	if n, ok := node.(*ast.InsertStmt); ok && n.Select != nil {
+		for _, item := range n.Select.OrderByClause {
+			if isRandomFuncCall(item.Expression) {
+				checker.adviceList = append(checker.adviceList, advisor.Advice{
+					Status:  checker.level,
+					Code:    advisor.InsertUseOrderByRand,
+					Title:   checker.title,
+					Content: fmt.Sprintf("\"%s\" uses ORDER BY RANDOM in the INSERT statement", checker.text),
+					Line:    checker.line,
+				})
+				break
+			}
+		}
+	}
+	return checker
+}
+
+func isRandomFuncCall(expression ast.ExpressionNode) bool {
+	funcCall, ok := expression.(*ast.FuncCallDef)
+	if !ok {
+		return false
+	}
+	schema := strings.ToLower(funcCall.Schema)
+	return strings.ToLower(funcCall.Name) == "random" && (schema == "" || schema == "pg_catalog")
+}
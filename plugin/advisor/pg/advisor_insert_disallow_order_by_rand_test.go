@@ -0,0 +1,66 @@
+package pg
+
+// Framework code is generated by the generator.
+
+import (
+	"testing"
+
+	"github.com/bytebase/bytebase/plugin/advisor"
+)
+
+func TestInsertDisallowOrderByRand(t *testing.T) {
+	tests := []advisor.TestCase{
+		{
+			Statement: `INSERT INTO tech_book SELECT * FROM tech_book ORDER BY id`,
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: `SELECT * FROM tech_book ORDER BY random()`,
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: `INSERT INTO tech_book SELECT * FROM tech_book ORDER BY random() LIMIT 10`,
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Warn,
+					Code:    advisor.InsertUseOrderByRand,
+					Title:   "statement.insert.disallow-order-by-rand",
+					Content: "\"INSERT INTO tech_book SELECT * FROM tech_book ORDER BY random() LIMIT 10\" uses ORDER BY RANDOM in the INSERT statement",
+					Line:    1,
+				},
+			},
+		},
+		{
+			Statement: `INSERT INTO tech_book SELECT * FROM tech_book ORDER BY id, pg_catalog.RANDOM()`,
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Warn,
+					Code:    advisor.InsertUseOrderByRand,
+					Title:   "statement.insert.disallow-order-by-rand",
+					Content: "\"INSERT INTO tech_book SELECT * FROM tech_book ORDER BY id, pg_catalog.RANDOM()\" uses ORDER BY RANDOM in the INSERT statement",
+					Line:    1,
+				},
+			},
+		},
+	}
+
+	advisor.RunSQLReviewRuleTests(t, tests, &InsertDisallowOrderByRandAdvisor{}, &advisor.SQLReviewRule{
+		Type:    advisor.SchemaRuleStatementInsertDisallowOrderByRand,
+		Level:   advisor.SchemaRuleLevelWarning,
+		Payload: "",
+	}, advisor.MockPostgreSQLDatabase)
+}
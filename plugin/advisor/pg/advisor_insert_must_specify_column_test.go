@@ -0,0 +1,55 @@
+package pg
+
+// Framework code is generated by the generator.
+
+import (
+	"testing"
+
+	"github.com/bytebase/bytebase/plugin/advisor"
+)
+
+func TestInsertMustSpecifyColumn(t *testing.T) {
+	tests := []advisor.TestCase{
+		{
+			Statement: `INSERT INTO tech_book(id, name) VALUES(1, 'a')`,
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: `INSERT INTO tech_book VALUES(1, 'a')`,
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Warn,
+					Code:    advisor.InsertNotSpecifyColumn,
+					Title:   "statement.insert.must-specify-column",
+					Content: "The INSERT statement must specify columns but \"INSERT INTO tech_book VALUES(1, 'a')\" does not",
+					Line:    1,
+				},
+			},
+		},
+		{
+			Statement: `INSERT INTO tech_book SELECT * FROM tech_book`,
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Warn,
+					Code:    advisor.InsertNotSpecifyColumn,
+					Title:   "statement.insert.must-specify-column",
+					Content: "The INSERT statement must specify columns but \"INSERT INTO tech_book SELECT * FROM tech_book\" does not",
+					Line:    1,
+				},
+			},
+		},
+	}
+
+	advisor.RunSQLReviewRuleTests(t, tests, &InsertMustSpecifyColumnAdvisor{}, &advisor.SQLReviewRule{
+		Type:    advisor.SchemaRuleStatementInsertMustSpecifyColumn,
+		Level:   advisor.SchemaRuleLevelWarning,
+		Payload: "",
+	}, advisor.MockPostgreSQLDatabase)
+}
@@ -0,0 +1,153 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/plugin/advisor"
+	"github.com/bytebase/bytebase/plugin/advisor/db"
+	"github.com/bytebase/bytebase/plugin/parser/ast"
+)
+
+var (
+	_ advisor.Advisor = (*StatementAffectedRowLimitAdvisor)(nil)
+	_ ast.Visitor     = (*statementAffectedRowLimitChecker)(nil)
+)
+
+func init() {
+	advisor.Register(db.Postgres, advisor.PostgreSQLStatementAffectedRowLimit, &StatementAffectedRowLimitAdvisor{})
+}
+
+// StatementAffectedRowLimitAdvisor is the advisor checking for UPDATE/DELETE affected row limit.
+type StatementAffectedRowLimitAdvisor struct {
+}
+
+// Check checks for UPDATE/DELETE affected row limit.
+func (*StatementAffectedRowLimitAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	stmts, errAdvice := parseStatement(statement)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := advisor.UnmarshalNumberTypeRulePayload(ctx.Rule.Payload)
+	if err != nil {
+		return nil, err
+	}
+	checker := &statementAffectedRowLimitChecker{
+		level:  level,
+		title:  string(ctx.Rule.Type),
+		maxRow: payload.Number,
+		driver: ctx.Driver,
+		ctx:    ctx.Context,
+	}
+
+	if checker.driver != nil {
+		for _, stmt := range stmts {
+			checker.text = stmt.Text()
+			checker.line = stmt.LastLine()
+			ast.Walk(checker, stmt)
+		}
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList, nil
+}
+
+type statementAffectedRowLimitChecker struct {
+	adviceList []advisor.Advice
+	level      advisor.Status
+	title      string
+	text       string
+	line       int
+	maxRow     int
+	driver     *sql.DB
+	ctx        context.Context
+}
+
+// Visit implements the ast.Visitor interface.
+func (checker *statementAffectedRowLimitChecker) Visit(node ast.Node) ast.Visitor {
+	switch node.(type) {
+	case *ast.UpdateStmt, *ast.DeleteStmt:
+		plan, err := explain(checker.ctx, checker.driver, checker.text)
+		if err != nil {
+			checker.adviceList = append(checker.adviceList, advisor.Advice{
+				Status:  checker.level,
+				Code:    advisor.StatementAffectedRowExceedsLimit,
+				Title:   checker.title,
+				Content: fmt.Sprintf("\"%s\" dry runs failed: %s", checker.text, err.Error()),
+				Line:    checker.line,
+			})
+			break
+		}
+		rowCount, err := getAffectedRows(plan)
+		if err != nil {
+			checker.adviceList = append(checker.adviceList, advisor.Advice{
+				Status:  checker.level,
+				Code:    advisor.Internal,
+				Title:   checker.title,
+				Content: fmt.Sprintf("failed to get row count for \"%s\": %s", checker.text, err.Error()),
+				Line:    checker.line,
+			})
+		} else if rowCount > int64(checker.maxRow) {
+			checker.adviceList = append(checker.adviceList, advisor.Advice{
+				Status:  checker.level,
+				Code:    advisor.StatementAffectedRowExceedsLimit,
+				Title:   checker.title,
+				Content: fmt.Sprintf("\"%s\" affected %d rows. The count exceeds %d.", checker.text, rowCount, checker.maxRow),
+				Line:    checker.line,
+			})
+		}
+	}
+	return checker
+}
+
+// explain returns the plan of the statement in JSON format without executing it.
+func explain(ctx context.Context, connection *sql.DB, statement string) (string, error) {
+	var plan string
+	if err := connection.QueryRowContext(ctx, fmt.Sprintf("EXPLAIN (FORMAT JSON) %s", statement)).Scan(&plan); err != nil {
+		return "", err
+	}
+	return plan, nil
+}
+
+type explainPlanNode struct {
+	NodeType string             `json:"Node Type"`
+	PlanRows int64              `json:"Plan Rows"`
+	Plans    []*explainPlanNode `json:"Plans"`
+}
+
+// getAffectedRows returns the estimated affected rows from the plan in JSON format.
+func getAffectedRows(plan string) (int64, error) {
+	var planList []struct {
+		Plan *explainPlanNode `json:"Plan"`
+	}
+	if err := json.Unmarshal([]byte(plan), &planList); err != nil {
+		return 0, errors.Wrapf(err, "failed to unmarshal the plan %q", plan)
+	}
+	if len(planList) == 0 || planList[0].Plan == nil {
+		return 0, errors.Errorf("not found any plan in %q", plan)
+	}
+	node := planList[0].Plan
+	// The ModifyTable node estimates 0 rows without RETURNING, so we use the rows of the scan it modifies.
+	//
+	// [{"Plan": {"Node Type": "ModifyTable", "Operation": "Delete", "Plan Rows": 0, "Plans": [{"Node Type": "Seq Scan", "Plan Rows": 1000}]}}]
+	if node.NodeType == "ModifyTable" && len(node.Plans) > 0 {
+		return node.Plans[0].PlanRows, nil
+	}
+	return node.PlanRows, nil
+}
@@ -0,0 +1,74 @@
+package pg
+
+// Framework code is generated by the generator.
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/plugin/advisor"
+)
+
+func TestStatementAffectedRowLimit(t *testing.T) {
+	tests := []advisor.TestCase{
+		{
+			Statement: `UPDATE tech_book SET id = 1`,
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: `DELETE FROM tech_book`,
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+	}
+
+	payload, err := json.Marshal(advisor.NumberTypeRulePayload{
+		Number: 5,
+	})
+	require.NoError(t, err)
+	advisor.RunSQLReviewRuleTests(t, tests, &StatementAffectedRowLimitAdvisor{}, &advisor.SQLReviewRule{
+		Type:    advisor.SchemaRuleStatementAffectedRowLimit,
+		Level:   advisor.SchemaRuleLevelWarning,
+		Payload: string(payload),
+	}, advisor.MockPostgreSQLDatabase)
+}
+
+func TestGetAffectedRows(t *testing.T) {
+	tests := []struct {
+		plan string
+		want int64
+	}{
+		{
+			plan: `[{"Plan": {"Node Type": "ModifyTable", "Operation": "Delete", "Plan Rows": 0, "Plans": [{"Node Type": "Seq Scan", "Plan Rows": 1000}]}}]`,
+			want: 1000,
+		},
+		{
+			plan: `[{"Plan": {"Node Type": "Update", "Plan Rows": 10, "Plans": [{"Node Type": "Index Scan", "Plan Rows": 10}]}}]`,
+			want: 10,
+		},
+	}
+
+	for _, test := range tests {
+		rows, err := getAffectedRows(test.plan)
+		require.NoError(t, err)
+		require.Equal(t, test.want, rows)
+	}
+
+	_, err := getAffectedRows(`[]`)
+	require.Error(t, err)
+}
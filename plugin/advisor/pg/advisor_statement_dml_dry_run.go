@@ -0,0 +1,88 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/bytebase/bytebase/plugin/advisor"
+	"github.com/bytebase/bytebase/plugin/advisor/db"
+	"github.com/bytebase/bytebase/plugin/parser/ast"
+)
+
+var (
+	_ advisor.Advisor = (*StatementDMLDryRunAdvisor)(nil)
+	_ ast.Visitor     = (*statementDMLDryRunChecker)(nil)
+)
+
+func init() {
+	advisor.Register(db.Postgres, advisor.PostgreSQLStatementDMLDryRun, &StatementDMLDryRunAdvisor{})
+}
+
+// StatementDMLDryRunAdvisor is the advisor checking for DML dry run.
+type StatementDMLDryRunAdvisor struct {
+}
+
+// Check checks for DML dry run.
+func (*StatementDMLDryRunAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	stmts, errAdvice := parseStatement(statement)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	checker := &statementDMLDryRunChecker{
+		level:  level,
+		title:  string(ctx.Rule.Type),
+		driver: ctx.Driver,
+		ctx:    ctx.Context,
+	}
+
+	if checker.driver != nil {
+		for _, stmt := range stmts {
+			checker.text = stmt.Text()
+			checker.line = stmt.LastLine()
+			ast.Walk(checker, stmt)
+		}
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList, nil
+}
+
+type statementDMLDryRunChecker struct {
+	adviceList []advisor.Advice
+	level      advisor.Status
+	title      string
+	text       string
+	line       int
+	driver     *sql.DB
+	ctx        context.Context
+}
+
+// Visit implements the ast.Visitor interface.
+func (checker *statementDMLDryRunChecker) Visit(node ast.Node) ast.Visitor {
+	switch node.(type) {
+	case *ast.InsertStmt, *ast.UpdateStmt, *ast.DeleteStmt:
+		if _, err := explain(checker.ctx, checker.driver, checker.text); err != nil {
+			checker.adviceList = append(checker.adviceList, advisor.Advice{
+				Status:  checker.level,
+				Code:    advisor.StatementDMLDryRunFailed,
+				Title:   checker.title,
+				Content: fmt.Sprintf("\"%s\" dry runs failed: %s", checker.text, err.Error()),
+				Line:    checker.line,
+			})
+		}
+	}
+	return checker
+}
@@ -0,0 +1,54 @@
+package pg
+
+// Framework code is generated by the generator.
+
+import (
+	"testing"
+
+	"github.com/bytebase/bytebase/plugin/advisor"
+)
+
+func TestStatementDisallowCommit(t *testing.T) {
+	tests := []advisor.TestCase{
+		{
+			Statement: "COMMIT;",
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Warn,
+					Code:    advisor.StatementDisallowCommit,
+					Title:   "statement.disallow-commit",
+					Content: "Commit is not allowed, related statement: \"COMMIT;\"",
+					Line:    1,
+				},
+			},
+		},
+		{
+			Statement: "BEGIN;\nROLLBACK;",
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: "DELETE FROM t1;",
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+	}
+
+	advisor.RunSQLReviewRuleTests(t, tests, &StatementDisallowCommitAdvisor{}, &advisor.SQLReviewRule{
+		Type:    advisor.SchemaRuleStatementDisallowCommit,
+		Level:   advisor.SchemaRuleLevelWarning,
+		Payload: "",
+	}, advisor.MockPostgreSQLDatabase)
+}
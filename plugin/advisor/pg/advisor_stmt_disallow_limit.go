@@ -0,0 +1,104 @@
+package pg
+
+import (
+	"fmt"
+
+	"github.com/bytebase/bytebase/plugin/advisor"
+	"github.com/bytebase/bytebase/plugin/advisor/db"
+	"github.com/bytebase/bytebase/plugin/parser/ast"
+)
+
+var (
+	_ advisor.Advisor = (*DisallowLimitAdvisor)(nil)
+	_ ast.Visitor     = (*disallowLimitChecker)(nil)
+)
+
+func init() {
+	advisor.Register(db.Postgres, advisor.PostgreSQLDisallowLimit, &DisallowLimitAdvisor{})
+}
+
+// DisallowLimitAdvisor is the advisor checking for no LIMIT clause in INSERT/UPDATE/DELETE statement.
+type DisallowLimitAdvisor struct {
+}
+
+// Check checks for no LIMIT clause in INSERT/UPDATE/DELETE statement.
+func (*DisallowLimitAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	stmts, errAdvice := parseStatement(statement)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	checker := &disallowLimitChecker{
+		level: level,
+		title: string(ctx.Rule.Type),
+	}
+
+	for _, stmt := range stmts {
+		checker.text = stmt.Text()
+		checker.line = stmt.LastLine()
+		ast.Walk(checker, stmt)
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList, nil
+}
+
+type disallowLimitChecker struct {
+	adviceList []advisor.Advice
+	level      advisor.Status
+	title      string
+	text       string
+	line       int
+}
+
+// Visit implements the ast.Visitor interface.
+func (checker *disallowLimitChecker) Visit(node ast.Node) ast.Visitor {
+	code := advisor.Ok
+	switch n := node.(type) {
+	case *ast.InsertStmt:
+		if n.Select != nil && n.Select.LimitCount != nil {
+			code = advisor.InsertUseLimit
+		}
+	// PostgreSQL doesn't support LIMIT in UPDATE and DELETE statements directly,
+	// so we check the subqueries used to limit the affected rows instead.
+	case *ast.UpdateStmt:
+		if subqueryUseLimit(n.SubqueryList) {
+			code = advisor.UpdateUseLimit
+		}
+	case *ast.DeleteStmt:
+		if subqueryUseLimit(n.SubqueryList) {
+			code = advisor.DeleteUseLimit
+		}
+	}
+
+	if code != advisor.Ok {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  checker.level,
+			Code:    code,
+			Title:   checker.title,
+			Content: fmt.Sprintf("LIMIT clause is forbidden in INSERT, UPDATE and DELETE statement, but \"%s\" uses", checker.text),
+			Line:    checker.line,
+		})
+	}
+	return checker
+}
+
+func subqueryUseLimit(subqueryList []*ast.SubqueryDef) bool {
+	for _, subquery := range subqueryList {
+		if subquery.Select != nil && subquery.Select.LimitCount != nil {
+			return true
+		}
+	}
+	return false
+}
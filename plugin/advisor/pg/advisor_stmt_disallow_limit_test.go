@@ -0,0 +1,78 @@
+package pg
+
+// Framework code is generated by the generator.
+
+import (
+	"testing"
+
+	"github.com/bytebase/bytebase/plugin/advisor"
+)
+
+func TestDisallowLimit(t *testing.T) {
+	tests := []advisor.TestCase{
+		{
+			Statement: `INSERT INTO tech_book SELECT * FROM tech_book`,
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: `DELETE FROM tech_book WHERE id IN (SELECT id FROM tech_book)`,
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Success,
+					Code:    advisor.Ok,
+					Title:   "OK",
+					Content: "",
+				},
+			},
+		},
+		{
+			Statement: `INSERT INTO tech_book SELECT * FROM tech_book LIMIT 10`,
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Warn,
+					Code:    advisor.InsertUseLimit,
+					Title:   "statement.disallow-limit",
+					Content: "LIMIT clause is forbidden in INSERT, UPDATE and DELETE statement, but \"INSERT INTO tech_book SELECT * FROM tech_book LIMIT 10\" uses",
+					Line:    1,
+				},
+			},
+		},
+		{
+			Statement: `UPDATE tech_book SET name = 'a' WHERE id IN (SELECT id FROM tech_book LIMIT 10)`,
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Warn,
+					Code:    advisor.UpdateUseLimit,
+					Title:   "statement.disallow-limit",
+					Content: "LIMIT clause is forbidden in INSERT, UPDATE and DELETE statement, but \"UPDATE tech_book SET name = 'a' WHERE id IN (SELECT id FROM tech_book LIMIT 10)\" uses",
+					Line:    1,
+				},
+			},
+		},
+		{
+			Statement: `DELETE FROM tech_book WHERE id IN (SELECT id FROM tech_book ORDER BY id FETCH FIRST 10 ROWS ONLY)`,
+			Want: []advisor.Advice{
+				{
+					Status:  advisor.Warn,
+					Code:    advisor.DeleteUseLimit,
+					Title:   "statement.disallow-limit",
+					Content: "LIMIT clause is forbidden in INSERT, UPDATE and DELETE statement, but \"DELETE FROM tech_book WHERE id IN (SELECT id FROM tech_book ORDER BY id FETCH FIRST 10 ROWS ONLY)\" uses",
+					Line:    1,
+				},
+			},
+		},
+	}
+
+	advisor.RunSQLReviewRuleTests(t, tests, &DisallowLimitAdvisor{}, &advisor.SQLReviewRule{
+		Type:    advisor.SchemaRuleStatementDisallowLimit,
+		Level:   advisor.SchemaRuleLevelWarning,
+		Payload: "",
+	}, advisor.MockPostgreSQLDatabase)
+}
@@ -0,0 +1,100 @@
+package pg
+
+import (
+	"fmt"
+
+	"github.com/bytebase/bytebase/plugin/advisor"
+	"github.com/bytebase/bytebase/plugin/advisor/db"
+	"github.com/bytebase/bytebase/plugin/parser/ast"
+)
+
+var (
+	_ advisor.Advisor = (*DisallowOrderByAdvisor)(nil)
+	_ ast.Visitor     = (*disallowOrderByChecker)(nil)
+)
+
+func init() {
+	advisor.Register(db.Postgres, advisor.PostgreSQLDisallowOrderBy, &DisallowOrderByAdvisor{})
+}
+
+// DisallowOrderByAdvisor is the advisor checking for no ORDER BY clause in DELETE/UPDATE statement.
+type DisallowOrderByAdvisor struct {
+}
+
+// Check checks for no ORDER BY clause in DELETE/UPDATE statement.
+func (*DisallowOrderByAdvisor) Check(ctx advisor.Context, statement string) ([]advisor.Advice, error) {
+	stmts, errAdvice := parseStatement(statement)
+	if errAdvice != nil {
+		return errAdvice, nil
+	}
+
+	level, err := advisor.NewStatusBySQLReviewRuleLevel(ctx.Rule.Level)
+	if err != nil {
+		return nil, err
+	}
+	checker := &disallowOrderByChecker{
+		level: level,
+		title: string(ctx.Rule.Type),
+	}
+
+	for _, stmt := range stmts {
+		checker.text = stmt.Text()
+		checker.line = stmt.LastLine()
+		ast.Walk(checker, stmt)
+	}
+
+	if len(checker.adviceList) == 0 {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  advisor.Success,
+			Code:    advisor.Ok,
+			Title:   "OK",
+			Content: "",
+		})
+	}
+	return checker.adviceList, nil
+}
+
+type disallowOrderByChecker struct {
+	adviceList []advisor.Advice
+	level      advisor.Status
+	title      string
+	text       string
+	line       int
+}
+
+// Visit implements the ast.Visitor interface.
+func (checker *disallowOrderByChecker) Visit(node ast.Node) ast.Visitor {
+	code := advisor.Ok
+	// PostgreSQL doesn't support ORDER BY in UPDATE and DELETE statements directly,
+	// so we check the subqueries used to order the affected rows instead.
+	switch n := node.(type) {
+	case *ast.UpdateStmt:
+		if subqueryUseOrderBy(n.SubqueryList) {
+			code = advisor.UpdateUseOrderBy
+		}
+	case *ast.DeleteStmt:
+		if subqueryUseOrderBy(n.SubqueryList) {
+			code = advisor.DeleteUseOrderBy
+		}
+	}
+
+	if code != advisor.Ok {
+		checker.adviceList = append(checker.adviceList, advisor.Advice{
+			Status:  checker.level,
+			Code:    code,
+			Title:   checker.title,
+			Content: fmt.Sprintf("ORDER BY clause is forbidden in DELETE and UPDATE statements, but \"%s\" uses", checker.text),
+			Line:    checker.line,
+		})
+	}
+	return checker
+}
+
+func subqueryUseOrderBy(subqueryList []*ast.SubqueryDef) bool {
+	for _, subquery := range subqueryList {
+		if subquery.Select != nil && len(subquery.Select.OrderByClause) > 0 {
+			return true
+		}
+	}
+	return false
+}
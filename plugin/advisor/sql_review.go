@@ -830,6 +830,8 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine db.Type) (Type, err
 		switch engine {
 		case db.MySQL, db.TiDB:
 			return MySQLStatementDisallowCommit, nil
+		case db.Postgres:
+			return PostgreSQLStatementDisallowCommit, nil
 		}
 	case SchemaRuleCharsetAllowlist:
 		switch engine {
@@ -864,21 +866,29 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine db.Type) (Type, err
 		switch engine {
 		case db.MySQL, db.TiDB:
 			return MySQLInsertMustSpecifyColumn, nil
+		case db.Postgres:
+			return PostgreSQLInsertMustSpecifyColumn, nil
 		}
 	case SchemaRuleStatementInsertDisallowOrderByRand:
 		switch engine {
 		case db.MySQL, db.TiDB:
 			return MySQLInsertDisallowOrderByRand, nil
+		case db.Postgres:
+			return PostgreSQLInsertDisallowOrderByRand, nil
 		}
 	case SchemaRuleStatementDisallowLimit:
 		switch engine {
 		case db.MySQL, db.TiDB:
 			return MySQLDisallowLimit, nil
+		case db.Postgres:
+			return PostgreSQLDisallowLimit, nil
 		}
 	case SchemaRuleStatementDisallowOrderBy:
 		switch engine {
 		case db.MySQL, db.TiDB:
 			return MySQLDisallowOrderBy, nil
+		case db.Postgres:
+			return PostgreSQLDisallowOrderBy, nil
 		}
 	case SchemaRuleStatementMergeAlterTable:
 		switch engine {
@@ -886,13 +896,18 @@ func getAdvisorTypeByRule(ruleType SQLReviewRuleType, engine db.Type) (Type, err
 			return MySQLMergeAlterTable, nil
 		}
 	case SchemaRuleStatementAffectedRowLimit:
-		if engine == db.MySQL {
+		switch engine {
+		case db.MySQL:
 			return MySQLStatementAffectedRowLimit, nil
+		case db.Postgres:
+			return PostgreSQLStatementAffectedRowLimit, nil
 		}
 	case SchemaRuleStatementDMLDryRun:
 		switch engine {
 		case db.MySQL, db.TiDB:
 			return MySQLStatementDMLDryRun, nil
+		case db.Postgres:
+			return PostgreSQLStatementDMLDryRun, nil
 		}
 	}
 	return Fake, errors.Errorf("unknown SQL review rule type %v for %v", ruleType, engine)
@@ -226,6 +226,16 @@ func (*MockDriver) Query(_ context.Context, statement string, _ int, _ bool) ([]
 	}, nil
 }
 
+// QueryRows implements the Driver interface.
+func (*MockDriver) QueryRows(_ context.Context, _ string, _ int, _ bool) (database.Rows, error) {
+	return nil, errors.Errorf("MockDriver doesn't support QueryRows")
+}
+
+// Explain implements the Driver interface.
+func (*MockDriver) Explain(_ context.Context, _ string) (*database.ExplainNode, error) {
+	return nil, errors.Errorf("MockDriver doesn't support Explain")
+}
+
 // SyncInstance implements the Driver interface.
 func (*MockDriver) SyncInstance(_ context.Context) (*database.InstanceMeta, error) {
 	return nil, nil
@@ -256,6 +266,11 @@ func (*MockDriver) FindMigrationHistoryList(_ context.Context, _ *database.Migra
 	return nil, nil
 }
 
+// UpdateMigrationHistoryStatementChecksum implements the Driver interface.
+func (*MockDriver) UpdateMigrationHistoryStatementChecksum(_ context.Context, _ int, _ string) error {
+	return nil
+}
+
 // Dump implements the Driver interface.
 func (*MockDriver) Dump(_ context.Context, _ string, _ io.Writer, _ bool) (string, error) {
 	return "", nil
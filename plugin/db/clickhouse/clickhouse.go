@@ -10,6 +10,7 @@ import (
 
 	clickhouse "github.com/ClickHouse/clickhouse-go/v2"
 	"github.com/pkg/errors"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
 	"github.com/bytebase/bytebase/common"
@@ -38,6 +39,8 @@ type Driver struct {
 	dbType        db.Type
 
 	db *sql.DB
+	// sshTunnel is the SSH tunnel to connect the database, it's nil if the SSH tunnel isn't configured.
+	sshTunnel *db.SSHTunnel
 }
 
 func newDriver(db.DriverConfig) db.Driver {
@@ -50,12 +53,20 @@ func (driver *Driver) Open(_ context.Context, dbType db.Type, config db.Connecti
 	if port == "" {
 		port = "9000"
 	}
-	addr := fmt.Sprintf("%s:%s", config.Host, port)
 	// Set SSL configuration.
 	tlsConfig, err := config.TLSConfig.GetSslConfig()
 	if err != nil {
 		return nil, errors.Wrap(err, "sql: tls config error")
 	}
+	// Connect the local end of the SSH tunnel instead of using the custom dialer of ClickHouse, which skips the TLS handshake.
+	sshTunnel, config, err := db.OpenSSHTunnelForConnection(config, port)
+	if err != nil {
+		return nil, err
+	}
+	if sshTunnel != nil {
+		port = config.Port
+	}
+	addr := fmt.Sprintf("%s:%s", config.Host, port)
 	// Default user name is "default".
 	conn := clickhouse.OpenDB(&clickhouse.Options{
 		Addr: []string{addr},
@@ -81,6 +92,7 @@ func (driver *Driver) Open(_ context.Context, dbType db.Type, config db.Connecti
 
 	driver.dbType = dbType
 	driver.db = conn
+	driver.sshTunnel = sshTunnel
 	driver.connectionCtx = connCtx
 
 	return driver, nil
@@ -88,7 +100,11 @@ func (driver *Driver) Open(_ context.Context, dbType db.Type, config db.Connecti
 
 // Close closes the driver.
 func (driver *Driver) Close(context.Context) error {
-	return driver.db.Close()
+	err := driver.db.Close()
+	if driver.sshTunnel != nil {
+		err = multierr.Append(err, driver.sshTunnel.Close())
+	}
+	return err
 }
 
 // Ping pings the database.
@@ -144,3 +160,13 @@ func (driver *Driver) Execute(ctx context.Context, statement string) error {
 func (driver *Driver) Query(ctx context.Context, statement string, limit int, readOnly bool) ([]interface{}, error) {
 	return util.Query(ctx, driver.dbType, driver.db, statement, limit, readOnly)
 }
+
+// QueryRows queries a SQL statement and returns the cursor of the result.
+func (driver *Driver) QueryRows(ctx context.Context, statement string, limit int, readOnly bool) (db.Rows, error) {
+	return util.QueryRows(ctx, driver.dbType, driver.db, statement, limit, readOnly)
+}
+
+// Explain returns the query plan tree of the statement.
+func (*Driver) Explain(context.Context, string) (*db.ExplainNode, error) {
+	return nil, errors.Errorf("explain is not supported for %s", db.ClickHouse)
+}
@@ -22,7 +22,7 @@ CREATE TABLE bytebase.migration_history (
     -- We call it source because maybe we could load history from other migration tool.
     -- Current allowed values are UI, VCS, LIBRARY.
     source TEXT NOT NULL,
-    -- Current allowed values are BASELINE, MIGRATE, MIGRATE_SDL, BRANCH, DATA.
+    -- Current allowed values are BASELINE, MIGRATE, MIGRATE_SDL, BRANCH, DATA, UNDO.
     type TEXT NOT NULL,
     -- Current allowed values are PENDING, DONE, FAILED.
     -- MySQL runs DDL in its own transaction, so we can't record DDL and migration_history into a single transaction.
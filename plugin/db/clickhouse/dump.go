@@ -30,17 +30,24 @@ const (
 		"-- View structure for `%s`\n" +
 		"--\n" +
 		"%s;\n"
+	tableDataFmt = "" +
+		"--\n" +
+		"-- Data for table `%s`\n" +
+		"--\n"
+	// dataInsertBatchSize is the max number of rows in an INSERT statement.
+	dataInsertBatchSize = 1000
 )
 
 // Dump dumps the database.
-func (driver *Driver) Dump(ctx context.Context, database string, out io.Writer, _ bool) (string, error) {
+// ClickHouse doesn't support snapshot reads, so the table data isn't guaranteed to be consistent across tables.
+func (driver *Driver) Dump(ctx context.Context, database string, out io.Writer, schemaOnly bool) (string, error) {
 	txn, err := driver.db.BeginTx(ctx, &sql.TxOptions{})
 	if err != nil {
 		return "", err
 	}
 	defer txn.Rollback()
 
-	if err := dumpTxn(ctx, txn, database, out); err != nil {
+	if err := dumpTxn(ctx, txn, database, out, schemaOnly); err != nil {
 		return "", err
 	}
 
@@ -73,8 +80,8 @@ func getDatabases(ctx context.Context, txn *sql.Tx) ([]string, error) {
 	return dbNames, nil
 }
 
-// dumpTxn will dump the input database. schemaOnly isn't supported yet and true by default.
-func dumpTxn(ctx context.Context, txn *sql.Tx, database string, out io.Writer) error {
+// dumpTxn will dump the input database. The table data is dumped as INSERT statements if schemaOnly is false.
+func dumpTxn(ctx context.Context, txn *sql.Tx, database string, out io.Writer, schemaOnly bool) error {
 	// Find all dumpable databases
 	dbNames, err := getDatabases(ctx, txn)
 	if err != nil {
@@ -135,6 +142,18 @@ func dumpTxn(ctx context.Context, txn *sql.Tx, database string, out io.Writer) e
 				return err
 			}
 		}
+
+		if schemaOnly {
+			continue
+		}
+		for _, tbl := range tables {
+			if !hasTableData(tbl) {
+				continue
+			}
+			if err := exportTableData(ctx, txn, dbName, tbl.name, out); err != nil {
+				return errors.Wrapf(err, "failed to export data of table %q in database %q", tbl.name, dbName)
+			}
+		}
 	}
 
 	return nil
@@ -193,6 +212,126 @@ func getTables(ctx context.Context, txn *sql.Tx, dbName string) ([]*tableSchema,
 	return tables, nil
 }
 
+// hasTableData returns true if the table stores data itself, so that the data should be dumped.
+// The tables of other engines, such as View, Distributed and Kafka, read data from elsewhere.
+// The inner tables of materialized views are skipped as well, because they are created along with the materialized views.
+func hasTableData(tbl *tableSchema) bool {
+	if strings.HasPrefix(tbl.name, ".inner") {
+		return false
+	}
+	switch tbl.tableType {
+	case "TinyLog", "StripeLog", "Log", "Memory":
+		return true
+	default:
+		return strings.HasSuffix(tbl.tableType, "MergeTree")
+	}
+}
+
+// getInsertableColumns gets the columns which can be inserted into, that is, except the MATERIALIZED, ALIAS and EPHEMERAL columns.
+func getInsertableColumns(ctx context.Context, txn *sql.Tx, dbName, tableName string) ([]string, error) {
+	query := "SELECT name FROM system.columns WHERE database = ? AND table = ? AND default_kind NOT IN ('MATERIALIZED', 'ALIAS', 'EPHEMERAL') ORDER BY position"
+	rows, err := txn.QueryContext(ctx, query, dbName, tableName)
+	if err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	defer rows.Close()
+
+	var columns []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		columns = append(columns, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return columns, nil
+}
+
+// exportTableData dumps the table data as INSERT statements.
+// The rows are formatted in the Values format by ClickHouse, in which the special characters of strings are escaped,
+// so that each INSERT statement is in a single line.
+func exportTableData(ctx context.Context, txn *sql.Tx, dbName, tableName string, out io.Writer) error {
+	columns, err := getInsertableColumns(ctx, txn, dbName, tableName)
+	if err != nil {
+		return err
+	}
+	if len(columns) == 0 {
+		return nil
+	}
+	var quotedColumns []string
+	for _, column := range columns {
+		quotedColumns = append(quotedColumns, quoteIdentifier(column))
+	}
+	columnList := strings.Join(quotedColumns, ", ")
+	query := fmt.Sprintf("SELECT formatRowNoNewline('Values', %s) FROM %s.%s", columnList, quoteIdentifier(dbName), quoteIdentifier(tableName))
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return util.FormatErrorWithQuery(err, query)
+	}
+	defer rows.Close()
+
+	w := newInsertWriter(out, fmt.Sprintf("INSERT INTO %s (%s) VALUES ", quoteIdentifier(tableName), columnList), fmt.Sprintf(tableDataFmt, tableName))
+	for rows.Next() {
+		var row string
+		if err := rows.Scan(&row); err != nil {
+			return err
+		}
+		if err := w.writeRow(row); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	return w.flush()
+}
+
+// insertWriter writes rows into INSERT statements with at most dataInsertBatchSize rows each.
+// The header is written before the first statement, so nothing is written for empty tables.
+type insertWriter struct {
+	out         io.Writer
+	prefix      string
+	header      string
+	rows        []string
+	wroteHeader bool
+}
+
+func newInsertWriter(out io.Writer, prefix, header string) *insertWriter {
+	return &insertWriter{out: out, prefix: prefix, header: header}
+}
+
+func (w *insertWriter) writeRow(row string) error {
+	w.rows = append(w.rows, row)
+	if len(w.rows) >= dataInsertBatchSize {
+		return w.flush()
+	}
+	return nil
+}
+
+func (w *insertWriter) flush() error {
+	if len(w.rows) == 0 {
+		return nil
+	}
+	if !w.wroteHeader {
+		if _, err := io.WriteString(w.out, w.header); err != nil {
+			return err
+		}
+		w.wroteHeader = true
+	}
+	if _, err := io.WriteString(w.out, fmt.Sprintf("%s%s;\n", w.prefix, strings.Join(w.rows, ","))); err != nil {
+		return err
+	}
+	w.rows = w.rows[:0]
+	return nil
+}
+
+func quoteIdentifier(name string) string {
+	return fmt.Sprintf("`%s`", strings.ReplaceAll(name, "`", "``"))
+}
+
 // Restore restores a database.
 func (driver *Driver) Restore(ctx context.Context, sc io.Reader) (err error) {
 	txn, err := driver.db.BeginTx(ctx, nil)
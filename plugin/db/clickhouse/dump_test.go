@@ -0,0 +1,44 @@
+package clickhouse
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHasTableData(t *testing.T) {
+	tests := []struct {
+		table *tableSchema
+		want  bool
+	}{
+		{table: &tableSchema{name: "t", tableType: "MergeTree"}, want: true},
+		{table: &tableSchema{name: "t", tableType: "ReplacingMergeTree"}, want: true},
+		{table: &tableSchema{name: "t", tableType: "Log"}, want: true},
+		{table: &tableSchema{name: "v", tableType: "View"}, want: false},
+		{table: &tableSchema{name: "d", tableType: "Distributed"}, want: false},
+		{table: &tableSchema{name: ".inner_id.0a1b", tableType: "MergeTree"}, want: false},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.want, hasTableData(test.table), test.table.name)
+	}
+}
+
+func TestInsertWriter(t *testing.T) {
+	var out strings.Builder
+	w := newInsertWriter(&out, "INSERT INTO `t` (`a`) VALUES ", "-- header\n")
+	require.NoError(t, w.flush())
+	require.Equal(t, "", out.String())
+
+	for i := 0; i < dataInsertBatchSize+1; i++ {
+		require.NoError(t, w.writeRow(fmt.Sprintf("(%d)", i)))
+	}
+	require.NoError(t, w.flush())
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	require.Len(t, lines, 3)
+	require.Equal(t, "-- header", lines[0])
+	require.True(t, strings.HasPrefix(lines[1], "INSERT INTO `t` (`a`) VALUES (0),(1),"))
+	require.True(t, strings.HasSuffix(lines[1], fmt.Sprintf(",(%d);", dataInsertBatchSize-1)))
+	require.Equal(t, fmt.Sprintf("INSERT INTO `t` (`a`) VALUES (%d);", dataInsertBatchSize), lines[2])
+}
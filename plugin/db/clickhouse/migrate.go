@@ -215,11 +215,29 @@ func (Driver) UpdateHistoryAsFailed(ctx context.Context, tx *sql.Tx, migrationDu
 	return err
 }
 
+// UpdateHistoryPayload will update the payload of the migration record.
+func (Driver) UpdateHistoryPayload(ctx context.Context, tx *sql.Tx, payload string, id int64) error {
+	const updateHistoryPayloadQuery = `
+		ALTER TABLE
+			bytebase.migration_history
+		UPDATE
+			payload = $1
+		WHERE id = $2
+	`
+	_, err := tx.ExecContext(ctx, updateHistoryPayloadQuery, payload, id)
+	return err
+}
+
 // ExecuteMigration will execute the migration.
 func (driver *Driver) ExecuteMigration(ctx context.Context, m *db.MigrationInfo, statement string) (int64, string, error) {
 	return util.ExecuteMigration(ctx, driver, m, statement, db.BytebaseDatabase)
 }
 
+// UpdateMigrationHistoryStatementChecksum updates the statement checksum recorded in the migration history payload.
+func (driver *Driver) UpdateMigrationHistoryStatementChecksum(ctx context.Context, id int, checksum string) error {
+	return util.UpdateMigrationHistoryStatementChecksum(ctx, driver, id, checksum, db.BytebaseDatabase)
+}
+
 // FindMigrationHistoryList finds the migration history.
 func (driver *Driver) FindMigrationHistoryList(ctx context.Context, find *db.MigrationHistoryFind) ([]*db.MigrationHistory, error) {
 	baseQuery := `
@@ -10,6 +10,7 @@ import (
 	"regexp"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -36,6 +37,9 @@ const (
 
 	// BytebaseDatabase is the database installed in the controlled database server.
 	BytebaseDatabase = "bytebase"
+
+	// DefaultMigrationLockTimeout is the default max duration to wait for the migration lock of a database.
+	DefaultMigrationLockTimeout = 5 * time.Minute
 )
 
 // User is the database user.
@@ -62,6 +66,43 @@ type Extension struct {
 	Description string
 }
 
+// Function is the database function.
+type Function struct {
+	Name string
+	// Arguments is the argument list without the function name, such as "a integer, b text".
+	// Functions are identified by both name and arguments because of overloading.
+	Arguments  string
+	ReturnType string
+	Language   string
+	Definition string
+	Comment    string
+}
+
+// Sequence is the database sequence.
+type Sequence struct {
+	Name       string
+	DataType   string
+	StartValue int64
+	MinValue   int64
+	MaxValue   int64
+	Increment  int64
+	Cycle      bool
+	// OwnedBy is the column owning the sequence in the format of "table.column", empty if the sequence isn't owned.
+	OwnedBy string
+}
+
+// Trigger is the database trigger.
+type Trigger struct {
+	Name      string
+	TableName string
+	// Timing is BEFORE, AFTER or INSTEAD OF.
+	Timing string
+	// Event is the events firing the trigger, such as "INSERT OR UPDATE".
+	Event      string
+	Definition string
+	Comment    string
+}
+
 // Index is the database index.
 type Index struct {
 	Name string
@@ -94,6 +135,21 @@ type Column struct {
 	Comment string
 }
 
+// ForeignKey is the database foreign key.
+// A multi-column foreign key has one entry per column ordered by Position.
+type ForeignKey struct {
+	Name     string
+	Column   string
+	Position int
+	// ReferencedTable is named the same way as Table.Name of the referenced table.
+	// It is prefixed with the database name for MySQL if the referenced table is in another database.
+	ReferencedTable  string
+	ReferencedColumn string
+	// OnUpdate and OnDelete are referential actions such as "CASCADE" and "NO ACTION".
+	OnUpdate string
+	OnDelete string
+}
+
 // Table is the database table.
 type Table struct {
 	Name string
@@ -120,6 +176,17 @@ type Table struct {
 	ColumnList []Column
 	// IndexList isn't supported for ClickHouse, Snowflake.
 	IndexList []Index
+	// ForeignKeyList isn't supported for ClickHouse, Snowflake, SQLite.
+	ForeignKeyList []ForeignKey
+	// PartitionKey is the partition key of a partitioned table, such as "RANGE (created_ts)".
+	// PartitionKey is only supported for Postgres.
+	PartitionKey string
+	// PartitionParent is the parent table name if the table is a partition.
+	// PartitionParent is only supported for Postgres.
+	PartitionParent string
+	// PartitionBound is the partition bound of a partition, such as "FOR VALUES FROM (1) TO (100)".
+	// PartitionBound is only supported for Postgres.
+	PartitionBound string
 }
 
 // InstanceMeta is the metadata for an instance.
@@ -148,6 +215,14 @@ type Schema struct {
 	TableList     []Table
 	ViewList      []View
 	ExtensionList []Extension
+	// MaterializedViewList is only supported for Postgres.
+	MaterializedViewList []View
+	// FunctionList is only supported for Postgres.
+	FunctionList []Function
+	// SequenceList is only supported for Postgres.
+	SequenceList []Sequence
+	// TriggerList is only supported for Postgres.
+	TriggerList []Trigger
 }
 
 var (
@@ -161,6 +236,11 @@ type DriverConfig struct {
 	// We use resource directory to splice the path of embedded binary, likes binaries in mysqlutil package.
 	ResourceDir string
 	BinlogDir   string
+	// NativePgDump dumps PostgreSQL databases without the pg_dump binary if true.
+	NativePgDump bool
+	// DumpParallelism is the number of connections used to dump MySQL table data concurrently.
+	// The table data is dumped serially if it's not greater than 1.
+	DumpParallelism int
 }
 
 type driverFunc func(DriverConfig) Driver
@@ -198,6 +278,9 @@ const (
 	// Data is the migration type for DATA.
 	// Used for DML change.
 	Data MigrationType = "DATA"
+	// Undo is the migration type for UNDO.
+	// Used for reversing an applied migration with its undo statement, the reversed version is recorded in MigrationInfoPayload.UndoVersion.
+	Undo MigrationType = "UNDO"
 )
 
 // MigrationStatus is the status of migration.
@@ -215,6 +298,13 @@ const (
 // MigrationInfoPayload is the API message for migration info payload.
 type MigrationInfoPayload struct {
 	VCSPushEvent *vcs.PushEvent `json:"pushEvent,omitempty"`
+	// UndoStatement is the statement to reverse the migration, such as the paired undo file in VCS.
+	UndoStatement string `json:"undoStatement,omitempty"`
+	// UndoVersion is the version reversed by the UNDO migration.
+	UndoVersion string `json:"undoVersion,omitempty"`
+	// StatementChecksum is the checksum of the normalized statement, used to detect the modified migration files of the applied versions.
+	// It's replaced by the checksum of the modified file once the modification is accepted.
+	StatementChecksum string `json:"statementChecksum,omitempty"`
 }
 
 // MigrationInfo is the API message for migration info.
@@ -245,6 +335,18 @@ type MigrationInfo struct {
 	// This applies to BASELINE and MIGRATE types of migrations because most of these migrations are retry-able.
 	// We don't use force option for DATA type of migrations yet till there's customer needs.
 	Force bool
+	// LockTimeout is the max duration to wait for the migration lock of the namespace, DefaultMigrationLockTimeout is used if it's 0.
+	LockTimeout time.Duration
+	// ProgressReporter is notified of the statement-level progress of the migration if it's set.
+	ProgressReporter StatementProgressReporter
+}
+
+// StatementProgressReporter is notified when the statements of a migration are executed one by one.
+type StatementProgressReporter interface {
+	// OnStatementStart is called before executing the statement of the index (starting from 0) among the total statements.
+	OnStatementStart(index, total int, statement string)
+	// OnStatementDone is called after the statement of the index is executed successfully.
+	OnStatementDone(index int, duration time.Duration)
 }
 
 // placeholderRegexp is the regexp for placeholder.
@@ -308,8 +410,10 @@ func ParseMigrationInfo(filePath, filePathTemplate string, allowOmitDatabaseName
 					mi.Type = Migrate
 				case "ddl":
 					mi.Type = Migrate
+				case "undo":
+					mi.Type = Undo
 				default:
-					return nil, errors.Errorf("file path %q contains invalid migration type %q, must be 'migrate'('ddl') or 'data'('dml')", filePath, matchList[index])
+					return nil, errors.Errorf("file path %q contains invalid migration type %q, must be 'migrate'('ddl'), 'data'('dml') or 'undo'", filePath, matchList[index])
 				}
 			case "DESCRIPTION":
 				mi.Description = matchList[index]
@@ -330,6 +434,8 @@ func ParseMigrationInfo(filePath, filePathTemplate string, allowOmitDatabaseName
 			mi.Description = fmt.Sprintf("Create %s baseline", mi.Database)
 		case Data:
 			mi.Description = fmt.Sprintf("Create %s data change", mi.Database)
+		case Undo:
+			mi.Description = fmt.Sprintf("Create %s undo migration", mi.Database)
 		default:
 			mi.Description = fmt.Sprintf("Create %s schema migration", mi.Database)
 		}
@@ -436,6 +542,8 @@ type ConnectionConfig struct {
 	ReadOnly bool
 	// StrictUseDb will only set as true if the user gives only a database instead of a whole instance to access.
 	StrictUseDb bool
+	// SSHConfig is the SSH tunnel to connect the database, it's not used if the host is empty.
+	SSHConfig SSHConfig
 }
 
 // ConnectionContext is the context for connection.
@@ -445,6 +553,62 @@ type ConnectionContext struct {
 	InstanceName    string
 }
 
+// QueryColumn is the column metadata of a query result.
+type QueryColumn struct {
+	Name string
+	// TypeName is the database system name of the column type in upper case, such as "VARCHAR", "INT4" and "DECIMAL".
+	TypeName string
+	// Nullable is false if the driver doesn't report the nullability.
+	Nullable bool
+	// Length is the length of variable length types such as text and binary, 0 if not applicable or not reported by the driver.
+	Length int64
+	// Precision and Scale are set for decimal types, 0 if not applicable or not reported by the driver.
+	Precision int64
+	Scale     int64
+}
+
+// ExplainNode is a node of the query plan tree, normalized from the engine specific EXPLAIN output.
+type ExplainNode struct {
+	// NodeType is the engine specific operation of the node, such as "Seq Scan" for Postgres and the access type "ALL" for MySQL.
+	NodeType string `json:"nodeType"`
+	// Relation is the table read by the node, empty if the node doesn't read a table.
+	Relation string `json:"relation"`
+	// Index is the index used by the node, empty if the node doesn't use an index.
+	Index string `json:"index"`
+	// FullScan is true if the node reads all rows of the relation.
+	FullScan bool `json:"fullScan"`
+	// EstimatedRows is the number of rows estimated by the optimizer.
+	EstimatedRows float64 `json:"estimatedRows"`
+	// EstimatedCost is the total cost of the node including its children estimated by the optimizer, in the engine specific unit.
+	EstimatedCost float64        `json:"estimatedCost"`
+	Children      []*ExplainNode `json:"children"`
+}
+
+// Walk calls fn for the node and its descendants in depth-first order.
+func (n *ExplainNode) Walk(fn func(*ExplainNode)) {
+	fn(n)
+	for _, child := range n.Children {
+		child.Walk(fn)
+	}
+}
+
+// Rows is the cursor of a query result.
+// Rows are read from the database incrementally, so callers can stop reading at any time without loading the whole result set.
+// Remember to call Close to release the underlying connection.
+type Rows interface {
+	// Columns returns the column metadata of the query result.
+	Columns() []QueryColumn
+	// Next prepares the next row for reading with Values.
+	// It returns false if there is no next row or an error happened, and Err should be consulted to distinguish between the two cases.
+	Next() bool
+	// Values returns the values of the current row.
+	// A value is one of nil, bool, int64, float64 and string.
+	Values() ([]interface{}, error)
+	// Err returns the error, if any, that was encountered during iteration.
+	Err() error
+	Close() error
+}
+
 // Driver is the interface for database driver.
 type Driver interface {
 	// General execution
@@ -460,7 +624,13 @@ type Driver interface {
 	Execute(ctx context.Context, statement string) error
 	// Used for execute readonly SELECT statement
 	// limit is the maximum row count returned. No limit enforced if limit <= 0
+	// The result is fully materialized as [columnNames, columnTypeNames, rows], use QueryRows for large result sets.
 	Query(ctx context.Context, statement string, limit int, readOnly bool) ([]interface{}, error)
+	// QueryRows is the cursor-style version of Query, the rows are yielded incrementally.
+	// limit is the maximum row count returned. No limit enforced if limit <= 0
+	QueryRows(ctx context.Context, statement string, limit int, readOnly bool) (Rows, error)
+	// Explain returns the query plan tree of the statement without executing it.
+	Explain(ctx context.Context, statement string) (*ExplainNode, error)
 
 	// Sync schema
 	// SyncInstance syncs the instance metadata.
@@ -479,6 +649,8 @@ type Driver interface {
 	ExecuteMigration(ctx context.Context, m *MigrationInfo, statement string) (int64, string, error)
 	// Find the migration history list and return most recent item first.
 	FindMigrationHistoryList(ctx context.Context, find *MigrationHistoryFind) ([]*MigrationHistory, error)
+	// UpdateMigrationHistoryStatementChecksum updates the statement checksum recorded in the migration history payload.
+	UpdateMigrationHistoryStatementChecksum(ctx context.Context, id int, checksum string) error
 
 	// Dump and restore
 	// Dump the database, if dbName is empty, then dump all databases.
@@ -229,6 +229,25 @@ func TestParseMigrationInfo(t *testing.T) {
 			},
 			wantErr: "",
 		},
+		{
+			filePath:         "db1##001foo##undo##create_table_t1",
+			filePathTemplate: "{{DB_NAME}}##{{VERSION}}##{{TYPE}}##{{DESCRIPTION}}",
+			want: &MigrationInfo{
+				Version:     "001foo",
+				Namespace:   "db1",
+				Database:    "db1",
+				Source:      VCS,
+				Type:        Undo,
+				Description: "Create table t1",
+			},
+			wantErr: "",
+		},
+		{
+			filePath:         "db1##001foo##down",
+			filePathTemplate: "{{DB_NAME}}##{{VERSION}}##{{TYPE}}",
+			want:             nil,
+			wantErr:          "invalid migration type \"down\"",
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.filePath, func(t *testing.T) {
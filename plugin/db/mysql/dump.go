@@ -10,6 +10,8 @@ import (
 	"os/exec"
 	"regexp"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.uber.org/zap"
@@ -88,6 +90,9 @@ var (
 func (driver *Driver) Dump(ctx context.Context, database string, out io.Writer, schemaOnly bool) (string, error) {
 	// mysqldump -u root --databases dbName --no-data --routines --events --triggers --compact
 
+	atomic.StoreInt64(&driver.dumpCompletedTables, 0)
+	atomic.StoreInt64(&driver.dumpTotalTables, 0)
+
 	// We must use the same MySQL connection to lock and unlock tables.
 	conn, err := driver.db.Conn(ctx)
 	if err != nil {
@@ -96,6 +101,7 @@ func (driver *Driver) Dump(ctx context.Context, database string, out io.Writer,
 	defer conn.Close()
 
 	var payloadBytes []byte
+	var dataConns []*sql.Conn
 	// Before we dump the real data, we should record the binlog position for PITR.
 	// Please refer to https://github.com/bytebase/bytebase/blob/main/docs/design/pitr-mysql.md#full-backup for details.
 	if !schemaOnly {
@@ -119,6 +125,16 @@ func (driver *Driver) Dump(ctx context.Context, database string, out io.Writer,
 		if err != nil {
 			return "", err
 		}
+
+		// The data connections must start their snapshots while we are still holding the read locks,
+		// so that all of them see the same data at the binlog position above.
+		if driver.dumpParallelism > 1 {
+			dataConns, err = driver.beginConsistentSnapshotConns(ctx, driver.dumpParallelism)
+			if err != nil {
+				return "", errors.Wrap(err, "failed to begin consistent snapshot for parallel dump")
+			}
+			defer closeConsistentSnapshotConns(dataConns)
+		}
 	}
 
 	options := sql.TxOptions{}
@@ -135,8 +151,8 @@ func (driver *Driver) Dump(ctx context.Context, database string, out io.Writer,
 	}
 	defer txn.Rollback()
 
-	log.Debug("begin to dump database", zap.String("database", database), zap.Bool("schemaOnly", schemaOnly))
-	if err := dumpTxn(ctx, txn, database, out, schemaOnly); err != nil {
+	log.Debug("begin to dump database", zap.String("database", database), zap.Bool("schemaOnly", schemaOnly), zap.Int("dataConnections", len(dataConns)))
+	if err := driver.dumpTxn(ctx, txn, dataConns, database, out, schemaOnly); err != nil {
 		return "", err
 	}
 
@@ -183,7 +199,46 @@ func FlushTablesWithReadLock(ctx context.Context, conn *sql.Conn, database strin
 	return txn.Commit()
 }
 
-func dumpTxn(ctx context.Context, txn *sql.Tx, database string, out io.Writer, schemaOnly bool) error {
+// beginConsistentSnapshotConns opens n connections, each in a transaction with a consistent snapshot.
+func (driver *Driver) beginConsistentSnapshotConns(ctx context.Context, n int) ([]*sql.Conn, error) {
+	stmt := "START TRANSACTION WITH CONSISTENT SNAPSHOT"
+	// TiDB does not support readonly, so we only set for MySQL.
+	if driver.dbType == "MYSQL" {
+		stmt += ", READ ONLY"
+	}
+	var conns []*sql.Conn
+	for i := 0; i < n; i++ {
+		conn, err := driver.db.Conn(ctx)
+		if err != nil {
+			closeConsistentSnapshotConns(conns)
+			return nil, err
+		}
+		conns = append(conns, conn)
+		if _, err := conn.ExecContext(ctx, "SET SESSION TRANSACTION ISOLATION LEVEL REPEATABLE READ"); err != nil {
+			closeConsistentSnapshotConns(conns)
+			return nil, err
+		}
+		if _, err := conn.ExecContext(ctx, stmt); err != nil {
+			closeConsistentSnapshotConns(conns)
+			return nil, err
+		}
+	}
+	return conns, nil
+}
+
+func closeConsistentSnapshotConns(conns []*sql.Conn) {
+	for _, conn := range conns {
+		if _, err := conn.ExecContext(context.Background(), "ROLLBACK"); err != nil {
+			log.Warn("failed to rollback the consistent snapshot transaction", zap.Error(err))
+		}
+		conn.Close()
+	}
+}
+
+// dumpTxn dumps the database in the transaction.
+// If dataConns isn't empty, the table data is exported concurrently with the data connections instead of the transaction.
+// The data connections must be in transactions with the same consistent snapshot as the transaction.
+func (driver *Driver) dumpTxn(ctx context.Context, txn *sql.Tx, dataConns []*sql.Conn, database string, out io.Writer, schemaOnly bool) error {
 	// Find all dumpable databases
 	dbNames, err := getDatabases(ctx, txn)
 	if err != nil {
@@ -247,6 +302,17 @@ func dumpTxn(ctx context.Context, txn *sql.Tx, database string, out io.Writer, s
 		if err != nil {
 			return errors.Wrapf(err, "failed to get tables of database %q", dbName)
 		}
+		var baseTableNames []string
+		for _, tbl := range tables {
+			if tbl.TableType == baseTableType {
+				baseTableNames = append(baseTableNames, tbl.Name)
+			}
+		}
+		if !schemaOnly {
+			atomic.AddInt64(&driver.dumpTotalTables, int64(len(baseTableNames)))
+		}
+		// Include db prefix if dumping multiple databases.
+		includeDbPrefix := len(dumpableDbNames) > 1
 		// Construct temporal views.
 		// Create a temporary view with the same name as the view and with columns of
 		// the same name in order to satisfy views that depend on this view.
@@ -276,12 +342,17 @@ func dumpTxn(ctx context.Context, txn *sql.Tx, database string, out io.Writer, s
 			if _, err := io.WriteString(out, fmt.Sprintf("%s\n", tbl.Statement)); err != nil {
 				return err
 			}
-			if !schemaOnly {
-				// Include db prefix if dumping multiple databases.
-				includeDbPrefix := len(dumpableDbNames) > 1
-				if err := exportTableData(txn, dbName, tbl.Name, includeDbPrefix, out); err != nil {
+			if !schemaOnly && len(dataConns) == 0 {
+				if err := exportTableData(ctx, txn, dbName, tbl.Name, includeDbPrefix, out); err != nil {
 					return err
 				}
+				atomic.AddInt64(&driver.dumpCompletedTables, 1)
+			}
+		}
+		// Export the table data concurrently after all tables are created.
+		if !schemaOnly && len(dataConns) > 0 {
+			if err := driver.exportTableDataConcurrently(ctx, dataConns, dbName, baseTableNames, includeDbPrefix, out); err != nil {
+				return err
 			}
 		}
 		// Construct final views.
@@ -518,10 +589,105 @@ func getViewColumns(txn *sql.Tx, dbName, tblName string) ([]string, error) {
 	return fields, nil
 }
 
+// exportTableDataConcurrently exports the data of tables with a worker per data connection.
+// The INSERT statements of different tables are interleaved in the output, and each chunk of statements is written atomically.
+func (driver *Driver) exportTableDataConcurrently(ctx context.Context, dataConns []*sql.Conn, dbName string, tableNames []string, includeDbPrefix bool, out io.Writer) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	tableCh := make(chan string)
+	errCh := make(chan error, len(dataConns))
+	var outLock sync.Mutex
+	var wg sync.WaitGroup
+	for _, conn := range dataConns {
+		wg.Add(1)
+		go func(conn *sql.Conn) {
+			defer wg.Done()
+			for tblName := range tableCh {
+				w := &chunkWriter{out: out, lock: &outLock}
+				if err := exportTableData(ctx, conn, dbName, tblName, includeDbPrefix, w); err != nil {
+					errCh <- errors.Wrapf(err, "failed to export data of table %q", tblName)
+					cancel()
+					return
+				}
+				if err := w.Flush(); err != nil {
+					errCh <- err
+					cancel()
+					return
+				}
+				atomic.AddInt64(&driver.dumpCompletedTables, 1)
+			}
+		}(conn)
+	}
+
+	go func() {
+		defer close(tableCh)
+		for _, tblName := range tableNames {
+			select {
+			case tableCh <- tblName:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	wg.Wait()
+
+	select {
+	case err := <-errCh:
+		return err
+	default:
+		return ctx.Err()
+	}
+}
+
+// chunkWriterSize is the size of the statements buffered before writing to the shared output.
+const chunkWriterSize = 1024 * 1024
+
+// chunkWriter buffers the statements of a table and writes them to the shared output in chunks.
+// It only splits the output at the write boundaries, so a statement written in one call is never interleaved.
+type chunkWriter struct {
+	out  io.Writer
+	lock *sync.Mutex
+	buf  bytes.Buffer
+}
+
+func (w *chunkWriter) Write(p []byte) (int, error) {
+	n, _ := w.buf.Write(p)
+	if w.buf.Len() >= chunkWriterSize {
+		if err := w.Flush(); err != nil {
+			return 0, err
+		}
+	}
+	return n, nil
+}
+
+// Flush writes the buffered statements to the shared output.
+func (w *chunkWriter) Flush() error {
+	if w.buf.Len() == 0 {
+		return nil
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if _, err := w.buf.WriteTo(w.out); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetDumpProgress gets the number of tables whose data has been dumped and the total number of tables to dump.
+func (driver *Driver) GetDumpProgress() (completed int64, total int64) {
+	return atomic.LoadInt64(&driver.dumpCompletedTables), atomic.LoadInt64(&driver.dumpTotalTables)
+}
+
+// queryer is the interface to query in a transaction or on a connection.
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
 // exportTableData gets the data of a table.
-func exportTableData(txn *sql.Tx, dbName, tblName string, includeDbPrefix bool, out io.Writer) error {
+func exportTableData(ctx context.Context, q queryer, dbName, tblName string, includeDbPrefix bool, out io.Writer) error {
 	query := fmt.Sprintf("SELECT * FROM `%s`.`%s`;", dbName, tblName)
-	rows, err := txn.Query(query)
+	rows, err := q.QueryContext(ctx, query)
 	if err != nil {
 		return err
 	}
@@ -1,6 +1,10 @@
 package mysql
 
 import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -45,3 +49,40 @@ func TestExcludeSchemaAutoIncrementValue(t *testing.T) {
 		require.Equal(t, test.want, got)
 	}
 }
+
+func TestChunkWriter(t *testing.T) {
+	a := require.New(t)
+	var out bytes.Buffer
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	// Each statement is larger than a tenth of the chunk size, so that the writers flush in the middle.
+	stmtSize := chunkWriterSize / 10
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			w := &chunkWriter{out: &out, lock: &lock}
+			for j := 0; j < 50; j++ {
+				stmt := fmt.Sprintf("INSERT INTO `t%d` VALUES ('%s');\n", i, strings.Repeat("x", stmtSize))
+				_, err := w.Write([]byte(stmt))
+				a.NoError(err)
+			}
+			a.NoError(w.Flush())
+		}(i)
+	}
+	wg.Wait()
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	a.Len(lines, 200)
+	counts := make(map[string]int)
+	for _, line := range lines {
+		// Statements must not be interleaved.
+		a.True(strings.HasPrefix(line, "INSERT INTO `t"))
+		a.True(strings.HasSuffix(line, "');"))
+		a.Len(line, len("INSERT INTO `t0` VALUES ('');")+stmtSize)
+		counts[line[:len("INSERT INTO `t0`")]]++
+	}
+	for i := 0; i < 4; i++ {
+		a.Equal(50, counts[fmt.Sprintf("INSERT INTO `t%d`", i)])
+	}
+}
@@ -0,0 +1,131 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/plugin/db"
+	"github.com/bytebase/bytebase/plugin/db/util"
+)
+
+// Explain returns the query plan tree of the statement from EXPLAIN FORMAT=JSON.
+func (driver *Driver) Explain(ctx context.Context, statement string) (*db.ExplainNode, error) {
+	if driver.dbType == db.TiDB {
+		// TiDB doesn't support EXPLAIN FORMAT=JSON in the MySQL format.
+		return nil, errors.Errorf("explain is not supported for %s", driver.dbType)
+	}
+	tx, err := driver.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := fmt.Sprintf("EXPLAIN FORMAT=JSON %s", strings.TrimRight(statement, " \n\t;"))
+	var plan string
+	if err := tx.QueryRowContext(ctx, query).Scan(&plan); err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	return parseExplainJSON(plan)
+}
+
+// parseExplainJSON parses the output of EXPLAIN FORMAT=JSON, which looks like
+// {"query_block": {"cost_info": {...}, "nested_loop": [{"table": {...}}, ...]}}.
+// The plan nodes are the JSON objects, we keep the "table" nodes and the structural nodes such as "nested_loop" and "ordering_operation".
+func parseExplainJSON(plan string) (*db.ExplainNode, error) {
+	var root map[string]interface{}
+	if err := json.Unmarshal([]byte(plan), &root); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal explain plan %q", plan)
+	}
+	children := parseExplainChildren(root)
+	if len(children) == 1 {
+		return children[0], nil
+	}
+	return &db.ExplainNode{
+		NodeType: "plan",
+		Children: children,
+	}, nil
+}
+
+func parseExplainNode(nodeType string, object map[string]interface{}) *db.ExplainNode {
+	node := &db.ExplainNode{
+		NodeType: nodeType,
+	}
+	costInfo, _ := object["cost_info"].(map[string]interface{})
+	if nodeType == "table" {
+		// The access type tells how MySQL reads the table, see https://dev.mysql.com/doc/refman/8.0/en/explain-output.html#explain-join-types.
+		accessType, _ := object["access_type"].(string)
+		if accessType != "" {
+			node.NodeType = accessType
+		}
+		node.Relation, _ = object["table_name"].(string)
+		node.Index, _ = object["key"].(string)
+		node.FullScan = accessType == "ALL"
+		// MySQL 5.6 reports "rows" instead of "rows_examined_per_scan".
+		if rows, ok := getExplainNumber(object["rows_examined_per_scan"]); ok {
+			node.EstimatedRows = rows
+		} else if rows, ok := getExplainNumber(object["rows"]); ok {
+			node.EstimatedRows = rows
+		}
+		node.EstimatedCost, _ = getExplainNumber(costInfo["prefix_cost"])
+	} else {
+		node.EstimatedCost, _ = getExplainNumber(costInfo["query_cost"])
+	}
+	node.Children = parseExplainChildren(object)
+	return node
+}
+
+func parseExplainChildren(object map[string]interface{}) []*db.ExplainNode {
+	var keys []string
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var children []*db.ExplainNode
+	for _, key := range keys {
+		switch value := object[key].(type) {
+		case map[string]interface{}:
+			if key == "cost_info" {
+				continue
+			}
+			children = append(children, parseExplainNode(key, value))
+		case []interface{}:
+			// The arrays such as "nested_loop" and "query_specifications" keep the order of the plan nodes.
+			// The arrays of strings such as "used_columns" are skipped.
+			node := &db.ExplainNode{
+				NodeType: key,
+			}
+			for _, element := range value {
+				if elementObject, ok := element.(map[string]interface{}); ok {
+					node.Children = append(node.Children, parseExplainChildren(elementObject)...)
+				}
+			}
+			if len(node.Children) > 0 {
+				children = append(children, node)
+			}
+		}
+	}
+	return children
+}
+
+// getExplainNumber gets the number from the explain plan, MySQL 5.7 and later report costs as strings such as "1.20".
+func getExplainNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case string:
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0, false
+		}
+		return n, true
+	}
+	return 0, false
+}
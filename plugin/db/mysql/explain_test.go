@@ -0,0 +1,110 @@
+package mysql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/plugin/db"
+)
+
+func TestParseExplainJSON(t *testing.T) {
+	tests := []struct {
+		plan string
+		want *db.ExplainNode
+	}{
+		{
+			// SELECT * FROM employee e JOIN salary s ON e.emp_no = s.emp_no;
+			plan: `{
+  "query_block": {
+    "select_id": 1,
+    "cost_info": {"query_cost": "1.75"},
+    "nested_loop": [
+      {
+        "table": {
+          "table_name": "e",
+          "access_type": "ALL",
+          "possible_keys": ["PRIMARY"],
+          "rows_examined_per_scan": 5,
+          "rows_produced_per_join": 5,
+          "filtered": "100.00",
+          "cost_info": {"read_cost": "0.25", "eval_cost": "0.50", "prefix_cost": "0.75", "data_read_per_join": "80"},
+          "used_columns": ["emp_no"]
+        }
+      },
+      {
+        "table": {
+          "table_name": "s",
+          "access_type": "ref",
+          "possible_keys": ["PRIMARY"],
+          "key": "PRIMARY",
+          "used_key_parts": ["emp_no"],
+          "key_length": "4",
+          "ref": ["test.e.emp_no"],
+          "rows_examined_per_scan": 1,
+          "rows_produced_per_join": 5,
+          "filtered": "100.00",
+          "cost_info": {"read_cost": "0.50", "eval_cost": "0.50", "prefix_cost": "1.75", "data_read_per_join": "80"},
+          "used_columns": ["emp_no", "salary"]
+        }
+      }
+    ]
+  }
+}`,
+			want: &db.ExplainNode{
+				NodeType:      "query_block",
+				EstimatedCost: 1.75,
+				Children: []*db.ExplainNode{
+					{
+						NodeType: "nested_loop",
+						Children: []*db.ExplainNode{
+							{
+								NodeType:      "ALL",
+								Relation:      "e",
+								FullScan:      true,
+								EstimatedRows: 5,
+								EstimatedCost: 0.75,
+							},
+							{
+								NodeType:      "ref",
+								Relation:      "s",
+								Index:         "PRIMARY",
+								EstimatedRows: 1,
+								EstimatedCost: 1.75,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			// MySQL 5.6 reports "rows" and doesn't report costs.
+			plan: `{"query_block": {"select_id": 1, "ordering_operation": {"using_filesort": true, "table": {"table_name": "t", "access_type": "range", "key": "idx_a", "rows": 10}}}}`,
+			want: &db.ExplainNode{
+				NodeType: "query_block",
+				Children: []*db.ExplainNode{
+					{
+						NodeType: "ordering_operation",
+						Children: []*db.ExplainNode{
+							{
+								NodeType:      "range",
+								Relation:      "t",
+								Index:         "idx_a",
+								EstimatedRows: 10,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		got, err := parseExplainJSON(test.plan)
+		require.NoError(t, err)
+		require.Equal(t, test.want, got)
+	}
+
+	_, err := parseExplainJSON("id\tselect_type")
+	require.Error(t, err)
+}
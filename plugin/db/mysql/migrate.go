@@ -8,6 +8,7 @@ import (
 	// embed will embeds the migration schema.
 	_ "embed"
 
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
 	"github.com/bytebase/bytebase/common"
@@ -21,6 +22,8 @@ var (
 	migrationSchema string
 
 	_ util.MigrationExecutor = (*Driver)(nil)
+	_ util.MigrationLocker   = (*Driver)(nil)
+	_ util.StatementExecutor = (*Driver)(nil)
 )
 
 // NeedsSetupMigration returns whether it needs to setup migration.
@@ -197,11 +200,72 @@ func (Driver) UpdateHistoryAsFailed(ctx context.Context, tx *sql.Tx, migrationDu
 	return err
 }
 
+// UpdateHistoryPayload will update the payload of the migration record.
+func (Driver) UpdateHistoryPayload(ctx context.Context, tx *sql.Tx, payload string, id int64) error {
+	const updateHistoryPayloadQuery = `
+		UPDATE
+			bytebase.migration_history
+		SET
+			payload = ?
+		WHERE id = ?
+		`
+	_, err := tx.ExecContext(ctx, updateHistoryPayloadQuery, payload, id)
+	return err
+}
+
 // ExecuteMigration will execute the migration.
 func (driver *Driver) ExecuteMigration(ctx context.Context, m *db.MigrationInfo, statement string) (int64, string, error) {
 	return util.ExecuteMigration(ctx, driver, m, statement, db.BytebaseDatabase)
 }
 
+// UpdateMigrationHistoryStatementChecksum updates the statement checksum recorded in the migration history payload.
+func (driver *Driver) UpdateMigrationHistoryStatementChecksum(ctx context.Context, id int, checksum string) error {
+	return util.UpdateMigrationHistoryStatementChecksum(ctx, driver, id, checksum, db.BytebaseDatabase)
+}
+
+// TryLockMigration tries to acquire the named lock of the namespace.
+func (Driver) TryLockMigration(ctx context.Context, conn *sql.Conn, namespace string) (bool, error) {
+	var locked sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, 0)", util.GetMigrationLockName(namespace)).Scan(&locked); err != nil {
+		return false, err
+	}
+	if !locked.Valid {
+		return false, errors.Errorf("failed to get the named lock %q", util.GetMigrationLockName(namespace))
+	}
+	return locked.Int64 == 1, nil
+}
+
+// UnlockMigration releases the named lock of the namespace.
+func (Driver) UnlockMigration(ctx context.Context, conn *sql.Conn, namespace string) error {
+	var released sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT RELEASE_LOCK(?)", util.GetMigrationLockName(namespace)).Scan(&released); err != nil {
+		return err
+	}
+	if released.Int64 != 1 {
+		return errors.Errorf("the migration lock of database %q is not held", namespace)
+	}
+	return nil
+}
+
+// FindMigrationLockHolder finds the connection holding the named lock of the namespace.
+func (Driver) FindMigrationLockHolder(ctx context.Context, conn *sql.Conn, namespace string) (string, error) {
+	var connectionID sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT IS_USED_LOCK(?)", util.GetMigrationLockName(namespace)).Scan(&connectionID); err != nil {
+		return "", err
+	}
+	if !connectionID.Valid {
+		return "", nil
+	}
+	var user, host string
+	if err := conn.QueryRowContext(ctx, "SELECT USER, HOST FROM information_schema.PROCESSLIST WHERE ID = ?", connectionID.Int64).Scan(&user, &host); err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Sprintf("connection %d", connectionID.Int64), nil
+		}
+		return "", err
+	}
+	return fmt.Sprintf("connection %d (user %q, host %q)", connectionID.Int64, user, host), nil
+}
+
 // FindMigrationHistoryList finds the migration history.
 func (driver *Driver) FindMigrationHistoryList(ctx context.Context, find *db.MigrationHistoryFind) ([]*db.MigrationHistory, error) {
 	baseQuery := `
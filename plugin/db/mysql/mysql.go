@@ -5,9 +5,11 @@ import (
 	"bytes"
 	"context"
 	"database/sql"
+	sqldriver "database/sql/driver"
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/go-sql-driver/mysql"
 	"github.com/pkg/errors"
@@ -23,7 +25,8 @@ var (
 	baseTableType = "BASE TABLE"
 	viewTableType = "VIEW"
 
-	_ db.Driver = (*Driver)(nil)
+	_ db.Driver              = (*Driver)(nil)
+	_ util.StatementExecutor = (*Driver)(nil)
 )
 
 func init() {
@@ -39,24 +42,35 @@ type Driver struct {
 	resourceDir   string
 	binlogDir     string
 	db            *sql.DB
+	// sshTunnel is the SSH tunnel to connect the database, it's nil if the SSH tunnel isn't configured.
+	sshTunnel *db.SSHTunnel
 	// migrationConn is used to execute migrations.
 	// Use a single connection for executing migrations in the lifetime of the driver can keep the thread ID unchanged.
 	// So that it's easy to get the thread ID for rollback SQL.
 	migrationConn *sql.Conn
+	// migrationConnUsed is true if statements have been executed on the migration connection since it's opened.
+	migrationConnUsed bool
 
 	replayedBinlogBytes *common.CountingReader
 	restoredBackupBytes *common.CountingReader
+
+	// dumpParallelism is the number of connections to export table data concurrently.
+	dumpParallelism int
+	// dumpCompletedTables and dumpTotalTables track the dump progress, and must be accessed atomically.
+	dumpCompletedTables int64
+	dumpTotalTables     int64
 }
 
 func newDriver(dc db.DriverConfig) db.Driver {
 	return &Driver{
-		resourceDir: dc.ResourceDir,
-		binlogDir:   dc.BinlogDir,
+		resourceDir:     dc.ResourceDir,
+		binlogDir:       dc.BinlogDir,
+		dumpParallelism: dc.DumpParallelism,
 	}
 }
 
 // Open opens a MySQL driver.
-func (driver *Driver) Open(ctx context.Context, dbType db.Type, connCfg db.ConnectionConfig, connCtx db.ConnectionContext) (db.Driver, error) {
+func (driver *Driver) Open(ctx context.Context, dbType db.Type, connCfg db.ConnectionConfig, connCtx db.ConnectionContext) (_ db.Driver, retErr error) {
 	protocol := "tcp"
 	if strings.HasPrefix(connCfg.Host, "/") {
 		protocol = "unix"
@@ -72,8 +86,21 @@ func (driver *Driver) Open(ctx context.Context, dbType db.Type, connCfg db.Conne
 		}
 	}
 
-	tlsConfig, err := connCfg.TLSConfig.GetSslConfig()
+	// Connect the local end of the SSH tunnel, so that mysqlbinlog and mysql binaries can connect it as well.
+	sshTunnel, connCfg, err := db.OpenSSHTunnelForConnection(connCfg, port)
+	if err != nil {
+		return nil, err
+	}
+	if sshTunnel != nil {
+		port = connCfg.Port
+		defer func() {
+			if retErr != nil {
+				sshTunnel.Close()
+			}
+		}()
+	}
 
+	tlsConfig, err := connCfg.TLSConfig.GetSslConfig()
 	if err != nil {
 		return nil, errors.Wrap(err, "sql: tls config error")
 	}
@@ -97,10 +124,12 @@ func (driver *Driver) Open(ctx context.Context, dbType db.Type, connCfg db.Conne
 	}
 	conn, err := db.Conn(ctx)
 	if err != nil {
+		db.Close()
 		return nil, err
 	}
 	driver.dbType = dbType
 	driver.db = db
+	driver.sshTunnel = sshTunnel
 	driver.migrationConn = conn
 	driver.connectionCtx = connCtx
 	driver.connCfg = connCfg
@@ -111,11 +140,31 @@ func (driver *Driver) Open(ctx context.Context, dbType db.Type, connCfg db.Conne
 // Close closes the driver.
 func (driver *Driver) Close(context.Context) error {
 	var err error
-	err = multierr.Append(err, driver.db.Close())
 	err = multierr.Append(err, driver.migrationConn.Close())
+	err = multierr.Append(err, driver.db.Close())
+	if driver.sshTunnel != nil {
+		err = multierr.Append(err, driver.sshTunnel.Close())
+	}
 	return err
 }
 
+// ResetSession replaces the migration connection with a new one, so that the session variables set by the previous migration don't carry over.
+func (driver *Driver) ResetSession(ctx context.Context) error {
+	if !driver.migrationConnUsed {
+		return nil
+	}
+	conn, err := driver.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	// Returning driver.ErrBadConn discards the connection instead of returning it to the pool.
+	_ = driver.migrationConn.Raw(func(interface{}) error { return sqldriver.ErrBadConn })
+	_ = driver.migrationConn.Close()
+	driver.migrationConn = conn
+	driver.migrationConnUsed = false
+	return nil
+}
+
 // Ping pings the database.
 func (driver *Driver) Ping(ctx context.Context) error {
 	return driver.db.PingContext(ctx)
@@ -169,6 +218,7 @@ func (driver *Driver) Execute(ctx context.Context, statement string) error {
 		return err
 	}
 	transformedStatement := buf.String()
+	driver.migrationConnUsed = true
 	tx, err := driver.migrationConn.BeginTx(ctx, nil)
 	if err != nil {
 		return err
@@ -186,6 +236,40 @@ func (driver *Driver) Execute(ctx context.Context, statement string) error {
 	return err
 }
 
+// ExecuteStatements executes the statements one by one in a transaction, and reports the progress to the reporter.
+func (driver *Driver) ExecuteStatements(ctx context.Context, statement string, reporter db.StatementProgressReporter) error {
+	statements, err := splitStatement(statement)
+	if err != nil {
+		return err
+	}
+	driver.migrationConnUsed = true
+	tx, err := driver.migrationConn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var executableStatements []string
+	for _, stmt := range statements {
+		// MySQL rejects the empty query with error 1065.
+		if isCommentOnly(stmt) {
+			continue
+		}
+		executableStatements = append(executableStatements, stmt)
+	}
+	statements = executableStatements
+	for i, stmt := range statements {
+		startedTime := time.Now()
+		reporter.OnStatementStart(i, len(statements), stmt)
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+		reporter.OnStatementDone(i, time.Since(startedTime))
+	}
+
+	return tx.Commit()
+}
+
 // GetMigrationConnID gets the ID of the connection executing migrations.
 func (driver *Driver) GetMigrationConnID(ctx context.Context) (string, error) {
 	var id string
@@ -200,19 +284,72 @@ func (driver *Driver) Query(ctx context.Context, statement string, limit int, re
 	return util.Query(ctx, driver.dbType, driver.db, statement, limit, readOnly)
 }
 
+// QueryRows queries a SQL statement and returns the cursor of the result.
+func (driver *Driver) QueryRows(ctx context.Context, statement string, limit int, readOnly bool) (db.Rows, error) {
+	return util.QueryRows(ctx, driver.dbType, driver.db, statement, limit, readOnly)
+}
+
+// isCommentOnly returns true if the statement is empty or only contains comments.
+// The MySQL executable comments such as "/*!50001 ... */" are not treated as comments.
+func isCommentOnly(statement string) bool {
+	s := statement
+	for {
+		s = strings.TrimSpace(s)
+		switch {
+		case s == "":
+			return true
+		case strings.HasPrefix(s, "--"), strings.HasPrefix(s, "#"):
+			end := strings.Index(s, "\n")
+			if end < 0 {
+				return true
+			}
+			s = s[end+1:]
+		case strings.HasPrefix(s, "/*") && !strings.HasPrefix(s, "/*!"):
+			end := strings.Index(s[2:], "*/")
+			if end < 0 {
+				return true
+			}
+			s = s[end+4:]
+		default:
+			return false
+		}
+	}
+}
+
 // transformDelimiter transform the delimiter to the MySQL default delimiter.
 func transformDelimiter(out io.Writer, statement string) error {
+	statements, err := splitStatement(statement)
+	if err != nil {
+		return err
+	}
+	for _, stmt := range statements {
+		if _, err = out.Write([]byte(stmt)); err != nil {
+			return errors.Wrapf(err, "failed to write SQL statement")
+		}
+	}
+	return nil
+}
+
+// splitStatement splits the statement into the single statements ending with the MySQL default delimiter.
+// The DELIMITER statements are consumed.
+func splitStatement(statement string) ([]string, error) {
 	statements, err := bbparser.SplitMultiSQL(bbparser.MySQL, statement)
 	if err != nil {
-		return errors.Wrapf(err, "failed to split SQL statements")
+		return nil, errors.Wrapf(err, "failed to split SQL statements")
 	}
+	var result []string
 	delimiter := `;`
 	for _, singleSQL := range statements {
 		stmt := singleSQL.Text
+		if isCommentOnly(stmt) {
+			// The trailing comments are split as a statement without the delimiter.
+			result = append(result, stmt)
+			continue
+		}
 		if bbparser.IsDelimiter(stmt) {
 			delimiter, err = bbparser.ExtractDelimiter(stmt)
 			if err != nil {
-				return errors.Wrapf(err, "failed to extract delimiter")
+				return nil, errors.Wrapf(err, "failed to extract delimiter")
 			}
 			continue
 		}
@@ -220,9 +357,7 @@ func transformDelimiter(out io.Writer, statement string) error {
 			// Trim delimiter
 			stmt = fmt.Sprintf("%s;", stmt[:len(stmt)-len(delimiter)])
 		}
-		if _, err = out.Write([]byte(stmt)); err != nil {
-			return errors.Wrapf(err, "failed to write SQL statement")
-		}
+		result = append(result, stmt)
 	}
-	return nil
+	return result, nil
 }
@@ -85,3 +85,42 @@ func TestTransformDelimiter(t *testing.T) {
 		a.Equal(test.want, buf.String())
 	}
 }
+
+func TestSplitStatement(t *testing.T) {
+	statement := "CREATE TABLE t1(id INT);\nDELIMITER ;;\nCREATE PROCEDURE p1() BEGIN SELECT 1; END;;\nDELIMITER ;\nINSERT INTO t1 VALUES (1);"
+	statements, err := splitStatement(statement)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"CREATE TABLE t1(id INT);",
+		"CREATE PROCEDURE p1() BEGIN SELECT 1; END;",
+		"INSERT INTO t1 VALUES (1);",
+	}, statements)
+}
+
+func TestSplitStatementWithTrailingComment(t *testing.T) {
+	statement := "CREATE TABLE t1(id INT);\nDELIMITER ;;\nCREATE PROCEDURE p1() BEGIN SELECT 1; END;;\n-- trailing comment"
+	statements, err := splitStatement(statement)
+	require.NoError(t, err)
+	require.Len(t, statements, 3)
+	require.Equal(t, "CREATE TABLE t1(id INT);", statements[0])
+	require.Equal(t, "CREATE PROCEDURE p1() BEGIN SELECT 1; END;", statements[1])
+	require.True(t, isCommentOnly(statements[2]))
+}
+
+func TestIsCommentOnly(t *testing.T) {
+	tests := []struct {
+		statement string
+		want      bool
+	}{
+		{statement: "", want: true},
+		{statement: "\n  \n", want: true},
+		{statement: "\n-- trailing comment", want: true},
+		{statement: "# comment\n/* block\ncomment */\n", want: true},
+		{statement: "-- comment\nSELECT 1;", want: false},
+		{statement: "/* comment */ SELECT 1;", want: false},
+		{statement: "/*!40101 SET NAMES utf8 */;", want: false},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.want, isCommentOnly(test.statement), test.statement)
+	}
+}
@@ -173,6 +173,64 @@ func (driver *Driver) SyncDBSchema(ctx context.Context, databaseName string) (*d
 		return nil, util.FormatErrorWithQuery(err, indexQuery)
 	}
 
+	// Query foreign key info
+	foreignKeyWhere := fmt.Sprintf("LOWER(k.TABLE_SCHEMA) = '%s'", strings.ToLower(databaseName))
+	foreignKeyQuery := `
+			SELECT
+				k.TABLE_SCHEMA,
+				k.TABLE_NAME,
+				k.CONSTRAINT_NAME,
+				k.COLUMN_NAME,
+				k.ORDINAL_POSITION,
+				k.REFERENCED_TABLE_SCHEMA,
+				k.REFERENCED_TABLE_NAME,
+				k.REFERENCED_COLUMN_NAME,
+				r.UPDATE_RULE,
+				r.DELETE_RULE
+			FROM information_schema.KEY_COLUMN_USAGE k
+			JOIN information_schema.REFERENTIAL_CONSTRAINTS r
+				ON k.CONSTRAINT_SCHEMA = r.CONSTRAINT_SCHEMA AND k.CONSTRAINT_NAME = r.CONSTRAINT_NAME AND k.TABLE_NAME = r.TABLE_NAME
+			WHERE k.REFERENCED_TABLE_NAME IS NOT NULL AND ` + foreignKeyWhere + `
+			ORDER BY k.TABLE_NAME, k.CONSTRAINT_NAME, k.ORDINAL_POSITION`
+	foreignKeyRows, err := driver.db.QueryContext(ctx, foreignKeyQuery)
+	if err != nil {
+		return nil, util.FormatErrorWithQuery(err, foreignKeyQuery)
+	}
+	defer foreignKeyRows.Close()
+
+	// dbName/tableName -> foreignKeyList map
+	foreignKeyMap := make(map[string][]db.ForeignKey)
+	for foreignKeyRows.Next() {
+		var dbName string
+		var tableName string
+		var referencedDBName string
+		var foreignKey db.ForeignKey
+		if err := foreignKeyRows.Scan(
+			&dbName,
+			&tableName,
+			&foreignKey.Name,
+			&foreignKey.Column,
+			&foreignKey.Position,
+			&referencedDBName,
+			&foreignKey.ReferencedTable,
+			&foreignKey.ReferencedColumn,
+			&foreignKey.OnUpdate,
+			&foreignKey.OnDelete,
+		); err != nil {
+			return nil, err
+		}
+
+		if referencedDBName != dbName {
+			foreignKey.ReferencedTable = fmt.Sprintf("%s.%s", referencedDBName, foreignKey.ReferencedTable)
+		}
+
+		key := fmt.Sprintf("%s/%s", dbName, tableName)
+		foreignKeyMap[key] = append(foreignKeyMap[key], foreignKey)
+	}
+	if err := foreignKeyRows.Err(); err != nil {
+		return nil, util.FormatErrorWithQuery(err, foreignKeyQuery)
+	}
+
 	// Query column info
 	columnWhere := fmt.Sprintf("LOWER(TABLE_SCHEMA) = '%s'", strings.ToLower(databaseName))
 	columnQuery := `
@@ -299,6 +357,7 @@ func (driver *Driver) SyncDBSchema(ctx context.Context, databaseName string) (*d
 			key := fmt.Sprintf("%s/%s", dbName, table.Name)
 			table.ColumnList = columnMap[key]
 			table.IndexList = indexMap[key]
+			table.ForeignKeyList = foreignKeyMap[key]
 
 			if tableList, ok := tableMap[dbName]; ok {
 				tableMap[dbName] = append(tableList, table)
@@ -3,12 +3,15 @@ package pg
 import (
 	"bufio"
 	"context"
+	"database/sql"
 	"fmt"
 	"io"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"strings"
 
+	"github.com/jackc/pgx/v4/stdlib"
 	"github.com/pkg/errors"
 
 	"github.com/bytebase/bytebase/common/log"
@@ -48,6 +51,13 @@ func (driver *Driver) Dump(ctx context.Context, database string, out io.Writer,
 	}
 
 	for _, dbName := range dumpableDbNames {
+		// Fall back to the native dump if pg_dump isn't available, e.g. the driver is used without the embedded Postgres instance.
+		if driver.nativePgDump || driver.pgInstanceDir == "" {
+			if err := driver.dumpOneDatabaseNative(ctx, dbName, out, schemaOnly); err != nil {
+				return "", err
+			}
+			continue
+		}
 		if err := driver.dumpOneDatabaseWithPgDump(ctx, dbName, out, schemaOnly); err != nil {
 			return "", err
 		}
@@ -158,9 +168,18 @@ func (driver *Driver) dumpOneDatabaseWithPgDump(ctx context.Context, database st
 	return nil
 }
 
+// copyFromStdinRegexp matches the COPY statement for the data section of a dump, such as `COPY "public"."t" ("a", "b") FROM stdin;`.
+var copyFromStdinRegexp = regexp.MustCompile(`(?i)^COPY\s+.+\s+FROM\s+stdin;\s*$`)
+
 // Restore restores a database.
+// Besides SQL statements, the dump may contain COPY data blocks ended by "\.", which are loaded with the COPY protocol.
 func (driver *Driver) Restore(ctx context.Context, sc io.Reader) error {
-	txn, err := driver.db.BeginTx(ctx, nil)
+	conn, err := driver.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	txn, err := conn.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
@@ -192,7 +211,38 @@ func (driver *Driver) Restore(ctx context.Context, sc io.Reader) error {
 		return nil
 	}
 
-	if _, err := parser.SplitMultiSQLStream(parser.Postgres, sc, f); err != nil {
+	var buf strings.Builder
+	flush := func() error {
+		if buf.Len() == 0 {
+			return nil
+		}
+		if _, err := parser.SplitMultiSQLStream(parser.Postgres, strings.NewReader(buf.String()), f); err != nil {
+			return err
+		}
+		buf.Reset()
+		return nil
+	}
+	reader := bufio.NewReader(sc)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+		if copyFromStdinRegexp.MatchString(line) {
+			if err := flush(); err != nil {
+				return err
+			}
+			if err := copyFromStdin(ctx, conn, strings.TrimSpace(line), &copyDataReader{reader: reader}); err != nil {
+				return errors.Wrapf(err, "failed to load data with %q", strings.TrimSpace(line))
+			}
+		} else {
+			buf.WriteString(line)
+		}
+		if err == io.EOF {
+			break
+		}
+	}
+	if err := flush(); err != nil {
 		return err
 	}
 
@@ -202,3 +252,46 @@ func (driver *Driver) Restore(ctx context.Context, sc io.Reader) error {
 
 	return txn.Commit()
 }
+
+// copyFromStdin loads the COPY data with the COPY protocol on the connection in the current transaction.
+func copyFromStdin(ctx context.Context, conn *sql.Conn, stmt string, data io.Reader) error {
+	return conn.Raw(func(driverConn interface{}) error {
+		pgxConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return errors.Errorf("unexpected driver connection type %T", driverConn)
+		}
+		_, err := pgxConn.Conn().PgConn().CopyFrom(ctx, data, stmt)
+		return err
+	})
+}
+
+// copyDataReader reads the COPY data lines from the reader until the end-of-data marker "\.".
+type copyDataReader struct {
+	reader *bufio.Reader
+	buf    []byte
+	done   bool
+}
+
+func (r *copyDataReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		line, err := r.reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return 0, err
+		}
+		if strings.TrimRight(line, "\r\n") == "\\." {
+			r.done = true
+			return 0, io.EOF
+		}
+		// The COPY data must be ended by the end-of-data marker.
+		if err == io.EOF {
+			return 0, io.ErrUnexpectedEOF
+		}
+		r.buf = []byte(line)
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
@@ -0,0 +1,670 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/jackc/pgx/v4/stdlib"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/plugin/db"
+)
+
+const nativeDumpHeader = "" +
+	"SET statement_timeout = 0;\n" +
+	"SET lock_timeout = 0;\n" +
+	"SET client_encoding = 'UTF8';\n" +
+	"SET standard_conforming_strings = on;\n" +
+	"SET check_function_bodies = false;\n" +
+	"SET client_min_messages = warning;\n" +
+	"SET row_security = off;\n\n"
+
+// dumpColumnSchema describes a table column with the exact type and default needed to recreate it.
+type dumpColumnSchema struct {
+	name      string
+	typeName  string
+	notNull   bool
+	defaultV  string
+	collation string
+	// identity is "a" for GENERATED ALWAYS, "d" for GENERATED BY DEFAULT and empty otherwise.
+	identity         string
+	identitySequence string
+	// generated is "s" for stored generated columns and empty otherwise.
+	generated string
+}
+
+// viewDependency describes that the view depends on the referenced view or materialized view.
+type viewDependency struct {
+	view       string
+	referenced string
+}
+
+// dumpOneDatabaseNative dumps the database without the pg_dump binary.
+// The dump is taken in a repeatable read transaction so that the schema and data are consistent.
+// It consists of the schema built from the same catalog queries as schema sync, and the table data in COPY format.
+func (driver *Driver) dumpOneDatabaseNative(ctx context.Context, database string, out io.Writer, schemaOnly bool) error {
+	sqldb, err := driver.GetDBConnection(ctx, database)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get database connection for %q", database)
+	}
+	conn, err := sqldb.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	txn, err := conn.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer txn.Rollback()
+
+	d, err := getNativeDump(txn)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get schema of database %q", database)
+	}
+
+	if _, err := io.WriteString(out, d.schemaSQL()); err != nil {
+		return err
+	}
+	if !schemaOnly {
+		for _, tbl := range d.tables {
+			// Partitioned tables don't hold data, the data is dumped from each partition.
+			if d.partitions[tbl.key()] != nil && d.partitions[tbl.key()].key != "" {
+				continue
+			}
+			if err := dumpTableData(ctx, conn, tbl.schemaName, tbl.name, d.columns[tbl.key()], out); err != nil {
+				return errors.Wrapf(err, "failed to dump data of table %q", tbl.key())
+			}
+		}
+		if _, err := io.WriteString(out, d.dataPostSQL()); err != nil {
+			return err
+		}
+	}
+	if _, err := io.WriteString(out, d.postSchemaSQL()); err != nil {
+		return err
+	}
+
+	return txn.Commit()
+}
+
+// dumpTableData writes the data of a table in COPY format.
+func dumpTableData(ctx context.Context, conn *sql.Conn, schemaName, tableName string, columns []*dumpColumnSchema, out io.Writer) error {
+	var columnNames []string
+	for _, column := range columns {
+		// Generated columns are computed on insert, so they cannot be copied.
+		if column.generated != "" {
+			continue
+		}
+		columnNames = append(columnNames, quoteIdentifier(column.name))
+	}
+	// "COPY t () ..." is a syntax error, so we skip the tables without any column to copy, such as the zero-column tables.
+	if len(columnNames) == 0 {
+		return nil
+	}
+	table := fmt.Sprintf("%s.%s", quoteIdentifier(schemaName), quoteIdentifier(tableName))
+	columnList := strings.Join(columnNames, ", ")
+	if _, err := fmt.Fprintf(out, "COPY %s (%s) FROM stdin;\n", table, columnList); err != nil {
+		return err
+	}
+	if err := conn.Raw(func(driverConn interface{}) error {
+		pgxConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return errors.Errorf("unexpected driver connection type %T", driverConn)
+		}
+		_, err := pgxConn.Conn().PgConn().CopyTo(ctx, out, fmt.Sprintf("COPY %s (%s) TO STDOUT", table, columnList))
+		return err
+	}); err != nil {
+		return err
+	}
+	_, err := io.WriteString(out, "\\.\n\n")
+	return err
+}
+
+// nativeDump is the catalog metadata of a database needed by the native dump.
+type nativeDump struct {
+	versionNum        int
+	schemaNames       []string
+	extensions        []db.Extension
+	sequences         []db.Sequence
+	functions         []db.Function
+	tables            []*tableSchema
+	columns           map[string][]*dumpColumnSchema
+	partitions        map[string]*partitionSchema
+	indices           []*indexSchema
+	views             []*viewSchema
+	materializedViews map[string]bool
+	viewDependencies  []*viewDependency
+	triggers          []db.Trigger
+	sequenceValues    map[string]string
+}
+
+func (t *tableSchema) key() string {
+	return fmt.Sprintf("%s.%s", t.schemaName, t.name)
+}
+
+func getNativeDump(txn *sql.Tx) (*nativeDump, error) {
+	var err error
+	d := &nativeDump{
+		partitions:        make(map[string]*partitionSchema),
+		materializedViews: make(map[string]bool),
+		sequenceValues:    make(map[string]string),
+	}
+	if d.versionNum, err = getServerVersionNum(txn); err != nil {
+		return nil, errors.Wrap(err, "failed to get server version")
+	}
+	if d.schemaNames, err = getSchemaNames(txn); err != nil {
+		return nil, errors.Wrap(err, "failed to get schemas")
+	}
+	if d.extensions, err = getExtensions(txn); err != nil {
+		return nil, errors.Wrap(err, "failed to get extensions")
+	}
+	if d.sequences, err = getSequences(txn); err != nil {
+		return nil, errors.Wrap(err, "failed to get sequences")
+	}
+	if d.functions, err = getFunctions(txn, d.versionNum); err != nil {
+		return nil, errors.Wrap(err, "failed to get functions")
+	}
+	if d.tables, err = getPgTables(txn); err != nil {
+		return nil, errors.Wrap(err, "failed to get tables")
+	}
+	if d.columns, err = getDumpColumns(txn, d.versionNum); err != nil {
+		return nil, errors.Wrap(err, "failed to get columns")
+	}
+	// Declarative partitioning is introduced in PostgreSQL 10.
+	if d.versionNum >= 100000 {
+		partitions, err := getPartitions(txn)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get partitions")
+		}
+		for _, partition := range partitions {
+			d.partitions[fmt.Sprintf("%s.%s", partition.schemaName, partition.tableName)] = partition
+		}
+	}
+	if d.indices, err = getIndices(txn); err != nil {
+		return nil, errors.Wrap(err, "failed to get indices")
+	}
+	if d.views, err = getViews(txn); err != nil {
+		return nil, errors.Wrap(err, "failed to get views")
+	}
+	materializedViews, err := getMaterializedViews(txn)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get materialized views")
+	}
+	for _, view := range materializedViews {
+		d.materializedViews[fmt.Sprintf("%s.%s", view.schemaName, view.name)] = true
+	}
+	d.views = append(d.views, materializedViews...)
+	if d.viewDependencies, err = getViewDependencies(txn); err != nil {
+		return nil, errors.Wrap(err, "failed to get view dependencies")
+	}
+	if d.triggers, err = getTriggers(txn); err != nil {
+		return nil, errors.Wrap(err, "failed to get triggers")
+	}
+	for _, sequence := range d.sequences {
+		schemaName, name := splitQualifiedName(sequence.Name)
+		var lastValue string
+		var isCalled bool
+		if err := txn.QueryRow(fmt.Sprintf("SELECT last_value, is_called FROM %s.%s;", quoteIdentifier(schemaName), quoteIdentifier(name))).Scan(&lastValue, &isCalled); err != nil {
+			return nil, errors.Wrapf(err, "failed to get value of sequence %q", sequence.Name)
+		}
+		d.sequenceValues[sequence.Name] = fmt.Sprintf("%s, %t", lastValue, isCalled)
+	}
+
+	sort.Slice(d.tables, func(i, j int) bool {
+		return d.tables[i].key() < d.tables[j].key()
+	})
+	return d, nil
+}
+
+// schemaSQL returns the statements to create the schema before loading data.
+func (d *nativeDump) schemaSQL() string {
+	var buf strings.Builder
+	buf.WriteString(nativeDumpHeader)
+
+	for _, schemaName := range d.schemaNames {
+		if schemaName == "public" {
+			continue
+		}
+		fmt.Fprintf(&buf, "CREATE SCHEMA IF NOT EXISTS %s;\n\n", quoteIdentifier(schemaName))
+	}
+
+	for _, extension := range d.extensions {
+		fmt.Fprintf(&buf, "CREATE EXTENSION IF NOT EXISTS %s WITH SCHEMA %s;\n\n", quoteIdentifier(extension.Name), quoteIdentifier(extension.Schema))
+	}
+
+	identitySequences := make(map[string]bool)
+	for _, columns := range d.columns {
+		for _, column := range columns {
+			if column.identitySequence != "" {
+				identitySequences[column.identitySequence] = true
+			}
+		}
+	}
+	for _, sequence := range d.sequences {
+		// Identity sequences are created with the identity columns.
+		if identitySequences[sequence.Name] {
+			continue
+		}
+		fmt.Fprintf(&buf, "CREATE SEQUENCE %s%s;\n\n", quoteQualifiedName(sequence.Name), d.sequenceOptions(sequence))
+	}
+
+	for _, function := range d.functions {
+		fmt.Fprintf(&buf, "%s;\n\n", strings.TrimSpace(function.Definition))
+	}
+
+	for _, tbl := range d.sortedTables() {
+		buf.WriteString(d.createTableStatement(tbl))
+	}
+
+	sequenceMap := make(map[string]db.Sequence)
+	for _, sequence := range d.sequences {
+		sequenceMap[sequence.Name] = sequence
+	}
+	for _, tbl := range d.tables {
+		// Partitions inherit the identity columns from the partitioned table.
+		if d.isPartition(tbl.key()) {
+			continue
+		}
+		for _, column := range d.columns[tbl.key()] {
+			if column.identity == "" {
+				continue
+			}
+			generated := "BY DEFAULT"
+			if column.identity == "a" {
+				generated = "ALWAYS"
+			}
+			var options string
+			if sequence, ok := sequenceMap[column.identitySequence]; ok {
+				options = fmt.Sprintf(" (\n    SEQUENCE NAME %s%s\n)", quoteQualifiedName(sequence.Name), d.sequenceOptions(sequence))
+			}
+			fmt.Fprintf(&buf, "ALTER TABLE %s ALTER COLUMN %s ADD GENERATED %s AS IDENTITY%s;\n\n", quoteQualifiedName(tbl.key()), quoteIdentifier(column.name), generated, options)
+		}
+	}
+	for _, sequence := range d.sequences {
+		if sequence.OwnedBy == "" || identitySequences[sequence.Name] {
+			continue
+		}
+		i := strings.LastIndex(sequence.OwnedBy, ".")
+		fmt.Fprintf(&buf, "ALTER SEQUENCE %s OWNED BY %s.%s;\n\n", quoteQualifiedName(sequence.Name), quoteQualifiedName(sequence.OwnedBy[:i]), quoteIdentifier(sequence.OwnedBy[i+1:]))
+	}
+
+	var viewNames []string
+	viewMap := make(map[string]*viewSchema)
+	for _, view := range d.views {
+		name := fmt.Sprintf("%s.%s", view.schemaName, view.name)
+		viewNames = append(viewNames, name)
+		viewMap[name] = view
+	}
+	for _, name := range sortViewsByDependency(viewNames, d.viewDependencies) {
+		view := viewMap[name]
+		definition := strings.TrimSuffix(strings.TrimSpace(view.definition), ";")
+		if d.materializedViews[name] {
+			// Materialized views are refreshed after the data is loaded.
+			fmt.Fprintf(&buf, "CREATE MATERIALIZED VIEW %s AS\n%s\nWITH NO DATA;\n\n", quoteQualifiedName(name), definition)
+		} else {
+			fmt.Fprintf(&buf, "CREATE VIEW %s AS\n%s;\n\n", quoteQualifiedName(name), definition)
+		}
+		if view.comment != "" {
+			kind := "VIEW"
+			if d.materializedViews[name] {
+				kind = "MATERIALIZED VIEW"
+			}
+			fmt.Fprintf(&buf, "COMMENT ON %s %s IS %s;\n\n", kind, quoteQualifiedName(name), quoteLiteral(view.comment))
+		}
+	}
+
+	return buf.String()
+}
+
+// dataPostSQL returns the statements to run after loading data.
+func (d *nativeDump) dataPostSQL() string {
+	var buf strings.Builder
+	for _, sequence := range d.sequences {
+		fmt.Fprintf(&buf, "SELECT pg_catalog.setval(%s, %s);\n\n", quoteLiteral(quoteQualifiedName(sequence.Name)), d.sequenceValues[sequence.Name])
+	}
+	var viewNames []string
+	for _, view := range d.views {
+		viewNames = append(viewNames, fmt.Sprintf("%s.%s", view.schemaName, view.name))
+	}
+	for _, name := range sortViewsByDependency(viewNames, d.viewDependencies) {
+		if d.materializedViews[name] {
+			fmt.Fprintf(&buf, "REFRESH MATERIALIZED VIEW %s;\n\n", quoteQualifiedName(name))
+		}
+	}
+	return buf.String()
+}
+
+// postSchemaSQL returns the statements to create indices, foreign keys and triggers.
+// They are created after loading data for faster restore.
+func (d *nativeDump) postSchemaSQL() string {
+	var buf strings.Builder
+	constraintNames := make(map[string]bool)
+	for _, tbl := range d.tables {
+		for _, constraint := range tbl.constraints {
+			constraintNames[fmt.Sprintf("%s.%s", tbl.schemaName, constraint.name)] = true
+		}
+	}
+	for _, idx := range d.indices {
+		// Indices on partitions are created from the indices on the partitioned table.
+		if d.isPartition(fmt.Sprintf("%s.%s", idx.schemaName, idx.tableName)) {
+			continue
+		}
+		// Indices backing constraints are created with the constraints.
+		if constraintNames[fmt.Sprintf("%s.%s", idx.schemaName, idx.name)] {
+			continue
+		}
+		statement := idx.statement
+		// The index definition of a partitioned table is "ON ONLY" which doesn't create indices on partitions.
+		if partition, ok := d.partitions[fmt.Sprintf("%s.%s", idx.schemaName, idx.tableName)]; ok && partition.key != "" {
+			statement = strings.Replace(statement, " ON ONLY ", " ON ", 1)
+		}
+		fmt.Fprintf(&buf, "%s;\n\n", statement)
+		if idx.comment != "" {
+			fmt.Fprintf(&buf, "COMMENT ON INDEX %s.%s IS %s;\n\n", quoteIdentifier(idx.schemaName), quoteIdentifier(idx.name), quoteLiteral(idx.comment))
+		}
+	}
+
+	for _, tbl := range d.tables {
+		if d.isPartition(tbl.key()) {
+			continue
+		}
+		for _, constraint := range tbl.constraints {
+			if !isForeignKeyConstraint(constraint) {
+				continue
+			}
+			fmt.Fprintf(&buf, "ALTER TABLE %s ADD CONSTRAINT %s %s;\n\n", quoteQualifiedName(tbl.key()), quoteIdentifier(constraint.name), constraint.constraint)
+		}
+	}
+
+	for _, trigger := range d.triggers {
+		// Triggers on partitions are cloned from the triggers on the partitioned table.
+		if d.isPartition(trigger.TableName) {
+			continue
+		}
+		fmt.Fprintf(&buf, "%s;\n\n", trigger.Definition)
+		if trigger.Comment != "" {
+			fmt.Fprintf(&buf, "COMMENT ON TRIGGER %s ON %s IS %s;\n\n", quoteIdentifier(trigger.Name), quoteQualifiedName(trigger.TableName), quoteLiteral(trigger.Comment))
+		}
+	}
+	return buf.String()
+}
+
+func (d *nativeDump) isPartition(tableName string) bool {
+	partition, ok := d.partitions[tableName]
+	return ok && partition.parent != ""
+}
+
+// sortedTables returns the tables with each partition after its parent.
+func (d *nativeDump) sortedTables() []*tableSchema {
+	var result []*tableSchema
+	created := make(map[string]bool)
+	remaining := d.tables
+	for len(remaining) > 0 {
+		var next []*tableSchema
+		for _, tbl := range remaining {
+			if partition, ok := d.partitions[tbl.key()]; ok && partition.parent != "" && !created[partition.parent] {
+				next = append(next, tbl)
+				continue
+			}
+			created[tbl.key()] = true
+			result = append(result, tbl)
+		}
+		// The parent of the remaining partitions isn't dumped, e.g. it's in an excluded schema.
+		if len(next) == len(remaining) {
+			result = append(result, next...)
+			break
+		}
+		remaining = next
+	}
+	return result
+}
+
+func (d *nativeDump) createTableStatement(tbl *tableSchema) string {
+	var buf strings.Builder
+	partition := d.partitions[tbl.key()]
+	if partition != nil && partition.parent != "" {
+		fmt.Fprintf(&buf, "CREATE TABLE %s PARTITION OF %s %s", quoteQualifiedName(tbl.key()), quoteQualifiedName(partition.parent), partition.bound)
+		if partition.key != "" {
+			fmt.Fprintf(&buf, " PARTITION BY %s", partition.key)
+		}
+		buf.WriteString(";\n\n")
+	} else {
+		var lines []string
+		for _, column := range d.columns[tbl.key()] {
+			line := fmt.Sprintf("    %s %s", quoteIdentifier(column.name), column.typeName)
+			if column.collation != "" {
+				line += fmt.Sprintf(" COLLATE %s", quoteIdentifier(column.collation))
+			}
+			if column.generated != "" {
+				line += fmt.Sprintf(" GENERATED ALWAYS AS (%s) STORED", column.defaultV)
+			} else if column.defaultV != "" {
+				line += fmt.Sprintf(" DEFAULT %s", column.defaultV)
+			}
+			if column.notNull {
+				line += " NOT NULL"
+			}
+			lines = append(lines, line)
+		}
+		for _, constraint := range tbl.constraints {
+			// Foreign keys are added after all tables are created and loaded.
+			if isForeignKeyConstraint(constraint) {
+				continue
+			}
+			lines = append(lines, fmt.Sprintf("    CONSTRAINT %s %s", quoteIdentifier(constraint.name), constraint.constraint))
+		}
+		fmt.Fprintf(&buf, "CREATE TABLE %s (\n%s\n)", quoteQualifiedName(tbl.key()), strings.Join(lines, ",\n"))
+		if partition != nil && partition.key != "" {
+			fmt.Fprintf(&buf, " PARTITION BY %s", partition.key)
+		}
+		buf.WriteString(";\n\n")
+	}
+
+	if tbl.comment != "" {
+		fmt.Fprintf(&buf, "COMMENT ON TABLE %s IS %s;\n\n", quoteQualifiedName(tbl.key()), quoteLiteral(tbl.comment))
+	}
+	for _, column := range tbl.columns {
+		if column.comment != "" {
+			fmt.Fprintf(&buf, "COMMENT ON COLUMN %s.%s IS %s;\n\n", quoteQualifiedName(tbl.key()), quoteIdentifier(column.columnName), quoteLiteral(column.comment))
+		}
+	}
+	return buf.String()
+}
+
+func (d *nativeDump) sequenceOptions(sequence db.Sequence) string {
+	var buf strings.Builder
+	// The sequence data type is introduced in PostgreSQL 10.
+	if d.versionNum >= 100000 {
+		fmt.Fprintf(&buf, "\n    AS %s", sequence.DataType)
+	}
+	fmt.Fprintf(&buf, "\n    START WITH %d\n    INCREMENT BY %d\n    MINVALUE %d\n    MAXVALUE %d", sequence.StartValue, sequence.Increment, sequence.MinValue, sequence.MaxValue)
+	if sequence.Cycle {
+		buf.WriteString("\n    CYCLE")
+	} else {
+		buf.WriteString("\n    NO CYCLE")
+	}
+	return buf.String()
+}
+
+func isForeignKeyConstraint(constraint *tableConstraint) bool {
+	return strings.HasPrefix(constraint.constraint, "FOREIGN KEY")
+}
+
+// sortViewsByDependency sorts views so that each view comes after the views it depends on.
+// Views are otherwise kept in name order, and views in a dependency cycle are appended in name order.
+func sortViewsByDependency(views []string, dependencies []*viewDependency) []string {
+	sorted := append([]string{}, views...)
+	sort.Strings(sorted)
+	viewSet := make(map[string]bool)
+	for _, view := range sorted {
+		viewSet[view] = true
+	}
+	dependencyMap := make(map[string][]string)
+	for _, dependency := range dependencies {
+		if viewSet[dependency.view] && viewSet[dependency.referenced] && dependency.view != dependency.referenced {
+			dependencyMap[dependency.view] = append(dependencyMap[dependency.view], dependency.referenced)
+		}
+	}
+
+	var result []string
+	visited := make(map[string]bool)
+	visiting := make(map[string]bool)
+	var visit func(view string)
+	visit = func(view string) {
+		if visited[view] || visiting[view] {
+			return
+		}
+		visiting[view] = true
+		for _, referenced := range dependencyMap[view] {
+			visit(referenced)
+		}
+		visiting[view] = false
+		visited[view] = true
+		result = append(result, view)
+	}
+	for _, view := range sorted {
+		visit(view)
+	}
+	return result
+}
+
+// getSchemaNames gets all user schemas of a database.
+func getSchemaNames(txn *sql.Tx) ([]string, error) {
+	query := `
+	SELECT nspname FROM pg_catalog.pg_namespace
+	WHERE nspname NOT IN ('pg_catalog', 'information_schema', 'pg_toast') AND nspname NOT LIKE 'pg_temp_%' AND nspname NOT LIKE 'pg_toast_temp_%'
+	ORDER BY nspname;`
+	var schemaNames []string
+	rows, err := txn.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var schemaName string
+		if err := rows.Scan(&schemaName); err != nil {
+			return nil, err
+		}
+		schemaNames = append(schemaNames, schemaName)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return schemaNames, nil
+}
+
+// getDumpColumns gets the columns of all tables keyed by "schema.table" in column order.
+func getDumpColumns(txn *sql.Tx, versionNum int) (map[string][]*dumpColumnSchema, error) {
+	// Identity columns are introduced in PostgreSQL 10, and generated columns are introduced in PostgreSQL 12.
+	identity, generated := "''", "''"
+	if versionNum >= 100000 {
+		identity = "a.attidentity"
+	}
+	if versionNum >= 120000 {
+		generated = "a.attgenerated"
+	}
+	query := `
+	SELECT n.nspname, c.relname, a.attname, pg_catalog.format_type(a.atttypid, a.atttypmod), a.attnotnull,
+		COALESCE(pg_catalog.pg_get_expr(ad.adbin, ad.adrelid), ''),
+		COALESCE(co.collname, ''),
+		` + identity + `::text,
+		COALESCE((
+			SELECT sn.nspname || '.' || s.relname
+			FROM pg_catalog.pg_depend d
+			JOIN pg_catalog.pg_class s ON s.oid = d.objid
+			JOIN pg_catalog.pg_namespace sn ON sn.oid = s.relnamespace
+			WHERE d.refobjid = a.attrelid AND d.refobjsubid = a.attnum AND d.deptype = 'i' AND s.relkind = 'S'
+		), ''),
+		` + generated + `::text
+	FROM pg_catalog.pg_attribute a
+	JOIN pg_catalog.pg_class c ON c.oid = a.attrelid
+	JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+	JOIN pg_catalog.pg_type t ON t.oid = a.atttypid
+	LEFT JOIN pg_catalog.pg_attrdef ad ON ad.adrelid = a.attrelid AND ad.adnum = a.attnum
+	LEFT JOIN pg_catalog.pg_collation co ON co.oid = a.attcollation AND a.attcollation <> t.typcollation
+	WHERE a.attnum > 0 AND NOT a.attisdropped AND c.relkind IN ('r', 'p') AND n.nspname NOT IN ('pg_catalog', 'information_schema')
+	ORDER BY n.nspname, c.relname, a.attnum;`
+	columnsMap := make(map[string][]*dumpColumnSchema)
+	rows, err := txn.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var schemaName, tableName string
+		var column dumpColumnSchema
+		if err := rows.Scan(&schemaName, &tableName, &column.name, &column.typeName, &column.notNull, &column.defaultV, &column.collation, &column.identity, &column.identitySequence, &column.generated); err != nil {
+			return nil, err
+		}
+		key := fmt.Sprintf("%s.%s", schemaName, tableName)
+		columnsMap[key] = append(columnsMap[key], &column)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return columnsMap, nil
+}
+
+// getViewDependencies gets the dependencies between views and materialized views.
+func getViewDependencies(txn *sql.Tx) ([]*viewDependency, error) {
+	query := `
+	SELECT DISTINCT vn.nspname, v.relname, rn.nspname, r.relname
+	FROM pg_catalog.pg_depend d
+	JOIN pg_catalog.pg_rewrite rw ON rw.oid = d.objid
+	JOIN pg_catalog.pg_class v ON v.oid = rw.ev_class
+	JOIN pg_catalog.pg_namespace vn ON vn.oid = v.relnamespace
+	JOIN pg_catalog.pg_class r ON r.oid = d.refobjid
+	JOIN pg_catalog.pg_namespace rn ON rn.oid = r.relnamespace
+	WHERE d.classid = 'pg_catalog.pg_rewrite'::regclass AND d.refclassid = 'pg_catalog.pg_class'::regclass
+		AND v.oid <> r.oid AND v.relkind IN ('v', 'm') AND r.relkind IN ('v', 'm')
+		AND vn.nspname NOT IN ('pg_catalog', 'information_schema');`
+	var dependencies []*viewDependency
+	rows, err := txn.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var viewSchemaName, viewName, referencedSchemaName, referencedName string
+		if err := rows.Scan(&viewSchemaName, &viewName, &referencedSchemaName, &referencedName); err != nil {
+			return nil, err
+		}
+		dependencies = append(dependencies, &viewDependency{
+			view:       fmt.Sprintf("%s.%s", viewSchemaName, viewName),
+			referenced: fmt.Sprintf("%s.%s", referencedSchemaName, referencedName),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return dependencies, nil
+}
+
+// splitQualifiedName splits the "schema.name" format used by the schema sync.
+func splitQualifiedName(name string) (string, string) {
+	i := strings.Index(name, ".")
+	if i < 0 {
+		return "public", name
+	}
+	return name[:i], name[i+1:]
+}
+
+// quoteQualifiedName quotes the "schema.name" format used by the schema sync.
+func quoteQualifiedName(name string) string {
+	schemaName, name := splitQualifiedName(name)
+	return fmt.Sprintf("%s.%s", quoteIdentifier(schemaName), quoteIdentifier(name))
+}
+
+func quoteIdentifier(name string) string {
+	return fmt.Sprintf(`"%s"`, strings.ReplaceAll(name, `"`, `""`))
+}
+
+func quoteLiteral(s string) string {
+	return fmt.Sprintf("'%s'", strings.ReplaceAll(s, "'", "''"))
+}
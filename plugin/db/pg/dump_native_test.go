@@ -0,0 +1,175 @@
+package pg
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuoteQualifiedName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{
+			name: "public.t",
+			want: `"public"."t"`,
+		},
+		{
+			name: "t",
+			want: `"public"."t"`,
+		},
+		{
+			name: `s.my"table`,
+			want: `"s"."my""table"`,
+		},
+		{
+			// Only the first dot separates the schema.
+			name: "s.a.b",
+			want: `"s"."a.b"`,
+		},
+	}
+
+	for _, test := range tests {
+		require.Equal(t, test.want, quoteQualifiedName(test.name))
+	}
+}
+
+func TestSortViewsByDependency(t *testing.T) {
+	tests := []struct {
+		views        []string
+		dependencies []*viewDependency
+		want         []string
+	}{
+		{
+			views: []string{"public.b", "public.a"},
+			want:  []string{"public.a", "public.b"},
+		},
+		{
+			views: []string{"public.a", "public.b", "public.c"},
+			dependencies: []*viewDependency{
+				{view: "public.a", referenced: "public.c"},
+				{view: "public.c", referenced: "public.b"},
+			},
+			want: []string{"public.b", "public.c", "public.a"},
+		},
+		{
+			// Dependencies on views that aren't dumped are ignored.
+			views: []string{"public.a"},
+			dependencies: []*viewDependency{
+				{view: "public.a", referenced: "other.x"},
+			},
+			want: []string{"public.a"},
+		},
+		{
+			views: []string{"public.a", "public.b"},
+			dependencies: []*viewDependency{
+				{view: "public.a", referenced: "public.b"},
+				{view: "public.b", referenced: "public.a"},
+			},
+			want: []string{"public.b", "public.a"},
+		},
+	}
+
+	for _, test := range tests {
+		require.Equal(t, test.want, sortViewsByDependency(test.views, test.dependencies))
+	}
+}
+
+func TestSortedTables(t *testing.T) {
+	d := &nativeDump{
+		tables: []*tableSchema{
+			{schemaName: "public", name: "a_2022"},
+			{schemaName: "public", name: "a_2022_01"},
+			{schemaName: "public", name: "b"},
+			{schemaName: "public", name: "z"},
+		},
+		partitions: map[string]*partitionSchema{
+			"public.z":         {schemaName: "public", tableName: "z", key: "RANGE (ts)"},
+			"public.a_2022":    {schemaName: "public", tableName: "a_2022", key: "RANGE (ts)", parent: "public.z"},
+			"public.a_2022_01": {schemaName: "public", tableName: "a_2022_01", parent: "public.a_2022"},
+		},
+	}
+	var got []string
+	for _, tbl := range d.sortedTables() {
+		got = append(got, tbl.key())
+	}
+	require.Equal(t, []string{"public.b", "public.z", "public.a_2022", "public.a_2022_01"}, got)
+}
+
+func TestCopyDataReader(t *testing.T) {
+	tests := []struct {
+		input    string
+		wantData string
+		wantRest string
+		wantErr  bool
+	}{
+		{
+			input:    "1\ta\n2\tb\n\\.\nSELECT 1;\n",
+			wantData: "1\ta\n2\tb\n",
+			wantRest: "SELECT 1;\n",
+		},
+		{
+			input:    "\\.\n",
+			wantData: "",
+			wantRest: "",
+		},
+		{
+			// The end-of-data marker is missing at the end of the file.
+			input:   "1\ta\n",
+			wantErr: true,
+		},
+		{
+			input:   "1\ta",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		reader := bufio.NewReader(strings.NewReader(test.input))
+		data, err := io.ReadAll(&copyDataReader{reader: reader})
+		if test.wantErr {
+			require.Error(t, err)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, test.wantData, string(data))
+		rest, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		require.Equal(t, test.wantRest, string(rest))
+	}
+}
+
+func TestCopyFromStdinRegexp(t *testing.T) {
+	require.True(t, copyFromStdinRegexp.MatchString(`COPY "public"."t" ("a", "b") FROM stdin;`+"\n"))
+	require.True(t, copyFromStdinRegexp.MatchString("COPY public.t (a) FROM stdin;"))
+	require.False(t, copyFromStdinRegexp.MatchString("COPY public.t (a) FROM '/tmp/data';"))
+	require.False(t, copyFromStdinRegexp.MatchString("SELECT 1;"))
+}
+
+func TestDumpTableDataWithoutColumn(t *testing.T) {
+	tests := []struct {
+		columns []*dumpColumnSchema
+	}{
+		{
+			// CREATE TABLE t();
+			columns: nil,
+		},
+		{
+			columns: []*dumpColumnSchema{
+				{name: "total", generated: "s"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		var buf strings.Builder
+		// The connection isn't used since there is no column to copy.
+		require.NoError(t, dumpTableData(context.Background(), nil /* conn */, "public", "t", test.columns, &buf))
+		require.Empty(t, buf.String())
+	}
+}
@@ -0,0 +1,69 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/plugin/db"
+	"github.com/bytebase/bytebase/plugin/db/util"
+)
+
+// explainPlan is the plan node of EXPLAIN (FORMAT JSON), see https://www.postgresql.org/docs/current/using-explain.html.
+type explainPlan struct {
+	NodeType     string         `json:"Node Type"`
+	RelationName string         `json:"Relation Name"`
+	IndexName    string         `json:"Index Name"`
+	PlanRows     float64        `json:"Plan Rows"`
+	TotalCost    float64        `json:"Total Cost"`
+	Plans        []*explainPlan `json:"Plans"`
+}
+
+// Explain returns the query plan tree of the statement from EXPLAIN (FORMAT JSON).
+func (driver *Driver) Explain(ctx context.Context, statement string) (*db.ExplainNode, error) {
+	tx, err := driver.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := fmt.Sprintf("EXPLAIN (FORMAT JSON) %s", strings.TrimRight(statement, " \n\t;"))
+	var plan string
+	if err := tx.QueryRowContext(ctx, query).Scan(&plan); err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	return parseExplainJSON(plan)
+}
+
+// parseExplainJSON parses the output of EXPLAIN (FORMAT JSON), which is a list of one query plan, [{"Plan": {...}}].
+func parseExplainJSON(plan string) (*db.ExplainNode, error) {
+	var queryList []struct {
+		Plan *explainPlan `json:"Plan"`
+	}
+	if err := json.Unmarshal([]byte(plan), &queryList); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal explain plan %q", plan)
+	}
+	if len(queryList) != 1 || queryList[0].Plan == nil {
+		return nil, errors.Errorf("expect one query plan, but got %q", plan)
+	}
+	return convertExplainPlan(queryList[0].Plan), nil
+}
+
+func convertExplainPlan(plan *explainPlan) *db.ExplainNode {
+	node := &db.ExplainNode{
+		NodeType:      plan.NodeType,
+		Relation:      plan.RelationName,
+		Index:         plan.IndexName,
+		FullScan:      plan.NodeType == "Seq Scan",
+		EstimatedRows: plan.PlanRows,
+		EstimatedCost: plan.TotalCost,
+	}
+	for _, child := range plan.Plans {
+		node.Children = append(node.Children, convertExplainPlan(child))
+	}
+	return node
+}
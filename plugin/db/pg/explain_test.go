@@ -0,0 +1,78 @@
+package pg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/plugin/db"
+)
+
+func TestParseExplainJSON(t *testing.T) {
+	// SELECT * FROM employee e JOIN salary s ON e.emp_no = s.emp_no WHERE s.amount > 1000;
+	plan := `[
+  {
+    "Plan": {
+      "Node Type": "Nested Loop",
+      "Parallel Aware": false,
+      "Join Type": "Inner",
+      "Startup Cost": 0.29,
+      "Total Cost": 45.12,
+      "Plan Rows": 12,
+      "Plan Width": 72,
+      "Plans": [
+        {
+          "Node Type": "Seq Scan",
+          "Parent Relationship": "Outer",
+          "Relation Name": "salary",
+          "Alias": "s",
+          "Startup Cost": 0.00,
+          "Total Cost": 25.88,
+          "Plan Rows": 12,
+          "Plan Width": 36,
+          "Filter": "(amount > 1000)"
+        },
+        {
+          "Node Type": "Index Scan",
+          "Parent Relationship": "Inner",
+          "Scan Direction": "Forward",
+          "Index Name": "employee_pkey",
+          "Relation Name": "employee",
+          "Alias": "e",
+          "Startup Cost": 0.29,
+          "Total Cost": 1.60,
+          "Plan Rows": 1,
+          "Plan Width": 36,
+          "Index Cond": "(emp_no = s.emp_no)"
+        }
+      ]
+    }
+  }
+]`
+	got, err := parseExplainJSON(plan)
+	require.NoError(t, err)
+	require.Equal(t, &db.ExplainNode{
+		NodeType:      "Nested Loop",
+		EstimatedRows: 12,
+		EstimatedCost: 45.12,
+		Children: []*db.ExplainNode{
+			{
+				NodeType:      "Seq Scan",
+				Relation:      "salary",
+				FullScan:      true,
+				EstimatedRows: 12,
+				EstimatedCost: 25.88,
+			},
+			{
+				NodeType:      "Index Scan",
+				Relation:      "employee",
+				Index:         "employee_pkey",
+				EstimatedRows: 1,
+				EstimatedCost: 1.60,
+			},
+		},
+	}, got)
+
+	_, err = parseExplainJSON(`[]`)
+	require.Error(t, err)
+}
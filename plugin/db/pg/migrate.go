@@ -20,7 +20,10 @@ var (
 	//go:embed pg_migration_schema.sql
 	migrationSchema string
 
-	_ util.MigrationExecutor = (*Driver)(nil)
+	_ util.MigrationExecutor     = (*Driver)(nil)
+	_ util.MigrationLocker       = (*Driver)(nil)
+	_ util.MigrationLockDBOpener = (*Driver)(nil)
+	_ util.StatementExecutor     = (*Driver)(nil)
 )
 
 // NeedsSetupMigration returns whether it needs to setup migration.
@@ -241,6 +244,19 @@ func (Driver) UpdateHistoryAsFailed(ctx context.Context, tx *sql.Tx, migrationDu
 	return err
 }
 
+// UpdateHistoryPayload will update the payload of the migration record.
+func (Driver) UpdateHistoryPayload(ctx context.Context, tx *sql.Tx, payload string, id int64) error {
+	const updateHistoryPayloadQuery = `
+	UPDATE
+		migration_history
+	SET
+		payload = $1
+	WHERE id = $2
+	`
+	_, err := tx.ExecContext(ctx, updateHistoryPayloadQuery, payload, id)
+	return err
+}
+
 // ExecuteMigration will execute the migration.
 func (driver *Driver) ExecuteMigration(ctx context.Context, m *db.MigrationInfo, statement string) (int64, string, error) {
 	if driver.strictUseDb() {
@@ -249,6 +265,66 @@ func (driver *Driver) ExecuteMigration(ctx context.Context, m *db.MigrationInfo,
 	return util.ExecuteMigration(ctx, driver, m, statement, db.BytebaseDatabase)
 }
 
+// TryLockMigration tries to acquire the session-level advisory lock of the namespace.
+func (Driver) TryLockMigration(ctx context.Context, conn *sql.Conn, namespace string) (bool, error) {
+	var locked bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", util.GetMigrationLockKey(namespace)).Scan(&locked); err != nil {
+		return false, err
+	}
+	return locked, nil
+}
+
+// UnlockMigration releases the session-level advisory lock of the namespace.
+func (Driver) UnlockMigration(ctx context.Context, conn *sql.Conn, namespace string) error {
+	var unlocked bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_advisory_unlock($1)", util.GetMigrationLockKey(namespace)).Scan(&unlocked); err != nil {
+		return err
+	}
+	if !unlocked {
+		return errors.Errorf("the migration lock of database %q is not held", namespace)
+	}
+	return nil
+}
+
+// OpenMigrationLockDB opens a connection pool to the database for the migration lock.
+// It's not closed by switchDatabase, so that the advisory lock is held while the migration runs in the migrated database.
+func (driver *Driver) OpenMigrationLockDB(_ context.Context, databaseName string) (*sql.DB, error) {
+	return sql.Open(driverName, driver.baseDSN+" dbname="+databaseName)
+}
+
+// FindMigrationLockHolder finds the backend holding the advisory lock of the namespace.
+func (Driver) FindMigrationLockHolder(ctx context.Context, conn *sql.Conn, namespace string) (string, error) {
+	// The bigint key of the advisory lock is split into classid for the high 32 bits and objid for the low 32 bits in pg_locks.
+	key := uint64(util.GetMigrationLockKey(namespace))
+	query := `
+		SELECT a.pid, a.usename, a.application_name, COALESCE(host(a.client_addr), '')
+		FROM pg_locks l
+		JOIN pg_stat_activity a ON l.pid = a.pid
+		WHERE l.locktype = 'advisory'
+			AND l.granted
+			AND l.database = (SELECT oid FROM pg_database WHERE datname = current_database())
+			AND l.classid::bigint = $1
+			AND l.objid::bigint = $2
+			AND l.objsubid = 1`
+	var pid int
+	var user, application, client string
+	if err := conn.QueryRowContext(ctx, query, int64(key>>32), int64(key&0xFFFFFFFF)).Scan(&pid, &user, &application, &client); err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", err
+	}
+	return fmt.Sprintf("process %d (user %q, application %q, client %q)", pid, user, application, client), nil
+}
+
+// UpdateMigrationHistoryStatementChecksum updates the statement checksum recorded in the migration history payload.
+func (driver *Driver) UpdateMigrationHistoryStatementChecksum(ctx context.Context, id int, checksum string) error {
+	if driver.strictUseDb() {
+		return util.UpdateMigrationHistoryStatementChecksum(ctx, driver, id, checksum, driver.strictDatabase)
+	}
+	return util.UpdateMigrationHistoryStatementChecksum(ctx, driver, id, checksum, db.BytebaseDatabase)
+}
+
 // FindMigrationHistoryList finds the migration history.
 func (driver *Driver) FindMigrationHistoryList(ctx context.Context, find *db.MigrationHistoryFind) ([]*db.MigrationHistory, error) {
 	baseQuery := `
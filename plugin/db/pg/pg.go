@@ -6,11 +6,13 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	// Import pg driver.
 	// init() in pgx/v4/stdlib will register it's pgx driver.
 	_ "github.com/jackc/pgx/v4/stdlib"
 	"github.com/pkg/errors"
+	"go.uber.org/multierr"
 
 	"github.com/bytebase/bytebase/common"
 	"github.com/bytebase/bytebase/plugin/db"
@@ -55,24 +57,42 @@ type Driver struct {
 	db           *sql.DB
 	baseDSN      string
 	databaseName string
+	// sshTunnel is the SSH tunnel to connect the database, it's nil if the SSH tunnel isn't configured.
+	sshTunnel *db.SSHTunnel
 
 	// strictDatabase should be used only if the user gives only a database instead of a whole instance to access.
 	strictDatabase string
+	// nativePgDump dumps databases without the pg_dump binary.
+	nativePgDump bool
 }
 
 func newDriver(config db.DriverConfig) db.Driver {
 	return &Driver{
 		pgInstanceDir: config.PgInstanceDir,
+		nativePgDump:  config.NativePgDump,
 	}
 }
 
 // Open opens a Postgres driver.
-func (driver *Driver) Open(_ context.Context, _ db.Type, config db.ConnectionConfig, connCtx db.ConnectionContext) (db.Driver, error) {
+func (driver *Driver) Open(_ context.Context, _ db.Type, config db.ConnectionConfig, connCtx db.ConnectionContext) (_ db.Driver, retErr error) {
 	if (config.TLSConfig.SslCert == "" && config.TLSConfig.SslKey != "") ||
 		(config.TLSConfig.SslCert != "" && config.TLSConfig.SslKey == "") {
 		return nil, errors.Errorf("ssl-cert and ssl-key must be both set or unset")
 	}
 
+	// Connect the local end of the SSH tunnel, so that pg_dump and psql binaries can connect it as well.
+	sshTunnel, config, err := db.OpenSSHTunnelForConnection(config, "5432")
+	if err != nil {
+		return nil, err
+	}
+	if sshTunnel != nil {
+		defer func() {
+			if retErr != nil {
+				sshTunnel.Close()
+			}
+		}()
+	}
+
 	databaseName, dsn, err := guessDSN(
 		config.Username,
 		config.Password,
@@ -91,6 +111,7 @@ func (driver *Driver) Open(_ context.Context, _ db.Type, config db.ConnectionCon
 	}
 	driver.databaseName = databaseName
 	driver.baseDSN = dsn
+	driver.sshTunnel = sshTunnel
 	driver.connectionCtx = connCtx
 	driver.config = config
 	if config.StrictUseDb {
@@ -167,7 +188,11 @@ func guessDSN(username, password, hostname, port, database, sslCA, sslCert, sslK
 
 // Close closes the driver.
 func (driver *Driver) Close(context.Context) error {
-	return driver.db.Close()
+	err := driver.db.Close()
+	if driver.sshTunnel != nil {
+		err = multierr.Append(err, driver.sshTunnel.Close())
+	}
+	return err
 }
 
 // Ping pings the database.
@@ -311,6 +336,60 @@ func (driver *Driver) Execute(ctx context.Context, statement string) error {
 	return tx.Commit()
 }
 
+// ExecuteStatements executes the statements one by one in a transaction, and reports the progress to the reporter.
+func (driver *Driver) ExecuteStatements(ctx context.Context, statement string, reporter db.StatementProgressReporter) error {
+	statements, err := parser.SplitMultiSQL(parser.Postgres, statement)
+	if err != nil {
+		return err
+	}
+	for _, singleSQL := range statements {
+		// The statements handled specially by Execute are rare in migrations, so we just execute the statement as a whole.
+		if isNonTransactionalStatement(singleSQL.Text) || isSuperuserStatement(singleSQL.Text) {
+			startedTime := time.Now()
+			reporter.OnStatementStart(0, 1, statement)
+			if err := driver.Execute(ctx, statement); err != nil {
+				return err
+			}
+			reporter.OnStatementDone(0, time.Since(startedTime))
+			return nil
+		}
+	}
+
+	owner, err := driver.GetCurrentDatabaseOwner()
+	if err != nil {
+		return err
+	}
+	tx, err := driver.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Set the current transaction role to the database owner so that the owner of created database will be the same as the database owner.
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("SET LOCAL ROLE %s", owner)); err != nil {
+		return err
+	}
+
+	for i, singleSQL := range statements {
+		startedTime := time.Now()
+		reporter.OnStatementStart(i, len(statements), singleSQL.Text)
+		if _, err := tx.ExecContext(ctx, singleSQL.Text); err != nil {
+			return err
+		}
+		reporter.OnStatementDone(i, time.Since(startedTime))
+	}
+
+	return tx.Commit()
+}
+
+// isNonTransactionalStatement returns true if the statement is executed outside of the transaction by Execute.
+func isNonTransactionalStatement(stmt string) bool {
+	return strings.HasPrefix(stmt, "CREATE DATABASE ") ||
+		strings.HasPrefix(stmt, "GRANT") ||
+		(strings.HasPrefix(stmt, "ALTER DATABASE") && strings.Contains(stmt, " OWNER TO ")) ||
+		strings.HasPrefix(stmt, "\\connect ")
+}
+
 func isSuperuserStatement(stmt string) bool {
 	upperCaseStmt := strings.ToUpper(stmt)
 	if strings.Contains(upperCaseStmt, "CREATE EVENT TRIGGER") || strings.Contains(upperCaseStmt, "CREATE EXTENSION") || strings.Contains(upperCaseStmt, "COMMENT ON EXTENSION") || strings.Contains(upperCaseStmt, "COMMENT ON EVENT TRIGGER") {
@@ -331,6 +410,11 @@ func getDatabaseInCreateDatabaseStatement(createDatabaseStatement string) (strin
 	return databaseName, nil
 }
 
+// GetCurrentDatabaseName returns the database the driver is connected to.
+func (driver *Driver) GetCurrentDatabaseName() string {
+	return driver.databaseName
+}
+
 // GetCurrentDatabaseOwner gets the role of the current database.
 func (driver *Driver) GetCurrentDatabaseOwner() (string, error) {
 	const query = `
@@ -369,6 +453,11 @@ func (driver *Driver) Query(ctx context.Context, statement string, limit int, re
 	return util.Query(ctx, db.Postgres, driver.db, statement, limit, readOnly)
 }
 
+// QueryRows queries a SQL statement and returns the cursor of the result.
+func (driver *Driver) QueryRows(ctx context.Context, statement string, limit int, readOnly bool) (db.Rows, error) {
+	return util.QueryRows(ctx, db.Postgres, driver.db, statement, limit, readOnly)
+}
+
 func (driver *Driver) switchDatabase(dbName string) error {
 	if driver.db != nil {
 		if err := driver.db.Close(); err != nil {
@@ -49,3 +49,42 @@ func TestGetDatabaseInCreateDatabaseStatement(t *testing.T) {
 		require.Equal(t, test.want, got)
 	}
 }
+
+func TestGetTriggerTimingAndEvent(t *testing.T) {
+	tests := []struct {
+		tgType     int
+		wantTiming string
+		wantEvent  string
+	}{
+		{
+			// BEFORE INSERT FOR EACH ROW.
+			tgType:     7,
+			wantTiming: "BEFORE",
+			wantEvent:  "INSERT",
+		},
+		{
+			// AFTER INSERT OR UPDATE OR DELETE FOR EACH ROW.
+			tgType:     29,
+			wantTiming: "AFTER",
+			wantEvent:  "INSERT OR UPDATE OR DELETE",
+		},
+		{
+			// INSTEAD OF UPDATE FOR EACH ROW.
+			tgType:     81,
+			wantTiming: "INSTEAD OF",
+			wantEvent:  "UPDATE",
+		},
+		{
+			// AFTER TRUNCATE FOR EACH STATEMENT.
+			tgType:     32,
+			wantTiming: "AFTER",
+			wantEvent:  "TRUNCATE",
+		},
+	}
+
+	for _, test := range tests {
+		timing, event := getTriggerTimingAndEvent(test.tgType)
+		require.Equal(t, test.wantTiming, timing)
+		require.Equal(t, test.wantEvent, event)
+	}
+}
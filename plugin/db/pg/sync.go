@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -64,6 +65,15 @@ type viewSchema struct {
 	comment    string
 }
 
+// partitionSchema describes the partition hierarchy of a pg table.
+type partitionSchema struct {
+	schemaName string
+	tableName  string
+	key        string
+	parent     string
+	bound      string
+}
+
 // indexSchema describes the schema of a pg index.
 type indexSchema struct {
 	schemaName string
@@ -155,6 +165,11 @@ func (driver *Driver) SyncDBSchema(ctx context.Context, databaseName string) (*d
 	}
 	defer txn.Rollback()
 
+	versionNum, err := getServerVersionNum(txn)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get server version from database %q", databaseName)
+	}
+
 	// Index statements.
 	indicesMap := make(map[string][]*indexSchema)
 	indices, err := getIndices(txn)
@@ -166,6 +181,25 @@ func (driver *Driver) SyncDBSchema(ctx context.Context, databaseName string) (*d
 		indicesMap[key] = append(indicesMap[key], idx)
 	}
 
+	// Partitions.
+	partitionMap := make(map[string]*partitionSchema)
+	// Declarative partitioning is introduced in PostgreSQL 10.
+	if versionNum >= 100000 {
+		partitions, err := getPartitions(txn)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get partitions from database %q", databaseName)
+		}
+		for _, partition := range partitions {
+			partitionMap[fmt.Sprintf("%s.%s", partition.schemaName, partition.tableName)] = partition
+		}
+	}
+
+	// Foreign keys.
+	foreignKeysMap, err := getForeignKeys(txn)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get foreign keys from database %q", databaseName)
+	}
+
 	// Table statements.
 	tables, err := getPgTables(txn)
 	if err != nil {
@@ -204,6 +238,12 @@ func (driver *Driver) SyncDBSchema(ctx context.Context, databaseName string) (*d
 				dbTable.IndexList = append(dbTable.IndexList, dbIndex)
 			}
 		}
+		dbTable.ForeignKeyList = foreignKeysMap[dbTable.Name]
+		if partition, ok := partitionMap[dbTable.Name]; ok {
+			dbTable.PartitionKey = partition.key
+			dbTable.PartitionParent = partition.parent
+			dbTable.PartitionBound = partition.bound
+		}
 
 		schema.TableList = append(schema.TableList, dbTable)
 	}
@@ -222,12 +262,45 @@ func (driver *Driver) SyncDBSchema(ctx context.Context, databaseName string) (*d
 
 		schema.ViewList = append(schema.ViewList, dbView)
 	}
+	// Materialized view statements.
+	materializedViews, err := getMaterializedViews(txn)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get materialized views from database %q", databaseName)
+	}
+	for _, view := range materializedViews {
+		var dbView db.View
+		dbView.Name = fmt.Sprintf("%s.%s", view.schemaName, view.name)
+		// Postgres does not store
+		dbView.CreatedTs = time.Now().Unix()
+		dbView.Definition = view.definition
+		dbView.Comment = view.comment
+
+		schema.MaterializedViewList = append(schema.MaterializedViewList, dbView)
+	}
 	// Extensions.
 	extensions, err := getExtensions(txn)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to get extensions from database %q", databaseName)
 	}
 	schema.ExtensionList = extensions
+	// Functions.
+	functions, err := getFunctions(txn, versionNum)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get functions from database %q", databaseName)
+	}
+	schema.FunctionList = functions
+	// Sequences.
+	sequences, err := getSequences(txn)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get sequences from database %q", databaseName)
+	}
+	schema.SequenceList = sequences
+	// Triggers.
+	triggers, err := getTriggers(txn)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get triggers from database %q", databaseName)
+	}
+	schema.TriggerList = triggers
 
 	if err := txn.Commit(); err != nil {
 		return nil, err
@@ -456,6 +529,63 @@ func getTableConstraints(txn *sql.Tx) (map[string][]*tableConstraint, error) {
 	return ret, nil
 }
 
+// getForeignKeys gets all foreign keys of a database keyed by "schema.table".
+func getForeignKeys(txn *sql.Tx) (map[string][]db.ForeignKey, error) {
+	query := `
+	SELECT n.nspname, c.relname, con.conname, a.attname, k.ord, fn.nspname, fc.relname, fa.attname, con.confupdtype, con.confdeltype
+	FROM pg_catalog.pg_constraint con
+	JOIN pg_catalog.pg_class c ON c.oid = con.conrelid
+	JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+	JOIN pg_catalog.pg_class fc ON fc.oid = con.confrelid
+	JOIN pg_catalog.pg_namespace fn ON fn.oid = fc.relnamespace
+	CROSS JOIN LATERAL unnest(con.conkey, con.confkey) WITH ORDINALITY AS k(attnum, fattnum, ord)
+	JOIN pg_catalog.pg_attribute a ON a.attrelid = con.conrelid AND a.attnum = k.attnum
+	JOIN pg_catalog.pg_attribute fa ON fa.attrelid = con.confrelid AND fa.attnum = k.fattnum
+	WHERE con.contype = 'f' AND n.nspname NOT IN ('pg_catalog', 'information_schema')
+	ORDER BY n.nspname, c.relname, con.conname, k.ord;`
+	ret := make(map[string][]db.ForeignKey)
+	rows, err := txn.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var schemaName, tableName, referencedSchemaName, referencedTableName, onUpdate, onDelete string
+		var fk db.ForeignKey
+		if err := rows.Scan(&schemaName, &tableName, &fk.Name, &fk.Column, &fk.Position, &referencedSchemaName, &referencedTableName, &fk.ReferencedColumn, &onUpdate, &onDelete); err != nil {
+			return nil, err
+		}
+		fk.ReferencedTable = fmt.Sprintf("%s.%s", referencedSchemaName, referencedTableName)
+		fk.OnUpdate = getForeignKeyAction(onUpdate)
+		fk.OnDelete = getForeignKeyAction(onDelete)
+		key := fmt.Sprintf("%s.%s", schemaName, tableName)
+		ret[key] = append(ret[key], fk)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// getForeignKeyAction converts the pg_constraint.confupdtype and confdeltype codes to referential actions.
+func getForeignKeyAction(code string) string {
+	switch code {
+	case "a":
+		return "NO ACTION"
+	case "r":
+		return "RESTRICT"
+	case "c":
+		return "CASCADE"
+	case "n":
+		return "SET NULL"
+	case "d":
+		return "SET DEFAULT"
+	default:
+		return code
+	}
+}
+
 // getViews gets all views of a database.
 func getViews(txn *sql.Tx) ([]*viewSchema, error) {
 	query := `
@@ -521,6 +651,222 @@ func getExtensions(txn *sql.Tx) ([]db.Extension, error) {
 	return extensions, nil
 }
 
+// getMaterializedViews gets all materialized views of a database.
+func getMaterializedViews(txn *sql.Tx) ([]*viewSchema, error) {
+	query := `
+	SELECT schemaname, matviewname, definition, obj_description(format('%s.%s', quote_ident(schemaname), quote_ident(matviewname))::regclass) FROM pg_catalog.pg_matviews
+	WHERE schemaname NOT IN ('pg_catalog', 'information_schema');`
+	var views []*viewSchema
+	rows, err := txn.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var view viewSchema
+		var def, comment sql.NullString
+		if err := rows.Scan(&view.schemaName, &view.name, &def, &comment); err != nil {
+			return nil, err
+		}
+		if !def.Valid {
+			return nil, errors.Errorf("schema %q materialized view %q has empty definition; please check whether proper privileges have been granted to Bytebase", view.schemaName, view.name)
+		}
+		view.definition = def.String
+		if comment.Valid {
+			view.comment = comment.String
+		}
+		views = append(views, &view)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return views, nil
+}
+
+// getPartitions gets the partitioned tables and partitions of a database.
+func getPartitions(txn *sql.Tx) ([]*partitionSchema, error) {
+	query := `
+	SELECT n.nspname, c.relname,
+		COALESCE(pg_get_partkeydef(c.oid), ''),
+		COALESCE(pn.nspname || '.' || p.relname, ''),
+		COALESCE(pg_get_expr(c.relpartbound, c.oid), '')
+	FROM pg_catalog.pg_class c
+	JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+	LEFT JOIN pg_catalog.pg_inherits i ON i.inhrelid = c.oid AND c.relispartition
+	LEFT JOIN pg_catalog.pg_class p ON p.oid = i.inhparent
+	LEFT JOIN pg_catalog.pg_namespace pn ON pn.oid = p.relnamespace
+	WHERE (c.relkind = 'p' OR c.relispartition) AND n.nspname NOT IN ('pg_catalog', 'information_schema');`
+	var partitions []*partitionSchema
+	rows, err := txn.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var partition partitionSchema
+		if err := rows.Scan(&partition.schemaName, &partition.tableName, &partition.key, &partition.parent, &partition.bound); err != nil {
+			return nil, err
+		}
+		partitions = append(partitions, &partition)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return partitions, nil
+}
+
+// getFunctions gets all functions and procedures of a database.
+// Functions belonging to extensions are skipped because they are managed by the extensions.
+func getFunctions(txn *sql.Tx, versionNum int) ([]db.Function, error) {
+	// prokind is introduced in PostgreSQL 11, and pg_get_functiondef doesn't work on aggregate functions.
+	kindCondition := "NOT p.proisagg AND NOT p.proiswindow"
+	if versionNum >= 110000 {
+		kindCondition = "p.prokind IN ('f', 'p')"
+	}
+	query := `
+	SELECT n.nspname, p.proname, pg_get_function_arguments(p.oid), COALESCE(pg_get_function_result(p.oid), ''), l.lanname,
+		pg_get_functiondef(p.oid), obj_description(p.oid, 'pg_proc')
+	FROM pg_catalog.pg_proc p
+	JOIN pg_catalog.pg_namespace n ON n.oid = p.pronamespace
+	JOIN pg_catalog.pg_language l ON l.oid = p.prolang
+	WHERE n.nspname NOT IN ('pg_catalog', 'information_schema') AND ` + kindCondition + `
+		AND NOT EXISTS (SELECT 1 FROM pg_catalog.pg_depend d WHERE d.objid = p.oid AND d.deptype = 'e');`
+
+	var functions []db.Function
+	rows, err := txn.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var function db.Function
+		var schemaName, name string
+		var comment sql.NullString
+		if err := rows.Scan(&schemaName, &name, &function.Arguments, &function.ReturnType, &function.Language, &function.Definition, &comment); err != nil {
+			return nil, err
+		}
+		function.Name = fmt.Sprintf("%s.%s", schemaName, name)
+		if comment.Valid {
+			function.Comment = comment.String
+		}
+		functions = append(functions, function)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return functions, nil
+}
+
+// getSequences gets all sequences of a database.
+func getSequences(txn *sql.Tx) ([]db.Sequence, error) {
+	query := `
+	SELECT seq.sequence_schema, seq.sequence_name, seq.data_type, seq.start_value, seq.minimum_value, seq.maximum_value, seq.increment, seq.cycle_option,
+		COALESCE(owner.table_name || '.' || owner.column_name, '')
+	FROM information_schema.sequences AS seq
+	LEFT JOIN (
+		SELECT sn.nspname AS sequence_schema, s.relname AS sequence_name, tn.nspname || '.' || t.relname AS table_name, a.attname AS column_name
+		FROM pg_catalog.pg_class s
+		JOIN pg_catalog.pg_namespace sn ON sn.oid = s.relnamespace
+		JOIN pg_catalog.pg_depend d ON d.objid = s.oid AND d.classid = 'pg_catalog.pg_class'::regclass AND d.refclassid = 'pg_catalog.pg_class'::regclass AND d.deptype IN ('a', 'i')
+		JOIN pg_catalog.pg_class t ON t.oid = d.refobjid
+		JOIN pg_catalog.pg_namespace tn ON tn.oid = t.relnamespace
+		JOIN pg_catalog.pg_attribute a ON a.attrelid = t.oid AND a.attnum = d.refobjsubid
+		WHERE s.relkind = 'S'
+	) AS owner ON owner.sequence_schema = seq.sequence_schema AND owner.sequence_name = seq.sequence_name
+	WHERE seq.sequence_schema NOT IN ('pg_catalog', 'information_schema');`
+
+	var sequences []db.Sequence
+	rows, err := txn.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var sequence db.Sequence
+		var schemaName, name, startValue, minValue, maxValue, increment, cycle string
+		if err := rows.Scan(&schemaName, &name, &sequence.DataType, &startValue, &minValue, &maxValue, &increment, &cycle, &sequence.OwnedBy); err != nil {
+			return nil, err
+		}
+		sequence.Name = fmt.Sprintf("%s.%s", schemaName, name)
+		if sequence.StartValue, err = strconv.ParseInt(startValue, 10, 64); err != nil {
+			return nil, errors.Wrapf(err, "invalid start value %q of sequence %q", startValue, sequence.Name)
+		}
+		if sequence.MinValue, err = strconv.ParseInt(minValue, 10, 64); err != nil {
+			return nil, errors.Wrapf(err, "invalid minimum value %q of sequence %q", minValue, sequence.Name)
+		}
+		if sequence.MaxValue, err = strconv.ParseInt(maxValue, 10, 64); err != nil {
+			return nil, errors.Wrapf(err, "invalid maximum value %q of sequence %q", maxValue, sequence.Name)
+		}
+		if sequence.Increment, err = strconv.ParseInt(increment, 10, 64); err != nil {
+			return nil, errors.Wrapf(err, "invalid increment %q of sequence %q", increment, sequence.Name)
+		}
+		if sequence.Cycle, err = convertBoolFromYesNo(cycle); err != nil {
+			return nil, err
+		}
+		sequences = append(sequences, sequence)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return sequences, nil
+}
+
+// getTriggers gets all triggers of a database.
+// Internal triggers such as the ones for foreign keys are skipped.
+func getTriggers(txn *sql.Tx) ([]db.Trigger, error) {
+	query := `
+	SELECT n.nspname, c.relname, t.tgname, t.tgtype, pg_get_triggerdef(t.oid), obj_description(t.oid, 'pg_trigger')
+	FROM pg_catalog.pg_trigger t
+	JOIN pg_catalog.pg_class c ON c.oid = t.tgrelid
+	JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+	WHERE NOT t.tgisinternal AND n.nspname NOT IN ('pg_catalog', 'information_schema');`
+
+	var triggers []db.Trigger
+	rows, err := txn.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var trigger db.Trigger
+		var schemaName, tableName string
+		var tgType int
+		var comment sql.NullString
+		if err := rows.Scan(&schemaName, &tableName, &trigger.Name, &tgType, &trigger.Definition, &comment); err != nil {
+			return nil, err
+		}
+		trigger.TableName = fmt.Sprintf("%s.%s", schemaName, tableName)
+		trigger.Timing, trigger.Event = getTriggerTimingAndEvent(tgType)
+		if comment.Valid {
+			trigger.Comment = comment.String
+		}
+		triggers = append(triggers, trigger)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return triggers, nil
+}
+
+// getServerVersionNum gets the server version number such as 140005 for 14.5.
+func getServerVersionNum(txn *sql.Tx) (int, error) {
+	query := "SHOW server_version_num"
+	var version string
+	if err := txn.QueryRow(query).Scan(&version); err != nil {
+		return 0, util.FormatErrorWithQuery(err, query)
+	}
+	versionNum, err := strconv.Atoi(version)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid server version number %q", version)
+	}
+	return versionNum, nil
+}
+
 // getIndices gets all indices of a database.
 func getIndices(txn *sql.Tx) ([]*indexSchema, error) {
 	query := `
@@ -580,6 +926,40 @@ func convertBoolFromYesNo(s string) (bool, error) {
 	}
 }
 
+// getTriggerTimingAndEvent decodes the pg_trigger.tgtype bit mask.
+// https://github.com/postgres/postgres/blob/master/src/include/catalog/pg_trigger.h
+func getTriggerTimingAndEvent(tgType int) (string, string) {
+	const (
+		triggerTypeBefore   = 1 << 1
+		triggerTypeInsert   = 1 << 2
+		triggerTypeDelete   = 1 << 3
+		triggerTypeUpdate   = 1 << 4
+		triggerTypeTruncate = 1 << 5
+		triggerTypeInstead  = 1 << 6
+	)
+	timing := "AFTER"
+	if tgType&triggerTypeBefore != 0 {
+		timing = "BEFORE"
+	} else if tgType&triggerTypeInstead != 0 {
+		timing = "INSTEAD OF"
+	}
+
+	var events []string
+	if tgType&triggerTypeInsert != 0 {
+		events = append(events, "INSERT")
+	}
+	if tgType&triggerTypeUpdate != 0 {
+		events = append(events, "UPDATE")
+	}
+	if tgType&triggerTypeDelete != 0 {
+		events = append(events, "DELETE")
+	}
+	if tgType&triggerTypeTruncate != 0 {
+		events = append(events, "TRUNCATE")
+	}
+	return timing, strings.Join(events, " OR ")
+}
+
 func getIndexMethodType(stmt string) string {
 	re := regexp.MustCompile(`USING (\w+) `)
 	matches := re.FindStringSubmatch(stmt)
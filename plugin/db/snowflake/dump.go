@@ -18,17 +18,23 @@ const (
 		"--\n" +
 		"-- Snowflake database structure for %s\n" +
 		"--\n"
+	tableDataFmt = "" +
+		"--\n" +
+		"-- Data for table %s\n" +
+		"--\n"
+	// dataInsertBatchSize is the max number of rows in an INSERT statement.
+	dataInsertBatchSize = 100
 )
 
 // Dump dumps the database.
-func (driver *Driver) Dump(ctx context.Context, database string, out io.Writer, _ bool) (string, error) {
+func (driver *Driver) Dump(ctx context.Context, database string, out io.Writer, schemaOnly bool) (string, error) {
 	txn, err := driver.db.BeginTx(ctx, &sql.TxOptions{})
 	if err != nil {
 		return "", err
 	}
 	defer txn.Rollback()
 
-	if err := dumpTxn(ctx, txn, database, out); err != nil {
+	if err := dumpTxn(ctx, txn, database, out, schemaOnly); err != nil {
 		return "", err
 	}
 
@@ -39,8 +45,8 @@ func (driver *Driver) Dump(ctx context.Context, database string, out io.Writer,
 	return "", nil
 }
 
-// dumpTxn will dump the input database. schemaOnly isn't supported yet and true by default.
-func dumpTxn(ctx context.Context, txn *sql.Tx, database string, out io.Writer) error {
+// dumpTxn will dump the input database. The table data is dumped as INSERT statements if schemaOnly is false.
+func dumpTxn(ctx context.Context, txn *sql.Tx, database string, out io.Writer, schemaOnly bool) error {
 	// Find all dumpable databases
 	var dumpableDbNames []string
 	if database != "" {
@@ -65,6 +71,12 @@ func dumpTxn(ctx context.Context, txn *sql.Tx, database string, out io.Writer) e
 		if err := dumpOneDatabase(ctx, txn, dbName, out, dumpSingleDatabase); err != nil {
 			return err
 		}
+		if schemaOnly {
+			continue
+		}
+		if err := dumpDatabaseData(ctx, txn, dbName, out, dumpSingleDatabase); err != nil {
+			return errors.Wrapf(err, "failed to dump data of database %q", dbName)
+		}
 	}
 
 	return nil
@@ -135,10 +147,229 @@ func dumpOneDatabase(ctx context.Context, txn *sql.Tx, database string, out io.W
 	return nil
 }
 
+// columnSchema is the column used to export table data.
+type columnSchema struct {
+	name     string
+	dataType string
+}
+
+// dumpDatabaseData dumps the data of all tables in the database as INSERT statements.
+func dumpDatabaseData(ctx context.Context, txn *sql.Tx, database string, out io.Writer, dumpSingleDatabase bool) error {
+	tableColumns, tableList, err := getTableColumns(ctx, txn, database)
+	if err != nil {
+		return err
+	}
+	if len(tableList) == 0 {
+		return nil
+	}
+	// Separate the data from the database DDL which may not end with a new line.
+	if _, err := io.WriteString(out, "\n"); err != nil {
+		return err
+	}
+	if !dumpSingleDatabase {
+		if _, err := io.WriteString(out, fmt.Sprintf("USE DATABASE %s;\n", quoteIdentifier(database))); err != nil {
+			return err
+		}
+	}
+	for _, table := range tableList {
+		if err := exportTableData(ctx, txn, database, table, tableColumns[table], out); err != nil {
+			return errors.Wrapf(err, "failed to export data of table %q", table.String())
+		}
+	}
+	return nil
+}
+
+type tableName struct {
+	schema string
+	name   string
+}
+
+func (t tableName) String() string {
+	return fmt.Sprintf("%s.%s", quoteIdentifier(t.schema), quoteIdentifier(t.name))
+}
+
+// getTableColumns gets the columns of base tables in the database, and the tables in order.
+func getTableColumns(ctx context.Context, txn *sql.Tx, database string) (map[tableName][]*columnSchema, []tableName, error) {
+	query := fmt.Sprintf(`
+		SELECT c.TABLE_SCHEMA, c.TABLE_NAME, c.COLUMN_NAME, c.DATA_TYPE
+		FROM %s.INFORMATION_SCHEMA.COLUMNS AS c
+		JOIN %s.INFORMATION_SCHEMA.TABLES AS t
+			ON c.TABLE_SCHEMA = t.TABLE_SCHEMA AND c.TABLE_NAME = t.TABLE_NAME
+		WHERE t.TABLE_TYPE = 'BASE TABLE' AND c.TABLE_SCHEMA <> 'INFORMATION_SCHEMA'
+		ORDER BY c.TABLE_SCHEMA, c.TABLE_NAME, c.ORDINAL_POSITION`, quoteIdentifier(database), quoteIdentifier(database))
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, nil, util.FormatErrorWithQuery(err, query)
+	}
+	defer rows.Close()
+
+	tableColumns := make(map[tableName][]*columnSchema)
+	var tableList []tableName
+	for rows.Next() {
+		var table tableName
+		column := &columnSchema{}
+		if err := rows.Scan(&table.schema, &table.name, &column.name, &column.dataType); err != nil {
+			return nil, nil, err
+		}
+		if _, ok := tableColumns[table]; !ok {
+			tableList = append(tableList, table)
+		}
+		tableColumns[table] = append(tableColumns[table], column)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	return tableColumns, tableList, nil
+}
+
+// exportTableData dumps the table data as INSERT ... SELECT statements, because the VALUES clause only accepts constants,
+// which cannot express the semi-structured, binary and geospatial values.
+// Every value is selected as a string, and converted back to its data type in the INSERT statement.
+func exportTableData(ctx context.Context, txn *sql.Tx, database string, table tableName, columns []*columnSchema, out io.Writer) error {
+	var selectList, columnList []string
+	for _, column := range columns {
+		selectList = append(selectList, getColumnExportExpression(column))
+		columnList = append(columnList, quoteIdentifier(column.name))
+	}
+	query := fmt.Sprintf("SELECT %s FROM %s.%s", strings.Join(selectList, ", "), quoteIdentifier(database), table.String())
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return util.FormatErrorWithQuery(err, query)
+	}
+	defer rows.Close()
+
+	prefix := fmt.Sprintf("INSERT INTO %s (%s) ", table.String(), strings.Join(columnList, ", "))
+	var batch []string
+	wroteHeader := false
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if !wroteHeader {
+			if _, err := io.WriteString(out, fmt.Sprintf(tableDataFmt, table.String())); err != nil {
+				return err
+			}
+			wroteHeader = true
+		}
+		if _, err := io.WriteString(out, fmt.Sprintf("%s%s;\n", prefix, strings.Join(batch, " UNION ALL "))); err != nil {
+			return err
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	values := make([]sql.NullString, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return err
+		}
+		var literalList []string
+		for i, column := range columns {
+			literalList = append(literalList, getColumnImportLiteral(column, values[i]))
+		}
+		batch = append(batch, fmt.Sprintf("SELECT %s", strings.Join(literalList, ", ")))
+		if len(batch) >= dataInsertBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	return flush()
+}
+
+const (
+	dateFormat         = "YYYY-MM-DD"
+	timeFormat         = "HH24:MI:SS.FF9"
+	timestampFormat    = "YYYY-MM-DD HH24:MI:SS.FF9"
+	timestampTZFormat  = "YYYY-MM-DD HH24:MI:SS.FF9 TZH:TZM"
+	binaryExportFormat = "HEX"
+)
+
+// getColumnExportExpression returns the expression selecting the column value as a string without losing precision.
+func getColumnExportExpression(column *columnSchema) string {
+	name := quoteIdentifier(column.name)
+	switch column.dataType {
+	case "TEXT":
+		return name
+	case "DATE":
+		return fmt.Sprintf("TO_VARCHAR(%s, '%s')", name, dateFormat)
+	case "TIME":
+		return fmt.Sprintf("TO_VARCHAR(%s, '%s')", name, timeFormat)
+	case "TIMESTAMP_NTZ":
+		return fmt.Sprintf("TO_VARCHAR(%s, '%s')", name, timestampFormat)
+	case "TIMESTAMP_LTZ", "TIMESTAMP_TZ":
+		return fmt.Sprintf("TO_VARCHAR(%s, '%s')", name, timestampTZFormat)
+	case "BINARY":
+		return fmt.Sprintf("TO_VARCHAR(%s, '%s')", name, binaryExportFormat)
+	case "VARIANT", "OBJECT", "ARRAY":
+		return fmt.Sprintf("TO_JSON(%s)", name)
+	case "GEOGRAPHY", "GEOMETRY":
+		return fmt.Sprintf("ST_ASWKT(%s)", name)
+	default:
+		return fmt.Sprintf("TO_VARCHAR(%s)", name)
+	}
+}
+
+// getColumnImportLiteral returns the expression converting the exported string back to the column value.
+// Other data types such as NUMBER and BOOLEAN are converted implicitly on insertion.
+func getColumnImportLiteral(column *columnSchema, value sql.NullString) string {
+	if !value.Valid {
+		return "NULL"
+	}
+	literal := quoteString(value.String)
+	switch column.dataType {
+	case "DATE":
+		return fmt.Sprintf("TO_DATE(%s, '%s')", literal, dateFormat)
+	case "TIME":
+		return fmt.Sprintf("TO_TIME(%s, '%s')", literal, timeFormat)
+	case "TIMESTAMP_NTZ":
+		return fmt.Sprintf("TO_TIMESTAMP_NTZ(%s, '%s')", literal, timestampFormat)
+	case "TIMESTAMP_LTZ":
+		return fmt.Sprintf("TO_TIMESTAMP_LTZ(%s, '%s')", literal, timestampTZFormat)
+	case "TIMESTAMP_TZ":
+		return fmt.Sprintf("TO_TIMESTAMP_TZ(%s, '%s')", literal, timestampTZFormat)
+	case "BINARY":
+		return fmt.Sprintf("TO_BINARY(%s, '%s')", literal, binaryExportFormat)
+	case "VARIANT", "OBJECT", "ARRAY":
+		return fmt.Sprintf("PARSE_JSON(%s)", literal)
+	case "GEOGRAPHY":
+		return fmt.Sprintf("TO_GEOGRAPHY(%s)", literal)
+	case "GEOMETRY":
+		return fmt.Sprintf("TO_GEOMETRY(%s)", literal)
+	default:
+		return literal
+	}
+}
+
+// quoteString quotes the string as a Snowflake string literal.
+// The line breaks are escaped so that each statement is in a single line, which is required by util.ApplyMultiStatements.
+func quoteString(s string) string {
+	s = strings.NewReplacer(
+		`\`, `\\`,
+		`'`, `\'`,
+		"\n", `\n`,
+		"\r", `\r`,
+		"\t", `\t`,
+		"\x00", `\0`,
+	).Replace(s)
+	return fmt.Sprintf("'%s'", s)
+}
+
+func quoteIdentifier(name string) string {
+	return fmt.Sprintf(`"%s"`, strings.ReplaceAll(name, `"`, `""`))
+}
+
 // Restore restores a database.
 func (driver *Driver) Restore(ctx context.Context, sc io.Reader) (err error) {
 	if err := driver.useRole(ctx, sysAdminRole); err != nil {
-		return nil
+		return err
 	}
 	txn, err := driver.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -0,0 +1,42 @@
+package snowflake
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuoteString(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{s: "abc", want: `'abc'`},
+		{s: "it's", want: `'it\'s'`},
+		{s: `a\b`, want: `'a\\b'`},
+		{s: "line1\n-- line2;\r\n", want: `'line1\n-- line2;\r\n'`},
+		{s: "a\tb\x00", want: `'a\tb\0'`},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.want, quoteString(test.s))
+	}
+}
+
+func TestGetColumnImportLiteral(t *testing.T) {
+	tests := []struct {
+		dataType string
+		value    sql.NullString
+		want     string
+	}{
+		{dataType: "NUMBER", value: sql.NullString{}, want: "NULL"},
+		{dataType: "NUMBER", value: sql.NullString{String: "1.50", Valid: true}, want: `'1.50'`},
+		{dataType: "TEXT", value: sql.NullString{String: "", Valid: true}, want: `''`},
+		{dataType: "TIMESTAMP_TZ", value: sql.NullString{String: "2022-01-02 03:04:05.000000000 +08:00", Valid: true}, want: `TO_TIMESTAMP_TZ('2022-01-02 03:04:05.000000000 +08:00', 'YYYY-MM-DD HH24:MI:SS.FF9 TZH:TZM')`},
+		{dataType: "BINARY", value: sql.NullString{String: "0A0B", Valid: true}, want: `TO_BINARY('0A0B', 'HEX')`},
+		{dataType: "VARIANT", value: sql.NullString{String: `{"a":"b'c"}`, Valid: true}, want: `PARSE_JSON('{"a":"b\'c"}')`},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.want, getColumnImportLiteral(&columnSchema{name: "c", dataType: test.dataType}, test.value))
+	}
+}
@@ -20,7 +20,8 @@ var (
 	//go:embed snowflake_migration_schema.sql
 	migrationSchema string
 
-	_ util.MigrationExecutor = (*Driver)(nil)
+	_ util.MigrationExecutor  = (*Driver)(nil)
+	_ util.MigrationLockTable = (*Driver)(nil)
 )
 
 // NeedsSetupMigration returns whether it needs to setup migration.
@@ -222,6 +223,19 @@ func (Driver) UpdateHistoryAsFailed(ctx context.Context, tx *sql.Tx, migrationDu
 	return err
 }
 
+// UpdateHistoryPayload will update the payload of the migration record.
+func (Driver) UpdateHistoryPayload(ctx context.Context, tx *sql.Tx, payload string, id int64) error {
+	const updateHistoryPayloadQuery = `
+		UPDATE
+			bytebase.public.migration_history
+		SET
+			payload = ?
+		WHERE id = ?
+	`
+	_, err := tx.ExecContext(ctx, updateHistoryPayloadQuery, payload, id)
+	return err
+}
+
 // ExecuteMigration will execute the migration.
 func (driver *Driver) ExecuteMigration(ctx context.Context, m *db.MigrationInfo, statement string) (int64, string, error) {
 	if err := driver.useRole(ctx, sysAdminRole); err != nil {
@@ -230,6 +244,14 @@ func (driver *Driver) ExecuteMigration(ctx context.Context, m *db.MigrationInfo,
 	return util.ExecuteMigration(ctx, driver, m, statement, bytebaseDatabase)
 }
 
+// UpdateMigrationHistoryStatementChecksum updates the statement checksum recorded in the migration history payload.
+func (driver *Driver) UpdateMigrationHistoryStatementChecksum(ctx context.Context, id int, checksum string) error {
+	if err := driver.useRole(ctx, sysAdminRole); err != nil {
+		return err
+	}
+	return util.UpdateMigrationHistoryStatementChecksum(ctx, driver, id, checksum, bytebaseDatabase)
+}
+
 // FindMigrationHistoryList finds the migration history.
 func (driver *Driver) FindMigrationHistoryList(ctx context.Context, find *db.MigrationHistoryFind) ([]*db.MigrationHistory, error) {
 	baseQuery := `
@@ -295,3 +317,79 @@ func (driver *Driver) hasBytebaseDatabase(ctx context.Context) (bool, error) {
 	}
 	return exist, nil
 }
+
+// createMigrationLockTableStmt creates the table of the migration lock rows, see util.MigrationLockTable.
+const createMigrationLockTableStmt = `
+	CREATE TABLE IF NOT EXISTS bytebase.public.migration_lock (
+		namespace TEXT PRIMARY KEY,
+		holder TEXT NOT NULL,
+		heartbeat_ts BIGINT NOT NULL
+	)`
+
+// CreateMigrationLockTableIfNotExists creates the migration lock table if it doesn't exist.
+func (driver *Driver) CreateMigrationLockTableIfNotExists(ctx context.Context) error {
+	if _, err := driver.db.ExecContext(ctx, createMigrationLockTableStmt); err != nil {
+		return util.FormatErrorWithQuery(err, createMigrationLockTableStmt)
+	}
+	return nil
+}
+
+// InsertMigrationLockRow inserts the lock row of the namespace if it doesn't exist.
+// Snowflake doesn't enforce the primary key, so we insert it by MERGE, which locks the table so that the concurrent inserts are serialized.
+func (driver *Driver) InsertMigrationLockRow(ctx context.Context, namespace, holder string, heartbeatTs int64) (bool, error) {
+	const query = `
+		MERGE INTO bytebase.public.migration_lock AS t
+		USING (SELECT ? AS namespace, ? AS holder, ? AS heartbeat_ts) AS s
+		ON t.namespace = s.namespace
+		WHEN NOT MATCHED THEN INSERT (namespace, holder, heartbeat_ts) VALUES (s.namespace, s.holder, s.heartbeat_ts)`
+	result, err := driver.db.ExecContext(ctx, query, namespace, holder, heartbeatTs)
+	if err != nil {
+		return false, util.FormatErrorWithQuery(err, query)
+	}
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return inserted == 1, nil
+}
+
+// UpdateMigrationLockRowHeartbeat updates the heartbeat of the lock row of the namespace held by the holder.
+func (driver *Driver) UpdateMigrationLockRowHeartbeat(ctx context.Context, namespace, holder string, heartbeatTs int64) error {
+	const query = `UPDATE bytebase.public.migration_lock SET heartbeat_ts = ? WHERE namespace = ? AND holder = ?`
+	if _, err := driver.db.ExecContext(ctx, query, heartbeatTs, namespace, holder); err != nil {
+		return util.FormatErrorWithQuery(err, query)
+	}
+	return nil
+}
+
+// DeleteMigrationLockRow deletes the lock row of the namespace held by the holder.
+func (driver *Driver) DeleteMigrationLockRow(ctx context.Context, namespace, holder string) error {
+	const query = `DELETE FROM bytebase.public.migration_lock WHERE namespace = ? AND holder = ?`
+	if _, err := driver.db.ExecContext(ctx, query, namespace, holder); err != nil {
+		return util.FormatErrorWithQuery(err, query)
+	}
+	return nil
+}
+
+// DeleteStaleMigrationLockRow deletes the lock row of the namespace whose last heartbeat is before staleTs.
+func (driver *Driver) DeleteStaleMigrationLockRow(ctx context.Context, namespace string, staleTs int64) error {
+	const query = `DELETE FROM bytebase.public.migration_lock WHERE namespace = ? AND heartbeat_ts < ?`
+	if _, err := driver.db.ExecContext(ctx, query, namespace, staleTs); err != nil {
+		return util.FormatErrorWithQuery(err, query)
+	}
+	return nil
+}
+
+// FindMigrationLockRow returns the holder and the last heartbeat of the lock row of the namespace.
+func (driver *Driver) FindMigrationLockRow(ctx context.Context, namespace string) (string, int64, error) {
+	const query = `SELECT holder, heartbeat_ts FROM bytebase.public.migration_lock WHERE namespace = ?`
+	var holder string
+	var heartbeatTs int64
+	if err := driver.db.QueryRowContext(ctx, query, namespace).Scan(&holder, &heartbeatTs); err != nil {
+		if err == sql.ErrNoRows {
+			return "", 0, nil
+		}
+		return "", 0, util.FormatErrorWithQuery(err, query)
+	}
+	return holder, heartbeatTs, nil
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -15,6 +16,7 @@ import (
 	"github.com/bytebase/bytebase/plugin/db/util"
 
 	snow "github.com/snowflakedb/gosnowflake"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 )
 
@@ -36,6 +38,8 @@ type Driver struct {
 	dbType        db.Type
 
 	db *sql.DB
+	// sshTunnel is the SSH tunnel to connect the database, it's nil if the SSH tunnel isn't configured.
+	sshTunnel *db.SSHTunnel
 }
 
 func newDriver(db.DriverConfig) db.Driver {
@@ -82,12 +86,31 @@ func (driver *Driver) Open(_ context.Context, dbType db.Type, config db.Connecti
 		zap.String("environment", connCtx.EnvironmentName),
 		zap.String("database", connCtx.InstanceName),
 	)
-	db, err := sql.Open("snowflake", dsn)
-	if err != nil {
-		panic(err)
+	var sshTunnel *db.SSHTunnel
+	if config.SSHConfig.Enabled() {
+		// Snowflake is connected over HTTPS, so we dial through the SSH tunnel instead of forwarding a local port,
+		// which would fail the verification of the server name.
+		cfg, err := snow.ParseDSN(dsn)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse the Snowflake DSN")
+		}
+		sshTunnel, err = db.OpenSSHTunnel(config.SSHConfig)
+		if err != nil {
+			return nil, err
+		}
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.DialContext = sshTunnel.DialContext
+		cfg.Transporter = transport
+		driver.db = sql.OpenDB(snow.NewConnector(snow.SnowflakeDriver{}, *cfg))
+	} else {
+		db, err := sql.Open("snowflake", dsn)
+		if err != nil {
+			panic(err)
+		}
+		driver.db = db
 	}
 	driver.dbType = dbType
-	driver.db = db
+	driver.sshTunnel = sshTunnel
 	driver.connectionCtx = connCtx
 
 	return driver, nil
@@ -95,7 +118,11 @@ func (driver *Driver) Open(_ context.Context, dbType db.Type, config db.Connecti
 
 // Close closes the driver.
 func (driver *Driver) Close(context.Context) error {
-	return driver.db.Close()
+	err := driver.db.Close()
+	if driver.sshTunnel != nil {
+		err = multierr.Append(err, driver.sshTunnel.Close())
+	}
+	return err
 }
 
 // Ping pings the database.
@@ -259,3 +286,13 @@ func (driver *Driver) Execute(ctx context.Context, statement string) error {
 func (driver *Driver) Query(ctx context.Context, statement string, limit int, readOnly bool) ([]interface{}, error) {
 	return util.Query(ctx, db.Snowflake, driver.db, statement, limit, readOnly)
 }
+
+// QueryRows queries a SQL statement and returns the cursor of the result.
+func (driver *Driver) QueryRows(ctx context.Context, statement string, limit int, readOnly bool) (db.Rows, error) {
+	return util.QueryRows(ctx, db.Snowflake, driver.db, statement, limit, readOnly)
+}
+
+// Explain returns the query plan tree of the statement.
+func (*Driver) Explain(context.Context, string) (*db.ExplainNode, error) {
+	return nil, errors.Errorf("explain is not supported for %s", db.Snowflake)
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"path"
+	"time"
 
 	// embed will embeds the migration schema.
 	_ "embed"
@@ -21,7 +23,8 @@ var (
 	//go:embed sqlite_migration_schema.sql
 	migrationSchema string
 
-	_ util.MigrationExecutor = (*Driver)(nil)
+	_ util.MigrationExecutor  = (*Driver)(nil)
+	_ util.MigrationLockTable = (*Driver)(nil)
 )
 
 // NeedsSetupMigration returns whether it needs to setup migration.
@@ -217,11 +220,29 @@ func (Driver) UpdateHistoryAsFailed(ctx context.Context, tx *sql.Tx, migrationDu
 	return err
 }
 
+// UpdateHistoryPayload will update the payload of the migration record.
+func (Driver) UpdateHistoryPayload(ctx context.Context, tx *sql.Tx, payload string, id int64) error {
+	const updateHistoryPayloadQuery = `
+	UPDATE
+		bytebase_migration_history
+	SET
+		payload = ?
+	WHERE id = ?
+	`
+	_, err := tx.ExecContext(ctx, updateHistoryPayloadQuery, payload, id)
+	return err
+}
+
 // ExecuteMigration will execute the migration.
 func (driver *Driver) ExecuteMigration(ctx context.Context, m *db.MigrationInfo, statement string) (int64, string, error) {
 	return util.ExecuteMigration(ctx, driver, m, statement, bytebaseDatabase)
 }
 
+// UpdateMigrationHistoryStatementChecksum updates the statement checksum recorded in the migration history payload.
+func (driver *Driver) UpdateMigrationHistoryStatementChecksum(ctx context.Context, id int, checksum string) error {
+	return util.UpdateMigrationHistoryStatementChecksum(ctx, driver, id, checksum, bytebaseDatabase)
+}
+
 // FindMigrationHistoryList finds the migration history.
 func (driver *Driver) FindMigrationHistoryList(ctx context.Context, find *db.MigrationHistoryFind) ([]*db.MigrationHistory, error) {
 	baseQuery := `
@@ -272,3 +293,90 @@ func (driver *Driver) FindMigrationHistoryList(ctx context.Context, find *db.Mig
 	}
 	return util.FindMigrationHistoryList(ctx, query, params, driver, bytebaseDatabase)
 }
+
+// createMigrationLockTableStmt creates the table of the migration lock rows, see util.MigrationLockTable.
+const createMigrationLockTableStmt = `
+	CREATE TABLE IF NOT EXISTS bytebase_migration_lock (
+		namespace TEXT PRIMARY KEY,
+		holder TEXT NOT NULL,
+		heartbeat_ts INTEGER NOT NULL
+	)`
+
+// migrationLockBusyTimeout is the time to wait for the other connections writing the bytebase database.
+const migrationLockBusyTimeout = 5 * time.Second
+
+// openMigrationLockDB opens the bytebase database for the migration lock.
+// It opens the bytebase database separately, because the driver switches the database during the migration.
+func (driver *Driver) openMigrationLockDB() (*sql.DB, error) {
+	return sql.Open("sqlite3", fmt.Sprintf("%s?_busy_timeout=%d", path.Join(driver.dir, fmt.Sprintf("%s.db", bytebaseDatabase)), migrationLockBusyTimeout.Milliseconds()))
+}
+
+// execMigrationLock executes the migration lock statement in the bytebase database.
+func (driver *Driver) execMigrationLock(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	bytebaseDB, err := driver.openMigrationLockDB()
+	if err != nil {
+		return nil, err
+	}
+	defer bytebaseDB.Close()
+	result, err := bytebaseDB.ExecContext(ctx, query, args...)
+	if err != nil {
+		return nil, util.FormatErrorWithQuery(err, query)
+	}
+	return result, nil
+}
+
+// CreateMigrationLockTableIfNotExists creates the migration lock table if it doesn't exist.
+func (driver *Driver) CreateMigrationLockTableIfNotExists(ctx context.Context) error {
+	_, err := driver.execMigrationLock(ctx, createMigrationLockTableStmt)
+	return err
+}
+
+// InsertMigrationLockRow inserts the lock row of the namespace, the primary key makes sure only one holder can insert it.
+func (driver *Driver) InsertMigrationLockRow(ctx context.Context, namespace, holder string, heartbeatTs int64) (bool, error) {
+	result, err := driver.execMigrationLock(ctx, `INSERT OR IGNORE INTO bytebase_migration_lock (namespace, holder, heartbeat_ts) VALUES (?, ?, ?)`, namespace, holder, heartbeatTs)
+	if err != nil {
+		return false, err
+	}
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return inserted == 1, nil
+}
+
+// UpdateMigrationLockRowHeartbeat updates the heartbeat of the lock row of the namespace held by the holder.
+func (driver *Driver) UpdateMigrationLockRowHeartbeat(ctx context.Context, namespace, holder string, heartbeatTs int64) error {
+	_, err := driver.execMigrationLock(ctx, `UPDATE bytebase_migration_lock SET heartbeat_ts = ? WHERE namespace = ? AND holder = ?`, heartbeatTs, namespace, holder)
+	return err
+}
+
+// DeleteMigrationLockRow deletes the lock row of the namespace held by the holder.
+func (driver *Driver) DeleteMigrationLockRow(ctx context.Context, namespace, holder string) error {
+	_, err := driver.execMigrationLock(ctx, `DELETE FROM bytebase_migration_lock WHERE namespace = ? AND holder = ?`, namespace, holder)
+	return err
+}
+
+// DeleteStaleMigrationLockRow deletes the lock row of the namespace whose last heartbeat is before staleTs.
+func (driver *Driver) DeleteStaleMigrationLockRow(ctx context.Context, namespace string, staleTs int64) error {
+	_, err := driver.execMigrationLock(ctx, `DELETE FROM bytebase_migration_lock WHERE namespace = ? AND heartbeat_ts < ?`, namespace, staleTs)
+	return err
+}
+
+// FindMigrationLockRow returns the holder and the last heartbeat of the lock row of the namespace.
+func (driver *Driver) FindMigrationLockRow(ctx context.Context, namespace string) (string, int64, error) {
+	bytebaseDB, err := driver.openMigrationLockDB()
+	if err != nil {
+		return "", 0, err
+	}
+	defer bytebaseDB.Close()
+	const query = `SELECT holder, heartbeat_ts FROM bytebase_migration_lock WHERE namespace = ?`
+	var holder string
+	var heartbeatTs int64
+	if err := bytebaseDB.QueryRowContext(ctx, query, namespace).Scan(&holder, &heartbeatTs); err != nil {
+		if err == sql.ErrNoRows {
+			return "", 0, nil
+		}
+		return "", 0, util.FormatErrorWithQuery(err, query)
+	}
+	return holder, heartbeatTs, nil
+}
@@ -0,0 +1,42 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrationLockRow(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	driver := &Driver{dir: t.TempDir()}
+	a.NoError(driver.CreateMigrationLockTableIfNotExists(ctx))
+	// It's idempotent.
+	a.NoError(driver.CreateMigrationLockTableIfNotExists(ctx))
+
+	inserted, err := driver.InsertMigrationLockRow(ctx, "db1", "alice", 100)
+	a.NoError(err)
+	a.True(inserted)
+	inserted, err = driver.InsertMigrationLockRow(ctx, "db1", "bob", 100)
+	a.NoError(err)
+	a.False(inserted)
+
+	a.NoError(driver.UpdateMigrationLockRowHeartbeat(ctx, "db1", "alice", 200))
+	holder, heartbeatTs, err := driver.FindMigrationLockRow(ctx, "db1")
+	a.NoError(err)
+	a.Equal("alice", holder)
+	a.Equal(int64(200), heartbeatTs)
+
+	// The row of the other holder and the row with the recent heartbeat are kept.
+	a.NoError(driver.DeleteMigrationLockRow(ctx, "db1", "bob"))
+	a.NoError(driver.DeleteStaleMigrationLockRow(ctx, "db1", 200))
+	holder, _, err = driver.FindMigrationLockRow(ctx, "db1")
+	a.NoError(err)
+	a.Equal("alice", holder)
+
+	a.NoError(driver.DeleteStaleMigrationLockRow(ctx, "db1", 201))
+	holder, _, err = driver.FindMigrationLockRow(ctx, "db1")
+	a.NoError(err)
+	a.Empty(holder)
+}
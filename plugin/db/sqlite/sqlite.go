@@ -31,6 +31,7 @@ func init() {
 type Driver struct {
 	dir           string
 	db            *sql.DB
+	databaseName  string
 	connectionCtx db.ConnectionContext
 }
 
@@ -82,9 +83,15 @@ func (driver *Driver) GetDBConnection(_ context.Context, database string) (*sql.
 		return nil, err
 	}
 	driver.db = db
+	driver.databaseName = database
 	return db, nil
 }
 
+// GetCurrentDatabaseName returns the database the driver is connected to, and empty for the in-memory database.
+func (driver *Driver) GetCurrentDatabaseName() string {
+	return driver.databaseName
+}
+
 // getVersion gets the version.
 func (driver *Driver) getVersion(ctx context.Context) (string, error) {
 	var version string
@@ -174,3 +181,13 @@ func (driver *Driver) Execute(ctx context.Context, statement string) error {
 func (driver *Driver) Query(ctx context.Context, statement string, limit int, readOnly bool) ([]interface{}, error) {
 	return util.Query(ctx, db.SQLite, driver.db, statement, limit, readOnly)
 }
+
+// QueryRows queries a SQL statement and returns the cursor of the result.
+func (driver *Driver) QueryRows(ctx context.Context, statement string, limit int, readOnly bool) (db.Rows, error) {
+	return util.QueryRows(ctx, db.SQLite, driver.db, statement, limit, readOnly)
+}
+
+// Explain returns the query plan tree of the statement.
+func (*Driver) Explain(context.Context, string) (*db.ExplainNode, error) {
+	return nil, errors.Errorf("explain is not supported for %s", db.SQLite)
+}
@@ -0,0 +1,284 @@
+package db
+
+import (
+	"context"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	defaultSSHPort    = "22"
+	sshConnectTimeout = 10 * time.Second
+)
+
+// SSHConfig is the configuration for connecting the database through an SSH tunnel on a bastion host.
+type SSHConfig struct {
+	Host string
+	Port string
+	User string
+	// Password is the password of the user, or the passphrase of the private key if the private key is encrypted.
+	Password   string
+	PrivateKey string
+	// HostKey is the public key of the bastion host in the authorized_keys format, or its SHA256 fingerprint such as "SHA256:...".
+	// The bastion host is verified by the host key, so that the database credentials are not sent to an impersonator.
+	HostKey string
+}
+
+// Enabled returns true if the SSH tunnel is configured.
+func (c SSHConfig) Enabled() bool {
+	return c.Host != ""
+}
+
+func (c SSHConfig) getClientConfig() (*ssh.ClientConfig, error) {
+	var authList []ssh.AuthMethod
+	if c.PrivateKey != "" {
+		signer, err := ssh.ParsePrivateKey([]byte(c.PrivateKey))
+		if _, ok := err.(*ssh.PassphraseMissingError); ok {
+			signer, err = ssh.ParsePrivateKeyWithPassphrase([]byte(c.PrivateKey), []byte(c.Password))
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse the SSH private key")
+		}
+		authList = append(authList, ssh.PublicKeys(signer))
+	}
+	if c.Password != "" {
+		authList = append(authList, ssh.Password(c.Password))
+	}
+	if len(authList) == 0 {
+		return nil, errors.New("either SSH password or private key must be set")
+	}
+	hostKeyCallback, err := getHostKeyCallback(c.HostKey)
+	if err != nil {
+		return nil, err
+	}
+	return &ssh.ClientConfig{
+		User:            c.User,
+		Auth:            authList,
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         sshConnectTimeout,
+	}, nil
+}
+
+// getHostKeyCallback returns the callback verifying the bastion host by the host key or its SHA256 fingerprint.
+func getHostKeyCallback(hostKey string) (ssh.HostKeyCallback, error) {
+	hostKey = strings.TrimSpace(hostKey)
+	if hostKey == "" {
+		return nil, errors.New("SSH host key must be set to verify the SSH host")
+	}
+	if strings.HasPrefix(hostKey, "SHA256:") {
+		return func(_ string, _ net.Addr, key ssh.PublicKey) error {
+			if fingerprint := ssh.FingerprintSHA256(key); fingerprint != hostKey {
+				return errors.Errorf("SSH host key mismatch, expected fingerprint %s but got %s", hostKey, fingerprint)
+			}
+			return nil
+		}, nil
+	}
+	key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(hostKey))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse the SSH host key")
+	}
+	return ssh.FixedHostKey(key), nil
+}
+
+// SSHTunnel is an SSH connection to a bastion host, which connects the databases behind the bastion host.
+// Drivers with custom dialers dial through the tunnel by DialContext, and the others connect the local end of the tunnel by Forward,
+// which also works for the external tools such as mysqlbinlog and gh-ost.
+type SSHTunnel struct {
+	client *ssh.Client
+
+	mu        sync.Mutex
+	listeners []net.Listener
+	conns     map[net.Conn]bool
+	closed    bool
+	wg        sync.WaitGroup
+}
+
+// OpenSSHTunnel opens an SSH tunnel to the bastion host.
+func OpenSSHTunnel(config SSHConfig) (*SSHTunnel, error) {
+	clientConfig, err := config.getClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	port := config.Port
+	if port == "" {
+		port = defaultSSHPort
+	}
+	addr := net.JoinHostPort(config.Host, port)
+	client, err := ssh.Dial("tcp", addr, clientConfig)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to connect SSH host %q with user %q", addr, config.User)
+	}
+	return &SSHTunnel{
+		client: client,
+		conns:  make(map[net.Conn]bool),
+	}, nil
+}
+
+// DialContext connects the address from the bastion host.
+func (t *SSHTunnel) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	type dialResult struct {
+		conn net.Conn
+		err  error
+	}
+	resultCh := make(chan dialResult, 1)
+	go func() {
+		conn, err := t.client.Dial(network, addr)
+		resultCh <- dialResult{conn: conn, err: err}
+	}()
+	select {
+	case result := <-resultCh:
+		if result.err != nil {
+			return nil, errors.Wrapf(result.err, "failed to connect %q through SSH tunnel", addr)
+		}
+		return result.conn, nil
+	case <-ctx.Done():
+		// Close the connection established after the cancellation.
+		go func() {
+			if result := <-resultCh; result.conn != nil {
+				result.conn.Close()
+			}
+		}()
+		return nil, ctx.Err()
+	}
+}
+
+// Forward listens on a local port and forwards the connections to host:port from the bastion host.
+// It returns the local host and port, which are closed when the tunnel is closed.
+func (t *SSHTunnel) Forward(host, port string) (string, string, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", "", errors.Wrap(err, "failed to listen on local port for SSH tunnel")
+	}
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		listener.Close()
+		return "", "", errors.New("SSH tunnel is closed")
+	}
+	t.listeners = append(t.listeners, listener)
+	t.mu.Unlock()
+
+	addr := net.JoinHostPort(host, port)
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+		for {
+			local, err := listener.Accept()
+			if err != nil {
+				// The listener is closed.
+				return
+			}
+			t.wg.Add(1)
+			go func() {
+				defer t.wg.Done()
+				t.forwardConn(local, addr)
+			}()
+		}
+	}()
+
+	localHost, localPort, err := net.SplitHostPort(listener.Addr().String())
+	if err != nil {
+		return "", "", err
+	}
+	return localHost, localPort, nil
+}
+
+func (t *SSHTunnel) forwardConn(local net.Conn, addr string) {
+	remote, err := t.client.Dial("tcp", addr)
+	if err != nil {
+		local.Close()
+		return
+	}
+	if !t.trackConns(local, remote) {
+		local.Close()
+		remote.Close()
+		return
+	}
+	defer t.untrackConns(local, remote)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	copyConn := func(dst, src net.Conn) {
+		defer wg.Done()
+		_, _ = io.Copy(dst, src)
+		// Close both sides so that the copying in the other direction stops as well.
+		dst.Close()
+		src.Close()
+	}
+	go copyConn(remote, local)
+	go copyConn(local, remote)
+	wg.Wait()
+}
+
+func (t *SSHTunnel) trackConns(connList ...net.Conn) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return false
+	}
+	for _, conn := range connList {
+		t.conns[conn] = true
+	}
+	return true
+}
+
+func (t *SSHTunnel) untrackConns(connList ...net.Conn) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, conn := range connList {
+		delete(t.conns, conn)
+	}
+}
+
+// Close closes the local listeners, the forwarded connections and the SSH connection.
+func (t *SSHTunnel) Close() error {
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		return nil
+	}
+	t.closed = true
+	var err error
+	for _, listener := range t.listeners {
+		err = multierr.Append(err, listener.Close())
+	}
+	for conn := range t.conns {
+		conn.Close()
+	}
+	t.mu.Unlock()
+
+	err = multierr.Append(err, t.client.Close())
+	t.wg.Wait()
+	return err
+}
+
+// OpenSSHTunnelForConnection opens an SSH tunnel forwarding to the database if it's configured, and returns the connection config
+// whose host and port are the local end of the tunnel. The tunnel is nil if it's not configured or the host is a unix socket.
+// Otherwise, the caller should close the tunnel after closing the database connections.
+func OpenSSHTunnelForConnection(config ConnectionConfig, defaultPort string) (*SSHTunnel, ConnectionConfig, error) {
+	if !config.SSHConfig.Enabled() || strings.HasPrefix(config.Host, "/") {
+		return nil, config, nil
+	}
+	tunnel, err := OpenSSHTunnel(config.SSHConfig)
+	if err != nil {
+		return nil, config, err
+	}
+	port := config.Port
+	if port == "" {
+		port = defaultPort
+	}
+	localHost, localPort, err := tunnel.Forward(config.Host, port)
+	if err != nil {
+		tunnel.Close()
+		return nil, config, err
+	}
+	config.Host, config.Port = localHost, localPort
+	return tunnel, config, nil
+}
@@ -0,0 +1,190 @@
+package db
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"io"
+	"net"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	testSSHUser     = "bastion"
+	testSSHPassword = "secret"
+)
+
+// startTestSSHServer starts an SSH server supporting password auth and direct-tcpip channels, and returns its port and host key.
+func startTestSSHServer(t *testing.T) (string, ssh.PublicKey) {
+	_, hostKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signer, err := ssh.NewSignerFromKey(hostKey)
+	require.NoError(t, err)
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if conn.User() == testSSHUser && string(password) == testSSHPassword {
+				return nil, nil
+			}
+			return nil, io.EOF
+		},
+	}
+	config.AddHostKey(signer)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveTestSSHConn(conn, config)
+		}
+	}()
+	_, port, err := net.SplitHostPort(listener.Addr().String())
+	require.NoError(t, err)
+	return port, signer.PublicKey()
+}
+
+func serveTestSSHConn(conn net.Conn, config *ssh.ServerConfig) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+	for newChannel := range chans {
+		if newChannel.ChannelType() != "direct-tcpip" {
+			_ = newChannel.Reject(ssh.UnknownChannelType, "unsupported channel type")
+			continue
+		}
+		// The payload of direct-tcpip is the target host, target port, origin host and origin port, see RFC 4254 7.2.
+		var payload struct {
+			Host       string
+			Port       uint32
+			OriginHost string
+			OriginPort uint32
+		}
+		if err := ssh.Unmarshal(newChannel.ExtraData(), &payload); err != nil {
+			_ = newChannel.Reject(ssh.ConnectionFailed, err.Error())
+			continue
+		}
+		target, err := net.Dial("tcp", net.JoinHostPort(payload.Host, strconv.FormatUint(uint64(payload.Port), 10)))
+		if err != nil {
+			_ = newChannel.Reject(ssh.ConnectionFailed, err.Error())
+			continue
+		}
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			target.Close()
+			continue
+		}
+		go ssh.DiscardRequests(requests)
+		go func() {
+			_, _ = io.Copy(channel, target)
+			channel.Close()
+		}()
+		go func() {
+			_, _ = io.Copy(target, channel)
+			target.Close()
+		}()
+	}
+}
+
+// startEchoServer starts a TCP server echoing back what it receives, and returns its port.
+func startEchoServer(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				_, _ = io.Copy(conn, conn)
+				conn.Close()
+			}()
+		}
+	}()
+	_, port, err := net.SplitHostPort(listener.Addr().String())
+	require.NoError(t, err)
+	return port
+}
+
+func requireEcho(t *testing.T, conn net.Conn) {
+	_, err := conn.Write([]byte("ping"))
+	require.NoError(t, err)
+	buf := make([]byte, 4)
+	_, err = io.ReadFull(conn, buf)
+	require.NoError(t, err)
+	require.Equal(t, "ping", string(buf))
+}
+
+func TestSSHTunnel(t *testing.T) {
+	sshPort, sshHostKey := startTestSSHServer(t)
+	hostKey := string(ssh.MarshalAuthorizedKey(sshHostKey))
+	echoPort := startEchoServer(t)
+
+	_, err := OpenSSHTunnel(SSHConfig{Host: "127.0.0.1", Port: sshPort, User: testSSHUser, HostKey: hostKey})
+	require.Error(t, err)
+	_, err = OpenSSHTunnel(SSHConfig{Host: "127.0.0.1", Port: sshPort, User: testSSHUser, Password: "wrong", HostKey: hostKey})
+	require.Error(t, err)
+
+	// The SSH host is refused if the host key is not set or doesn't match.
+	_, err = OpenSSHTunnel(SSHConfig{Host: "127.0.0.1", Port: sshPort, User: testSSHUser, Password: testSSHPassword})
+	require.Error(t, err)
+	_, otherHostKey := startTestSSHServer(t)
+	_, err = OpenSSHTunnel(SSHConfig{Host: "127.0.0.1", Port: sshPort, User: testSSHUser, Password: testSSHPassword, HostKey: string(ssh.MarshalAuthorizedKey(otherHostKey))})
+	require.Error(t, err)
+	_, err = OpenSSHTunnel(SSHConfig{Host: "127.0.0.1", Port: sshPort, User: testSSHUser, Password: testSSHPassword, HostKey: ssh.FingerprintSHA256(otherHostKey)})
+	require.Error(t, err)
+	tunnel, err := OpenSSHTunnel(SSHConfig{Host: "127.0.0.1", Port: sshPort, User: testSSHUser, Password: testSSHPassword, HostKey: ssh.FingerprintSHA256(sshHostKey)})
+	require.NoError(t, err)
+	require.NoError(t, tunnel.Close())
+
+	// The connection config is returned as is if SSH is not configured.
+	tunnel, config, err := OpenSSHTunnelForConnection(ConnectionConfig{Host: "127.0.0.1", Port: echoPort}, "3306")
+	require.NoError(t, err)
+	require.Nil(t, tunnel)
+	require.Equal(t, echoPort, config.Port)
+
+	tunnel, config, err = OpenSSHTunnelForConnection(ConnectionConfig{
+		Host: "127.0.0.1",
+		Port: echoPort,
+		SSHConfig: SSHConfig{
+			Host:     "127.0.0.1",
+			Port:     sshPort,
+			User:     testSSHUser,
+			Password: testSSHPassword,
+			HostKey:  hostKey,
+		},
+	}, "3306")
+	require.NoError(t, err)
+	require.NotNil(t, tunnel)
+	require.NotEqual(t, echoPort, config.Port)
+
+	// Connect the echo server through the forwarded local port.
+	conn, err := net.Dial("tcp", net.JoinHostPort(config.Host, config.Port))
+	require.NoError(t, err)
+	requireEcho(t, conn)
+
+	// Connect the echo server through the dialer.
+	dialConn, err := tunnel.DialContext(context.Background(), "tcp", net.JoinHostPort("127.0.0.1", echoPort))
+	require.NoError(t, err)
+	requireEcho(t, dialConn)
+	dialConn.Close()
+
+	// Closing the tunnel closes the forwarded connections and the local listener.
+	require.NoError(t, tunnel.Close())
+	_, err = conn.Read(make([]byte, 1))
+	require.Error(t, err)
+	conn.Close()
+	_, err = net.Dial("tcp", net.JoinHostPort(config.Host, config.Port))
+	require.Error(t, err)
+}
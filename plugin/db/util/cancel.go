@@ -0,0 +1,102 @@
+package util
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/bytebase/bytebase/common/log"
+	"github.com/bytebase/bytebase/plugin/db"
+)
+
+const (
+	// queryCancelTimeout is the max time to cancel a query on the database side.
+	queryCancelTimeout = 10 * time.Second
+)
+
+// queryCancelKey is the context key to request canceling the query on the database side.
+type queryCancelKey struct{}
+
+// WithQueryCancel returns the context requesting to cancel the query on the database side once the context is done.
+// It's used by the queries with a timeout or cancelable by users, such as the SQL editor queries.
+func WithQueryCancel(ctx context.Context) context.Context {
+	return context.WithValue(ctx, queryCancelKey{}, true)
+}
+
+// prepareQueryCancel returns the statement to cancel the query which will run on the connection.
+// Canceling the context only stops the client from waiting for the query, the query keeps running on the database side,
+// so we have to cancel it with the statement on another connection.
+// The returned context is used to run the query, and the statement is empty if the engine doesn't support canceling queries
+// or the context doesn't request canceling, so that we don't look up the connection for every query.
+func prepareQueryCancel(ctx context.Context, dbType db.Type, conn *sql.Conn) (context.Context, string, error) {
+	if requested, _ := ctx.Value(queryCancelKey{}).(bool); !requested {
+		return ctx, "", nil
+	}
+	switch dbType {
+	case db.MySQL, db.TiDB:
+		var connectionID int64
+		if err := conn.QueryRowContext(ctx, "SELECT CONNECTION_ID()").Scan(&connectionID); err != nil {
+			return ctx, "", FormatErrorWithQuery(err, "SELECT CONNECTION_ID()")
+		}
+		if dbType == db.TiDB {
+			// KILL QUERY is only accepted by TiDB with the compatible-kill-query config, KILL TIDB QUERY is accepted by all versions.
+			return ctx, fmt.Sprintf("KILL TIDB QUERY %d", connectionID), nil
+		}
+		return ctx, fmt.Sprintf("KILL QUERY %d", connectionID), nil
+	case db.Postgres:
+		var pid int64
+		if err := conn.QueryRowContext(ctx, "SELECT pg_backend_pid()").Scan(&pid); err != nil {
+			return ctx, "", FormatErrorWithQuery(err, "SELECT pg_backend_pid()")
+		}
+		return ctx, fmt.Sprintf("SELECT pg_cancel_backend(%d)", pid), nil
+	case db.ClickHouse:
+		// ClickHouse cancels queries by the query ID instead of the connection, so we assign the query ID ourselves.
+		queryID := uuid.New().String()
+		return clickhouse.Context(ctx, clickhouse.WithQueryID(queryID)), fmt.Sprintf("KILL QUERY WHERE query_id = '%s'", queryID), nil
+	}
+	return ctx, "", nil
+}
+
+// watchQueryCancel executes the cancel statement on another connection of the pool once the context is done.
+// The returned function stops the watch and must be called before the query connection is released.
+func watchQueryCancel(ctx context.Context, sqldb *sql.DB, cancelStatement string) func() {
+	if cancelStatement == "" {
+		return func() {}
+	}
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		select {
+		case <-ctx.Done():
+			cancelCtx, cancel := context.WithTimeout(context.Background(), queryCancelTimeout)
+			defer cancel()
+			if _, err := sqldb.ExecContext(cancelCtx, cancelStatement); err != nil {
+				log.Warn("Failed to cancel the query on the database side",
+					zap.String("statement", cancelStatement),
+					zap.Error(err),
+				)
+				return
+			}
+			log.Debug("Canceled the query on the database side",
+				zap.String("statement", cancelStatement),
+				zap.NamedError("reason", ctx.Err()),
+			)
+		case <-done:
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+			wg.Wait()
+		})
+	}
+}
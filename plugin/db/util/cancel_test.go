@@ -0,0 +1,26 @@
+package util
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/plugin/db"
+)
+
+func TestPrepareQueryCancel(t *testing.T) {
+	a := require.New(t)
+
+	// The connection is not used unless canceling is requested.
+	for _, dbType := range []db.Type{db.MySQL, db.TiDB, db.Postgres, db.ClickHouse} {
+		_, cancelStatement, err := prepareQueryCancel(context.Background(), dbType, nil /* conn */)
+		a.NoError(err)
+		a.Empty(cancelStatement)
+	}
+
+	_, cancelStatement, err := prepareQueryCancel(WithQueryCancel(context.Background()), db.ClickHouse, nil /* conn */)
+	a.NoError(err)
+	a.True(strings.HasPrefix(cancelStatement, "KILL QUERY WHERE query_id = "), cancelStatement)
+}
@@ -5,7 +5,10 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
 	"strconv"
@@ -14,6 +17,7 @@ import (
 
 	"github.com/blang/semver/v4"
 	"github.com/pkg/errors"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
 	"github.com/bytebase/bytebase/common"
@@ -143,11 +147,20 @@ type MigrationExecutor interface {
 	UpdateHistoryAsDone(ctx context.Context, tx *sql.Tx, migrationDurationNs int64, updatedSchema string, insertedID int64) error
 	// UpdateHistoryAsFailed will update the migration record as failed.
 	UpdateHistoryAsFailed(ctx context.Context, tx *sql.Tx, migrationDurationNs int64, insertedID int64) error
+	// UpdateHistoryPayload will update the payload of the migration record.
+	UpdateHistoryPayload(ctx context.Context, tx *sql.Tx, payload string, id int64) error
 }
 
 // ExecuteMigration will execute the database migration.
 // Returns the created migration history id and the updated schema on success.
 func ExecuteMigration(ctx context.Context, executor MigrationExecutor, m *db.MigrationInfo, statement string, databaseName string) (migrationHistoryID int64, updatedSchema string, resErr error) {
+	// Hold the migration lock during the whole migration, so that the migrations of the same database from the server and bb don't race.
+	release, err := lockMigration(ctx, executor, m, databaseName)
+	if err != nil {
+		return -1, "", err
+	}
+	defer release()
+
 	var prevSchemaBuf bytes.Buffer
 	// Don't record schema if the database hasn't exist yet.
 	if !m.CreateDatabase {
@@ -197,7 +210,7 @@ func ExecuteMigration(ctx context.Context, executor MigrationExecutor, m *db.Mig
 				return -1, "", err
 			}
 		}
-		if err := executor.Execute(ctx, statement); err != nil {
+		if err := executeMigrationStatement(ctx, executor, statement, m.ProgressReporter); err != nil {
 			return -1, "", FormatError(err)
 		}
 	}
@@ -211,6 +224,30 @@ func ExecuteMigration(ctx context.Context, executor MigrationExecutor, m *db.Mig
 	return insertedID, afterSchemaBuf.String(), nil
 }
 
+// StatementExecutor is implemented by the migration executors which can execute the statements one by one and report the progress.
+type StatementExecutor interface {
+	// ExecuteStatements executes the statements one by one with the same transaction semantics as Execute.
+	ExecuteStatements(ctx context.Context, statement string, reporter db.StatementProgressReporter) error
+}
+
+// executeMigrationStatement executes the migration statement, and reports the statement-level progress to the reporter if it's not nil.
+func executeMigrationStatement(ctx context.Context, executor MigrationExecutor, statement string, reporter db.StatementProgressReporter) error {
+	if reporter == nil {
+		return executor.Execute(ctx, statement)
+	}
+	if statementExecutor, ok := executor.(StatementExecutor); ok {
+		return statementExecutor.ExecuteStatements(ctx, statement, reporter)
+	}
+	// The executors without the statement splitting support execute the statement as a whole.
+	startedTime := time.Now()
+	reporter.OnStatementStart(0, 1, statement)
+	if err := executor.Execute(ctx, statement); err != nil {
+		return err
+	}
+	reporter.OnStatementDone(0, time.Since(startedTime))
+	return nil
+}
+
 // BeginMigration checks before executing migration and inserts a migration history record with pending status.
 func BeginMigration(ctx context.Context, executor MigrationExecutor, m *db.MigrationInfo, prevSchema string, statement string, databaseName string) (insertedID int64, err error) {
 	// Convert version to stored version.
@@ -280,7 +317,13 @@ func BeginMigration(ctx context.Context, executor MigrationExecutor, m *db.Migra
 	// MySQL runs DDL in its own transaction, so we can't commit migration history together with DDL in a single transaction.
 	// Thus we sort of doing a 2-phase commit, where we first write a PENDING migration record, and after migration completes, we then
 	// update the record to DONE together with the updated schema.
-	if insertedID, err = executor.InsertPendingHistory(ctx, tx, largestSequence+1, prevSchema, m, storedVersion, statement); err != nil {
+	payload, err := setStatementChecksum(m.Payload, GetStatementChecksum(statement))
+	if err != nil {
+		return -1, err
+	}
+	mi := *m
+	mi.Payload = payload
+	if insertedID, err = executor.InsertPendingHistory(ctx, tx, largestSequence+1, prevSchema, &mi, storedVersion, statement); err != nil {
 		return -1, err
 	}
 
@@ -291,6 +334,78 @@ func BeginMigration(ctx context.Context, executor MigrationExecutor, m *db.Migra
 	return insertedID, nil
 }
 
+// GetStatementChecksum returns the checksum of the normalized statement.
+// The line endings, trailing spaces of lines and leading and trailing blank lines don't change the checksum.
+func GetStatementChecksum(statement string) string {
+	lines := strings.Split(strings.ReplaceAll(statement, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+	sum := sha256.Sum256([]byte(strings.TrimSpace(strings.Join(lines, "\n"))))
+	return hex.EncodeToString(sum[:])
+}
+
+// GetMigrationHistoryStatementChecksum returns the statement checksum recorded in the migration history.
+// The checksum is calculated from the statement for the migration history recorded before the checksum is introduced.
+func GetMigrationHistoryStatementChecksum(history *db.MigrationHistory) (string, error) {
+	payload := &db.MigrationInfoPayload{}
+	if history.Payload != "" {
+		if err := json.Unmarshal([]byte(history.Payload), payload); err != nil {
+			return "", errors.Wrapf(err, "failed to unmarshal the payload of migration history %d", history.ID)
+		}
+	}
+	if payload.StatementChecksum != "" {
+		return payload.StatementChecksum, nil
+	}
+	return GetStatementChecksum(history.Statement), nil
+}
+
+// setStatementChecksum returns the migration payload with the statement checksum, the other fields of the payload are preserved.
+func setStatementChecksum(payloadStr, checksum string) (string, error) {
+	payload := &db.MigrationInfoPayload{}
+	if payloadStr != "" {
+		if err := json.Unmarshal([]byte(payloadStr), payload); err != nil {
+			return "", errors.Wrap(err, "failed to unmarshal the migration payload")
+		}
+	}
+	payload.StatementChecksum = checksum
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal the migration payload")
+	}
+	return string(payloadBytes), nil
+}
+
+// UpdateMigrationHistoryStatementChecksum updates the statement checksum recorded in the payload of the migration history.
+func UpdateMigrationHistoryStatementChecksum(ctx context.Context, executor MigrationExecutor, id int, checksum string, databaseName string) error {
+	list, err := executor.FindMigrationHistoryList(ctx, &db.MigrationHistoryFind{ID: &id})
+	if err != nil {
+		return err
+	}
+	if len(list) == 0 {
+		return common.Errorf(common.NotFound, "migration history %d not found", id)
+	}
+	payload, err := setStatementChecksum(list[0].Payload, checksum)
+	if err != nil {
+		return err
+	}
+
+	sqldb, err := executor.GetDBConnection(ctx, databaseName)
+	if err != nil {
+		return err
+	}
+	tx, err := sqldb.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := executor.UpdateHistoryPayload(ctx, tx, payload, int64(id)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
 // EndMigration updates the migration history record to DONE or FAILED depending on migration is done or not.
 func EndMigration(ctx context.Context, executor MigrationExecutor, startedNs int64, migrationHistoryID int64, updatedSchema string, databaseName string, isDone bool) (err error) {
 	migrationDurationNs := time.Now().UnixNano() - startedNs
@@ -321,10 +436,69 @@ func EndMigration(ctx context.Context, executor MigrationExecutor, startedNs int
 }
 
 // Query will execute a readonly / SELECT query.
+// It returns the fully materialized result as [columnNames, columnTypeNames, rows].
 func Query(ctx context.Context, dbType db.Type, sqldb *sql.DB, statement string, limit int, readOnly bool) ([]interface{}, error) {
-	if !readOnly {
-		return queryAdmin(ctx, sqldb, statement, limit)
+	rows, err := QueryRows(ctx, dbType, sqldb, statement, limit, readOnly)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columnNames := []string{}
+	columnTypeNames := []string{}
+	for _, column := range rows.Columns() {
+		columnNames = append(columnNames, column.Name)
+		columnTypeNames = append(columnTypeNames, column.TypeName)
+	}
+
+	data := []interface{}{}
+	for rows.Next() {
+		rowData, err := rows.Values()
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, rowData)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return []interface{}{columnNames, columnTypeNames, data}, nil
+}
+
+// QueryRows will execute a readonly / SELECT query and return a cursor of the result.
+// For readonly queries, the cursor holds a read-only transaction which is rolled back on Close.
+// The query is canceled on the database side if the context is done before the cursor is closed.
+func QueryRows(ctx context.Context, dbType db.Type, sqldb *sql.DB, statement string, limit int, readOnly bool) (db.Rows, error) {
+	// The query runs on a dedicated connection, so that we know which connection to cancel the query on.
+	conn, err := sqldb.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancelStatement, err := prepareQueryCancel(ctx, dbType, conn)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	stopWatch := watchQueryCancel(ctx, sqldb, cancelStatement)
+
+	var queryRows *sqlRows
+	if readOnly {
+		queryRows, err = queryReadOnlyRows(ctx, dbType, conn, statement, limit)
+	} else {
+		queryRows, err = queryAdminRows(ctx, conn, statement, limit)
+	}
+	if err != nil {
+		stopWatch()
+		_ = conn.Close()
+		return nil, err
 	}
+	queryRows.conn = conn
+	queryRows.stopWatch = stopWatch
+	return queryRows, nil
+}
+
+func queryReadOnlyRows(ctx context.Context, dbType db.Type, conn *sql.Conn, statement string, limit int) (*sqlRows, error) {
 	// Limit SQL query result size.
 	if dbType == db.MySQL {
 		// MySQL 5.7 doesn't support WITH clause.
@@ -336,182 +510,173 @@ func Query(ctx context.Context, dbType db.Type, sqldb *sql.DB, statement string,
 	// TiDB doesn't support READ ONLY transactions. We have to skip the flag for it.
 	// https://github.com/pingcap/tidb/issues/34626
 	// Clickhouse doesn't support READ ONLY transactions (Error: sql: driver does not support read-only transactions).
+	readOnly := true
 	if dbType == db.TiDB || dbType == db.ClickHouse {
 		readOnly = false
 	}
-	tx, err := sqldb.BeginTx(ctx, &sql.TxOptions{ReadOnly: readOnly})
+	tx, err := conn.BeginTx(ctx, &sql.TxOptions{ReadOnly: readOnly})
 	if err != nil {
 		return nil, err
 	}
-	defer tx.Rollback()
 
 	rows, err := tx.QueryContext(ctx, statement)
 	if err != nil {
+		_ = tx.Rollback()
 		return nil, FormatErrorWithQuery(err, statement)
 	}
-	defer rows.Close()
+	queryRows, err := newRows(tx, rows)
+	if err != nil {
+		_ = rows.Close()
+		_ = tx.Rollback()
+		return nil, err
+	}
+	return queryRows, nil
+}
 
-	columnNames, err := rows.Columns()
+// queryAdminRows will execute a query without the read-only transaction and the result limit.
+func queryAdminRows(ctx context.Context, conn *sql.Conn, statement string, _ int) (*sqlRows, error) {
+	rows, err := conn.QueryContext(ctx, statement)
 	if err != nil {
-		return nil, FormatError(err)
+		return nil, FormatErrorWithQuery(err, statement)
 	}
+	queryRows, err := newRows(nil /* tx */, rows)
+	if err != nil {
+		_ = rows.Close()
+		return nil, err
+	}
+	return queryRows, nil
+}
+
+// sqlRows is the db.Rows backed by database/sql rows.
+type sqlRows struct {
+	// tx is nil if the query isn't executed in a transaction.
+	tx      *sql.Tx
+	rows    *sql.Rows
+	columns []db.QueryColumn
+	// conn is the dedicated connection running the query, it's released to the pool on Close.
+	conn *sql.Conn
+	// stopWatch stops canceling the query on the database side once the context is done.
+	stopWatch func()
+}
 
+func newRows(tx *sql.Tx, rows *sql.Rows) (*sqlRows, error) {
 	columnTypes, err := rows.ColumnTypes()
 	if err != nil {
 		return nil, FormatError(err)
 	}
 
-	colCount := len(columnTypes)
-
-	var columnTypeNames []string
+	var columns []db.QueryColumn
 	for _, v := range columnTypes {
-		// DatabaseTypeName returns the database system name of the column type.
-		// refer: https://pkg.go.dev/database/sql#ColumnType.DatabaseTypeName
-		columnTypeNames = append(columnTypeNames, strings.ToUpper(v.DatabaseTypeName()))
-	}
-
-	data := []interface{}{}
-	for rows.Next() {
-		scanArgs := make([]interface{}, colCount)
-		for i, v := range columnTypeNames {
-			// TODO(steven need help): Consult a common list of data types from database driver documentation. e.g. MySQL,PostgreSQL.
-			switch v {
-			case "VARCHAR", "TEXT", "UUID", "TIMESTAMP":
-				scanArgs[i] = new(sql.NullString)
-			case "BOOL":
-				scanArgs[i] = new(sql.NullBool)
-			case "INT", "INTEGER":
-				scanArgs[i] = new(sql.NullInt64)
-			case "FLOAT":
-				scanArgs[i] = new(sql.NullFloat64)
-			default:
-				scanArgs[i] = new(sql.NullString)
-			}
+		column := db.QueryColumn{
+			Name: v.Name(),
+			// DatabaseTypeName returns the database system name of the column type.
+			// refer: https://pkg.go.dev/database/sql#ColumnType.DatabaseTypeName
+			TypeName: strings.ToUpper(v.DatabaseTypeName()),
 		}
-
-		if err := rows.Scan(scanArgs...); err != nil {
-			return nil, FormatError(err)
+		if nullable, ok := v.Nullable(); ok {
+			column.Nullable = nullable
 		}
-
-		rowData := []interface{}{}
-		for i := range columnTypes {
-			if v, ok := (scanArgs[i]).(*sql.NullBool); ok && v.Valid {
-				rowData = append(rowData, v.Bool)
-				continue
-			}
-			if v, ok := (scanArgs[i]).(*sql.NullString); ok && v.Valid {
-				rowData = append(rowData, v.String)
-				continue
-			}
-			if v, ok := (scanArgs[i]).(*sql.NullInt64); ok && v.Valid {
-				rowData = append(rowData, v.Int64)
-				continue
-			}
-			if v, ok := (scanArgs[i]).(*sql.NullInt32); ok && v.Valid {
-				rowData = append(rowData, v.Int32)
-				continue
-			}
-			if v, ok := (scanArgs[i]).(*sql.NullFloat64); ok && v.Valid {
-				rowData = append(rowData, v.Float64)
-				continue
-			}
-			// If none of them match, set nil to its value.
-			rowData = append(rowData, nil)
+		if length, ok := v.Length(); ok {
+			column.Length = length
 		}
-
-		data = append(data, rowData)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
+		if precision, scale, ok := v.DecimalSize(); ok {
+			column.Precision, column.Scale = precision, scale
+		}
+		columns = append(columns, column)
 	}
 
-	return []interface{}{columnNames, columnTypeNames, data}, nil
+	return &sqlRows{
+		tx:      tx,
+		rows:    rows,
+		columns: columns,
+	}, nil
 }
 
-// query will execute a query.
-func queryAdmin(ctx context.Context, sqldb *sql.DB, statement string, _ int) ([]interface{}, error) {
-	rows, err := sqldb.QueryContext(ctx, statement)
-	if err != nil {
-		return nil, FormatErrorWithQuery(err, statement)
-	}
-	defer rows.Close()
+// Columns implements the db.Rows interface.
+func (r *sqlRows) Columns() []db.QueryColumn {
+	return r.columns
+}
 
-	columnNames, err := rows.Columns()
-	if err != nil {
-		return nil, FormatError(err)
-	}
+// Next implements the db.Rows interface.
+func (r *sqlRows) Next() bool {
+	return r.rows.Next()
+}
 
-	columnTypes, err := rows.ColumnTypes()
-	if err != nil {
-		return nil, FormatError(err)
+// Values implements the db.Rows interface.
+func (r *sqlRows) Values() ([]interface{}, error) {
+	scanArgs := make([]interface{}, len(r.columns))
+	for i, column := range r.columns {
+		// TODO(steven need help): Consult a common list of data types from database driver documentation. e.g. MySQL,PostgreSQL.
+		switch column.TypeName {
+		case "VARCHAR", "TEXT", "UUID", "TIMESTAMP":
+			scanArgs[i] = new(sql.NullString)
+		case "BOOL":
+			scanArgs[i] = new(sql.NullBool)
+		case "INT", "INTEGER":
+			scanArgs[i] = new(sql.NullInt64)
+		case "FLOAT":
+			scanArgs[i] = new(sql.NullFloat64)
+		default:
+			scanArgs[i] = new(sql.NullString)
+		}
 	}
 
-	colCount := len(columnTypes)
-
-	var columnTypeNames []string
-	for _, v := range columnTypes {
-		// DatabaseTypeName returns the database system name of the column type.
-		// refer: https://pkg.go.dev/database/sql#ColumnType.DatabaseTypeName
-		columnTypeNames = append(columnTypeNames, strings.ToUpper(v.DatabaseTypeName()))
+	if err := r.rows.Scan(scanArgs...); err != nil {
+		return nil, FormatError(err)
 	}
 
-	// TODO(d): share the common code.
-	data := []interface{}{}
-	for rows.Next() {
-		scanArgs := make([]interface{}, colCount)
-		for i, v := range columnTypeNames {
-			// TODO(steven need help): Consult a common list of data types from database driver documentation. e.g. MySQL,PostgreSQL.
-			switch v {
-			case "VARCHAR", "TEXT", "UUID", "TIMESTAMP":
-				scanArgs[i] = new(sql.NullString)
-			case "BOOL":
-				scanArgs[i] = new(sql.NullBool)
-			case "INT", "INTEGER":
-				scanArgs[i] = new(sql.NullInt64)
-			case "FLOAT":
-				scanArgs[i] = new(sql.NullFloat64)
-			default:
-				scanArgs[i] = new(sql.NullString)
-			}
+	rowData := []interface{}{}
+	for i := range r.columns {
+		if v, ok := (scanArgs[i]).(*sql.NullBool); ok && v.Valid {
+			rowData = append(rowData, v.Bool)
+			continue
 		}
-
-		if err := rows.Scan(scanArgs...); err != nil {
-			return nil, FormatError(err)
+		if v, ok := (scanArgs[i]).(*sql.NullString); ok && v.Valid {
+			rowData = append(rowData, v.String)
+			continue
 		}
-
-		rowData := []interface{}{}
-		for i := range columnTypes {
-			if v, ok := (scanArgs[i]).(*sql.NullBool); ok && v.Valid {
-				rowData = append(rowData, v.Bool)
-				continue
-			}
-			if v, ok := (scanArgs[i]).(*sql.NullString); ok && v.Valid {
-				rowData = append(rowData, v.String)
-				continue
-			}
-			if v, ok := (scanArgs[i]).(*sql.NullInt64); ok && v.Valid {
-				rowData = append(rowData, v.Int64)
-				continue
-			}
-			if v, ok := (scanArgs[i]).(*sql.NullInt32); ok && v.Valid {
-				rowData = append(rowData, v.Int32)
-				continue
-			}
-			if v, ok := (scanArgs[i]).(*sql.NullFloat64); ok && v.Valid {
-				rowData = append(rowData, v.Float64)
-				continue
-			}
-			// If none of them match, set nil to its value.
-			rowData = append(rowData, nil)
+		if v, ok := (scanArgs[i]).(*sql.NullInt64); ok && v.Valid {
+			rowData = append(rowData, v.Int64)
+			continue
+		}
+		if v, ok := (scanArgs[i]).(*sql.NullInt32); ok && v.Valid {
+			rowData = append(rowData, v.Int32)
+			continue
 		}
+		if v, ok := (scanArgs[i]).(*sql.NullFloat64); ok && v.Valid {
+			rowData = append(rowData, v.Float64)
+			continue
+		}
+		// If none of them match, set nil to its value.
+		rowData = append(rowData, nil)
+	}
+	return rowData, nil
+}
 
-		data = append(data, rowData)
+// Err implements the db.Rows interface.
+func (r *sqlRows) Err() error {
+	return r.rows.Err()
+}
+
+// Close implements the db.Rows interface.
+func (r *sqlRows) Close() error {
+	err := r.rows.Close()
+	if r.tx != nil {
+		// The transaction is only used for reading, so we always roll it back.
+		if rollbackErr := r.tx.Rollback(); rollbackErr != nil && rollbackErr != sql.ErrTxDone {
+			err = multierr.Append(err, rollbackErr)
+		}
 	}
-	if err := rows.Err(); err != nil {
-		return nil, err
+	// Stop the cancel watch before releasing the connection, so that we never cancel a query running on the reused connection.
+	if r.stopWatch != nil {
+		r.stopWatch()
 	}
-
-	return []interface{}{columnNames, columnTypeNames, data}, nil
+	if r.conn != nil {
+		if closeErr := r.conn.Close(); closeErr != nil && closeErr != sql.ErrConnDone {
+			err = multierr.Append(err, closeErr)
+		}
+	}
+	return err
 }
 
 func getStatementWithResultLimit(stmt string, limit int) string {
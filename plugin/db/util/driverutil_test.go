@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/plugin/db"
 )
 
 func TestToStoredVersion(t *testing.T) {
@@ -223,6 +225,39 @@ func TestApplyMultiStatements(t *testing.T) {
 	}
 }
 
+func TestGetStatementChecksum(t *testing.T) {
+	checksum := GetStatementChecksum("CREATE TABLE t(a int);\nINSERT INTO t VALUES (1);")
+	for _, statement := range []string{
+		"CREATE TABLE t(a int);\r\nINSERT INTO t VALUES (1);\r\n",
+		"\n\nCREATE TABLE t(a int);  \nINSERT INTO t VALUES (1);\t\n\n",
+	} {
+		require.Equal(t, checksum, GetStatementChecksum(statement), statement)
+	}
+	for _, statement := range []string{
+		"CREATE TABLE t(a int);\nINSERT INTO t VALUES (2);",
+		"CREATE TABLE t(a int);\n\nINSERT INTO t VALUES (1);",
+		"CREATE TABLE t(a  int);\nINSERT INTO t VALUES (1);",
+	} {
+		require.NotEqual(t, checksum, GetStatementChecksum(statement), statement)
+	}
+}
+
+func TestGetMigrationHistoryStatementChecksum(t *testing.T) {
+	statement := "CREATE TABLE t(a int);"
+
+	// The checksum is calculated from the statement for the migration history without the checksum recorded.
+	checksum, err := GetMigrationHistoryStatementChecksum(&db.MigrationHistory{Statement: statement})
+	require.NoError(t, err)
+	require.Equal(t, GetStatementChecksum(statement), checksum)
+
+	payload, err := setStatementChecksum(`{"undoVersion":"0001"}`, "accepted")
+	require.NoError(t, err)
+	require.JSONEq(t, `{"undoVersion":"0001","statementChecksum":"accepted"}`, payload)
+	checksum, err = GetMigrationHistoryStatementChecksum(&db.MigrationHistory{Statement: statement, Payload: payload})
+	require.NoError(t, err)
+	require.Equal(t, "accepted", checksum)
+}
+
 func generateOneMBInsert() string {
 	rand.Seed(time.Now().UnixNano())
 	letterList := []byte("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
@@ -0,0 +1,234 @@
+package util
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/common/log"
+	"github.com/bytebase/bytebase/plugin/db"
+)
+
+const (
+	// migrationLockRetryInterval is the interval to retry acquiring the migration lock held by others.
+	migrationLockRetryInterval = time.Second
+	// migrationLockHeartbeatInterval is the interval for the holder to refresh the heartbeat of the migration lock row.
+	migrationLockHeartbeatInterval = 10 * time.Second
+	// migrationLockStaleTimeout is the time after which the migration lock row without heartbeat is considered stale,
+	// e.g. the holder crashed without releasing it, and is deleted by the others acquiring the lock.
+	migrationLockStaleTimeout = 6 * migrationLockHeartbeatInterval
+)
+
+// MigrationLocker is implemented by the migration executors taking a database-level lock natively, such as the advisory lock of PostgreSQL.
+// For the other executors, the migration lock is emulated by a row in the migration lock table, see MigrationLockTable.
+type MigrationLocker interface {
+	// TryLockMigration tries to acquire the migration lock of the namespace in the session of the connection without waiting.
+	TryLockMigration(ctx context.Context, conn *sql.Conn, namespace string) (bool, error)
+	// UnlockMigration releases the migration lock of the namespace acquired in the session of the connection.
+	UnlockMigration(ctx context.Context, conn *sql.Conn, namespace string) error
+	// FindMigrationLockHolder returns the session holding the migration lock of the namespace, or empty if it's not held.
+	FindMigrationLockHolder(ctx context.Context, conn *sql.Conn, namespace string) (string, error)
+}
+
+// MigrationLockDBOpener is implemented by the migration locker whose connection pool from GetDBConnection is closed on switching
+// the connected database, such as PostgreSQL. The migration lock is held on a dedicated connection pool instead, otherwise the lock
+// is released once the migration switches to the migrated database.
+type MigrationLockDBOpener interface {
+	// OpenMigrationLockDB opens a dedicated connection pool to the database for the migration lock, and the caller closes it.
+	OpenMigrationLockDB(ctx context.Context, databaseName string) (*sql.DB, error)
+}
+
+// MigrationLockTable is implemented by the migration executors without a native database-level lock, which can insert the lock row atomically,
+// e.g. by the primary key for SQLite and the serialized MERGE for Snowflake. ClickHouse can't, so it doesn't support the migration lock.
+// The migration lock is held by inserting the row of the namespace into the migration lock table, whose primary key is the namespace,
+// and is released by deleting the row. The holder refreshes the heartbeat of the row, so that the row left by a crashed holder expires.
+type MigrationLockTable interface {
+	// CreateMigrationLockTableIfNotExists creates the migration lock table if it doesn't exist, e.g. for the migration schema set up by the previous versions.
+	CreateMigrationLockTableIfNotExists(ctx context.Context) error
+	// InsertMigrationLockRow inserts the lock row of the namespace held by the holder, and returns false if the row of the namespace exists.
+	InsertMigrationLockRow(ctx context.Context, namespace, holder string, heartbeatTs int64) (bool, error)
+	// UpdateMigrationLockRowHeartbeat updates the heartbeat of the lock row of the namespace held by the holder.
+	UpdateMigrationLockRowHeartbeat(ctx context.Context, namespace, holder string, heartbeatTs int64) error
+	// DeleteMigrationLockRow deletes the lock row of the namespace held by the holder.
+	DeleteMigrationLockRow(ctx context.Context, namespace, holder string) error
+	// DeleteStaleMigrationLockRow deletes the lock row of the namespace whose last heartbeat is before staleTs.
+	DeleteStaleMigrationLockRow(ctx context.Context, namespace string, staleTs int64) error
+	// FindMigrationLockRow returns the holder and the last heartbeat of the lock row of the namespace, or empty holder if it's not held.
+	FindMigrationLockRow(ctx context.Context, namespace string) (string, int64, error)
+}
+
+// GetMigrationLockKey returns the key of the migration lock of the namespace.
+// The server and bb must use the same key, so that they exclude each other.
+func GetMigrationLockKey(namespace string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte("bytebase_migration." + namespace))
+	return int64(h.Sum64())
+}
+
+// GetMigrationLockName returns the name of the migration lock of the namespace for the engines using named locks.
+// It's derived from the lock key to fit the length limit of the name, which is 64 characters for MySQL.
+func GetMigrationLockName(namespace string) string {
+	return fmt.Sprintf("bytebase_migration_%016x", uint64(GetMigrationLockKey(namespace)))
+}
+
+// lockMigration acquires the migration lock of the namespace, and returns the function to release it.
+func lockMigration(ctx context.Context, executor MigrationExecutor, m *db.MigrationInfo, databaseName string) (func(), error) {
+	timeout := m.LockTimeout
+	if timeout == 0 {
+		timeout = db.DefaultMigrationLockTimeout
+	}
+
+	locker, ok := executor.(MigrationLocker)
+	if !ok {
+		table, ok := executor.(MigrationLockTable)
+		if !ok {
+			// The migration lock can't be emulated atomically on the engines without unique constraints such as ClickHouse,
+			// so the migrations of the same database from the server and bb are not serialized there.
+			log.Warn("Migration lock is not supported, the migration runs without the lock", zap.String("namespace", m.Namespace))
+			return func() {}, nil
+		}
+		return lockMigrationTable(ctx, table, m, timeout)
+	}
+
+	var sqldb *sql.DB
+	closeDB := func() {}
+	if opener, ok := executor.(MigrationLockDBOpener); ok {
+		lockDB, err := opener.OpenMigrationLockDB(ctx, databaseName)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to open the connection for the migration lock")
+		}
+		sqldb = lockDB
+		closeDB = func() {
+			lockDB.Close()
+		}
+	} else {
+		lockDB, err := executor.GetDBConnection(ctx, databaseName)
+		if err != nil {
+			return nil, err
+		}
+		sqldb = lockDB
+	}
+	// The lock belongs to the session, so we hold a dedicated connection till the lock is released.
+	conn, err := sqldb.Conn(ctx)
+	if err != nil {
+		closeDB()
+		return nil, errors.Wrap(err, "failed to get the connection for the migration lock")
+	}
+	if err := waitForMigrationLock(ctx, m.Namespace, timeout,
+		func() (bool, error) {
+			return locker.TryLockMigration(ctx, conn, m.Namespace)
+		},
+		func() (string, error) {
+			return locker.FindMigrationLockHolder(ctx, conn, m.Namespace)
+		},
+	); err != nil {
+		conn.Close()
+		closeDB()
+		return nil, err
+	}
+	return func() {
+		if err := locker.UnlockMigration(ctx, conn, m.Namespace); err != nil {
+			log.Error("Failed to release the migration lock", zap.String("namespace", m.Namespace), zap.Error(err))
+		}
+		conn.Close()
+		closeDB()
+	}, nil
+}
+
+// waitForMigrationLock retries acquiring the migration lock till the timeout, and returns the error naming the holder on timeout.
+func waitForMigrationLock(ctx context.Context, namespace string, timeout time.Duration, tryLock func() (bool, error), findHolder func() (string, error)) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		locked, err := tryLock()
+		if err != nil {
+			return errors.Wrapf(err, "failed to acquire the migration lock of database %q", namespace)
+		}
+		if locked {
+			return nil
+		}
+		if !time.Now().Before(deadline) {
+			holder, err := findHolder()
+			if err != nil {
+				return errors.Wrapf(err, "failed to find the holder of the migration lock of database %q", namespace)
+			}
+			if holder == "" {
+				// The lock is released right after the last attempt.
+				holder = "another migration"
+			}
+			return common.Errorf(common.MigrationLocked, "timed out after %s waiting for the migration lock of database %q, which is held by %s", timeout, namespace, holder)
+		}
+		log.Debug("Waiting for the migration lock", zap.String("namespace", namespace))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(migrationLockRetryInterval):
+		}
+	}
+}
+
+// lockMigrationTable acquires the migration lock of the namespace by inserting the lock row, and returns the function to release it.
+func lockMigrationTable(ctx context.Context, table MigrationLockTable, m *db.MigrationInfo, timeout time.Duration) (func(), error) {
+	if err := table.CreateMigrationLockTableIfNotExists(ctx); err != nil {
+		return nil, errors.Wrap(err, "failed to create the migration lock table")
+	}
+	// The holder is unique for each migration, so that we never release the lock held by others.
+	holder := fmt.Sprintf("the migration version %s of issue %q created by %q (%s)", m.Version, m.IssueID, m.Creator, uuid.New().String())
+	if err := waitForMigrationLock(ctx, m.Namespace, timeout,
+		func() (bool, error) {
+			now := time.Now()
+			if err := table.DeleteStaleMigrationLockRow(ctx, m.Namespace, now.Add(-migrationLockStaleTimeout).Unix()); err != nil {
+				return false, err
+			}
+			return table.InsertMigrationLockRow(ctx, m.Namespace, holder, now.Unix())
+		},
+		func() (string, error) {
+			lockHolder, heartbeatTs, err := table.FindMigrationLockRow(ctx, m.Namespace)
+			if err != nil || lockHolder == "" {
+				return "", err
+			}
+			return fmt.Sprintf("%s, last heartbeat at %s", lockHolder, time.Unix(heartbeatTs, 0).UTC().Format(time.RFC3339)), nil
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	// Keep the heartbeat till the lock is released, so that the others don't take the lock row as stale.
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(migrationLockHeartbeatInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := table.UpdateMigrationLockRowHeartbeat(ctx, m.Namespace, holder, time.Now().Unix()); err != nil {
+					log.Warn("Failed to update the heartbeat of the migration lock", zap.String("namespace", m.Namespace), zap.Error(err))
+				}
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		wg.Wait()
+		// Release the lock even if the context is canceled, otherwise the others have to wait till the lock row is stale.
+		releaseCtx, cancel := context.WithTimeout(context.Background(), migrationLockStaleTimeout)
+		defer cancel()
+		if err := table.DeleteMigrationLockRow(releaseCtx, m.Namespace, holder); err != nil {
+			log.Error("Failed to release the migration lock", zap.String("namespace", m.Namespace), zap.Error(err))
+		}
+	}, nil
+}
@@ -0,0 +1,213 @@
+package util
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"testing"
+	"time"
+
+	// Register the sqlite3 driver for the fake migration locker.
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/plugin/db"
+)
+
+func TestGetMigrationLockName(t *testing.T) {
+	name := GetMigrationLockName("a_very_long_database_name_which_exceeds_the_length_limit_of_the_named_lock")
+	// MySQL limits the length of the named lock to 64 characters.
+	require.LessOrEqual(t, len(name), 64)
+	require.Equal(t, name, GetMigrationLockName("a_very_long_database_name_which_exceeds_the_length_limit_of_the_named_lock"))
+	require.NotEqual(t, name, GetMigrationLockName("db1"))
+	require.NotEqual(t, GetMigrationLockKey("db1"), GetMigrationLockKey("db2"))
+}
+
+func TestWaitForMigrationLock(t *testing.T) {
+	ctx := context.Background()
+	findHolder := func() (string, error) {
+		return "process 42", nil
+	}
+
+	err := waitForMigrationLock(ctx, "db1", 0, func() (bool, error) { return true, nil }, findHolder)
+	require.NoError(t, err)
+
+	// The lock is tried once without waiting if the timeout is 0.
+	tries := 0
+	err = waitForMigrationLock(ctx, "db1", 0, func() (bool, error) {
+		tries++
+		return false, nil
+	}, findHolder)
+	require.Equal(t, 1, tries)
+	require.Equal(t, common.MigrationLocked, common.ErrorCode(err))
+	require.Contains(t, err.Error(), "process 42")
+}
+
+// fakeMigrationLockTable is the migration lock table in memory.
+type fakeMigrationLockTable struct {
+	mu   sync.Mutex
+	rows map[string]*fakeMigrationLockRow
+}
+
+type fakeMigrationLockRow struct {
+	holder      string
+	heartbeatTs int64
+}
+
+func (*fakeMigrationLockTable) CreateMigrationLockTableIfNotExists(context.Context) error {
+	return nil
+}
+
+func (t *fakeMigrationLockTable) InsertMigrationLockRow(_ context.Context, namespace, holder string, heartbeatTs int64) (bool, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.rows[namespace]; ok {
+		return false, nil
+	}
+	t.rows[namespace] = &fakeMigrationLockRow{holder: holder, heartbeatTs: heartbeatTs}
+	return true, nil
+}
+
+func (t *fakeMigrationLockTable) UpdateMigrationLockRowHeartbeat(_ context.Context, namespace, holder string, heartbeatTs int64) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if row, ok := t.rows[namespace]; ok && row.holder == holder {
+		row.heartbeatTs = heartbeatTs
+	}
+	return nil
+}
+
+func (t *fakeMigrationLockTable) DeleteMigrationLockRow(_ context.Context, namespace, holder string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if row, ok := t.rows[namespace]; ok && row.holder == holder {
+		delete(t.rows, namespace)
+	}
+	return nil
+}
+
+func (t *fakeMigrationLockTable) DeleteStaleMigrationLockRow(_ context.Context, namespace string, staleTs int64) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if row, ok := t.rows[namespace]; ok && row.heartbeatTs < staleTs {
+		delete(t.rows, namespace)
+	}
+	return nil
+}
+
+func (t *fakeMigrationLockTable) FindMigrationLockRow(_ context.Context, namespace string) (string, int64, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if row, ok := t.rows[namespace]; ok {
+		return row.holder, row.heartbeatTs, nil
+	}
+	return "", 0, nil
+}
+
+func TestLockMigrationTable(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	table := &fakeMigrationLockTable{rows: make(map[string]*fakeMigrationLockRow)}
+	m := &db.MigrationInfo{Namespace: "db1", Version: "0001", IssueID: "101", Creator: "alice"}
+
+	release, err := lockMigrationTable(ctx, table, m, 0 /* timeout */)
+	a.NoError(err)
+	// The lock is held by the other migrations of the same namespace, and the error names the holder.
+	_, err = lockMigrationTable(ctx, table, &db.MigrationInfo{Namespace: "db1", Version: "0002"}, 0 /* timeout */)
+	a.Equal(common.MigrationLocked, common.ErrorCode(err))
+	a.Contains(err.Error(), `the migration version 0001 of issue "101" created by "alice"`)
+	// The lock of the other namespaces is not affected.
+	releaseOther, err := lockMigrationTable(ctx, table, &db.MigrationInfo{Namespace: "db2"}, 0 /* timeout */)
+	a.NoError(err)
+	releaseOther()
+
+	// The lock row is deleted on release.
+	release()
+	holder, _, err := table.FindMigrationLockRow(ctx, "db1")
+	a.NoError(err)
+	a.Empty(holder)
+
+	// The lock row left by a crashed holder without heartbeat is taken over once it's stale.
+	_, err = table.InsertMigrationLockRow(ctx, "db1", "crashed", time.Now().Add(-migrationLockStaleTimeout-time.Second).Unix())
+	a.NoError(err)
+	release, err = lockMigrationTable(ctx, table, m, 0 /* timeout */)
+	a.NoError(err)
+	holder, _, err = table.FindMigrationLockRow(ctx, "db1")
+	a.NoError(err)
+	a.Contains(holder, "the migration version 0001")
+	release()
+}
+
+// fakeMigrationLocker is the migration locker which closes its connection pool on switching the database like PostgreSQL.
+type fakeMigrationLocker struct {
+	MigrationExecutor
+	sqldb     *sql.DB
+	lockDB    *sql.DB
+	unlockErr error
+}
+
+func (e *fakeMigrationLocker) GetDBConnection(context.Context, string) (*sql.DB, error) {
+	if e.sqldb != nil {
+		if err := e.sqldb.Close(); err != nil {
+			return nil, err
+		}
+	}
+	sqldb, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		return nil, err
+	}
+	e.sqldb = sqldb
+	return sqldb, nil
+}
+
+func (e *fakeMigrationLocker) OpenMigrationLockDB(context.Context, string) (*sql.DB, error) {
+	lockDB, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		return nil, err
+	}
+	e.lockDB = lockDB
+	return lockDB, nil
+}
+
+func (*fakeMigrationLocker) TryLockMigration(ctx context.Context, conn *sql.Conn, _ string) (bool, error) {
+	_, err := conn.ExecContext(ctx, "SELECT 1")
+	return err == nil, err
+}
+
+func (e *fakeMigrationLocker) UnlockMigration(ctx context.Context, conn *sql.Conn, _ string) error {
+	_, e.unlockErr = conn.ExecContext(ctx, "SELECT 1")
+	return e.unlockErr
+}
+
+func (*fakeMigrationLocker) FindMigrationLockHolder(context.Context, *sql.Conn, string) (string, error) {
+	return "", nil
+}
+
+func TestLockMigrationWithDedicatedDB(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	executor := &fakeMigrationLocker{}
+	m := &db.MigrationInfo{Namespace: "db1"}
+
+	release, err := lockMigration(ctx, executor, m, "bytebase")
+	a.NoError(err)
+	a.NotNil(executor.lockDB)
+	// Switching the database to run the migration doesn't close the connection holding the lock.
+	_, err = executor.GetDBConnection(ctx, "db1")
+	a.NoError(err)
+	_, err = executor.GetDBConnection(ctx, "db2")
+	a.NoError(err)
+
+	// The lock is released on the connection holding it, and the dedicated connection pool is closed then.
+	release()
+	a.NoError(executor.unlockErr)
+	a.Error(executor.lockDB.PingContext(ctx))
+}
+
+func TestLockMigrationNotSupported(t *testing.T) {
+	// The migration runs without the lock on the engines not supporting it, such as ClickHouse.
+	release, err := lockMigration(context.Background(), struct{ MigrationExecutor }{}, &db.MigrationInfo{Namespace: "db1"}, "bytebase")
+	require.NoError(t, err)
+	release()
+}
@@ -32,3 +32,10 @@ func GetSafeName(baseName, suffix string) string {
 	extraCharacters := len(name) - MaxDatabaseNameLength
 	return fmt.Sprintf("%s_%s", baseName[0:len(baseName)-extraCharacters], suffix)
 }
+
+// GetBackupVerificationDatabaseName composes a scratch database name that we use as the target database for backup verification.
+// For example, GetBackupVerificationDatabaseName("dbfoo", 1653018005) -> "dbfoo_verify_1653018005".
+func GetBackupVerificationDatabaseName(database string, suffixTs int64) string {
+	suffix := fmt.Sprintf("verify_%d", suffixTs)
+	return GetSafeName(database, suffix)
+}
@@ -0,0 +1,8 @@
+package ast
+
+// ByItemDef is the struct for the item definition in ORDER BY clause.
+type ByItemDef struct {
+	node
+
+	Expression ExpressionNode
+}
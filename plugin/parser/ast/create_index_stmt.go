@@ -5,4 +5,6 @@ type CreateIndexStmt struct {
 	ddl
 
 	Index *IndexDef
+	// Concurrently is true for CREATE INDEX CONCURRENTLY in PostgreSQL, which cannot run in a transaction block.
+	Concurrently bool
 }
@@ -0,0 +1,10 @@
+package ast
+
+// FuncCallDef is the struct for function call expression definition, e.g. random().
+type FuncCallDef struct {
+	expression
+
+	// Schema is empty if the function name isn't qualified.
+	Schema string
+	Name   string
+}
@@ -4,7 +4,9 @@ package ast
 type InsertStmt struct {
 	dml
 
-	Table     *TableDef
-	ValueList [][]ExpressionNode
-	Select    *SelectStmt
+	Table *TableDef
+	// ColumnList is the list of the column names specified in INSERT, empty if not specified.
+	ColumnList []string
+	ValueList  [][]ExpressionNode
+	Select     *SelectStmt
 }
@@ -43,4 +43,9 @@ type SelectStmt struct {
 	PatternLikeList []*PatternLikeDef
 	// SubqueryList is the list of the subquery nodes.
 	SubqueryList []*SubqueryDef
+
+	// OrderByClause and LimitCount also apply to the result of the set operation.
+	OrderByClause []*ByItemDef
+	// LimitCount is nil if there is no LIMIT or FETCH FIRST clause.
+	LimitCount ExpressionNode
 }
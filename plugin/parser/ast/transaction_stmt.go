@@ -0,0 +1,22 @@
+package ast
+
+// TransactionType is the type for transaction control statements.
+type TransactionType int
+
+const (
+	// TransactionTypeOther is the type for the transaction control statements not listed below, such as SAVEPOINT.
+	TransactionTypeOther TransactionType = iota
+	// TransactionTypeBegin is the type for BEGIN and START TRANSACTION.
+	TransactionTypeBegin
+	// TransactionTypeCommit is the type for COMMIT and COMMIT PREPARED.
+	TransactionTypeCommit
+	// TransactionTypeRollback is the type for ROLLBACK and ROLLBACK PREPARED.
+	TransactionTypeRollback
+)
+
+// TransactionStmt is the struct for transaction control statements, such as BEGIN, COMMIT and ROLLBACK.
+type TransactionStmt struct {
+	node
+
+	Type TransactionType
+}
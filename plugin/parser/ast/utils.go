@@ -33,6 +33,10 @@ func Walk(v Visitor, node Node) {
 		for _, cmd := range n.AlterItemList {
 			Walk(v, cmd)
 		}
+	case *ByItemDef:
+		if n.Expression != nil {
+			Walk(v, n.Expression)
+		}
 	case *ChangeColumnStmt:
 		if n.Table != nil {
 			Walk(v, n.Table)
@@ -117,6 +121,8 @@ func Walk(v Visitor, node Node) {
 		if n.Table != nil {
 			Walk(v, n.Table)
 		}
+	case *FuncCallDef:
+		// No members to walk through.
 	case *IndexDef:
 		if n.Table != nil {
 			Walk(v, n.Table)
@@ -176,6 +182,9 @@ func Walk(v Visitor, node Node) {
 		for _, subquery := range n.SubqueryList {
 			Walk(v, subquery)
 		}
+		for _, item := range n.OrderByClause {
+			Walk(v, item)
+		}
 	case *SetNotNullStmt:
 		if n.Table != nil {
 			Walk(v, n.Table)
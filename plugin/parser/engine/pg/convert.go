@@ -227,11 +227,11 @@ func convert(node *pgquery.Node, statement parser.SingleSQL) (res ast.Node, err
 			}
 		}
 
-		return &ast.CreateIndexStmt{Index: indexDef}, nil
+		return &ast.CreateIndexStmt{Index: indexDef, Concurrently: in.IndexStmt.Concurrent}, nil
 	case *pgquery.Node_DropStmt:
 		switch in.DropStmt.RemoveType {
 		case pgquery.ObjectType_OBJECT_INDEX:
-			dropIndex := &ast.DropIndexStmt{}
+			dropIndex := &ast.DropIndexStmt{Concurrently: in.DropStmt.Concurrent}
 			for _, object := range in.DropStmt.Objects {
 				list, ok := object.Node.(*pgquery.Node_List)
 				if !ok {
@@ -293,6 +293,8 @@ func convert(node *pgquery.Node, statement parser.SingleSQL) (res ast.Node, err
 			}
 			return dropSchema, nil
 		}
+	case *pgquery.Node_TransactionStmt:
+		return convertTransactionStmt(in.TransactionStmt), nil
 	case *pgquery.Node_DropdbStmt:
 		return &ast.DropDatabaseStmt{
 			DatabaseName: in.DropdbStmt.Dbname,
@@ -374,6 +376,13 @@ func convert(node *pgquery.Node, statement parser.SingleSQL) (res ast.Node, err
 		insertStmt := &ast.InsertStmt{
 			Table: convertRangeVarToTableName(in.InsertStmt.Relation, ast.TableTypeBaseTable),
 		}
+		for _, col := range in.InsertStmt.Cols {
+			target, ok := col.Node.(*pgquery.Node_ResTarget)
+			if !ok {
+				return nil, parser.NewConvertErrorf("expected ResTarget but found %t", col.Node)
+			}
+			insertStmt.ColumnList = append(insertStmt.ColumnList, target.ResTarget.Name)
+		}
 
 		if in.InsertStmt.SelectStmt != nil {
 			if selectNode, ok := in.InsertStmt.SelectStmt.Node.(*pgquery.Node_SelectStmt); ok {
@@ -570,7 +579,28 @@ func convertExpressionNode(node *pgquery.Node) (ast.ExpressionNode, []*ast.Patte
 			likeList = append(likeList, interLike...)
 			subqueryList = append(subqueryList, interSubquery...)
 		}
-		return &ast.UnconvertedExpressionDef{}, likeList, subqueryList, nil
+		funcCall := &ast.FuncCallDef{}
+		// The function name may be qualified by the schema name, e.g. pg_catalog.random.
+		switch len(in.FuncCall.Funcname) {
+		case 2:
+			schema, ok := in.FuncCall.Funcname[0].Node.(*pgquery.Node_String_)
+			if !ok {
+				return nil, nil, nil, parser.NewConvertErrorf("expected String but found %t", in.FuncCall.Funcname[0].Node)
+			}
+			funcCall.Schema = schema.String_.Str
+			fallthrough
+		case 1:
+			name, ok := in.FuncCall.Funcname[len(in.FuncCall.Funcname)-1].Node.(*pgquery.Node_String_)
+			if !ok {
+				return nil, nil, nil, parser.NewConvertErrorf("expected String but found %t", in.FuncCall.Funcname[len(in.FuncCall.Funcname)-1].Node)
+			}
+			funcCall.Name = name.String_.Str
+		default:
+			// The function name may be qualified by the database name, e.g. db.pg_catalog.random.
+			// We don't convert such function calls for now.
+			return &ast.UnconvertedExpressionDef{}, likeList, subqueryList, nil
+		}
+		return funcCall, likeList, subqueryList, nil
 	case *pgquery.Node_AExpr:
 		var likeList, interLike []*ast.PatternLikeDef
 		var subqueryList, interSubquery []*ast.SubqueryDef
@@ -667,6 +697,25 @@ func convertSelectStmt(in *pgquery.SelectStmt) (*ast.SelectStmt, error) {
 		return nil, err
 	}
 
+	// Convert ORDER BY clause
+	for _, item := range in.SortClause {
+		sortBy, ok := item.Node.(*pgquery.Node_SortBy)
+		if !ok {
+			return nil, parser.NewConvertErrorf("expected SortBy but found %t", item.Node)
+		}
+		expression, _, _, err := convertExpressionNode(sortBy.SortBy.Node)
+		if err != nil {
+			return nil, err
+		}
+		selectStmt.OrderByClause = append(selectStmt.OrderByClause, &ast.ByItemDef{Expression: expression})
+	}
+	// Convert LIMIT clause
+	if in.LimitCount != nil {
+		if selectStmt.LimitCount, _, _, err = convertExpressionNode(in.LimitCount); err != nil {
+			return nil, err
+		}
+	}
+
 	selectStmt.SetOperation = setOperation
 	if setOperation != ast.SetOperationTypeNone {
 		lQuery, err := convertSelectStmt(in.Larg)
@@ -714,6 +763,21 @@ func convertSelectStmt(in *pgquery.SelectStmt) (*ast.SelectStmt, error) {
 	return selectStmt, nil
 }
 
+func convertTransactionStmt(in *pgquery.TransactionStmt) *ast.TransactionStmt {
+	transactionStmt := &ast.TransactionStmt{}
+	switch in.Kind {
+	case pgquery.TransactionStmtKind_TRANS_STMT_BEGIN, pgquery.TransactionStmtKind_TRANS_STMT_START:
+		transactionStmt.Type = ast.TransactionTypeBegin
+	case pgquery.TransactionStmtKind_TRANS_STMT_COMMIT, pgquery.TransactionStmtKind_TRANS_STMT_COMMIT_PREPARED:
+		transactionStmt.Type = ast.TransactionTypeCommit
+	case pgquery.TransactionStmtKind_TRANS_STMT_ROLLBACK, pgquery.TransactionStmtKind_TRANS_STMT_ROLLBACK_PREPARED:
+		transactionStmt.Type = ast.TransactionTypeRollback
+	default:
+		transactionStmt.Type = ast.TransactionTypeOther
+	}
+	return transactionStmt
+}
+
 func convertRangeSubselect(node *pgquery.RangeSubselect) (*ast.SubqueryDef, error) {
 	subselect, ok := node.Subquery.Node.(*pgquery.Node_SelectStmt)
 	if !ok {
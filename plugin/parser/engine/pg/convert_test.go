@@ -760,6 +760,30 @@ func TestPGCreateIndexStmt(t *testing.T) {
 				},
 			},
 		},
+		{
+			stmt: "CREATE INDEX CONCURRENTLY idx_id ON tech_book (id)",
+			want: []ast.Node{
+				&ast.CreateIndexStmt{
+					Index: &ast.IndexDef{
+						Name:  "idx_id",
+						Table: &ast.TableDef{Name: "tech_book"},
+						KeyList: []*ast.IndexKeyDef{
+							{
+								Type: ast.IndexKeyTypeColumn,
+								Key:  "id",
+							},
+						},
+					},
+					Concurrently: true,
+				},
+			},
+			statementList: []parser.SingleSQL{
+				{
+					Text:     "CREATE INDEX CONCURRENTLY idx_id ON tech_book (id)",
+					LastLine: 1,
+				},
+			},
+		},
 	}
 
 	runTests(t, tests)
@@ -787,6 +811,23 @@ func TestPGDropIndexStmt(t *testing.T) {
 				},
 			},
 		},
+		{
+			stmt: "DROP INDEX CONCURRENTLY idx_x",
+			want: []ast.Node{
+				&ast.DropIndexStmt{
+					IndexList: []*ast.IndexDef{
+						{Name: "idx_x"},
+					},
+					Concurrently: true,
+				},
+			},
+			statementList: []parser.SingleSQL{
+				{
+					Text:     "DROP INDEX CONCURRENTLY idx_x",
+					LastLine: 1,
+				},
+			},
+		},
 	}
 
 	runTests(t, tests)
@@ -1353,7 +1394,7 @@ func TestPGSelectStmt(t *testing.T) {
 								Table:      &ast.TableDef{},
 								ColumnName: "b",
 							},
-							&ast.UnconvertedExpressionDef{},
+							&ast.FuncCallDef{Name: "lower"},
 							&ast.UnconvertedExpressionDef{},
 						},
 						WhereClause: &ast.UnconvertedExpressionDef{},
@@ -1777,6 +1818,78 @@ func TestInsertStmt(t *testing.T) {
 				},
 			},
 		},
+		{
+			stmt: "INSERT INTO tech_book(id, name) SELECT id, name FROM book ORDER BY random() LIMIT 10",
+			want: []ast.Node{
+				&ast.InsertStmt{
+					Table: &ast.TableDef{
+						Type: ast.TableTypeBaseTable,
+						Name: "tech_book",
+					},
+					ColumnList: []string{"id", "name"},
+					Select: &ast.SelectStmt{
+						FieldList: []ast.ExpressionNode{
+							&ast.ColumnNameDef{
+								Table:      &ast.TableDef{},
+								ColumnName: "id",
+							},
+							&ast.ColumnNameDef{
+								Table:      &ast.TableDef{},
+								ColumnName: "name",
+							},
+						},
+						OrderByClause: []*ast.ByItemDef{
+							{
+								Expression: &ast.FuncCallDef{Name: "random"},
+							},
+						},
+						LimitCount: &ast.UnconvertedExpressionDef{},
+					},
+				},
+			},
+			statementList: []parser.SingleSQL{
+				{
+					Text:     "INSERT INTO tech_book(id, name) SELECT id, name FROM book ORDER BY random() LIMIT 10",
+					LastLine: 1,
+				},
+			},
+		},
+		{
+			stmt: "INSERT INTO tech_book(id, name) SELECT id, name FROM book ORDER BY db.pg_catalog.random() LIMIT 10",
+			want: []ast.Node{
+				&ast.InsertStmt{
+					Table: &ast.TableDef{
+						Type: ast.TableTypeBaseTable,
+						Name: "tech_book",
+					},
+					ColumnList: []string{"id", "name"},
+					Select: &ast.SelectStmt{
+						FieldList: []ast.ExpressionNode{
+							&ast.ColumnNameDef{
+								Table:      &ast.TableDef{},
+								ColumnName: "id",
+							},
+							&ast.ColumnNameDef{
+								Table:      &ast.TableDef{},
+								ColumnName: "name",
+							},
+						},
+						OrderByClause: []*ast.ByItemDef{
+							{
+								Expression: &ast.UnconvertedExpressionDef{},
+							},
+						},
+						LimitCount: &ast.UnconvertedExpressionDef{},
+					},
+				},
+			},
+			statementList: []parser.SingleSQL{
+				{
+					Text:     "INSERT INTO tech_book(id, name) SELECT id, name FROM book ORDER BY db.pg_catalog.random() LIMIT 10",
+					LastLine: 1,
+				},
+			},
+		},
 		{
 			stmt: "INSERT INTO tech_book VALUES(1, 2, 3, 4, 5)",
 			want: []ast.Node{
@@ -2081,3 +2194,49 @@ func TestAlterColumnDefault(t *testing.T) {
 	}
 	runTests(t, tests)
 }
+
+func TestPGTransactionStmt(t *testing.T) {
+	tests := []testData{
+		{
+			stmt: "BEGIN;\nCOMMIT;",
+			want: []ast.Node{
+				&ast.TransactionStmt{Type: ast.TransactionTypeBegin},
+				&ast.TransactionStmt{Type: ast.TransactionTypeCommit},
+			},
+			statementList: []parser.SingleSQL{
+				{
+					Text:     "BEGIN;",
+					LastLine: 1,
+				},
+				{
+					Text:     "COMMIT;",
+					LastLine: 2,
+				},
+			},
+		},
+		{
+			stmt: "START TRANSACTION;\nSAVEPOINT s1;\nROLLBACK;",
+			want: []ast.Node{
+				&ast.TransactionStmt{Type: ast.TransactionTypeBegin},
+				&ast.TransactionStmt{Type: ast.TransactionTypeOther},
+				&ast.TransactionStmt{Type: ast.TransactionTypeRollback},
+			},
+			statementList: []parser.SingleSQL{
+				{
+					Text:     "START TRANSACTION;",
+					LastLine: 1,
+				},
+				{
+					Text:     "SAVEPOINT s1;",
+					LastLine: 2,
+				},
+				{
+					Text:     "ROLLBACK;",
+					LastLine: 3,
+				},
+			},
+		},
+	}
+
+	runTests(t, tests)
+}
@@ -0,0 +1,25 @@
+// Package gcs provides the client for Google Cloud Storage (GCS).
+package gcs
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	"github.com/bytebase/bytebase/plugin/storage/s3"
+)
+
+// endpoint is the XML API endpoint of GCS which is interoperable with AWS S3.
+const endpoint = "https://storage.googleapis.com"
+
+// NewClient returns a new GCS client.
+// It accesses GCS through the XML API with HMAC keys, which are given as the access key ID and the secret access key of the credentials.
+// See https://cloud.google.com/storage/docs/interoperability.
+func NewClient(ctx context.Context, bucket string, credentials aws.Credentials) (*s3.Client, error) {
+	return s3.NewClientWithOptions(ctx, "auto", bucket, credentials, s3.Options{
+		Endpoint: endpoint,
+		// The XML API of GCS doesn't support the multi-object delete or the flexible checksum of AWS S3.
+		DisableBatchDelete: true,
+		DisableChecksum:    true,
+	})
+}
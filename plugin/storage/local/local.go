@@ -0,0 +1,169 @@
+// Package local provides the storage on the local file system.
+package local
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/plugin/storage"
+)
+
+var _ storage.Backend = (*Storage)(nil)
+
+// Storage stores the objects as files under the root directory.
+type Storage struct {
+	root string
+}
+
+// NewStorage returns a new storage under the root directory.
+func NewStorage(root string) *Storage {
+	return &Storage{root: root}
+}
+
+// getFilePath returns the file path of the object, and rejects the path escaping from the root directory.
+func (s *Storage) getFilePath(objectPath string) (string, error) {
+	cleaned := path.Clean("/" + objectPath)
+	if cleaned == "/" {
+		return "", errors.Errorf("invalid object path %q", objectPath)
+	}
+	return filepath.Join(s.root, filepath.FromSlash(cleaned)), nil
+}
+
+// Upload writes the object to a temporary file and renames it, so that a failed upload doesn't leave a partial object.
+func (s *Storage) Upload(_ context.Context, objectPath string, body io.Reader) error {
+	filePath, err := s.getFilePath(objectPath)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(filePath), os.ModePerm); err != nil {
+		return errors.Wrapf(err, "failed to create directory for %q", filePath)
+	}
+	f, err := os.CreateTemp(filepath.Dir(filePath), filepath.Base(filePath)+".*.tmp")
+	if err != nil {
+		return errors.Wrapf(err, "failed to create temporary file for %q", filePath)
+	}
+	defer os.Remove(f.Name())
+	if _, err := io.Copy(f, body); err != nil {
+		f.Close()
+		return errors.Wrapf(err, "failed to write %q", filePath)
+	}
+	if err := f.Close(); err != nil {
+		return errors.Wrapf(err, "failed to close %q", f.Name())
+	}
+	if err := os.Rename(f.Name(), filePath); err != nil {
+		return errors.Wrapf(err, "failed to rename %q to %q", f.Name(), filePath)
+	}
+	return nil
+}
+
+// Download copies the object to w.
+func (s *Storage) Download(ctx context.Context, objectPath string, w io.WriterAt) (int64, error) {
+	r, err := s.ReadRange(ctx, objectPath, 0, -1)
+	if err != nil {
+		return 0, err
+	}
+	defer r.Close()
+	return io.Copy(&offsetWriter{w: w}, r)
+}
+
+// ReadRange reads the object in the range [offset, offset+length).
+func (s *Storage) ReadRange(_ context.Context, objectPath string, offset, length int64) (io.ReadCloser, error) {
+	filePath, err := s.getFilePath(objectPath)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open %q", filePath)
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		f.Close()
+		return nil, errors.Wrapf(err, "failed to seek %q to offset %d", filePath, offset)
+	}
+	if length < 0 {
+		return f, nil
+	}
+	return &limitedReadCloser{Reader: io.LimitReader(f, length), Closer: f}, nil
+}
+
+// List lists the objects whose paths have the prefix, like AWS S3 the prefix isn't necessarily a directory.
+func (s *Storage) List(_ context.Context, prefix string) ([]storage.Object, error) {
+	// Only walk the deepest directory containing all the objects with the prefix.
+	dir := prefix
+	if !strings.HasSuffix(dir, "/") {
+		dir = path.Dir(dir)
+	}
+	dirPath := filepath.Join(s.root, filepath.FromSlash(path.Clean("/"+dir)))
+	var ret []storage.Object
+	err := filepath.WalkDir(dirPath, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		relPath, err := filepath.Rel(s.root, filePath)
+		if err != nil {
+			return err
+		}
+		objectPath := filepath.ToSlash(relPath)
+		if !strings.HasPrefix(objectPath, strings.TrimPrefix(prefix, "/")) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		ret = append(ret, storage.Object{
+			Path:         objectPath,
+			Size:         info.Size(),
+			LastModified: info.ModTime(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list directory %q", dirPath)
+	}
+	return ret, nil
+}
+
+// Delete removes the files of the objects.
+func (s *Storage) Delete(_ context.Context, pathList ...string) error {
+	for _, objectPath := range pathList {
+		filePath, err := s.getFilePath(objectPath)
+		if err != nil {
+			return err
+		}
+		if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "failed to remove %q", filePath)
+		}
+	}
+	return nil
+}
+
+// offsetWriter writes to the io.WriterAt sequentially.
+type offsetWriter struct {
+	w      io.WriterAt
+	offset int64
+}
+
+func (w *offsetWriter) Write(p []byte) (int, error) {
+	n, err := w.w.WriteAt(p, w.offset)
+	w.offset += int64(n)
+	return n, err
+}
+
+type limitedReadCloser struct {
+	io.Reader
+	io.Closer
+}
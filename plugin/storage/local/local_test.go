@@ -0,0 +1,83 @@
+package local
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/plugin/storage"
+)
+
+func TestStorage(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	root := t.TempDir()
+	s := NewStorage(root)
+
+	objects := map[string]string{
+		"backup/instance/1/binlog.000001":      "binlog1",
+		"backup/instance/1/binlog.000001.meta": "meta1",
+		"backup/instance/10/binlog.000001":     "binlog10",
+		"backup/db/1/backup.sql":               "CREATE TABLE t(id INT);",
+	}
+	for objectPath, content := range objects {
+		a.NoError(s.Upload(ctx, objectPath, strings.NewReader(content)))
+	}
+
+	listPaths := func(prefix string) []string {
+		list, err := s.List(ctx, prefix)
+		a.NoError(err)
+		var paths []string
+		for _, object := range list {
+			a.Equal(int64(len(objects[object.Path])), object.Size)
+			a.False(object.LastModified.IsZero())
+			paths = append(paths, object.Path)
+		}
+		sort.Strings(paths)
+		return paths
+	}
+	a.Equal([]string{"backup/instance/1/binlog.000001", "backup/instance/1/binlog.000001.meta"}, listPaths("backup/instance/1/"))
+	a.Equal([]string{"backup/instance/1/binlog.000001", "backup/instance/1/binlog.000001.meta", "backup/instance/10/binlog.000001"}, listPaths("backup/instance/1"))
+	a.Equal([]string{"backup/db/1/backup.sql"}, listPaths("backup/db"))
+	a.Empty(listPaths("backup/nonexistent/"))
+
+	// Download to a local file.
+	filePath := filepath.Join(t.TempDir(), "backup.sql")
+	a.NoError(storage.DownloadFile(ctx, s, filePath, "backup/db/1/backup.sql"))
+	content, err := os.ReadFile(filePath)
+	a.NoError(err)
+	a.Equal(objects["backup/db/1/backup.sql"], string(content))
+
+	// Read ranges.
+	readRange := func(offset, length int64) string {
+		r, err := s.ReadRange(ctx, "backup/db/1/backup.sql", offset, length)
+		a.NoError(err)
+		defer r.Close()
+		content, err := io.ReadAll(r)
+		a.NoError(err)
+		return string(content)
+	}
+	a.Equal("TABLE", readRange(7, 5))
+	a.Equal("t(id INT);", readRange(13, -1))
+	a.Equal("", readRange(100, 5))
+
+	// Overwrite.
+	a.NoError(s.Upload(ctx, "backup/db/1/backup.sql", strings.NewReader("new")))
+	a.Equal("new", readRange(0, -1))
+
+	// Delete, including a nonexistent object.
+	a.NoError(s.Delete(ctx, "backup/instance/1/binlog.000001", "backup/instance/1/nonexistent"))
+	a.Equal([]string{"backup/instance/1/binlog.000001.meta"}, listPaths("backup/instance/1/"))
+
+	// Paths cannot escape from the root directory.
+	a.Error(s.Upload(ctx, "", strings.NewReader("")))
+	a.NoError(s.Upload(ctx, "../escape", strings.NewReader("escape")))
+	_, err = os.Stat(filepath.Join(root, "escape"))
+	a.NoError(err)
+}
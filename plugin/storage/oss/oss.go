@@ -0,0 +1,26 @@
+// Package oss provides the client for AliCloud Object Storage Service (OSS).
+package oss
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	"github.com/bytebase/bytebase/plugin/storage/s3"
+)
+
+// NewClient returns a new OSS client for the bucket in the region, e.g., cn-hangzhou.
+// It accesses OSS through the API compatible with AWS S3 with the AccessKey pair given as the credentials.
+// See https://www.alibabacloud.com/help/en/object-storage-service/latest/compatibility-with-amazon-s3.
+func NewClient(ctx context.Context, region, bucket string, credentials aws.Credentials) (*s3.Client, error) {
+	return s3.NewClientWithOptions(ctx, region, bucket, credentials, s3.Options{
+		Endpoint: getEndpoint(region),
+		// OSS doesn't support the flexible checksum of AWS S3.
+		DisableChecksum: true,
+	})
+}
+
+func getEndpoint(region string) string {
+	return fmt.Sprintf("https://oss-%s.aliyuncs.com", region)
+}
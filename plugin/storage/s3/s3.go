@@ -1,10 +1,11 @@
-// Package s3 provides the client for AWS S3 storage.
+// Package s3 provides the client for AWS S3 and S3-compatible storage.
 package s3
 
 import (
 	"context"
+	"fmt"
 	"io"
-	"os"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
@@ -13,12 +14,27 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/plugin/storage"
 )
 
+var _ storage.Backend = (*Client)(nil)
+
 // Client wraps the AWS S3 client.
 type Client struct {
 	c      *s3.Client
 	bucket string
+	opts   Options
+}
+
+// Options is the options for S3-compatible storage.
+type Options struct {
+	// Endpoint is the URL of the S3-compatible service. The AWS S3 endpoint of the region is used if it's empty.
+	Endpoint string
+	// DisableBatchDelete deletes objects one by one for the service without the DeleteObjects API.
+	DisableBatchDelete bool
+	// DisableChecksum doesn't send the SHA256 checksum in uploads for the service without the flexible checksum.
+	DisableChecksum bool
 }
 
 // GetCredentialsFromFile load AWS credentials from file.
@@ -38,6 +54,11 @@ func GetCredentialsFromFile(ctx context.Context, credentialsFileName string) (aw
 
 // NewClient returns a new AWS S3 client.
 func NewClient(ctx context.Context, region, bucket string, credentials aws.Credentials) (*Client, error) {
+	return NewClientWithOptions(ctx, region, bucket, credentials, Options{})
+}
+
+// NewClientWithOptions returns a new client for AWS S3 or S3-compatible storage.
+func NewClientWithOptions(ctx context.Context, region, bucket string, credentials aws.Credentials, opts Options) (*Client, error) {
 	cfg, err := awsconfig.LoadDefaultConfig(ctx,
 		awsconfig.WithRegion(region),
 		awsconfig.WithCredentialsProvider(awscredentials.NewStaticCredentialsProvider(credentials.AccessKeyID, credentials.SecretAccessKey, "")),
@@ -45,15 +66,22 @@ func NewClient(ctx context.Context, region, bucket string, credentials aws.Crede
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to load AWS S3 config")
 	}
+	var optFns []func(*s3.Options)
+	if opts.Endpoint != "" {
+		optFns = append(optFns, func(o *s3.Options) {
+			o.EndpointResolver = s3.EndpointResolverFromURL(opts.Endpoint)
+		})
+	}
 	return &Client{
-		c:      s3.NewFromConfig(cfg),
+		c:      s3.NewFromConfig(cfg, optFns...),
 		bucket: bucket,
+		opts:   opts,
 	}, nil
 }
 
-// ListObjects lists objects with prefix in their names.
-func (c *Client) ListObjects(ctx context.Context, prefix string) ([]types.Object, error) {
-	var ret []types.Object
+// List lists objects with prefix in their names.
+func (c *Client) List(ctx context.Context, prefix string) ([]storage.Object, error) {
+	var ret []storage.Object
 	paginator := s3.NewListObjectsV2Paginator(c.c, &s3.ListObjectsV2Input{
 		Bucket: &c.bucket,
 		Prefix: &prefix,
@@ -63,14 +91,20 @@ func (c *Client) ListObjects(ctx context.Context, prefix string) ([]types.Object
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to load the next page of S3 objects")
 		}
-		ret = append(ret, output.Contents...)
+		for _, object := range output.Contents {
+			ret = append(ret, storage.Object{
+				Path:         aws.ToString(object.Key),
+				Size:         object.Size,
+				LastModified: aws.ToTime(object.LastModified),
+			})
+		}
 	}
 	return ret, nil
 }
 
-// DownloadObject downloads the object with path.
+// Download downloads the object with path.
 // Defaults to multipart download with chunk size 5MB.
-func (c *Client) DownloadObject(ctx context.Context, path string, w io.WriterAt) (int64, error) {
+func (c *Client) Download(ctx context.Context, path string, w io.WriterAt) (int64, error) {
 	downloader := manager.NewDownloader(c.c)
 	return downloader.Download(ctx, w, &s3.GetObjectInput{
 		Bucket: &c.bucket,
@@ -78,50 +112,79 @@ func (c *Client) DownloadObject(ctx context.Context, path string, w io.WriterAt)
 	})
 }
 
-// UploadObject uploads an object with the path.
+// ReadRange reads the object with path in the range [offset, offset+length).
+func (c *Client) ReadRange(ctx context.Context, path string, offset, length int64) (io.ReadCloser, error) {
+	if length == 0 {
+		return io.NopCloser(strings.NewReader("")), nil
+	}
+	objectRange := fmt.Sprintf("bytes=%d-", offset)
+	if length > 0 {
+		objectRange = fmt.Sprintf("bytes=%d-%d", offset, offset+length-1)
+	}
+	output, err := c.c.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: &c.bucket,
+		Key:    &path,
+		Range:  &objectRange,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get object %q in range %q", path, objectRange)
+	}
+	return output.Body, nil
+}
+
+// Upload uploads an object with the path.
 // Defaults to multipart upload with chunk size 5MB.
-func (c *Client) UploadObject(ctx context.Context, path string, body io.Reader) (*manager.UploadOutput, error) {
+func (c *Client) Upload(ctx context.Context, path string, body io.Reader) error {
 	uploader := manager.NewUploader(c.c)
-	return uploader.Upload(ctx, &s3.PutObjectInput{
-		Bucket:            &c.bucket,
-		Key:               &path,
-		Body:              body,
-		ChecksumAlgorithm: types.ChecksumAlgorithmSha256,
-	})
+	input := &s3.PutObjectInput{
+		Bucket: &c.bucket,
+		Key:    &path,
+		Body:   body,
+	}
+	if !c.opts.DisableChecksum {
+		input.ChecksumAlgorithm = types.ChecksumAlgorithmSha256
+	}
+	_, err := uploader.Upload(ctx, input)
+	return err
 }
 
-// DeleteObjects deletes the objects with path.
-func (c *Client) DeleteObjects(ctx context.Context, pathList ...string) (*s3.DeleteObjectsOutput, error) {
+// Delete deletes the objects with path.
+func (c *Client) Delete(ctx context.Context, pathList ...string) error {
+	if len(pathList) == 0 {
+		return nil
+	}
+	if c.opts.DisableBatchDelete {
+		for _, path := range pathList {
+			path := path // create a new 'path'.
+			if _, err := c.c.DeleteObject(ctx, &s3.DeleteObjectInput{
+				Bucket: &c.bucket,
+				Key:    &path,
+			}); err != nil {
+				return errors.Wrapf(err, "failed to delete object %q", path)
+			}
+		}
+		return nil
+	}
 	var oidList []types.ObjectIdentifier
 	for _, path := range pathList {
 		path := path // create a new 'path'.
 		oidList = append(oidList, types.ObjectIdentifier{Key: &path})
 	}
-	return c.c.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+	output, err := c.c.DeleteObjects(ctx, &s3.DeleteObjectsInput{
 		Bucket: &c.bucket,
 		Delete: &types.Delete{Objects: oidList},
 	})
+	if err != nil {
+		return err
+	}
+	if len(output.Errors) > 0 {
+		e := output.Errors[0]
+		return errors.Errorf("failed to delete %d objects, the first error is %q for object %q", len(output.Errors), aws.ToString(e.Message), aws.ToString(e.Key))
+	}
+	return nil
 }
 
 // GetBucket returns the bucket.
 func (c *Client) GetBucket() string {
 	return c.bucket
 }
-
-// DownloadFileFromCloud downloads a binlog or metadata file from the cloud storage.
-// In case of network errors which will get partially downloaded files, we first download to a temporary file.
-// After that, we then rename it to the target file path.
-func (c *Client) DownloadFileFromCloud(ctx context.Context, filePathLocal, filePathOnCloud string) error {
-	filePathTemp := filePathLocal + ".tmp"
-	fileTemp, err := os.Create(filePathTemp)
-	if err != nil {
-		return errors.Wrapf(err, "failed to create the local temporary file %s", filePathTemp)
-	}
-	if _, err := c.DownloadObject(ctx, filePathOnCloud, fileTemp); err != nil {
-		return errors.Wrapf(err, "failed to download file %q from the cloud storage", filePathOnCloud)
-	}
-	if err := os.Rename(filePathTemp, filePathLocal); err != nil {
-		return errors.Wrapf(err, "failed to rename %q to %q", filePathTemp, filePathLocal)
-	}
-	return nil
-}
@@ -3,6 +3,7 @@ package s3
 import (
 	"bytes"
 	"context"
+	"io"
 	"os"
 	"testing"
 
@@ -34,33 +35,40 @@ func TestS3Operations(t *testing.T) {
 	client, err := NewClient(ctx, region, bucket, credentials)
 	a.NoError(err)
 
-	t.Run("ListObjects", func(t *testing.T) {
-		list, err := client.ListObjects(ctx, "backup/")
+	t.Run("List", func(t *testing.T) {
+		list, err := client.List(ctx, "backup/")
 		a.NoError(err)
 		for _, obj := range list {
-			log.Info("Object", zap.String("Key", *obj.Key), zap.Time("LastModified", *obj.LastModified))
+			log.Info("Object", zap.String("Path", obj.Path), zap.Time("LastModified", obj.LastModified))
 		}
 	})
 
-	t.Run("UploadObjects", func(t *testing.T) {
+	t.Run("Upload", func(t *testing.T) {
 		buf := make([]byte, 10*1024*1024)
 		blob := bytes.NewReader(buf)
-		resp, err := client.UploadObject(ctx, "backup/test/blob", blob)
+		err := client.Upload(ctx, "backup/test/blob", blob)
 		a.NoError(err)
-		log.Info("Uploaded", zap.String("name", *resp.Key))
 	})
 
-	t.Run("DownloadObjects", func(t *testing.T) {
+	t.Run("Download", func(t *testing.T) {
 		file, err := os.CreateTemp(t.TempDir(), "blob")
 		a.NoError(err)
-		n, err := client.DownloadObject(ctx, "backup/test/blob", file)
+		n, err := client.Download(ctx, "backup/test/blob", file)
 		a.NoError(err)
 		log.Info("Downloaded", zap.Int64("length", n))
 	})
 
-	t.Run("DeleteObjects", func(t *testing.T) {
-		resp, err := client.DeleteObjects(ctx, "backup/test/blob")
+	t.Run("ReadRange", func(t *testing.T) {
+		r, err := client.ReadRange(ctx, "backup/test/blob", 1024, 1024)
+		a.NoError(err)
+		defer r.Close()
+		got, err := io.ReadAll(r)
+		a.NoError(err)
+		a.Len(got, 1024)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		err := client.Delete(ctx, "backup/test/blob")
 		a.NoError(err)
-		log.Info("Deleted", zap.Any("meta", resp.ResultMetadata))
 	})
 }
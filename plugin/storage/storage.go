@@ -0,0 +1,57 @@
+// Package storage provides the interface of the storage for backups and binlogs.
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Object is the metadata of an object in the storage.
+type Object struct {
+	// Path is the path of the object which always uses / as the separator.
+	Path         string
+	Size         int64
+	LastModified time.Time
+}
+
+// Backend is the interface of the storage for backups and binlogs, such as AWS S3, GCS, OSS and the local file system.
+// All paths use / as the separator.
+type Backend interface {
+	// Upload uploads the content of body as the object with path, it overwrites the existing object.
+	Upload(ctx context.Context, path string, body io.Reader) error
+	// Download downloads the object with path to w, and returns the number of bytes downloaded.
+	Download(ctx context.Context, path string, w io.WriterAt) (int64, error)
+	// ReadRange returns the content of the object with path in the range [offset, offset+length).
+	// The content is read until the end of the object if length is negative. The caller must close the reader.
+	ReadRange(ctx context.Context, path string, offset, length int64) (io.ReadCloser, error)
+	// List lists the objects whose paths have the prefix.
+	List(ctx context.Context, prefix string) ([]Object, error)
+	// Delete deletes the objects with paths. It's not an error if an object doesn't exist.
+	Delete(ctx context.Context, pathList ...string) error
+}
+
+// DownloadFile downloads the object with path to the local file.
+// In case of network errors which will get partially downloaded files, we first download to a temporary file.
+// After that, we then rename it to the target file path.
+func DownloadFile(ctx context.Context, backend Backend, filePathLocal, path string) error {
+	filePathTemp := filePathLocal + ".tmp"
+	fileTemp, err := os.Create(filePathTemp)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create the local temporary file %s", filePathTemp)
+	}
+	defer fileTemp.Close()
+	if _, err := backend.Download(ctx, path, fileTemp); err != nil {
+		return errors.Wrapf(err, "failed to download file %q from the storage", path)
+	}
+	if err := fileTemp.Close(); err != nil {
+		return errors.Wrapf(err, "failed to close the local temporary file %s", filePathTemp)
+	}
+	if err := os.Rename(filePathTemp, filePathLocal); err != nil {
+		return errors.Wrapf(err, "failed to rename %q to %q", filePathTemp, filePathLocal)
+	}
+	return nil
+}
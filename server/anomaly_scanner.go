@@ -15,6 +15,8 @@ import (
 	"github.com/bytebase/bytebase/common"
 	"github.com/bytebase/bytebase/common/log"
 	"github.com/bytebase/bytebase/plugin/db"
+	"github.com/bytebase/bytebase/plugin/parser"
+	"github.com/bytebase/bytebase/plugin/parser/differ"
 )
 
 const (
@@ -324,6 +326,17 @@ func (s *AnomalyScanner) checkDatabaseAnomaly(ctx context.Context, instance *api
 					Expect:  list[0].Schema,
 					Actual:  schemaBuf.String(),
 				}
+				// The diff is best-effort, users can still compare the schemas if the differ doesn't support the engine.
+				diff, revertStatement, err := getSchemaDriftDiff(instance.Engine, anomalyPayload.Expect, anomalyPayload.Actual)
+				if err != nil {
+					log.Warn("Failed to compute schema drift diff",
+						zap.String("instance", instance.Name),
+						zap.String("database", database.Name),
+						zap.Error(err))
+				} else {
+					anomalyPayload.Diff = diff
+					anomalyPayload.RevertStatement = revertStatement
+				}
 				payload, err := json.Marshal(anomalyPayload)
 				if err != nil {
 					log.Error("Failed to marshal anomaly payload",
@@ -523,4 +536,98 @@ func (s *AnomalyScanner) checkBackupAnomaly(ctx context.Context, instance *api.I
 			}
 		}
 	}
+
+	// Check backup verification failure
+	{
+		var backupVerificationFailurePayload *api.AnomalyDatabaseBackupVerificationFailurePayload
+		// The anomaly fires if the latest verified backup fails the verification.
+		status := api.BackupStatusDone
+		backupFind := &api.BackupFind{
+			DatabaseID: &database.ID,
+			Status:     &status,
+		}
+		backupList, err := s.server.store.FindBackup(ctx, backupFind)
+		if err != nil {
+			log.Error("Failed to retrieve backup list",
+				zap.String("instance", instance.Name),
+				zap.String("database", database.Name),
+				zap.Error(err))
+		}
+		for _, backup := range backupList {
+			verification := backup.Payload.Verification
+			if verification == nil {
+				continue
+			}
+			if verification.Status == api.BackupVerificationStatusFailed {
+				backupVerificationFailurePayload = &api.AnomalyDatabaseBackupVerificationFailurePayload{
+					BackupID:   backup.ID,
+					BackupName: backup.Name,
+					VerifiedTs: verification.VerifiedTs,
+					Detail:     verification.Detail,
+				}
+			}
+			break
+		}
+
+		if backupVerificationFailurePayload != nil {
+			payload, err := json.Marshal(*backupVerificationFailurePayload)
+			if err != nil {
+				log.Error("Failed to marshal anomaly payload",
+					zap.String("instance", instance.Name),
+					zap.String("database", database.Name),
+					zap.String("type", string(api.AnomalyDatabaseBackupVerificationFailure)),
+					zap.Error(err))
+			} else {
+				if _, err = s.server.store.UpsertActiveAnomaly(ctx, &api.AnomalyUpsert{
+					CreatorID:  api.SystemBotID,
+					InstanceID: instance.ID,
+					DatabaseID: &database.ID,
+					Type:       api.AnomalyDatabaseBackupVerificationFailure,
+					Payload:    string(payload),
+				}); err != nil {
+					log.Error("Failed to create anomaly",
+						zap.String("instance", instance.Name),
+						zap.String("database", database.Name),
+						zap.String("type", string(api.AnomalyDatabaseBackupVerificationFailure)),
+						zap.Error(err))
+				}
+			}
+		} else {
+			err := s.server.store.ArchiveAnomaly(ctx, &api.AnomalyArchive{
+				DatabaseID: &database.ID,
+				Type:       api.AnomalyDatabaseBackupVerificationFailure,
+			})
+			if err != nil && common.ErrorCode(err) != common.NotFound {
+				log.Error("Failed to close anomaly",
+					zap.String("instance", instance.Name),
+					zap.String("database", database.Name),
+					zap.String("type", string(api.AnomalyDatabaseBackupVerificationFailure)),
+					zap.Error(err))
+			}
+		}
+	}
+}
+
+// getSchemaDriftDiff returns the DDL turning the expected schema into the actual one, and the DDL reverting it.
+func getSchemaDriftDiff(engineType db.Type, expect, actual string) (string, string, error) {
+	var engine parser.EngineType
+	switch engineType {
+	case db.Postgres:
+		engine = parser.Postgres
+	case db.MySQL, db.TiDB:
+		// TiDB is compatible with the MySQL schema.
+		engine = parser.MySQL
+	default:
+		return "", "", errors.Errorf("unsupported database engine %q", engineType)
+	}
+
+	diff, err := differ.SchemaDiff(engine, expect, actual)
+	if err != nil {
+		return "", "", errors.Wrap(err, "compute schema drift diff")
+	}
+	revertStatement, err := differ.SchemaDiff(engine, actual, expect)
+	if err != nil {
+		return "", "", errors.Wrap(err, "compute schema drift revert statement")
+	}
+	return diff, revertStatement, nil
 }
@@ -0,0 +1,29 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/plugin/db"
+
+	// Register the MySQL schema differ.
+	_ "github.com/bytebase/bytebase/plugin/parser/differ/mysql"
+)
+
+func TestGetSchemaDriftDiff(t *testing.T) {
+	expect := "CREATE TABLE `t` (\n  `id` int NOT NULL,\n  PRIMARY KEY (`id`)\n);\n"
+	actual := "CREATE TABLE `t` (\n  `id` int NOT NULL,\n  `name` varchar(255) DEFAULT NULL,\n  PRIMARY KEY (`id`)\n);\n"
+
+	diff, revertStatement, err := getSchemaDriftDiff(db.MySQL, expect, actual)
+	require.NoError(t, err)
+	require.Equal(t, "SET FOREIGN_KEY_CHECKS=0;\nALTER TABLE `t` ADD COLUMN `name` VARCHAR(255) DEFAULT NULL AFTER `id`;\nSET FOREIGN_KEY_CHECKS=1;\n", diff)
+	require.Equal(t, "SET FOREIGN_KEY_CHECKS=0;\nALTER TABLE `t` DROP COLUMN `name`;\nSET FOREIGN_KEY_CHECKS=1;\n", revertStatement)
+
+	tidbDiff, _, err := getSchemaDriftDiff(db.TiDB, expect, actual)
+	require.NoError(t, err)
+	require.Equal(t, diff, tidbDiff)
+
+	_, _, err = getSchemaDriftDiff(db.SQLite, expect, actual)
+	require.Error(t, err)
+}
@@ -6,8 +6,6 @@ import (
 	"fmt"
 	"math"
 	"net/http"
-	"os"
-	"path"
 	"sync"
 	"time"
 
@@ -19,6 +17,11 @@ import (
 	"github.com/bytebase/bytebase/common/log"
 	"github.com/bytebase/bytebase/plugin/db"
 	"github.com/bytebase/bytebase/plugin/db/mysql"
+	"github.com/bytebase/bytebase/plugin/storage"
+	"github.com/bytebase/bytebase/plugin/storage/gcs"
+	"github.com/bytebase/bytebase/plugin/storage/local"
+	"github.com/bytebase/bytebase/plugin/storage/oss"
+	s3bb "github.com/bytebase/bytebase/plugin/storage/s3"
 )
 
 // NewBackupRunner creates a new backup runner.
@@ -146,64 +149,32 @@ func (r *BackupRunner) getMaxRetentionPeriodTsForMySQLInstance(ctx context.Conte
 	return maxRetentionPeriodTs, nil
 }
 
+// purgeBinlogFiles deletes the expired binlog files and their metadata files in the storage.
 func (r *BackupRunner) purgeBinlogFiles(ctx context.Context, instanceID, retentionPeriodTs int) error {
-	binlogDir := getBinlogAbsDir(r.server.profile.DataDir, instanceID)
-	switch r.server.profile.BackupStorageBackend {
-	case api.BackupStorageBackendLocal:
-		return r.purgeBinlogFilesLocal(binlogDir, retentionPeriodTs)
-	case api.BackupStorageBackendS3:
-		return r.purgeBinlogFilesOnCloud(ctx, binlogDir, retentionPeriodTs)
-	default:
-		return errors.Errorf("purge binlog files not implemented for storage backend %s", r.server.profile.BackupStorageBackend)
+	backupStorage, err := r.server.getBackupStorage(r.server.profile.BackupStorageBackend)
+	if err != nil {
+		return errors.Wrap(err, "failed to get the backup storage")
 	}
-}
-
-func (r *BackupRunner) purgeBinlogFilesOnCloud(ctx context.Context, binlogDir string, retentionPeriodTs int) error {
-	binlogDirOnCloud := common.GetBinlogRelativeDir(binlogDir)
-	listOutput, err := r.server.s3Client.ListObjects(ctx, binlogDirOnCloud)
+	// Append a trailing / so that the binlog files of other instances with the same ID prefix are not listed.
+	binlogDir := common.GetBinlogRelativeDir(getBinlogAbsDir(r.server.profile.DataDir, instanceID)) + "/"
+	objectList, err := backupStorage.List(ctx, binlogDir)
 	if err != nil {
-		return errors.Wrapf(err, "failed to list binlog dir %q in the cloud storage", binlogDirOnCloud)
+		return errors.Wrapf(err, "failed to list binlog dir %q in the storage", binlogDir)
 	}
 	var purgeBinlogPathList []string
-	for _, item := range listOutput {
-		expireTime := item.LastModified.Add(time.Duration(retentionPeriodTs) * time.Second)
+	for _, object := range objectList {
+		// For local binlog files, we use the modification time which is later than the modification time of that on the MySQL server,
+		// which in turn is later than the last event timestamp of the binlog file.
+		// This is not accurate and gives about 10 minutes (backup runner interval) more retention time to the binlog files, which is acceptable.
+		expireTime := object.LastModified.Add(time.Duration(retentionPeriodTs) * time.Second)
 		if time.Now().After(expireTime) {
-			purgeBinlogPathList = append(purgeBinlogPathList, *item.Key)
+			purgeBinlogPathList = append(purgeBinlogPathList, object.Path)
 		}
 	}
 	if len(purgeBinlogPathList) > 0 {
-		log.Debug(fmt.Sprintf("Deleting %d expired binlog files from the cloud storage.", len(purgeBinlogPathList)))
-		if _, err := r.server.s3Client.DeleteObjects(ctx, purgeBinlogPathList...); err != nil {
-			return errors.Wrapf(err, "failed to delete %d expired binlog files from the cloud storage", len(purgeBinlogPathList))
-		}
-	}
-	return nil
-}
-
-// TODO(dragonly): Remove metadata as well.
-func (*BackupRunner) purgeBinlogFilesLocal(binlogDir string, retentionPeriodTs int) error {
-	binlogFileInfoList, err := os.ReadDir(binlogDir)
-	if err != nil {
-		return errors.Wrapf(err, "failed to read backup directory %q", binlogDir)
-	}
-	for _, binlogFileInfo := range binlogFileInfoList {
-		// We use modification time of local binlog files which is later than the modification time of that on the MySQL server,
-		// which in turn is later than the last event timestamp of the binlog file.
-		// This is not accurate and gives about 10 minutes (backup runner interval) more retention time to the binlog files, which is acceptable.
-		fileInfo, err := binlogFileInfo.Info()
-		if err != nil {
-			log.Warn("Failed to get file info.", zap.String("path", binlogFileInfo.Name()), zap.Error(err))
-			continue
-		}
-		expireTime := fileInfo.ModTime().Add(time.Duration(retentionPeriodTs) * time.Second)
-		if time.Now().After(expireTime) {
-			binlogFilePath := path.Join(binlogDir, binlogFileInfo.Name())
-			log.Debug("Deleting expired local binlog file for MySQL instance.", zap.String("path", binlogFilePath))
-			if err := os.Remove(binlogFilePath); err != nil {
-				log.Warn("Failed to remove an expired binlog file.", zap.String("path", binlogFilePath), zap.Error(err))
-				continue
-			}
-			log.Info("Deleted expired binlog file.", zap.String("path", binlogFilePath))
+		log.Debug(fmt.Sprintf("Deleting %d expired binlog files from the storage.", len(purgeBinlogPathList)), zap.String("storageBackend", string(r.server.profile.BackupStorageBackend)))
+		if err := backupStorage.Delete(ctx, purgeBinlogPathList...); err != nil {
+			return errors.Wrapf(err, "failed to delete %d expired binlog files from the storage", len(purgeBinlogPathList))
 		}
 	}
 	return nil
@@ -221,21 +192,14 @@ func (r *BackupRunner) purgeBackup(ctx context.Context, backup *api.Backup) erro
 	}
 	log.Debug("Archived expired backup record", zap.String("name", backup.Name), zap.Int("id", backup.ID))
 
-	switch backup.StorageBackend {
-	case api.BackupStorageBackendLocal:
-		backupFilePath := getBackupAbsFilePath(r.server.profile.DataDir, backup.DatabaseID, backup.Name)
-		if err := os.Remove(backupFilePath); err != nil {
-			return errors.Wrapf(err, "failed to delete an expired backup file %q", backupFilePath)
-		}
-		log.Debug(fmt.Sprintf("Deleted expired local backup file %s", backupFilePath))
-	case api.BackupStorageBackendS3:
-		backupFilePath := getBackupRelativeFilePath(backup.DatabaseID, backup.Name)
-		if _, err := r.server.s3Client.DeleteObjects(ctx, backupFilePath); err != nil {
-			return errors.Wrapf(err, "failed to delete backup file %s in the cloud storage", backupFilePath)
-		}
-		log.Debug(fmt.Sprintf("Deleted expired backup file %s in the cloud storage", backupFilePath))
+	backupStorage, err := r.server.getBackupStorage(backup.StorageBackend)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get the storage of backup %q", backup.Name)
 	}
-
+	if err := backupStorage.Delete(ctx, backup.Path); err != nil {
+		return errors.Wrapf(err, "failed to delete an expired backup file %q in the %s storage", backup.Path, backup.StorageBackend)
+	}
+	log.Debug(fmt.Sprintf("Deleted expired backup file %s in the %s storage", backup.Path, backup.StorageBackend))
 	return nil
 }
 
@@ -275,12 +239,12 @@ func (r *BackupRunner) downloadBinlogFilesForInstance(ctx context.Context, insta
 	}
 	defer driver.Close(ctx)
 
-	mysqlDriver, ok := driver.(*mysql.Driver)
+	mysqlDriver, ok := unwrapDriver(driver).(*mysql.Driver)
 	if !ok {
 		log.Error("Failed to cast driver to mysql.Driver", zap.String("instance", instance.Name))
 		return
 	}
-	if err := mysqlDriver.FetchAllBinlogFiles(ctx, false /* downloadLatestBinlogFile */, r.server.s3Client); err != nil {
+	if err := mysqlDriver.FetchAllBinlogFiles(ctx, false /* downloadLatestBinlogFile */, r.server.backupStorage); err != nil {
 		log.Error("Failed to download all binlog files for instance", zap.String("instance", instance.Name), zap.Error(err))
 		return
 	}
@@ -435,3 +399,33 @@ func (s *Server) scheduleBackupTask(ctx context.Context, database *api.Database,
 	}
 	return backupNew, nil
 }
+
+// newBackupStorage creates the client of the cloud storage for backups from the profile.
+func newBackupStorage(ctx context.Context, prof Profile) (storage.Backend, error) {
+	credentials, err := s3bb.GetCredentialsFromFile(ctx, prof.BackupCredentialFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get credentials from file")
+	}
+	switch prof.BackupStorageBackend {
+	case api.BackupStorageBackendS3:
+		return s3bb.NewClient(ctx, prof.BackupRegion, prof.BackupBucket, credentials)
+	case api.BackupStorageBackendGCS:
+		return gcs.NewClient(ctx, prof.BackupBucket, credentials)
+	case api.BackupStorageBackendOSS:
+		return oss.NewClient(ctx, prof.BackupRegion, prof.BackupBucket, credentials)
+	default:
+		return nil, errors.Errorf("unsupported backup storage backend %s", prof.BackupStorageBackend)
+	}
+}
+
+// getBackupStorage returns the storage of the backups and binlogs stored in the storage backend.
+// The local storage is rooted at the data directory, so the objects have the same relative paths as those on the cloud storage.
+func (s *Server) getBackupStorage(backend api.BackupStorageBackend) (storage.Backend, error) {
+	if backend == api.BackupStorageBackendLocal {
+		return local.NewStorage(s.profile.DataDir), nil
+	}
+	if backend != s.profile.BackupStorageBackend || s.backupStorage == nil {
+		return nil, errors.Errorf("storage backend %s is not configured", backend)
+	}
+	return s.backupStorage, nil
+}
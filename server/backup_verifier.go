@@ -0,0 +1,270 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/common/log"
+	"github.com/bytebase/bytebase/plugin/db"
+	"github.com/bytebase/bytebase/plugin/db/util"
+)
+
+// NewBackupVerifier creates a new backup verifier.
+func NewBackupVerifier(server *Server, interval time.Duration) *BackupVerifier {
+	return &BackupVerifier{
+		server:   server,
+		interval: interval,
+	}
+}
+
+// BackupVerifier is the backup verifier which restores the latest backup of each database into a scratch database
+// and checks the restored database, so that unrestorable backups are found before they are needed.
+type BackupVerifier struct {
+	server   *Server
+	interval time.Duration
+}
+
+// Run is the runner for backup verifier.
+func (v *BackupVerifier) Run(ctx context.Context, wg *sync.WaitGroup) {
+	ticker := time.NewTicker(v.interval)
+	defer ticker.Stop()
+	defer wg.Done()
+	log.Debug("Backup verifier started", zap.Duration("interval", v.interval))
+	for {
+		select {
+		case <-ticker.C:
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						err, ok := r.(error)
+						if !ok {
+							err = errors.Errorf("%v", r)
+						}
+						log.Error("Backup verifier PANIC RECOVER", zap.Error(err), zap.Stack("panic-stack"))
+					}
+				}()
+				v.verifyLatestBackups(ctx)
+			}()
+		case <-ctx.Done(): // if cancel() execute
+			return
+		}
+	}
+}
+
+// verifyLatestBackups verifies the latest backup of each database if it hasn't been verified.
+func (v *BackupVerifier) verifyLatestBackups(ctx context.Context) {
+	rowStatus := api.Normal
+	databaseList, err := v.server.store.FindDatabase(ctx, &api.DatabaseFind{})
+	if err != nil {
+		log.Error("Failed to retrieve database list", zap.Error(err))
+		return
+	}
+	for _, database := range databaseList {
+		if ctx.Err() != nil {
+			return
+		}
+		instance := database.Instance
+		if instance.RowStatus != rowStatus || !isBackupVerificationSupported(instance.Engine) {
+			continue
+		}
+		status := api.BackupStatusDone
+		backupList, err := v.server.store.FindBackup(ctx, &api.BackupFind{
+			DatabaseID: &database.ID,
+			RowStatus:  &rowStatus,
+			Status:     &status,
+		})
+		if err != nil {
+			log.Error("Failed to retrieve backup list",
+				zap.String("instance", instance.Name),
+				zap.String("database", database.Name),
+				zap.Error(err))
+			continue
+		}
+		if len(backupList) == 0 || backupList[0].Payload.Verification != nil {
+			continue
+		}
+		backup := backupList[0]
+
+		log.Debug("Verifying backup", zap.String("instance", instance.Name), zap.String("database", database.Name), zap.String("backup", backup.Name))
+		verification := v.verifyBackup(ctx, database, backup)
+		if verification.Status == api.BackupVerificationStatusFailed {
+			log.Warn("Backup verification failed",
+				zap.String("instance", instance.Name),
+				zap.String("database", database.Name),
+				zap.String("backup", backup.Name),
+				zap.String("detail", verification.Detail))
+		}
+		if err := v.patchBackupVerification(ctx, backup, verification); err != nil {
+			log.Error("Failed to record backup verification",
+				zap.String("instance", instance.Name),
+				zap.String("database", database.Name),
+				zap.String("backup", backup.Name),
+				zap.Error(err))
+		}
+	}
+}
+
+func (v *BackupVerifier) patchBackupVerification(ctx context.Context, backup *api.Backup, verification *api.BackupVerification) error {
+	payload := backup.Payload
+	payload.Verification = verification
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal backup payload %+v", payload)
+	}
+	payloadString := string(payloadBytes)
+	if _, err := v.server.store.PatchBackup(ctx, &api.BackupPatch{
+		ID:        backup.ID,
+		UpdaterID: api.SystemBotID,
+		Payload:   &payloadString,
+	}); err != nil {
+		return errors.Wrapf(err, "failed to patch backup %q", backup.Name)
+	}
+	return nil
+}
+
+// verifyBackup restores the backup into a scratch database and checks the restored tables and rows.
+func (v *BackupVerifier) verifyBackup(ctx context.Context, database *api.Database, backup *api.Backup) *api.BackupVerification {
+	instance := v.getVerificationInstance(ctx, database.Instance)
+	verification := &api.BackupVerification{
+		Status:     api.BackupVerificationStatusFailed,
+		VerifiedTs: time.Now().Unix(),
+		InstanceID: instance.ID,
+	}
+	tableCount, rowCount, err := v.restoreAndCount(ctx, instance, database.Name, backup)
+	if err != nil {
+		verification.Detail = err.Error()
+		return verification
+	}
+	verification.TableCount = tableCount
+	verification.RowCount = rowCount
+
+	// A backup restoring no tables is broken unless the database has no tables either.
+	sourceTableList, err := v.server.store.FindTable(ctx, &api.TableFind{DatabaseID: &database.ID})
+	if err != nil {
+		verification.Detail = fmt.Sprintf("failed to find tables of database %q: %v", database.Name, err)
+		return verification
+	}
+	if tableCount == 0 && len(sourceTableList) > 0 {
+		verification.Detail = fmt.Sprintf("the backup restores no tables while the database has %d tables", len(sourceTableList))
+		return verification
+	}
+
+	verification.Status = api.BackupVerificationStatusPassed
+	return verification
+}
+
+// getVerificationInstance returns the designated verification instance if it has the same engine as the instance, otherwise the instance itself.
+func (v *BackupVerifier) getVerificationInstance(ctx context.Context, instance *api.Instance) *api.Instance {
+	instanceID := v.server.profile.BackupVerificationInstanceID
+	if instanceID == 0 || instanceID == instance.ID {
+		return instance
+	}
+	verificationInstance, err := v.server.store.GetInstanceByID(ctx, instanceID)
+	if err != nil {
+		log.Error("Failed to find the backup verification instance", zap.Int("instanceID", instanceID), zap.Error(err))
+		return instance
+	}
+	if verificationInstance == nil || verificationInstance.RowStatus != api.Normal || verificationInstance.Engine != instance.Engine {
+		return instance
+	}
+	return verificationInstance
+}
+
+// restoreAndCount restores the backup into a scratch database on the instance, and returns the number of tables and rows in it.
+// The scratch database is always dropped afterwards.
+func (v *BackupVerifier) restoreAndCount(ctx context.Context, instance *api.Instance, databaseName string, backup *api.Backup) (int, int64, error) {
+	scratchDatabaseName := util.GetBackupVerificationDatabaseName(databaseName, time.Now().Unix())
+	adminDriver, err := v.server.getAdminDatabaseDriver(ctx, instance, "")
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "failed to connect instance %q", instance.Name)
+	}
+	defer adminDriver.Close(ctx)
+	// Postgres cannot create or drop databases without connecting to a database, so we use the bytebase database.
+	adminDatabaseName := ""
+	if instance.Engine == db.Postgres {
+		adminDatabaseName = db.BytebaseDatabase
+	}
+	adminDB, err := adminDriver.GetDBConnection(ctx, adminDatabaseName)
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "failed to connect instance %q", instance.Name)
+	}
+	quotedName := quoteBackupVerificationIdentifier(instance.Engine, scratchDatabaseName)
+	if _, err := adminDB.ExecContext(ctx, fmt.Sprintf("CREATE DATABASE %s", quotedName)); err != nil {
+		return 0, 0, errors.Wrapf(err, "failed to create scratch database %q", scratchDatabaseName)
+	}
+	defer func() {
+		// Use a new context so that the scratch database is dropped even if the server is shutting down.
+		if _, err := adminDB.ExecContext(context.Background(), fmt.Sprintf("DROP DATABASE IF EXISTS %s", quotedName)); err != nil {
+			log.Error("Failed to drop the backup verification scratch database", zap.String("instance", instance.Name), zap.String("database", scratchDatabaseName), zap.Error(err))
+		}
+	}()
+
+	if err := restoreDatabase(ctx, v.server, instance, scratchDatabaseName, backup); err != nil {
+		return 0, 0, errors.Wrapf(err, "failed to restore backup %q", backup.Name)
+	}
+	return v.countTablesAndRows(ctx, instance, scratchDatabaseName)
+}
+
+func (v *BackupVerifier) countTablesAndRows(ctx context.Context, instance *api.Instance, databaseName string) (int, int64, error) {
+	driver, err := v.server.getAdminDatabaseDriver(ctx, instance, databaseName)
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "failed to connect database %q", databaseName)
+	}
+	defer driver.Close(ctx)
+	schema, err := driver.SyncDBSchema(ctx, databaseName)
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "failed to sync schema of the restored database %q", databaseName)
+	}
+	sqldb, err := driver.GetDBConnection(ctx, databaseName)
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "failed to connect database %q", databaseName)
+	}
+	var rowCount int64
+	for _, table := range schema.TableList {
+		// The rows of partitions are counted in their parent tables.
+		if table.PartitionParent != "" {
+			continue
+		}
+		var count int64
+		query := fmt.Sprintf("SELECT COUNT(*) FROM %s", quoteBackupVerificationTableName(instance.Engine, table.Name))
+		if err := sqldb.QueryRowContext(ctx, query).Scan(&count); err != nil {
+			return 0, 0, errors.Wrapf(err, "failed to count rows of the restored table %q", table.Name)
+		}
+		rowCount += count
+	}
+	return len(schema.TableList), rowCount, nil
+}
+
+func isBackupVerificationSupported(engine db.Type) bool {
+	switch engine {
+	case db.MySQL, db.TiDB, db.Postgres:
+		return true
+	default:
+		return false
+	}
+}
+
+func quoteBackupVerificationIdentifier(engine db.Type, name string) string {
+	if engine == db.Postgres {
+		return fmt.Sprintf(`"%s"`, strings.ReplaceAll(name, `"`, `""`))
+	}
+	return fmt.Sprintf("`%s`", strings.ReplaceAll(name, "`", "``"))
+}
+
+// quoteBackupVerificationTableName quotes the table name, which is qualified with the schema as "schema.table" for Postgres.
+func quoteBackupVerificationTableName(engine db.Type, name string) string {
+	if engine == db.Postgres {
+		if i := strings.Index(name, "."); i >= 0 {
+			return fmt.Sprintf("%s.%s", quoteBackupVerificationIdentifier(engine, name[:i]), quoteBackupVerificationIdentifier(engine, name[i+1:]))
+		}
+	}
+	return quoteBackupVerificationIdentifier(engine, name)
+}
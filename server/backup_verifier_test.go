@@ -0,0 +1,43 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/plugin/db"
+)
+
+func TestQuoteBackupVerificationTableName(t *testing.T) {
+	tests := []struct {
+		engine db.Type
+		name   string
+		want   string
+	}{
+		{
+			engine: db.MySQL,
+			name:   "t1",
+			want:   "`t1`",
+		},
+		{
+			engine: db.MySQL,
+			name:   "a`b",
+			want:   "`a``b`",
+		},
+		{
+			engine: db.Postgres,
+			name:   "public.t1",
+			want:   `"public"."t1"`,
+		},
+		{
+			engine: db.Postgres,
+			name:   `s."t.1"`,
+			want:   `"s"."""t.1"""`,
+		},
+	}
+
+	a := require.New(t)
+	for _, test := range tests {
+		a.Equal(test.want, quoteBackupVerificationTableName(test.engine, test.name))
+	}
+}
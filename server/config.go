@@ -18,6 +18,8 @@ type config struct {
 	secret string
 	// workspaceID used to initial the identify for a new workspace.
 	workspaceID string
+	// backupEncryptionKey used to encrypt backups.
+	backupEncryptionKey string
 }
 
 // Profile is the configuration to start main server.
@@ -65,6 +67,24 @@ type Profile struct {
 	MetricConnectionKey string
 	// DisableMetric will disable the metric collector.
 	DisableMetric bool
+	// NativePgDump will dump PostgreSQL databases without the pg_dump binary.
+	NativePgDump bool
+	// MySQLDumpParallelism is the number of connections to dump MySQL table data concurrently.
+	MySQLDumpParallelism int
+	// BackupCompression is the compression algorithm of backups, such as GZIP and ZSTD, and empty for no compression.
+	BackupCompression string
+	// BackupEncryption decides whether to encrypt backups with the workspace backup encryption key.
+	BackupEncryption bool
+	// BackupVerificationInterval is the interval for backup verifier, backups are not verified if it's 0.
+	BackupVerificationInterval time.Duration
+	// BackupVerificationInstanceID is the instance to restore backups for verification, and 0 for the instance of the backup.
+	BackupVerificationInstanceID int
+	// MaxDriversPerInstance is the max number of open database drivers per instance, and 0 for unlimited.
+	MaxDriversPerInstance int
+	// DriverIdleTimeout is the duration to keep an idle database driver open for reuse, drivers are not reused if it's 0.
+	DriverIdleTimeout time.Duration
+	// MigrationLockTimeout is the max duration to wait for the migration lock of a database.
+	MigrationLockTimeout time.Duration
 }
 
 func (prof *Profile) useEmbedDB() bool {
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
 	"strconv"
 
 	"github.com/google/jsonapi"
@@ -275,6 +276,16 @@ func (s *Server) registerDatabaseRoutes(g *echo.Group) {
 				return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch index list for database id: %d, table name: %s", id, table.Name)).SetInternal(err)
 			}
 			table.IndexList = indexList
+
+			foreignKeyFind := &api.ForeignKeyFind{
+				DatabaseID: &id,
+				TableID:    &table.ID,
+			}
+			foreignKeyList, err := s.store.FindForeignKey(ctx, foreignKeyFind)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch foreign key list for database id: %d, table name: %s", id, table.Name)).SetInternal(err)
+			}
+			table.ForeignKeyList = foreignKeyList
 		}
 
 		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
@@ -333,6 +344,16 @@ func (s *Server) registerDatabaseRoutes(g *echo.Group) {
 		}
 		table.IndexList = indexList
 
+		foreignKeyFind := &api.ForeignKeyFind{
+			DatabaseID: &id,
+			TableID:    &table.ID,
+		}
+		foreignKeyList, err := s.store.FindForeignKey(ctx, foreignKeyFind)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch foreign key list for database id: %d, table name: %s", id, table.Name)).SetInternal(err)
+		}
+		table.ForeignKeyList = foreignKeyList
+
 		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
 		if err := jsonapi.MarshalPayload(c.Response().Writer, table); err != nil {
 			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to marshal fetch table response: %v", id)).SetInternal(err)
@@ -340,6 +361,29 @@ func (s *Server) registerDatabaseRoutes(g *echo.Group) {
 		return nil
 	})
 
+	g.GET("/database/:databaseID/erd", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		id, err := strconv.Atoi(c.Param("databaseID"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("ID is not a number: %s", c.Param("databaseID"))).SetInternal(err)
+		}
+
+		tableList, err := s.store.FindTable(ctx, &api.TableFind{DatabaseID: &id})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch table list for database id: %d", id)).SetInternal(err)
+		}
+		columnList, err := s.store.FindColumn(ctx, &api.ColumnFind{DatabaseID: &id})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch column list for database id: %d", id)).SetInternal(err)
+		}
+		foreignKeyList, err := s.store.FindForeignKey(ctx, &api.ForeignKeyFind{DatabaseID: &id})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch foreign key list for database id: %d", id)).SetInternal(err)
+		}
+
+		return c.JSON(http.StatusOK, buildERD(tableList, columnList, foreignKeyList))
+	})
+
 	g.GET("/database/:databaseID/view", func(c echo.Context) error {
 		ctx := c.Request().Context()
 		id, err := strconv.Atoi(c.Param("databaseID"))
@@ -384,6 +428,72 @@ func (s *Server) registerDatabaseRoutes(g *echo.Group) {
 		return nil
 	})
 
+	g.GET("/database/:databaseID/function", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		id, err := strconv.Atoi(c.Param("databaseID"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("ID is not a number: %s", c.Param("databaseID"))).SetInternal(err)
+		}
+
+		dbFunctionFind := &api.DBFunctionFind{
+			DatabaseID: &id,
+		}
+		dbFunctionList, err := s.store.FindDBFunction(ctx, dbFunctionFind)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch dbFunction list for database ID: %d", id)).SetInternal(err)
+		}
+
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+		if err := jsonapi.MarshalPayload(c.Response().Writer, dbFunctionList); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to marshal fetch dbFunction list response: %v", id)).SetInternal(err)
+		}
+		return nil
+	})
+
+	g.GET("/database/:databaseID/sequence", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		id, err := strconv.Atoi(c.Param("databaseID"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("ID is not a number: %s", c.Param("databaseID"))).SetInternal(err)
+		}
+
+		dbSequenceFind := &api.DBSequenceFind{
+			DatabaseID: &id,
+		}
+		dbSequenceList, err := s.store.FindDBSequence(ctx, dbSequenceFind)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch dbSequence list for database ID: %d", id)).SetInternal(err)
+		}
+
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+		if err := jsonapi.MarshalPayload(c.Response().Writer, dbSequenceList); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to marshal fetch dbSequence list response: %v", id)).SetInternal(err)
+		}
+		return nil
+	})
+
+	g.GET("/database/:databaseID/trigger", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		id, err := strconv.Atoi(c.Param("databaseID"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("ID is not a number: %s", c.Param("databaseID"))).SetInternal(err)
+		}
+
+		dbTriggerFind := &api.DBTriggerFind{
+			DatabaseID: &id,
+		}
+		dbTriggerList, err := s.store.FindDBTrigger(ctx, dbTriggerFind)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch dbTrigger list for database ID: %d", id)).SetInternal(err)
+		}
+
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+		if err := jsonapi.MarshalPayload(c.Response().Writer, dbTriggerList); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to marshal fetch dbTrigger list response: %v", id)).SetInternal(err)
+		}
+		return nil
+	})
+
 	g.GET("/database/:databaseID/schema", func(c echo.Context) error {
 		ctx := c.Request().Context()
 		id, err := strconv.Atoi(c.Param("databaseID"))
@@ -399,7 +509,7 @@ func (s *Server) registerDatabaseRoutes(g *echo.Group) {
 			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Database not found with ID %d", id))
 		}
 
-		driver, err := tryGetReadOnlyDatabaseDriver(ctx, database.Instance, database.Name)
+		driver, err := s.tryGetReadOnlyDatabaseDriver(ctx, database.Instance, database.Name)
 		if err != nil {
 			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get database driver").SetInternal(err)
 		}
@@ -630,6 +740,11 @@ func (s *Server) registerDatabaseRoutes(g *echo.Group) {
 			}
 		}
 
+		// The SSH tunnel is only available in the dev schema for now.
+		if s.profile.Mode != common.ReleaseModeDev && dataSourceCreate.SSHHost != "" {
+			return echo.NewHTTPError(http.StatusBadRequest, "SSH tunnel is not supported yet")
+		}
+
 		if dataSourceCreate.Type == api.Admin && (dataSourceCreate.HostOverride != "" || dataSourceCreate.PortOverride != "") {
 			return echo.NewHTTPError(http.StatusBadRequest, "Host and port override cannot be set for admin type of data sources.")
 		}
@@ -641,6 +756,8 @@ func (s *Server) registerDatabaseRoutes(g *echo.Group) {
 		if err != nil {
 			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create data source").SetInternal(err)
 		}
+		// Close the cached drivers which fall back to the admin data source.
+		s.DriverManager.Invalidate(database.InstanceID)
 
 		// Refetch the instance to get the updated data source.
 		updatedInstance, err := s.store.GetInstanceByID(ctx, database.InstanceID)
@@ -707,6 +824,11 @@ func (s *Server) registerDatabaseRoutes(g *echo.Group) {
 			}
 		}
 
+		// The SSH tunnel is only available in the dev schema for now.
+		if s.profile.Mode != common.ReleaseModeDev && dataSourcePatch.SSHHost != nil && *dataSourcePatch.SSHHost != "" {
+			return echo.NewHTTPError(http.StatusBadRequest, "SSH tunnel is not supported yet")
+		}
+
 		dataSourcePatch.ID = dataSourceID
 		dataSourcePatch.UpdaterID = c.Get(getPrincipalIDContextKey()).(int)
 
@@ -722,6 +844,8 @@ func (s *Server) registerDatabaseRoutes(g *echo.Group) {
 		if err != nil {
 			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to update data source with ID %d", dataSourceID)).SetInternal(err)
 		}
+		// Close the cached drivers connected with the old credentials.
+		s.DriverManager.Invalidate(database.InstanceID)
 
 		// Refetch the instance to get the updated data source.
 		updatedInstance, err := s.store.GetInstanceByID(ctx, database.InstanceID)
@@ -785,6 +909,7 @@ func (s *Server) registerDatabaseRoutes(g *echo.Group) {
 		}); err != nil {
 			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to delete data source").SetInternal(err)
 		}
+		s.DriverManager.Invalidate(database.InstanceID)
 
 		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
 		c.Response().WriteHeader(http.StatusOK)
@@ -792,6 +917,69 @@ func (s *Server) registerDatabaseRoutes(g *echo.Group) {
 	})
 }
 
+// buildERD builds the entity-relationship diagram from the synced table, column and foreign key metadata.
+// A multi-column foreign key becomes a single relationship with the columns ordered by position.
+func buildERD(tableList []*api.Table, columnList []*api.Column, foreignKeyList []*api.ForeignKey) *api.ERD {
+	sort.SliceStable(columnList, func(i, j int) bool {
+		return columnList[i].TableID < columnList[j].TableID || (columnList[i].TableID == columnList[j].TableID && columnList[i].Position < columnList[j].Position)
+	})
+	tableColumnMap := make(map[int][]string)
+	for _, column := range columnList {
+		tableColumnMap[column.TableID] = append(tableColumnMap[column.TableID], column.Name)
+	}
+
+	erd := &api.ERD{
+		TableList:        []*api.ERDTable{},
+		RelationshipList: []*api.ERDRelationship{},
+	}
+	tableNameMap := make(map[int]string)
+	for _, table := range tableList {
+		tableNameMap[table.ID] = table.Name
+		erd.TableList = append(erd.TableList, &api.ERDTable{
+			Name:       table.Name,
+			ColumnList: tableColumnMap[table.ID],
+		})
+	}
+
+	sort.SliceStable(foreignKeyList, func(i, j int) bool {
+		a, b := foreignKeyList[i], foreignKeyList[j]
+		if a.TableID != b.TableID {
+			return a.TableID < b.TableID
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Position < b.Position
+	})
+	type relationshipKey struct {
+		tableID int
+		name    string
+	}
+	relationshipMap := make(map[relationshipKey]*api.ERDRelationship)
+	for _, foreignKey := range foreignKeyList {
+		tableName, ok := tableNameMap[foreignKey.TableID]
+		if !ok {
+			continue
+		}
+		key := relationshipKey{tableID: foreignKey.TableID, name: foreignKey.Name}
+		relationship, ok := relationshipMap[key]
+		if !ok {
+			relationship = &api.ERDRelationship{
+				Name:      foreignKey.Name,
+				FromTable: tableName,
+				ToTable:   foreignKey.ReferencedTable,
+				OnUpdate:  foreignKey.OnUpdate,
+				OnDelete:  foreignKey.OnDelete,
+			}
+			relationshipMap[key] = relationship
+			erd.RelationshipList = append(erd.RelationshipList, relationship)
+		}
+		relationship.FromColumnList = append(relationship.FromColumnList, foreignKey.Column)
+		relationship.ToColumnList = append(relationship.ToColumnList, foreignKey.ReferencedColumn)
+	}
+	return erd
+}
+
 func (s *Server) setDatabaseLabels(ctx context.Context, labelsJSON string, database *api.Database, project *api.Project, updaterID int, validateOnly bool) error {
 	// NOTE: this is a partially filled DatabaseLabel
 	var labels []*api.DatabaseLabel
@@ -841,20 +1029,24 @@ func (s *Server) setDatabaseLabels(ctx context.Context, labelsJSON string, datab
 }
 
 // Try to get database driver using the instance's admin data source.
-// Upon successful return, caller MUST call driver.Close, otherwise, it will leak the database connection.
+// The driver is managed by the driver manager and may be shared with later callers.
+// Upon successful return, caller MUST call driver.Close to release it, otherwise, it will leak the database connection.
 func (s *Server) getAdminDatabaseDriver(ctx context.Context, instance *api.Instance, databaseName string) (db.Driver, error) {
 	connCfg, err := getConnectionConfig(instance, databaseName)
 	if err != nil {
 		return nil, err
 	}
 
-	driver, err := getDatabaseDriver(
+	driver, err := s.DriverManager.GetDriver(
 		ctx,
+		instance.ID,
 		instance.Engine,
 		db.DriverConfig{
-			PgInstanceDir: s.pgInstance.BaseDir,
-			ResourceDir:   common.GetResourceDir(s.profile.DataDir),
-			BinlogDir:     getBinlogAbsDir(s.profile.DataDir, instance.ID),
+			PgInstanceDir:   s.pgInstance.BaseDir,
+			ResourceDir:     common.GetResourceDir(s.profile.DataDir),
+			BinlogDir:       getBinlogAbsDir(s.profile.DataDir, instance.ID),
+			NativePgDump:    s.profile.NativePgDump,
+			DumpParallelism: s.profile.MySQLDumpParallelism,
 		},
 		connCfg,
 		db.ConnectionContext{
@@ -884,15 +1076,29 @@ func getConnectionConfig(instance *api.Instance, databaseName string) (db.Connec
 			SslCert: adminDataSource.SslCert,
 			SslKey:  adminDataSource.SslKey,
 		},
-		Host:     instance.Host,
-		Port:     instance.Port,
-		Database: databaseName,
+		Host:      instance.Host,
+		Port:      instance.Port,
+		Database:  databaseName,
+		SSHConfig: getSSHConfig(adminDataSource),
 	}, nil
 }
 
+// getSSHConfig returns the SSH tunnel config of the data source.
+func getSSHConfig(dataSource *api.DataSource) db.SSHConfig {
+	return db.SSHConfig{
+		Host:       dataSource.SSHHost,
+		Port:       dataSource.SSHPort,
+		User:       dataSource.SSHUser,
+		Password:   dataSource.SSHPassword,
+		PrivateKey: dataSource.SSHPrivateKey,
+		HostKey:    dataSource.SSHHostKey,
+	}
+}
+
 // We'd like to use read-only data source whenever possible, but fallback to admin data source if there's no read-only data source.
-// Upon successful return, caller MUST call driver.Close, otherwise, it will leak the database connection.
-func tryGetReadOnlyDatabaseDriver(ctx context.Context, instance *api.Instance, databaseName string) (db.Driver, error) {
+// The driver is managed by the driver manager and may be shared with later callers.
+// Upon successful return, caller MUST call driver.Close to release it, otherwise, it will leak the database connection.
+func (s *Server) tryGetReadOnlyDatabaseDriver(ctx context.Context, instance *api.Instance, databaseName string) (db.Driver, error) {
 	dataSource := api.DataSourceFromInstanceWithType(instance, api.RO)
 	// If there are no read-only data source, fall back to admin data source.
 	if dataSource == nil {
@@ -906,8 +1112,9 @@ func tryGetReadOnlyDatabaseDriver(ctx context.Context, instance *api.Instance, d
 	if dataSource.HostOverride != "" || dataSource.PortOverride != "" {
 		host, port = dataSource.HostOverride, dataSource.PortOverride
 	}
-	driver, err := getDatabaseDriver(
+	driver, err := s.DriverManager.GetDriver(
 		ctx,
+		instance.ID,
 		instance.Engine,
 		// We don't need postgres installation for query.
 		db.DriverConfig{},
@@ -922,7 +1129,8 @@ func tryGetReadOnlyDatabaseDriver(ctx context.Context, instance *api.Instance, d
 				SslCert: dataSource.SslCert,
 				SslKey:  dataSource.SslKey,
 			},
-			ReadOnly: true,
+			ReadOnly:  true,
+			SSHConfig: getSSHConfig(dataSource),
 		},
 		db.ConnectionContext{
 			EnvironmentName: instance.Environment.Name,
@@ -0,0 +1,315 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/common/log"
+	"github.com/bytebase/bytebase/plugin/db"
+)
+
+// allPrivileges is the privilege including all the privileges allowed to grant.
+const allPrivileges = "ALL PRIVILEGES"
+
+// mysqlGrantPrivileges is the database level privileges of MySQL and TiDB.
+// https://dev.mysql.com/doc/refman/8.0/en/privileges-provided.html
+var mysqlGrantPrivileges = map[string]bool{
+	"ALL PRIVILEGES":          true,
+	"ALTER":                   true,
+	"ALTER ROUTINE":           true,
+	"CREATE":                  true,
+	"CREATE ROUTINE":          true,
+	"CREATE TEMPORARY TABLES": true,
+	"CREATE VIEW":             true,
+	"DELETE":                  true,
+	"DROP":                    true,
+	"EVENT":                   true,
+	"EXECUTE":                 true,
+	"INDEX":                   true,
+	"INSERT":                  true,
+	"LOCK TABLES":             true,
+	"REFERENCES":              true,
+	"SELECT":                  true,
+	"SHOW VIEW":               true,
+	"TRIGGER":                 true,
+	"UPDATE":                  true,
+}
+
+// pgGrantPrivileges is the table privileges of Postgres, which are granted on all tables of the database.
+// https://www.postgresql.org/docs/current/ddl-priv.html
+var pgGrantPrivileges = map[string]bool{
+	"ALL PRIVILEGES": true,
+	"DELETE":         true,
+	"INSERT":         true,
+	"REFERENCES":     true,
+	"SELECT":         true,
+	"TRIGGER":        true,
+	"TRUNCATE":       true,
+	"UPDATE":         true,
+}
+
+// databaseGrant is the database privileges granted to a database user.
+type databaseGrant struct {
+	engine       db.Type
+	databaseName string
+	userName     string
+	// host is only applicable to MySQL and TiDB.
+	host          string
+	password      string
+	privilegeList []string
+}
+
+// getGrantPrivileges returns the privileges allowed to grant for the engine, or nil if the engine doesn't support database grant.
+func getGrantPrivileges(engine db.Type) map[string]bool {
+	switch engine {
+	case db.MySQL, db.TiDB:
+		return mysqlGrantPrivileges
+	case db.Postgres:
+		return pgGrantPrivileges
+	}
+	return nil
+}
+
+// normalizeGrantPrivilegeList upper-cases the privileges and sorts them, and returns error if a privilege is not allowed for the engine.
+func normalizeGrantPrivilegeList(engine db.Type, privilegeList []string) ([]string, error) {
+	privileges := getGrantPrivileges(engine)
+	if privileges == nil {
+		return nil, errors.Errorf("database grant is not supported for engine %s", engine)
+	}
+	if len(privilegeList) == 0 {
+		return nil, errors.New("privilege list is empty")
+	}
+	var normalizedList []string
+	seen := make(map[string]bool)
+	for _, privilege := range privilegeList {
+		normalized := strings.ToUpper(strings.Join(strings.Fields(privilege), " "))
+		if !privileges[normalized] {
+			return nil, errors.Errorf("invalid privilege %q for engine %s", privilege, engine)
+		}
+		if seen[normalized] {
+			continue
+		}
+		seen[normalized] = true
+		normalizedList = append(normalizedList, normalized)
+	}
+	sort.Strings(normalizedList)
+	return normalizedList, nil
+}
+
+// getInstanceUserName returns the name of the user in the same format as the instance users synced from the instance.
+func (g *databaseGrant) getInstanceUserName() string {
+	switch g.engine {
+	case db.MySQL, db.TiDB:
+		return fmt.Sprintf("'%s'@'%s'", g.userName, g.host)
+	default:
+		return g.userName
+	}
+}
+
+func (g *databaseGrant) getMySQLUser() string {
+	return fmt.Sprintf("%s@%s", quoteMySQLString(g.userName), quoteMySQLString(g.host))
+}
+
+// getMySQLDatabase returns the quoted database name in GRANT and REVOKE statements.
+// The wildcards "_" and "%" in the database name are escaped so that the privileges are granted on the database only.
+func (g *databaseGrant) getMySQLDatabase() string {
+	name := strings.ReplaceAll(g.databaseName, "_", `\_`)
+	name = strings.ReplaceAll(name, "%", `\%`)
+	return fmt.Sprintf("`%s`", strings.ReplaceAll(name, "`", "``"))
+}
+
+// getMySQLGrantStatements returns the statements to create the user if it doesn't exist, and grant the privileges on the database.
+func getMySQLGrantStatements(g *databaseGrant, userExists bool) ([]string, error) {
+	var stmts []string
+	if !userExists {
+		if g.password == "" {
+			return nil, errors.Errorf("password is required to create user %s", g.getInstanceUserName())
+		}
+		stmts = append(stmts, fmt.Sprintf("CREATE USER %s IDENTIFIED BY %s;", g.getMySQLUser(), quoteMySQLString(g.password)))
+	} else if g.password != "" {
+		// The password of an existing user is never changed, otherwise a grant could take over any account such as root.
+		return nil, errors.Errorf("user %s already exists, the password can only be set for a new user", g.getInstanceUserName())
+	}
+	stmts = append(stmts, fmt.Sprintf("GRANT %s ON %s.* TO %s;", strings.Join(g.privilegeList, ", "), g.getMySQLDatabase(), g.getMySQLUser()))
+	return stmts, nil
+}
+
+// getMySQLRevokeStatements returns the statements to revoke the privileges on the database. The user is kept.
+func getMySQLRevokeStatements(g *databaseGrant) []string {
+	return []string{
+		fmt.Sprintf("REVOKE %s ON %s.* FROM %s;", strings.Join(g.privilegeList, ", "), g.getMySQLDatabase(), g.getMySQLUser()),
+	}
+}
+
+// getPGGrantStatements returns the statements to create the role if it doesn't exist, and grant the privileges on all tables of the database,
+// including the tables created by the database owner in the future.
+func getPGGrantStatements(g *databaseGrant, userExists bool, owner string, schemaList []string) ([]string, error) {
+	var stmts []string
+	role := quotePGIdentifier(g.userName)
+	if !userExists {
+		if g.password == "" {
+			return nil, errors.Errorf("password is required to create role %s", g.userName)
+		}
+		stmts = append(stmts, fmt.Sprintf("CREATE ROLE %s WITH LOGIN PASSWORD %s;", role, quotePGString(g.password)))
+	} else if g.password != "" {
+		// The password of an existing role is never changed, otherwise a grant could take over any role such as the admin role.
+		return nil, errors.Errorf("role %s already exists, the password can only be set for a new role", g.userName)
+	}
+	privileges := strings.Join(g.privilegeList, ", ")
+	stmts = append(stmts, fmt.Sprintf("GRANT CONNECT ON DATABASE %s TO %s;", quotePGIdentifier(g.databaseName), role))
+	for _, schema := range schemaList {
+		schema = quotePGIdentifier(schema)
+		stmts = append(stmts,
+			fmt.Sprintf("GRANT USAGE ON SCHEMA %s TO %s;", schema, role),
+			fmt.Sprintf("GRANT %s ON ALL TABLES IN SCHEMA %s TO %s;", privileges, schema, role),
+			fmt.Sprintf("ALTER DEFAULT PRIVILEGES FOR ROLE %s IN SCHEMA %s GRANT %s ON TABLES TO %s;", quotePGIdentifier(owner), schema, privileges, role),
+		)
+	}
+	return stmts, nil
+}
+
+// getPGRevokeStatements returns the statements to revoke the privileges on all tables of the database.
+// The role, and the CONNECT and USAGE privileges which don't grant access to any data, are kept.
+func getPGRevokeStatements(g *databaseGrant, owner string, schemaList []string) []string {
+	var stmts []string
+	role := quotePGIdentifier(g.userName)
+	privileges := strings.Join(g.privilegeList, ", ")
+	for _, schema := range schemaList {
+		schema = quotePGIdentifier(schema)
+		stmts = append(stmts,
+			fmt.Sprintf("ALTER DEFAULT PRIVILEGES FOR ROLE %s IN SCHEMA %s REVOKE %s ON TABLES FROM %s;", quotePGIdentifier(owner), schema, privileges, role),
+			fmt.Sprintf("REVOKE %s ON ALL TABLES IN SCHEMA %s FROM %s;", privileges, schema, role),
+		)
+	}
+	return stmts
+}
+
+// executeDatabaseGrant grants the privileges to the user if revoke is false, otherwise revokes them.
+// The driver should be connected to the database of the grant.
+func executeDatabaseGrant(ctx context.Context, driver db.Driver, g *databaseGrant, revoke bool) error {
+	sqlDB, err := driver.GetDBConnection(ctx, g.databaseName)
+	if err != nil {
+		return err
+	}
+
+	var stmts []string
+	switch g.engine {
+	case db.MySQL, db.TiDB:
+		if revoke {
+			stmts = getMySQLRevokeStatements(g)
+			break
+		}
+		var count int
+		if err := sqlDB.QueryRowContext(ctx, "SELECT COUNT(1) FROM mysql.user WHERE user = ? AND host = ?", g.userName, g.host).Scan(&count); err != nil {
+			return errors.Wrapf(err, "failed to check if user %s exists", g.getInstanceUserName())
+		}
+		if stmts, err = getMySQLGrantStatements(g, count > 0); err != nil {
+			return err
+		}
+	case db.Postgres:
+		owner, schemaList, err := getPGDatabaseOwnerAndSchemaList(ctx, sqlDB)
+		if err != nil {
+			return err
+		}
+		if revoke {
+			stmts = getPGRevokeStatements(g, owner, schemaList)
+			break
+		}
+		var count int
+		if err := sqlDB.QueryRowContext(ctx, "SELECT COUNT(1) FROM pg_catalog.pg_roles WHERE rolname = $1", g.userName).Scan(&count); err != nil {
+			return errors.Wrapf(err, "failed to check if role %s exists", g.userName)
+		}
+		if stmts, err = getPGGrantStatements(g, count > 0, owner, schemaList); err != nil {
+			return err
+		}
+	default:
+		return errors.Errorf("database grant is not supported for engine %s", g.engine)
+	}
+
+	// The statements are executed in a transaction, which is atomic for Postgres.
+	// MySQL commits the account management statements implicitly, and they are idempotent on retry.
+	tx, err := sqlDB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	for _, stmt := range stmts {
+		// The statement is not included in the error because it may contain the password.
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			if revoke {
+				return errors.Wrapf(err, "failed to revoke privileges from %s", g.getInstanceUserName())
+			}
+			return errors.Wrapf(err, "failed to grant privileges to %s", g.getInstanceUserName())
+		}
+	}
+	return tx.Commit()
+}
+
+// getPGDatabaseOwnerAndSchemaList returns the owner and the user schemas of the connected database.
+func getPGDatabaseOwnerAndSchemaList(ctx context.Context, sqlDB *sql.DB) (string, []string, error) {
+	var owner string
+	if err := sqlDB.QueryRowContext(ctx, "SELECT pg_catalog.pg_get_userbyid(datdba) FROM pg_catalog.pg_database WHERE datname = current_database()").Scan(&owner); err != nil {
+		return "", nil, errors.Wrap(err, "failed to get the database owner")
+	}
+	rows, err := sqlDB.QueryContext(ctx, `SELECT nspname FROM pg_catalog.pg_namespace WHERE nspname NOT LIKE 'pg\_%' AND nspname <> 'information_schema' ORDER BY nspname`)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "failed to list the schemas")
+	}
+	defer rows.Close()
+	var schemaList []string
+	for rows.Next() {
+		var schema string
+		if err := rows.Scan(&schema); err != nil {
+			return "", nil, err
+		}
+		schemaList = append(schemaList, schema)
+	}
+	if err := rows.Err(); err != nil {
+		return "", nil, err
+	}
+	return owner, schemaList, nil
+}
+
+// syncInstanceUser syncs the user of the grant from the instance, so that the instance user list shows the latest grants.
+func (s *Server) syncInstanceUser(ctx context.Context, instance *api.Instance, driver db.Driver, g *databaseGrant) {
+	instanceMeta, err := driver.SyncInstance(ctx)
+	if err != nil {
+		log.Warn("Failed to sync instance users", zap.String("instance", instance.Name), zap.Error(err))
+		return
+	}
+	name := g.getInstanceUserName()
+	for _, user := range instanceMeta.UserList {
+		if user.Name != name {
+			continue
+		}
+		if _, err := s.store.UpsertInstanceUser(ctx, &api.InstanceUserUpsert{
+			CreatorID:  api.SystemBotID,
+			InstanceID: instance.ID,
+			Name:       user.Name,
+			Grant:      user.Grant,
+		}); err != nil {
+			log.Warn("Failed to upsert instance user", zap.String("instance", instance.Name), zap.String("user", name), zap.Error(err))
+		}
+		return
+	}
+}
+
+func quoteMySQLString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return fmt.Sprintf("'%s'", strings.ReplaceAll(s, "'", "''"))
+}
+
+func quotePGIdentifier(s string) string {
+	return fmt.Sprintf(`"%s"`, strings.ReplaceAll(s, `"`, `""`))
+}
+
+func quotePGString(s string) string {
+	return fmt.Sprintf("'%s'", strings.ReplaceAll(s, "'", "''"))
+}
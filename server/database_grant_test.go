@@ -0,0 +1,142 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/plugin/db"
+)
+
+func TestNormalizeGrantPrivilegeList(t *testing.T) {
+	tests := []struct {
+		engine        db.Type
+		privilegeList []string
+		want          []string
+		wantErr       bool
+	}{
+		{
+			engine:        db.MySQL,
+			privilegeList: []string{"select", "Insert", "SELECT", "create  temporary tables"},
+			want:          []string{"CREATE TEMPORARY TABLES", "INSERT", "SELECT"},
+		},
+		{
+			engine:        db.Postgres,
+			privilegeList: []string{"truncate", "select"},
+			want:          []string{"SELECT", "TRUNCATE"},
+		},
+		{
+			// SHOW VIEW is not a table privilege of Postgres.
+			engine:        db.Postgres,
+			privilegeList: []string{"SHOW VIEW"},
+			wantErr:       true,
+		},
+		{
+			// Privileges are validated against the allowlist, so that they can be used in the statements.
+			engine:        db.MySQL,
+			privilegeList: []string{"SELECT ON *.* TO 'root'@'%'; --"},
+			wantErr:       true,
+		},
+		{
+			engine:        db.MySQL,
+			privilegeList: nil,
+			wantErr:       true,
+		},
+		{
+			engine:        db.Snowflake,
+			privilegeList: []string{"SELECT"},
+			wantErr:       true,
+		},
+	}
+
+	for _, test := range tests {
+		got, err := normalizeGrantPrivilegeList(test.engine, test.privilegeList)
+		if test.wantErr {
+			require.Error(t, err, test.privilegeList)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, test.want, got)
+	}
+}
+
+func TestGetMySQLGrantStatements(t *testing.T) {
+	g := &databaseGrant{
+		engine:        db.MySQL,
+		databaseName:  "employee_db",
+		userName:      "bob",
+		host:          "%",
+		password:      "it's",
+		privilegeList: []string{"INSERT", "SELECT"},
+	}
+	require.Equal(t, "'bob'@'%'", g.getInstanceUserName())
+
+	stmts, err := getMySQLGrantStatements(g, false /* userExists */)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"CREATE USER 'bob'@'%' IDENTIFIED BY 'it''s';",
+		"GRANT INSERT, SELECT ON `employee\\_db`.* TO 'bob'@'%';",
+	}, stmts)
+
+	// The password of an existing user can't be changed.
+	_, err = getMySQLGrantStatements(g, true /* userExists */)
+	require.Error(t, err)
+
+	require.Equal(t, []string{
+		"REVOKE INSERT, SELECT ON `employee\\_db`.* FROM 'bob'@'%';",
+	}, getMySQLRevokeStatements(g))
+
+	// The password is required to create the user.
+	g.password = ""
+	_, err = getMySQLGrantStatements(g, false /* userExists */)
+	require.Error(t, err)
+	stmts, err = getMySQLGrantStatements(g, true /* userExists */)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"GRANT INSERT, SELECT ON `employee\\_db`.* TO 'bob'@'%';",
+	}, stmts)
+}
+
+func TestGetPGGrantStatements(t *testing.T) {
+	g := &databaseGrant{
+		engine:        db.Postgres,
+		databaseName:  "employee",
+		userName:      `bob"`,
+		password:      "secret",
+		privilegeList: []string{"SELECT"},
+	}
+	schemaList := []string{"public", "hr"}
+
+	stmts, err := getPGGrantStatements(g, false /* userExists */, "owner", schemaList)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		`CREATE ROLE "bob""" WITH LOGIN PASSWORD 'secret';`,
+		`GRANT CONNECT ON DATABASE "employee" TO "bob""";`,
+		`GRANT USAGE ON SCHEMA "public" TO "bob""";`,
+		`GRANT SELECT ON ALL TABLES IN SCHEMA "public" TO "bob""";`,
+		`ALTER DEFAULT PRIVILEGES FOR ROLE "owner" IN SCHEMA "public" GRANT SELECT ON TABLES TO "bob""";`,
+		`GRANT USAGE ON SCHEMA "hr" TO "bob""";`,
+		`GRANT SELECT ON ALL TABLES IN SCHEMA "hr" TO "bob""";`,
+		`ALTER DEFAULT PRIVILEGES FOR ROLE "owner" IN SCHEMA "hr" GRANT SELECT ON TABLES TO "bob""";`,
+	}, stmts)
+
+	// The password of an existing role can't be changed.
+	_, err = getPGGrantStatements(g, true /* userExists */, "owner", schemaList)
+	require.Error(t, err)
+	g.password = ""
+	stmts, err = getPGGrantStatements(g, true /* userExists */, "owner", []string{"public"})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		`GRANT CONNECT ON DATABASE "employee" TO "bob""";`,
+		`GRANT USAGE ON SCHEMA "public" TO "bob""";`,
+		`GRANT SELECT ON ALL TABLES IN SCHEMA "public" TO "bob""";`,
+		`ALTER DEFAULT PRIVILEGES FOR ROLE "owner" IN SCHEMA "public" GRANT SELECT ON TABLES TO "bob""";`,
+	}, stmts)
+
+	require.Equal(t, []string{
+		`ALTER DEFAULT PRIVILEGES FOR ROLE "owner" IN SCHEMA "public" REVOKE SELECT ON TABLES FROM "bob""";`,
+		`REVOKE SELECT ON ALL TABLES IN SCHEMA "public" FROM "bob""";`,
+		`ALTER DEFAULT PRIVILEGES FOR ROLE "owner" IN SCHEMA "hr" REVOKE SELECT ON TABLES FROM "bob""";`,
+		`REVOKE SELECT ON ALL TABLES IN SCHEMA "hr" FROM "bob""";`,
+	}, getPGRevokeStatements(g, "owner", schemaList))
+}
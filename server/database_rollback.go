@@ -0,0 +1,121 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/plugin/db"
+)
+
+// undoMigration is an applied migration to reverse with its undo statement.
+type undoMigration struct {
+	version   string
+	statement string
+}
+
+// getAppliedMigrationHistoryList returns the successful migrations which haven't been reversed, ordered by the sequence.
+// The UNDO migrations are excluded, and so are the migrations reversed by them.
+func getAppliedMigrationHistoryList(historyList []*db.MigrationHistory) ([]*db.MigrationHistory, error) {
+	var doneList []*db.MigrationHistory
+	for _, history := range historyList {
+		if history.Status == db.Done {
+			doneList = append(doneList, history)
+		}
+	}
+	sort.Slice(doneList, func(i, j int) bool {
+		return doneList[i].Sequence < doneList[j].Sequence
+	})
+
+	undoneVersions := make(map[string]bool)
+	for _, history := range doneList {
+		if history.Type != db.Undo {
+			continue
+		}
+		payload, err := getMigrationInfoPayload(history)
+		if err != nil {
+			return nil, err
+		}
+		undoneVersions[payload.UndoVersion] = true
+	}
+
+	var appliedList []*db.MigrationHistory
+	for _, history := range doneList {
+		if history.Type == db.Undo || undoneVersions[history.Version] {
+			continue
+		}
+		appliedList = append(appliedList, history)
+	}
+	return appliedList, nil
+}
+
+// getUndoMigrationList returns the migrations to reverse for rolling back the database to the version, the most recent first.
+func getUndoMigrationList(historyList []*db.MigrationHistory, version string) ([]*undoMigration, error) {
+	appliedList, err := getAppliedMigrationHistoryList(historyList)
+	if err != nil {
+		return nil, err
+	}
+	targetIndex := -1
+	for i, history := range appliedList {
+		if history.Version == version {
+			targetIndex = i
+		}
+	}
+	if targetIndex < 0 {
+		return nil, errors.Errorf("version %s isn't applied or has been undone", version)
+	}
+
+	var undoList []*undoMigration
+	for i := len(appliedList) - 1; i > targetIndex; i-- {
+		history := appliedList[i]
+		if history.Type != db.Migrate && history.Type != db.Data {
+			return nil, errors.Errorf("cannot roll back across the %s migration version %s", history.Type, history.Version)
+		}
+		payload, err := getMigrationInfoPayload(history)
+		if err != nil {
+			return nil, err
+		}
+		if payload.UndoStatement == "" {
+			return nil, errors.Errorf("migration version %s doesn't have the undo statement", history.Version)
+		}
+		undoList = append(undoList, &undoMigration{
+			version:   history.Version,
+			statement: payload.UndoStatement,
+		})
+	}
+	if len(undoList) == 0 {
+		return nil, errors.Errorf("the database is already at version %s", version)
+	}
+	return undoList, nil
+}
+
+// checkMigrationUndoable checks if the version is the most recent migration applied, so that it can be reversed.
+func checkMigrationUndoable(historyList []*db.MigrationHistory, version string) error {
+	appliedList, err := getAppliedMigrationHistoryList(historyList)
+	if err != nil {
+		return err
+	}
+	if len(appliedList) == 0 || appliedList[len(appliedList)-1].Version != version {
+		return errors.Errorf("version %s isn't the most recent migration applied", version)
+	}
+	return nil
+}
+
+func getMigrationInfoPayload(history *db.MigrationHistory) (*db.MigrationInfoPayload, error) {
+	payload := &db.MigrationInfoPayload{}
+	if history.Payload == "" {
+		return payload, nil
+	}
+	if err := json.Unmarshal([]byte(history.Payload), payload); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal the payload of migration version %s", history.Version)
+	}
+	return payload, nil
+}
+
+// getUndoSchemaVersion returns the version of the UNDO migration, which keeps the reversed version for readability.
+func getUndoSchemaVersion(undoVersion string) string {
+	return fmt.Sprintf("%s-undo-%s", common.DefaultMigrationVersion(), undoVersion)
+}
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/plugin/db"
+)
+
+func TestGetUndoMigrationList(t *testing.T) {
+	// The migration history is most recent first, the same as the one returned by the driver.
+	historyList := []*db.MigrationHistory{
+		{Sequence: 7, Type: db.Migrate, Status: db.Failed, Version: "0005"},
+		{Sequence: 6, Type: db.Migrate, Status: db.Done, Version: "0004", Payload: `{"undoStatement":"DROP TABLE t4;"}`},
+		{Sequence: 5, Type: db.Undo, Status: db.Done, Version: "20221111000000-undo-0003", Payload: `{"undoVersion":"0003"}`},
+		{Sequence: 4, Type: db.Migrate, Status: db.Done, Version: "0003", Payload: `{"undoStatement":"DROP TABLE t3;"}`},
+		{Sequence: 3, Type: db.Data, Status: db.Done, Version: "0002", Payload: `{"undoStatement":"DELETE FROM t1;"}`},
+		{Sequence: 2, Type: db.Migrate, Status: db.Done, Version: "0001"},
+		{Sequence: 1, Type: db.Baseline, Status: db.Done, Version: "0000"},
+	}
+
+	tests := []struct {
+		version string
+		want    []*undoMigration
+		wantErr bool
+	}{
+		{
+			version: "0002",
+			want: []*undoMigration{
+				{version: "0004", statement: "DROP TABLE t4;"},
+			},
+		},
+		{
+			// The reversed version 0003 is skipped.
+			version: "0001",
+			want: []*undoMigration{
+				{version: "0004", statement: "DROP TABLE t4;"},
+				{version: "0002", statement: "DELETE FROM t1;"},
+			},
+		},
+		{
+			// Version 0001 doesn't have the undo statement.
+			version: "0000",
+			wantErr: true,
+		},
+		{
+			// Version 0003 has been undone.
+			version: "0003",
+			wantErr: true,
+		},
+		{
+			// Version 0005 failed.
+			version: "0005",
+			wantErr: true,
+		},
+		{
+			// The database is already at version 0004.
+			version: "0004",
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		got, err := getUndoMigrationList(historyList, test.version)
+		if test.wantErr {
+			require.Error(t, err, test.version)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, test.want, got)
+	}
+
+	require.NoError(t, checkMigrationUndoable(historyList, "0004"))
+	require.Error(t, checkMigrationUndoable(historyList, "0002"))
+}
@@ -152,3 +152,40 @@ func TestValidateDatabaseLabelList(t *testing.T) {
 		}
 	}
 }
+
+func TestBuildERD(t *testing.T) {
+	tableList := []*api.Table{
+		{ID: 1, Name: "public.author"},
+		{ID: 2, Name: "public.book"},
+	}
+	columnList := []*api.Column{
+		{TableID: 2, Name: "author_name", Position: 3},
+		{TableID: 1, Name: "id", Position: 1},
+		{TableID: 2, Name: "id", Position: 1},
+		{TableID: 2, Name: "author_id", Position: 2},
+		{TableID: 1, Name: "name", Position: 2},
+	}
+	foreignKeyList := []*api.ForeignKey{
+		{TableID: 2, Name: "book_author_fk", Column: "author_name", Position: 2, ReferencedTable: "public.author", ReferencedColumn: "name", OnUpdate: "NO ACTION", OnDelete: "CASCADE"},
+		{TableID: 2, Name: "book_author_fk", Column: "author_id", Position: 1, ReferencedTable: "public.author", ReferencedColumn: "id", OnUpdate: "NO ACTION", OnDelete: "CASCADE"},
+	}
+
+	want := &api.ERD{
+		TableList: []*api.ERDTable{
+			{Name: "public.author", ColumnList: []string{"id", "name"}},
+			{Name: "public.book", ColumnList: []string{"id", "author_id", "author_name"}},
+		},
+		RelationshipList: []*api.ERDRelationship{
+			{
+				Name:           "book_author_fk",
+				FromTable:      "public.book",
+				FromColumnList: []string{"author_id", "author_name"},
+				ToTable:        "public.author",
+				ToColumnList:   []string{"id", "name"},
+				OnUpdate:       "NO ACTION",
+				OnDelete:       "CASCADE",
+			},
+		},
+	}
+	assert.Equal(t, want, buildERD(tableList, columnList, foreignKeyList))
+}
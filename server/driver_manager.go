@@ -0,0 +1,393 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/common/log"
+	"github.com/bytebase/bytebase/plugin/db"
+)
+
+const (
+	// driverManagerEvictInterval is the interval to evict the idle drivers.
+	driverManagerEvictInterval = 1 * time.Minute
+	// driverAcquireTimeout is the max time to wait for a driver if the instance has reached the max number of open drivers.
+	// The waiting is bounded so that callers acquiring more than one driver of the same instance fail instead of deadlocking.
+	driverAcquireTimeout = 1 * time.Minute
+)
+
+// NewDriverManager creates a new driver manager.
+// maxDriversPerInstance is the max number of open drivers per instance, it's unlimited if it's not greater than 0.
+// The idle drivers are closed after idleTimeout, they are closed once they are released if idleTimeout is not greater than 0.
+func NewDriverManager(maxDriversPerInstance int, idleTimeout time.Duration) *DriverManager {
+	return &DriverManager{
+		maxDriversPerInstance: maxDriversPerInstance,
+		idleTimeout:           idleTimeout,
+		instances:             make(map[int]*instanceDrivers),
+	}
+}
+
+// DriverManager caches the open drivers per instance, data source and database, so that API calls and runners
+// don't open a new connection to the instance every time.
+// A cached driver is leased to one caller at a time, because drivers such as the Postgres driver switch their
+// connected database. The caller releases the driver to the manager by calling driver.Close.
+type DriverManager struct {
+	maxDriversPerInstance int
+	idleTimeout           time.Duration
+
+	mu        sync.Mutex
+	instances map[int]*instanceDrivers
+	closed    bool
+}
+
+// instanceDrivers is the drivers of an instance.
+type instanceDrivers struct {
+	// open is the number of open drivers of the instance, including both idle and leased drivers.
+	open int
+	// idle is the idle drivers keyed by the driver key.
+	idle map[string][]*managedDriver
+	// generation is bumped on invalidation, the leased drivers of older generations are closed once they are released.
+	generation int
+	// released is closed and replaced whenever a driver of the instance is released or closed, to wake up the waiters.
+	released chan struct{}
+}
+
+// managedDriver is a driver opened by the driver manager.
+type managedDriver struct {
+	driver     db.Driver
+	instanceID int
+	key        string
+	// database is the database the driver is connected to when it's opened, see getCurrentDatabaseName.
+	database   string
+	generation int
+	lastUsedTs time.Time
+}
+
+// Run is the runner for driver manager, which closes the drivers idle for too long.
+func (m *DriverManager) Run(ctx context.Context, wg *sync.WaitGroup) {
+	ticker := time.NewTicker(driverManagerEvictInterval)
+	defer ticker.Stop()
+	defer wg.Done()
+	log.Debug("Driver manager started", zap.Duration("idleTimeout", m.idleTimeout))
+	for {
+		select {
+		case <-ticker.C:
+			m.evictIdleDrivers(time.Now())
+		case <-ctx.Done(): // if cancel() execute
+			m.close()
+			return
+		}
+	}
+}
+
+// GetDriver returns a driver of the instance. The driver is reused if there is an idle one opened with the same config,
+// otherwise a new driver is opened. Upon successful return, caller MUST call driver.Close to release it.
+func (m *DriverManager) GetDriver(ctx context.Context, instanceID int, engine db.Type, driverConfig db.DriverConfig, connectionConfig db.ConnectionConfig, connCtx db.ConnectionContext) (db.Driver, error) {
+	key, err := getDriverKey(engine, driverConfig, connectionConfig, connCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, driverAcquireTimeout)
+	defer cancel()
+	for {
+		m.mu.Lock()
+		if m.closed {
+			m.mu.Unlock()
+			return nil, errors.New("driver manager is closed")
+		}
+		drivers := m.getInstanceDrivers(instanceID)
+		if idleList := drivers.idle[key]; len(idleList) > 0 {
+			md := idleList[len(idleList)-1]
+			drivers.idle[key] = idleList[:len(idleList)-1]
+			m.mu.Unlock()
+			if err := resetManagedDriver(ctx, md); err != nil {
+				log.Debug("Dropped the broken idle driver", zap.Int("instance_id", instanceID), zap.String("database", md.database), zap.Error(err))
+				m.mu.Lock()
+				drivers.open--
+				drivers.notify()
+				m.mu.Unlock()
+				closeManagedDriver(md)
+				continue
+			}
+			return &leasedDriver{Driver: md.driver, manager: m, md: md}, nil
+		}
+		// Close an idle driver of other configs to make room for the new driver.
+		if m.maxDriversPerInstance > 0 && drivers.open >= m.maxDriversPerInstance {
+			if md := drivers.popOldestIdle(); md != nil {
+				drivers.open--
+				m.mu.Unlock()
+				closeManagedDriver(md)
+				continue
+			}
+		}
+		if m.maxDriversPerInstance <= 0 || drivers.open < m.maxDriversPerInstance {
+			drivers.open++
+			generation := drivers.generation
+			m.mu.Unlock()
+
+			driver, err := getDatabaseDriver(ctx, engine, driverConfig, connectionConfig, connCtx)
+			if err != nil {
+				m.mu.Lock()
+				drivers.open--
+				drivers.notify()
+				m.mu.Unlock()
+				return nil, err
+			}
+			md := &managedDriver{
+				driver:     driver,
+				instanceID: instanceID,
+				key:        key,
+				database:   getCurrentDatabaseName(driver),
+				generation: generation,
+			}
+			return &leasedDriver{Driver: driver, manager: m, md: md}, nil
+		}
+		released := drivers.released
+		m.mu.Unlock()
+
+		select {
+		case <-released:
+		case <-ctx.Done():
+			return nil, common.Wrapf(ctx.Err(), common.DbConnectionFailure, "failed to connect database at %s:%s, too many open connections to the instance", connectionConfig.Host, connectionConfig.Port)
+		}
+	}
+}
+
+// Invalidate closes the idle drivers of the instance, and the leased drivers once they are released.
+// It should be called after the connection configs of the instance or its data sources change.
+func (m *DriverManager) Invalidate(instanceID int) {
+	m.mu.Lock()
+	drivers, ok := m.instances[instanceID]
+	if !ok {
+		m.mu.Unlock()
+		return
+	}
+	drivers.generation++
+	var closeList []*managedDriver
+	for key, idleList := range drivers.idle {
+		closeList = append(closeList, idleList...)
+		delete(drivers.idle, key)
+	}
+	drivers.open -= len(closeList)
+	drivers.notify()
+	m.mu.Unlock()
+
+	for _, md := range closeList {
+		closeManagedDriver(md)
+	}
+}
+
+// release returns the driver to the idle drivers, or closes it if it cannot be reused.
+func (m *DriverManager) release(md *managedDriver, reusable bool) {
+	m.mu.Lock()
+	drivers := m.getInstanceDrivers(md.instanceID)
+	if !reusable || m.closed || m.idleTimeout <= 0 || md.generation != drivers.generation {
+		drivers.open--
+		drivers.notify()
+		m.mu.Unlock()
+		closeManagedDriver(md)
+		return
+	}
+	md.lastUsedTs = time.Now()
+	drivers.idle[md.key] = append(drivers.idle[md.key], md)
+	drivers.notify()
+	m.mu.Unlock()
+}
+
+// evictIdleDrivers closes the drivers idle since before now - idleTimeout.
+func (m *DriverManager) evictIdleDrivers(now time.Time) {
+	m.mu.Lock()
+	var closeList []*managedDriver
+	for _, drivers := range m.instances {
+		evicted := false
+		for key, idleList := range drivers.idle {
+			var keepList []*managedDriver
+			for _, md := range idleList {
+				if now.Sub(md.lastUsedTs) >= m.idleTimeout {
+					closeList = append(closeList, md)
+					drivers.open--
+					evicted = true
+				} else {
+					keepList = append(keepList, md)
+				}
+			}
+			if len(keepList) == 0 {
+				delete(drivers.idle, key)
+			} else {
+				drivers.idle[key] = keepList
+			}
+		}
+		if evicted {
+			drivers.notify()
+		}
+	}
+	m.mu.Unlock()
+
+	for _, md := range closeList {
+		closeManagedDriver(md)
+	}
+}
+
+// close closes all idle drivers, and the leased drivers once they are released.
+func (m *DriverManager) close() {
+	m.mu.Lock()
+	m.closed = true
+	var closeList []*managedDriver
+	for _, drivers := range m.instances {
+		for key, idleList := range drivers.idle {
+			closeList = append(closeList, idleList...)
+			drivers.open -= len(idleList)
+			delete(drivers.idle, key)
+		}
+		drivers.notify()
+	}
+	m.mu.Unlock()
+
+	for _, md := range closeList {
+		closeManagedDriver(md)
+	}
+}
+
+// getInstanceDrivers returns the drivers of the instance, the caller must hold m.mu.
+func (m *DriverManager) getInstanceDrivers(instanceID int) *instanceDrivers {
+	drivers, ok := m.instances[instanceID]
+	if !ok {
+		drivers = &instanceDrivers{
+			idle:     make(map[string][]*managedDriver),
+			released: make(chan struct{}),
+		}
+		m.instances[instanceID] = drivers
+	}
+	return drivers
+}
+
+// popOldestIdle removes and returns the least recently used idle driver, or nil if there are no idle drivers.
+func (d *instanceDrivers) popOldestIdle() *managedDriver {
+	var oldestKey string
+	oldestIndex := -1
+	for key, idleList := range d.idle {
+		for i, md := range idleList {
+			if oldestIndex < 0 || md.lastUsedTs.Before(d.idle[oldestKey][oldestIndex].lastUsedTs) {
+				oldestKey, oldestIndex = key, i
+			}
+		}
+	}
+	if oldestIndex < 0 {
+		return nil
+	}
+	idleList := d.idle[oldestKey]
+	md := idleList[oldestIndex]
+	idleList = append(idleList[:oldestIndex], idleList[oldestIndex+1:]...)
+	if len(idleList) == 0 {
+		delete(d.idle, oldestKey)
+	} else {
+		d.idle[oldestKey] = idleList
+	}
+	return md
+}
+
+func (d *instanceDrivers) notify() {
+	close(d.released)
+	d.released = make(chan struct{})
+}
+
+func closeManagedDriver(md *managedDriver) {
+	if err := md.driver.Close(context.Background()); err != nil {
+		log.Warn("Failed to close driver", zap.Int("instance_id", md.instanceID), zap.String("database", md.database), zap.Error(err))
+	}
+}
+
+// sessionResetter is implemented by the drivers which keep a session across calls, such as the migration connection of the MySQL driver.
+type sessionResetter interface {
+	// ResetSession discards the session state left by the previous user of the driver.
+	ResetSession(ctx context.Context) error
+}
+
+// resetManagedDriver checks the idle driver is still healthy before reusing it, and resets its session.
+func resetManagedDriver(ctx context.Context, md *managedDriver) error {
+	if err := md.driver.Ping(ctx); err != nil {
+		return errors.Wrap(err, "failed to ping")
+	}
+	if resetter, ok := md.driver.(sessionResetter); ok {
+		if err := resetter.ResetSession(ctx); err != nil {
+			return errors.Wrap(err, "failed to reset session")
+		}
+	}
+	return nil
+}
+
+// getDriverKey returns the key of drivers which can be shared.
+// The key is hashed because the connection config contains the password.
+func getDriverKey(engine db.Type, driverConfig db.DriverConfig, connectionConfig db.ConnectionConfig, connCtx db.ConnectionContext) (string, error) {
+	bytes, err := json.Marshal(struct {
+		Engine           db.Type
+		DriverConfig     db.DriverConfig
+		ConnectionConfig db.ConnectionConfig
+		ConnCtx          db.ConnectionContext
+	}{
+		Engine:           engine,
+		DriverConfig:     driverConfig,
+		ConnectionConfig: connectionConfig,
+		ConnCtx:          connCtx,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal driver config")
+	}
+	sum := sha256.Sum256(bytes)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// currentDatabaseGetter is implemented by the drivers which switch their connected database, such as the Postgres driver.
+type currentDatabaseGetter interface {
+	GetCurrentDatabaseName() string
+}
+
+// getCurrentDatabaseName returns the database the driver is connected to, and empty if the driver doesn't switch databases.
+func getCurrentDatabaseName(driver db.Driver) string {
+	if getter, ok := driver.(currentDatabaseGetter); ok {
+		return getter.GetCurrentDatabaseName()
+	}
+	return ""
+}
+
+// leasedDriver is a driver leased from the driver manager, Close releases the driver to the manager instead of closing it.
+type leasedDriver struct {
+	db.Driver
+	manager *DriverManager
+	md      *managedDriver
+
+	mu       sync.Mutex
+	released bool
+}
+
+// Close releases the driver to the driver manager.
+// The driver is closed instead if it has switched to another database, because it cannot be reused for the original database.
+func (d *leasedDriver) Close(context.Context) error {
+	d.mu.Lock()
+	if d.released {
+		d.mu.Unlock()
+		return nil
+	}
+	d.released = true
+	d.mu.Unlock()
+	d.manager.release(d.md, getCurrentDatabaseName(d.Driver) == d.md.database)
+	return nil
+}
+
+// unwrapDriver returns the underlying driver if the driver is leased from the driver manager.
+// It should be used before type assertions to the engine specific drivers.
+func unwrapDriver(driver db.Driver) db.Driver {
+	if d, ok := driver.(*leasedDriver); ok {
+		return d.Driver
+	}
+	return driver
+}
@@ -0,0 +1,158 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/plugin/db"
+)
+
+const fakeDriverType db.Type = "FAKE"
+
+var (
+	fakeDriverMu         sync.Mutex
+	fakeDriverOpenCount  int
+	fakeDriverCloseCount int
+)
+
+func init() {
+	db.Register(fakeDriverType, func(db.DriverConfig) db.Driver {
+		return &fakeDriver{}
+	})
+}
+
+// fakeDriver is a driver which switches its connected database like the Postgres driver.
+type fakeDriver struct {
+	db.Driver
+	databaseName string
+	pingErr      error
+	resetCount   int
+}
+
+func (d *fakeDriver) Open(_ context.Context, _ db.Type, config db.ConnectionConfig, _ db.ConnectionContext) (db.Driver, error) {
+	fakeDriverMu.Lock()
+	defer fakeDriverMu.Unlock()
+	fakeDriverOpenCount++
+	d.databaseName = config.Database
+	return d, nil
+}
+
+func (*fakeDriver) Close(context.Context) error {
+	fakeDriverMu.Lock()
+	defer fakeDriverMu.Unlock()
+	fakeDriverCloseCount++
+	return nil
+}
+
+func (d *fakeDriver) Ping(context.Context) error {
+	return d.pingErr
+}
+
+func (d *fakeDriver) ResetSession(context.Context) error {
+	d.resetCount++
+	return nil
+}
+
+func (d *fakeDriver) GetCurrentDatabaseName() string {
+	return d.databaseName
+}
+
+func getFakeDriverCounts() (int, int) {
+	fakeDriverMu.Lock()
+	defer fakeDriverMu.Unlock()
+	return fakeDriverOpenCount, fakeDriverCloseCount
+}
+
+func getFakeDriver(ctx context.Context, m *DriverManager, instanceID int, database string) (db.Driver, error) {
+	return m.GetDriver(ctx, instanceID, fakeDriverType, db.DriverConfig{}, db.ConnectionConfig{Database: database}, db.ConnectionContext{})
+}
+
+func TestDriverManager(t *testing.T) {
+	ctx := context.Background()
+	m := NewDriverManager(2, time.Minute)
+	openCount, closeCount := getFakeDriverCounts()
+
+	// The released driver is reused for the same database.
+	d1, err := getFakeDriver(ctx, m, 1, "db1")
+	require.NoError(t, err)
+	require.NoError(t, d1.Close(ctx))
+	// Closing twice releases the driver only once.
+	require.NoError(t, d1.Close(ctx))
+	d2, err := getFakeDriver(ctx, m, 1, "db1")
+	require.NoError(t, err)
+	require.Same(t, unwrapDriver(d1), unwrapDriver(d2))
+	gotOpenCount, gotCloseCount := getFakeDriverCounts()
+	require.Equal(t, openCount+1, gotOpenCount)
+	require.Equal(t, closeCount, gotCloseCount)
+
+	// The instance has reached the max number of open drivers.
+	d3, err := getFakeDriver(ctx, m, 1, "db2")
+	require.NoError(t, err)
+	timeoutCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	_, err = getFakeDriver(timeoutCtx, m, 1, "db3")
+	cancel()
+	require.Error(t, err)
+	// Other instances are not limited.
+	d4, err := getFakeDriver(ctx, m, 2, "db1")
+	require.NoError(t, err)
+	require.NoError(t, d4.Close(ctx))
+
+	// The waiter gets a driver once a driver is released, and the idle driver of another database is closed to make room.
+	done := make(chan error)
+	go func() {
+		d, err := getFakeDriver(ctx, m, 1, "db3")
+		if err == nil {
+			err = d.Close(ctx)
+		}
+		done <- err
+	}()
+	require.NoError(t, d2.Close(ctx))
+	require.NoError(t, <-done)
+	_, gotCloseCount = getFakeDriverCounts()
+	require.Equal(t, closeCount+1, gotCloseCount)
+
+	// The driver switched to another database is closed on release.
+	unwrapDriver(d3).(*fakeDriver).databaseName = "db4"
+	require.NoError(t, d3.Close(ctx))
+	_, gotCloseCount = getFakeDriverCounts()
+	require.Equal(t, closeCount+2, gotCloseCount)
+
+	// Invalidation closes the idle drivers, and the leased drivers on release.
+	d5, err := getFakeDriver(ctx, m, 1, "db5")
+	require.NoError(t, err)
+	m.Invalidate(1)
+	_, gotCloseCount = getFakeDriverCounts()
+	require.Equal(t, closeCount+3, gotCloseCount)
+	require.NoError(t, d5.Close(ctx))
+	_, gotCloseCount = getFakeDriverCounts()
+	require.Equal(t, closeCount+4, gotCloseCount)
+
+	// The idle drivers are evicted after the idle timeout.
+	m.evictIdleDrivers(time.Now().Add(2 * time.Minute))
+	_, gotCloseCount = getFakeDriverCounts()
+	require.Equal(t, closeCount+5, gotCloseCount)
+
+	// The broken idle driver is dropped, and the healthy one is reset before it's reused.
+	d6, err := getFakeDriver(ctx, m, 3, "db1")
+	require.NoError(t, err)
+	unwrapDriver(d6).(*fakeDriver).pingErr = errors.New("broken connection")
+	require.NoError(t, d6.Close(ctx))
+	d7, err := getFakeDriver(ctx, m, 3, "db1")
+	require.NoError(t, err)
+	require.NotSame(t, unwrapDriver(d6), unwrapDriver(d7))
+	require.NoError(t, d7.Close(ctx))
+	d8, err := getFakeDriver(ctx, m, 3, "db1")
+	require.NoError(t, err)
+	require.Same(t, unwrapDriver(d7), unwrapDriver(d8))
+	require.Equal(t, 1, unwrapDriver(d8).(*fakeDriver).resetCount)
+	require.NoError(t, d8.Close(ctx))
+
+	m.close()
+	_, err = getFakeDriver(ctx, m, 1, "db1")
+	require.Error(t, err)
+}
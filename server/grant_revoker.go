@@ -0,0 +1,217 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/common/log"
+	"github.com/bytebase/bytebase/plugin/db"
+)
+
+const (
+	grantRevokerInterval = time.Duration(1) * time.Minute
+)
+
+// NewGrantRevoker creates a grant revoker.
+func NewGrantRevoker(server *Server) *GrantRevoker {
+	return &GrantRevoker{
+		server: server,
+	}
+}
+
+// GrantRevoker is the grant revoker which revokes the database privileges granted by the database grant issues once they expire.
+type GrantRevoker struct {
+	server *Server
+}
+
+// Run is the runner for grant revoker.
+func (r *GrantRevoker) Run(ctx context.Context, wg *sync.WaitGroup) {
+	ticker := time.NewTicker(grantRevokerInterval)
+	defer ticker.Stop()
+	defer wg.Done()
+	log.Debug(fmt.Sprintf("Grant revoker started and will run every %v", grantRevokerInterval))
+	for {
+		select {
+		case <-ticker.C:
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						err, ok := r.(error)
+						if !ok {
+							err = errors.Errorf("%v", r)
+						}
+						log.Error("Grant revoker PANIC RECOVER", zap.Error(err), zap.Stack("panic-stack"))
+					}
+				}()
+				r.revokeExpiredGrants(ctx)
+			}()
+		case <-ctx.Done(): // if cancel() execute
+			return
+		}
+	}
+}
+
+// revokeExpiredGrants revokes the active grants which have expired.
+// A grant failed to revoke is retried in the next run.
+func (r *GrantRevoker) revokeExpiredGrants(ctx context.Context) {
+	status := api.InstanceUserGrantActive
+	now := time.Now().Unix()
+	grantList, err := r.server.store.FindInstanceUserGrant(ctx, &api.InstanceUserGrantFind{
+		Status:         &status,
+		ExpireTsBefore: &now,
+	})
+	if err != nil {
+		log.Error("Failed to retrieve expired grants", zap.Error(err))
+		return
+	}
+	for _, grant := range grantList {
+		if ctx.Err() != nil {
+			return
+		}
+		if err := r.revokeGrant(ctx, grant); err != nil {
+			log.Error("Failed to revoke expired grant",
+				zap.Int("grant_id", grant.ID),
+				zap.Int("instance_id", grant.InstanceID),
+				zap.String("database", grant.DatabaseName),
+				zap.String("user", grant.UserName),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+func (r *GrantRevoker) revokeGrant(ctx context.Context, grant *api.InstanceUserGrant) error {
+	instance, err := r.server.store.GetInstanceByID(ctx, grant.InstanceID)
+	if err != nil {
+		return err
+	}
+	if instance == nil {
+		return errors.Errorf("instance not found with ID %d", grant.InstanceID)
+	}
+	// The privileges granted by the other active grants of the same user on the same database are kept.
+	status := api.InstanceUserGrantActive
+	activeGrantList, err := r.server.store.FindInstanceUserGrant(ctx, &api.InstanceUserGrantFind{
+		InstanceID: &grant.InstanceID,
+		Status:     &status,
+	})
+	if err != nil {
+		return err
+	}
+	g := &databaseGrant{
+		engine:        instance.Engine,
+		databaseName:  grant.DatabaseName,
+		userName:      grant.UserName,
+		host:          grant.Host,
+		privilegeList: getRevokePrivilegeList(instance.Engine, grant, activeGrantList, time.Now().Unix()),
+	}
+
+	driver, err := r.server.getAdminDatabaseDriver(ctx, instance, grant.DatabaseName)
+	if err != nil {
+		return err
+	}
+	defer driver.Close(ctx)
+	if len(g.privilegeList) > 0 {
+		if err := executeDatabaseGrant(ctx, driver, g, true /* revoke */); err != nil {
+			return err
+		}
+	}
+
+	revoked := api.InstanceUserGrantRevoked
+	if _, err := r.server.store.PatchInstanceUserGrant(ctx, &api.InstanceUserGrantPatch{
+		ID:        grant.ID,
+		UpdaterID: api.SystemBotID,
+		Status:    &revoked,
+	}); err != nil {
+		return errors.Wrap(err, "failed to mark the grant as revoked")
+	}
+	r.server.syncInstanceUser(ctx, instance, driver, g)
+
+	comment := fmt.Sprintf("Revoked %s on database %q from %s since the grant has expired.", strings.Join(g.privilegeList, ", "), grant.DatabaseName, g.getInstanceUserName())
+	if len(g.privilegeList) == 0 {
+		comment = fmt.Sprintf("The grant on database %q to %s has expired, and no privilege is revoked since they are all granted by the other active grants.", grant.DatabaseName, g.getInstanceUserName())
+	}
+	if err := r.createIssueComment(ctx, grant.IssueID, comment); err != nil {
+		log.Warn("Failed to create issue comment for the revoked grant", zap.Int("issue_id", grant.IssueID), zap.Error(err))
+	}
+	return nil
+}
+
+// getRevokePrivilegeList returns the privileges of the expired grant which are not granted by the other active grants of the same user on the same database.
+// ALL PRIVILEGES of the expired grant is expanded to the individual privileges if some of them are granted by the other grants.
+func getRevokePrivilegeList(engine db.Type, grant *api.InstanceUserGrant, activeGrantList []*api.InstanceUserGrant, now int64) []string {
+	kept := make(map[string]bool)
+	for _, active := range activeGrantList {
+		if active.ID == grant.ID || active.InstanceID != grant.InstanceID || active.DatabaseName != grant.DatabaseName || active.UserName != grant.UserName || active.Host != grant.Host {
+			continue
+		}
+		// The other expired grants are revoked as well.
+		if active.Status != api.InstanceUserGrantActive || (active.ExpireTs != 0 && active.ExpireTs <= now) {
+			continue
+		}
+		for _, privilege := range active.PrivilegeList {
+			kept[privilege] = true
+		}
+	}
+	if kept[allPrivileges] {
+		return nil
+	}
+	if len(kept) == 0 {
+		return grant.PrivilegeList
+	}
+
+	revokeList := grant.PrivilegeList
+	for _, privilege := range grant.PrivilegeList {
+		if privilege == allPrivileges {
+			revokeList = nil
+			for p := range getGrantPrivileges(engine) {
+				if p != allPrivileges {
+					revokeList = append(revokeList, p)
+				}
+			}
+			break
+		}
+	}
+	var result []string
+	for _, privilege := range revokeList {
+		if !kept[privilege] {
+			result = append(result, privilege)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+func (r *GrantRevoker) createIssueComment(ctx context.Context, issueID int, comment string) error {
+	issue, err := r.server.store.GetIssueByID(ctx, issueID)
+	if err != nil {
+		return err
+	}
+	if issue == nil {
+		return errors.Errorf("issue not found with ID %d", issueID)
+	}
+	bytes, err := json.Marshal(api.ActivityIssueCommentCreatePayload{
+		IssueName: issue.Name,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal activity payload")
+	}
+	activityCreate := &api.ActivityCreate{
+		CreatorID:   api.SystemBotID,
+		ContainerID: issue.ID,
+		Type:        api.ActivityIssueCommentCreate,
+		Level:       api.ActivityInfo,
+		Comment:     comment,
+		Payload:     string(bytes),
+	}
+	_, err = r.server.ActivityManager.CreateActivity(ctx, activityCreate, &ActivityMeta{issue: issue})
+	return err
+}
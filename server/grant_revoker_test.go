@@ -0,0 +1,93 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/plugin/db"
+)
+
+func TestGetRevokePrivilegeList(t *testing.T) {
+	const now = 1000
+	newGrant := func(id int, privilegeList []string, expireTs int64) *api.InstanceUserGrant {
+		return &api.InstanceUserGrant{
+			ID:            id,
+			InstanceID:    1,
+			DatabaseName:  "employee",
+			UserName:      "bob",
+			Host:          "%",
+			PrivilegeList: privilegeList,
+			Status:        api.InstanceUserGrantActive,
+			ExpireTs:      expireTs,
+		}
+	}
+	expired := newGrant(1, []string{"INSERT", "SELECT", "UPDATE"}, now-1)
+
+	tests := []struct {
+		name            string
+		grant           *api.InstanceUserGrant
+		activeGrantList []*api.InstanceUserGrant
+		want            []string
+	}{
+		{
+			name:            "no other grant",
+			grant:           expired,
+			activeGrantList: []*api.InstanceUserGrant{expired},
+			want:            []string{"INSERT", "SELECT", "UPDATE"},
+		},
+		{
+			name:  "overlapping active grant",
+			grant: expired,
+			activeGrantList: []*api.InstanceUserGrant{
+				expired,
+				newGrant(2, []string{"SELECT"}, now+100),
+				newGrant(3, []string{"UPDATE"}, 0 /* never expires */),
+			},
+			want: []string{"INSERT"},
+		},
+		{
+			name:  "overlapping grant also expired",
+			grant: expired,
+			activeGrantList: []*api.InstanceUserGrant{
+				expired,
+				newGrant(2, []string{"SELECT"}, now),
+			},
+			want: []string{"INSERT", "SELECT", "UPDATE"},
+		},
+		{
+			name:  "overlapping grant of another user or database",
+			grant: expired,
+			activeGrantList: []*api.InstanceUserGrant{
+				expired,
+				{ID: 2, InstanceID: 1, DatabaseName: "employee", UserName: "alice", Host: "%", PrivilegeList: []string{"SELECT"}, Status: api.InstanceUserGrantActive},
+				{ID: 3, InstanceID: 1, DatabaseName: "hr", UserName: "bob", Host: "%", PrivilegeList: []string{"SELECT"}, Status: api.InstanceUserGrantActive},
+				{ID: 4, InstanceID: 1, DatabaseName: "employee", UserName: "bob", Host: "localhost", PrivilegeList: []string{"SELECT"}, Status: api.InstanceUserGrantActive},
+			},
+			want: []string{"INSERT", "SELECT", "UPDATE"},
+		},
+		{
+			name:  "all privileges granted by active grant",
+			grant: expired,
+			activeGrantList: []*api.InstanceUserGrant{
+				expired,
+				newGrant(2, []string{"ALL PRIVILEGES"}, 0 /* never expires */),
+			},
+			want: nil,
+		},
+		{
+			name:  "expired all privileges expanded",
+			grant: newGrant(1, []string{"ALL PRIVILEGES"}, now-1),
+			activeGrantList: []*api.InstanceUserGrant{
+				newGrant(2, []string{"SELECT"}, 0 /* never expires */),
+			},
+			want: []string{"DELETE", "INSERT", "REFERENCES", "TRIGGER", "TRUNCATE", "UPDATE"},
+		},
+	}
+
+	for _, test := range tests {
+		got := getRevokePrivilegeList(db.Postgres, test.grant, test.activeGrantList, now)
+		require.Equal(t, test.want, got, test.name)
+	}
+}
@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
@@ -43,6 +44,10 @@ func (s *Server) registerInstanceRoutes(g *echo.Group) {
 		if err := s.disallowBytebaseStore(instanceCreate.Engine, instanceCreate.Host, instanceCreate.Port); err != nil {
 			return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
 		}
+		// The SSH tunnel is only available in the dev schema for now.
+		if s.profile.Mode != common.ReleaseModeDev && instanceCreate.SSHHost != "" {
+			return echo.NewHTTPError(http.StatusBadRequest, "SSH tunnel is not supported yet")
+		}
 
 		instance, err := s.store.CreateInstance(ctx, instanceCreate)
 		if err != nil {
@@ -189,6 +194,8 @@ func (s *Server) registerInstanceRoutes(g *echo.Group) {
 				}
 				return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to patch instance ID: %v", id)).SetInternal(err)
 			}
+			// Close the cached drivers connected to the old host and port, or of the archived instance.
+			s.DriverManager.Invalidate(id)
 		}
 
 		// Try immediately setup the migration schema, sync the engine version and schema after updating any connection related info.
@@ -377,28 +384,103 @@ func (s *Server) registerInstanceRoutes(g *echo.Group) {
 		entry := list[0]
 
 		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
-		if err := jsonapi.MarshalPayload(c.Response().Writer, &api.MigrationHistory{
-			ID:                    entry.ID,
-			Creator:               entry.Creator,
-			CreatedTs:             entry.CreatedTs,
-			Updater:               entry.Updater,
-			UpdatedTs:             entry.UpdatedTs,
-			ReleaseVersion:        entry.ReleaseVersion,
-			Database:              entry.Namespace,
-			Source:                entry.Source,
-			Type:                  entry.Type,
-			Status:                entry.Status,
-			Version:               entry.Version,
-			UseSemanticVersion:    entry.UseSemanticVersion,
-			SemanticVersionSuffix: entry.SemanticVersionSuffix,
-			Description:           entry.Description,
-			Statement:             entry.Statement,
-			Schema:                entry.Schema,
-			SchemaPrev:            entry.SchemaPrev,
-			ExecutionDurationNs:   entry.ExecutionDurationNs,
-			IssueID:               entry.IssueID,
-			Payload:               entry.Payload,
-		}); err != nil {
+		if err := jsonapi.MarshalPayload(c.Response().Writer, convertToAPIMigrationHistory(entry)); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to marshal migration history response for instance: %v", instance.Name)).SetInternal(err)
+		}
+		return nil
+	})
+
+	g.POST("/instance/:instanceID/migration/history/:historyID/accept-modified", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		id, err := strconv.Atoi(c.Param("instanceID"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Instance ID is not a number: %s", c.Param("instanceID"))).SetInternal(err)
+		}
+
+		historyID, err := strconv.Atoi(c.Param("historyID"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("History ID is not a number: %s", c.Param("historyID"))).SetInternal(err)
+		}
+
+		accept := &api.MigrationHistoryStatementChecksumAccept{
+			UpdaterID: c.Get(getPrincipalIDContextKey()).(int),
+		}
+		if err := jsonapi.UnmarshalPayload(c.Request().Body, accept); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Malformed accept modified migration request").SetInternal(err)
+		}
+		if accept.ActivityID == 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "Activity ID is required")
+		}
+		// The statement checksum is derived from the activity flagging the modified migration file instead of trusting the client.
+		activity, err := s.store.GetActivityByID(ctx, accept.ActivityID)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch activity ID: %v", accept.ActivityID)).SetInternal(err)
+		}
+		if activity == nil || activity.Type != api.ActivityPipelineTaskMigrationModified {
+			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Modified migration activity ID not found: %d", accept.ActivityID))
+		}
+		modified := &api.ActivityPipelineTaskMigrationModifiedPayload{}
+		if err := json.Unmarshal([]byte(activity.Payload), modified); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to unmarshal the payload of activity ID %d", accept.ActivityID)).SetInternal(err)
+		}
+		if modified.InstanceID != id || modified.MigrationHistoryID != historyID {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Activity ID %d doesn't flag migration history ID %d of instance ID %d", accept.ActivityID, historyID, id))
+		}
+
+		instance, err := s.store.GetInstanceByID(ctx, id)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch instance ID: %v", id)).SetInternal(err)
+		}
+		if instance == nil {
+			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Instance ID not found: %d", id))
+		}
+
+		driver, err := s.getAdminDatabaseDriver(ctx, instance, "" /* databaseName */)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to connect instance %q", instance.Name)).SetInternal(err)
+		}
+		defer driver.Close(ctx)
+		list, err := driver.FindMigrationHistoryList(ctx, &db.MigrationHistoryFind{ID: &historyID})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to fetch migration history list").SetInternal(err)
+		}
+		if len(list) == 0 {
+			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Migration history ID %d not found for instance %q", historyID, instance.Name))
+		}
+		if list[0].Status != db.Done {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Migration history ID %d is not applied", historyID))
+		}
+		if err := driver.UpdateMigrationHistoryStatementChecksum(ctx, historyID, modified.NewStatementChecksum); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to accept the modified statement of migration history ID %d", historyID)).SetInternal(err)
+		}
+		list, err = driver.FindMigrationHistoryList(ctx, &db.MigrationHistoryFind{ID: &historyID})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to fetch migration history list").SetInternal(err)
+		}
+		if len(list) == 0 {
+			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Migration history ID %d not found for instance %q", historyID, instance.Name))
+		}
+		entry := list[0]
+
+		// Leave a trace on the issue applying the migration, where the modification is flagged.
+		issue, err := s.store.GetIssueByID(ctx, activity.ContainerID)
+		if err != nil {
+			log.Warn("Failed to find the issue of the modified migration", zap.Int("issueID", activity.ContainerID), zap.Error(err))
+		} else if issue != nil {
+			activityCreate := &api.ActivityCreate{
+				CreatorID:   accept.UpdaterID,
+				ContainerID: issue.ID,
+				Type:        api.ActivityIssueCommentCreate,
+				Level:       api.ActivityInfo,
+				Comment:     fmt.Sprintf("Accepted the modified statement of version %s applied to database %q.", entry.Version, entry.Namespace),
+			}
+			if _, err := s.ActivityManager.CreateActivity(ctx, activityCreate, &ActivityMeta{issue: issue}); err != nil {
+				log.Warn("Failed to create the issue activity for accepting the modified migration", zap.Int("issueID", issue.ID), zap.Error(err))
+			}
+		}
+
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+		if err := jsonapi.MarshalPayload(c.Response().Writer, convertToAPIMigrationHistory(entry)); err != nil {
 			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to marshal migration history response for instance: %v", instance.Name)).SetInternal(err)
 		}
 		return nil
@@ -448,28 +530,7 @@ func (s *Server) registerInstanceRoutes(g *echo.Group) {
 		}
 
 		for _, entry := range list {
-			historyList = append(historyList, &api.MigrationHistory{
-				ID:                    entry.ID,
-				Creator:               entry.Creator,
-				CreatedTs:             entry.CreatedTs,
-				Updater:               entry.Updater,
-				UpdatedTs:             entry.UpdatedTs,
-				ReleaseVersion:        entry.ReleaseVersion,
-				Database:              entry.Namespace,
-				Source:                entry.Source,
-				Type:                  entry.Type,
-				Status:                entry.Status,
-				Version:               entry.Version,
-				UseSemanticVersion:    entry.UseSemanticVersion,
-				SemanticVersionSuffix: entry.SemanticVersionSuffix,
-				Description:           entry.Description,
-				Statement:             entry.Statement,
-				Schema:                entry.Schema,
-				SchemaPrev:            entry.SchemaPrev,
-				ExecutionDurationNs:   entry.ExecutionDurationNs,
-				IssueID:               entry.IssueID,
-				Payload:               entry.Payload,
-			})
+			historyList = append(historyList, convertToAPIMigrationHistory(entry))
 		}
 
 		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
@@ -533,3 +594,28 @@ func (s *Server) disallowBytebaseStore(engine db.Type, host, port string) error
 	}
 	return nil
 }
+
+func convertToAPIMigrationHistory(entry *db.MigrationHistory) *api.MigrationHistory {
+	return &api.MigrationHistory{
+		ID:                    entry.ID,
+		Creator:               entry.Creator,
+		CreatedTs:             entry.CreatedTs,
+		Updater:               entry.Updater,
+		UpdatedTs:             entry.UpdatedTs,
+		ReleaseVersion:        entry.ReleaseVersion,
+		Database:              entry.Namespace,
+		Source:                entry.Source,
+		Type:                  entry.Type,
+		Status:                entry.Status,
+		Version:               entry.Version,
+		UseSemanticVersion:    entry.UseSemanticVersion,
+		SemanticVersionSuffix: entry.SemanticVersionSuffix,
+		Description:           entry.Description,
+		Statement:             entry.Statement,
+		Schema:                entry.Schema,
+		SchemaPrev:            entry.SchemaPrev,
+		ExecutionDurationNs:   entry.ExecutionDurationNs,
+		IssueID:               entry.IssueID,
+		Payload:               entry.Payload,
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/jsonapi"
 	"github.com/labstack/echo/v4"
@@ -466,8 +467,12 @@ func (s *Server) getPipelineCreate(ctx context.Context, issueCreate *api.IssueCr
 	switch issueCreate.Type {
 	case api.IssueDatabaseCreate:
 		return s.getPipelineCreateForDatabaseCreate(ctx, issueCreate)
+	case api.IssueDatabaseGrant:
+		return s.getPipelineCreateForDatabaseGrant(ctx, issueCreate)
 	case api.IssueDatabaseRestorePITR:
 		return s.getPipelineCreateForDatabasePITR(ctx, issueCreate)
+	case api.IssueDatabaseRollback:
+		return s.getPipelineCreateForDatabaseRollback(ctx, issueCreate)
 	case api.IssueDatabaseSchemaUpdate, api.IssueDatabaseDataUpdate:
 		return s.getPipelineCreateForDatabaseSchemaAndDataUpdate(ctx, issueCreate)
 	case api.IssueDatabaseSchemaUpdateGhost:
@@ -569,6 +574,75 @@ func (s *Server) getPipelineCreateForDatabaseCreate(ctx context.Context, issueCr
 	}, nil
 }
 
+func (s *Server) getPipelineCreateForDatabaseGrant(ctx context.Context, issueCreate *api.IssueCreate) (*api.PipelineCreate, error) {
+	if s.profile.Mode != common.ReleaseModeDev {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Database grant is not supported yet")
+	}
+	c := api.DatabaseGrantContext{}
+	if err := json.Unmarshal([]byte(issueCreate.CreateContext), &c); err != nil {
+		return nil, err
+	}
+	if c.UserName == "" {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Failed to create issue, user name missing")
+	}
+	if c.ExpireTs != 0 && c.ExpireTs <= time.Now().Unix() {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Failed to create issue, the expiration time has passed")
+	}
+
+	database, err := s.store.GetDatabase(ctx, &api.DatabaseFind{ID: &c.DatabaseID})
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch database ID: %v", c.DatabaseID)).SetInternal(err)
+	}
+	if database == nil {
+		return nil, echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Database ID not found: %d", c.DatabaseID))
+	}
+	if database.ProjectID != issueCreate.ProjectID {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("The issue project %d must be the same as the database project %d.", issueCreate.ProjectID, database.ProjectID))
+	}
+	privilegeList, err := normalizeGrantPrivilegeList(database.Instance.Engine, c.PrivilegeList)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Failed to create issue, %v", err))
+	}
+
+	payload := api.TaskDatabaseGrantPayload{
+		UserName:      c.UserName,
+		PrivilegeList: privilegeList,
+		ExpireTs:      c.ExpireTs,
+	}
+	if database.Instance.Engine == db.MySQL || database.Instance.Engine == db.TiDB {
+		payload.Host = c.Host
+		if payload.Host == "" {
+			payload.Host = "%"
+		}
+	}
+	bytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create database grant task, unable to marshal payload")
+	}
+
+	return &api.PipelineCreate{
+		Name: fmt.Sprintf("Pipeline - Grant database %s", database.Name),
+		StageList: []api.StageCreate{
+			{
+				Name:          "Grant database",
+				EnvironmentID: database.Instance.Environment.ID,
+				TaskList: []api.TaskCreate{
+					{
+						InstanceID:   database.InstanceID,
+						DatabaseID:   &database.ID,
+						Name:         fmt.Sprintf("Grant %s on database %q to %s", strings.Join(privilegeList, ", "), database.Name, c.UserName),
+						Status:       api.TaskPendingApproval,
+						Type:         api.TaskDatabaseGrant,
+						DatabaseName: database.Name,
+						Payload:      string(bytes),
+						Secret:       c.Password,
+					},
+				},
+			},
+		},
+	}, nil
+}
+
 func (s *Server) getPipelineCreateForDatabasePITR(ctx context.Context, issueCreate *api.IssueCreate) (*api.PipelineCreate, error) {
 	c := api.PITRContext{}
 	if err := json.Unmarshal([]byte(issueCreate.CreateContext), &c); err != nil {
@@ -607,6 +681,81 @@ func (s *Server) getPipelineCreateForDatabasePITR(ctx context.Context, issueCrea
 	}, nil
 }
 
+func (s *Server) getPipelineCreateForDatabaseRollback(ctx context.Context, issueCreate *api.IssueCreate) (*api.PipelineCreate, error) {
+	c := api.DatabaseRollbackContext{}
+	if err := json.Unmarshal([]byte(issueCreate.CreateContext), &c); err != nil {
+		return nil, err
+	}
+	if c.Version == "" {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "Failed to create issue, version missing")
+	}
+
+	database, err := s.store.GetDatabase(ctx, &api.DatabaseFind{ID: &c.DatabaseID})
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch database ID: %v", c.DatabaseID)).SetInternal(err)
+	}
+	if database == nil {
+		return nil, echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Database ID not found: %d", c.DatabaseID))
+	}
+	if database.ProjectID != issueCreate.ProjectID {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("The issue project %d must be the same as the database project %d.", issueCreate.ProjectID, database.ProjectID))
+	}
+
+	driver, err := s.getAdminDatabaseDriver(ctx, database.Instance, "" /* databaseName */)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, "Failed to connect to the instance").SetInternal(err)
+	}
+	defer driver.Close(ctx)
+	historyList, err := driver.FindMigrationHistoryList(ctx, &db.MigrationHistoryFind{Database: &database.Name})
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch the migration history of database %q", database.Name)).SetInternal(err)
+	}
+	undoList, err := getUndoMigrationList(historyList, c.Version)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Failed to create issue, %v", err))
+	}
+
+	var taskCreateList []api.TaskCreate
+	var taskIndexDAGList []api.TaskIndexDAG
+	for i, undo := range undoList {
+		payload := api.TaskDatabaseUndoPayload{
+			Statement:     undo.statement,
+			SchemaVersion: getUndoSchemaVersion(undo.version),
+			UndoVersion:   undo.version,
+		}
+		bytes, err := json.Marshal(payload)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create database undo task, unable to marshal payload")
+		}
+		taskCreateList = append(taskCreateList, api.TaskCreate{
+			InstanceID:   database.InstanceID,
+			DatabaseID:   &database.ID,
+			Name:         fmt.Sprintf("Undo version %s of database %q", undo.version, database.Name),
+			Status:       api.TaskPendingApproval,
+			Type:         api.TaskDatabaseUndo,
+			DatabaseName: database.Name,
+			Statement:    undo.statement,
+			Payload:      string(bytes),
+		})
+		// The migrations are reversed one by one from the most recent.
+		if i > 0 {
+			taskIndexDAGList = append(taskIndexDAGList, api.TaskIndexDAG{FromIndex: i - 1, ToIndex: i})
+		}
+	}
+
+	return &api.PipelineCreate{
+		Name: fmt.Sprintf("Pipeline - Roll back database %s to version %s", database.Name, c.Version),
+		StageList: []api.StageCreate{
+			{
+				Name:             "Roll back database",
+				EnvironmentID:    database.Instance.Environment.ID,
+				TaskList:         taskCreateList,
+				TaskIndexDAGList: taskIndexDAGList,
+			},
+		},
+	}, nil
+}
+
 func (s *Server) getPipelineCreateForDatabaseSchemaAndDataUpdate(ctx context.Context, issueCreate *api.IssueCreate) (*api.PipelineCreate, error) {
 	c := api.MigrationContext{}
 	if err := json.Unmarshal([]byte(issueCreate.CreateContext), &c); err != nil {
@@ -854,6 +1003,10 @@ func getUpdateTask(database *api.Database, vcsPushEvent *vcs.PushEvent, d *api.M
 	var taskName string
 	var taskType api.TaskType
 
+	if d.UndoStatement != "" && d.MigrationType != db.Migrate && d.MigrationType != db.Data {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Undo statement is not supported for migration type %q", d.MigrationType))
+	}
+
 	var payloadString string
 	switch d.MigrationType {
 	case db.Baseline:
@@ -876,6 +1029,7 @@ func getUpdateTask(database *api.Database, vcsPushEvent *vcs.PushEvent, d *api.M
 			Statement:     d.Statement,
 			SchemaVersion: schemaVersion,
 			VCSPushEvent:  vcsPushEvent,
+			UndoStatement: d.UndoStatement,
 		}
 		bytes, err := json.Marshal(payload)
 		if err != nil {
@@ -902,6 +1056,7 @@ func getUpdateTask(database *api.Database, vcsPushEvent *vcs.PushEvent, d *api.M
 			Statement:     d.Statement,
 			SchemaVersion: schemaVersion,
 			VCSPushEvent:  vcsPushEvent,
+			UndoStatement: d.UndoStatement,
 		}
 		bytes, err := json.Marshal(payload)
 		if err != nil {
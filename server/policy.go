@@ -40,6 +40,8 @@ func (s *Server) hasAccessToUpsertPolicy(policyUpsert *api.PolicyUpsert) error {
 		}
 	case api.PolicyTypeSQLReview:
 		return nil
+	case api.PolicyTypeSQLQueryTimeout:
+		return nil
 	case api.PolicyTypeEnvironmentTier:
 		if !s.feature(api.FeatureEnvironmentTierPolicy) {
 			return errors.Errorf(api.FeatureEnvironmentTierPolicy.AccessErrorMessage())
@@ -32,7 +32,7 @@ import (
 	enterpriseService "github.com/bytebase/bytebase/enterprise/service"
 	"github.com/bytebase/bytebase/metric"
 	metricCollector "github.com/bytebase/bytebase/metric/collector"
-	s3bb "github.com/bytebase/bytebase/plugin/storage/s3"
+	"github.com/bytebase/bytebase/plugin/storage"
 	"github.com/bytebase/bytebase/resources/mysqlutil"
 	"github.com/bytebase/bytebase/resources/postgres"
 	"github.com/bytebase/bytebase/store"
@@ -49,7 +49,11 @@ type Server struct {
 	MetricReporter     *MetricReporter
 	SchemaSyncer       *SchemaSyncer
 	BackupRunner       *BackupRunner
+	BackupVerifier     *BackupVerifier
 	AnomalyScanner     *AnomalyScanner
+	GrantRevoker       *GrantRevoker
+	DriverManager      *DriverManager
+	SQLQueryManager    *SQLQueryManager
 	runnerWG           sync.WaitGroup
 
 	ActivityManager *ActivityManager
@@ -57,17 +61,20 @@ type Server struct {
 	LicenseService enterpriseAPI.LicenseService
 	subscription   enterpriseAPI.Subscription
 
-	profile         Profile
-	e               *echo.Echo
-	pgInstance      *postgres.Instance
-	metaDB          *store.MetadataDB
-	store           *store.Store
-	startedTs       int64
-	secret          string
-	workspaceID     string
-	errorRecordRing api.ErrorRecordRing
-
-	s3Client *s3bb.Client
+	profile     Profile
+	e           *echo.Echo
+	pgInstance  *postgres.Instance
+	metaDB      *store.MetadataDB
+	store       *store.Store
+	startedTs   int64
+	secret      string
+	workspaceID string
+	// backupEncryptionKey is the workspace key to encrypt and decrypt backups.
+	backupEncryptionKey string
+	errorRecordRing     api.ErrorRecordRing
+
+	// backupStorage is the cloud storage for backups and binlogs, it's nil if backups are stored locally.
+	backupStorage storage.Backend
 
 	// boot specifies that whether the server boot correctly
 	cancel context.CancelFunc
@@ -194,6 +201,7 @@ func NewServer(ctx context.Context, prof Profile) (*Server, error) {
 	}
 	s.secret = config.secret
 	s.workspaceID = config.workspaceID
+	s.backupEncryptionKey = config.backupEncryptionKey
 
 	e := echo.New()
 	e.Debug = prof.Debug
@@ -209,17 +217,19 @@ func NewServer(ctx context.Context, prof Profile) (*Server, error) {
 	s.e = e
 
 	if prof.BackupBucket != "" {
-		credentials, err := s3bb.GetCredentialsFromFile(ctx, prof.BackupCredentialFile)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to get credentials from file")
-		}
-		s3Client, err := s3bb.NewClient(ctx, prof.BackupRegion, prof.BackupBucket, credentials)
+		backupStorage, err := newBackupStorage(ctx, prof)
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to create AWS S3 client")
+			return nil, errors.Wrapf(err, "failed to create %s client for the backup bucket", prof.BackupStorageBackend)
 		}
-		s.s3Client = s3Client
+		s.backupStorage = backupStorage
 	}
 
+	// Driver manager
+	s.DriverManager = NewDriverManager(prof.MaxDriversPerInstance, prof.DriverIdleTimeout)
+
+	// SQL query manager
+	s.SQLQueryManager = NewSQLQueryManager()
+
 	if !prof.Readonly {
 		// Task scheduler
 		taskScheduler := NewTaskScheduler(s)
@@ -228,6 +238,8 @@ func NewServer(ctx context.Context, prof Profile) (*Server, error) {
 
 		taskScheduler.Register(api.TaskDatabaseCreate, NewDatabaseCreateTaskExecutor)
 
+		taskScheduler.Register(api.TaskDatabaseGrant, NewDatabaseGrantTaskExecutor)
+
 		taskScheduler.Register(api.TaskDatabaseSchemaBaseline, NewSchemaBaselineTaskExecutor)
 
 		taskScheduler.Register(api.TaskDatabaseSchemaUpdate, NewSchemaUpdateTaskExecutor)
@@ -236,6 +248,8 @@ func NewServer(ctx context.Context, prof Profile) (*Server, error) {
 
 		taskScheduler.Register(api.TaskDatabaseDataUpdate, NewDataUpdateTaskExecutor)
 
+		taskScheduler.Register(api.TaskDatabaseUndo, NewDatabaseUndoTaskExecutor)
+
 		taskScheduler.Register(api.TaskDatabaseBackup, NewDatabaseBackupTaskExecutor)
 
 		taskScheduler.Register(api.TaskDatabaseSchemaUpdateGhostSync, NewSchemaUpdateGhostSyncTaskExecutor)
@@ -261,6 +275,9 @@ func NewServer(ctx context.Context, prof Profile) (*Server, error) {
 		statementTypeExecutor := NewTaskCheckStatementTypeExecutor()
 		taskCheckScheduler.Register(api.TaskCheckDatabaseStatementType, statementTypeExecutor)
 
+		statementDryRunExecutor := NewTaskCheckStatementDryRunExecutor()
+		taskCheckScheduler.Register(api.TaskCheckDatabaseStatementDryRun, statementDryRunExecutor)
+
 		databaseConnectExecutor := NewTaskCheckDatabaseConnectExecutor()
 		taskCheckScheduler.Register(api.TaskCheckDatabaseConnect, databaseConnectExecutor)
 
@@ -284,9 +301,20 @@ func NewServer(ctx context.Context, prof Profile) (*Server, error) {
 		// Backup runner
 		s.BackupRunner = NewBackupRunner(s, prof.BackupRunnerInterval)
 
+		// Backup verifier
+		if prof.BackupVerificationInterval > 0 {
+			s.BackupVerifier = NewBackupVerifier(s, prof.BackupVerificationInterval)
+		}
+
 		// Anomaly scanner
 		s.AnomalyScanner = NewAnomalyScanner(s)
 
+		// Grant revoker
+		// The database grant is only available in dev mode for now.
+		if prof.Mode == common.ReleaseModeDev {
+			s.GrantRevoker = NewGrantRevoker(s)
+		}
+
 		// Metric reporter
 		s.initMetricReporter(config.workspaceID)
 	}
@@ -451,6 +479,22 @@ func getInitSetting(ctx context.Context, store *store.Store) (*config, error) {
 	}
 	conf.workspaceID = workspaceSetting.Value
 
+	// initial backup encryption key
+	backupEncryptionKey, err := common.RandomString(secretLength)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate random backup encryption key")
+	}
+	backupEncryptionKeySetting, err := store.CreateSettingIfNotExist(ctx, &api.SettingCreate{
+		CreatorID:   api.SystemBotID,
+		Name:        api.SettingBackupEncryptionKey,
+		Value:       backupEncryptionKey,
+		Description: "Random string used to encrypt backups.",
+	})
+	if err != nil {
+		return nil, err
+	}
+	conf.backupEncryptionKey = backupEncryptionKeySetting.Value
+
 	// initial license
 	if _, err = store.CreateSettingIfNotExist(ctx, &api.SettingCreate{
 		CreatorID:   api.SystemBotID,
@@ -478,11 +522,13 @@ func getInitSetting(ctx context.Context, store *store.Store) (*config, error) {
 func (s *Server) Run(ctx context.Context, port int) error {
 	ctx, cancel := context.WithCancel(ctx)
 	s.cancel = cancel
+	// runnerWG waits for all goroutines to complete.
+	s.runnerWG.Add(1)
+	go s.DriverManager.Run(ctx, &s.runnerWG)
 	if !s.profile.Readonly {
 		if err := s.TaskScheduler.ClearRunningTasks(ctx); err != nil {
 			return errors.Wrap(err, "failed to clear existing RUNNING tasks before start the task scheduler")
 		}
-		// runnerWG waits for all goroutines to complete.
 		s.runnerWG.Add(1)
 		go s.TaskScheduler.Run(ctx, &s.runnerWG)
 		s.runnerWG.Add(1)
@@ -494,6 +540,15 @@ func (s *Server) Run(ctx context.Context, port int) error {
 		s.runnerWG.Add(1)
 		go s.AnomalyScanner.Run(ctx, &s.runnerWG)
 
+		if s.GrantRevoker != nil {
+			s.runnerWG.Add(1)
+			go s.GrantRevoker.Run(ctx, &s.runnerWG)
+		}
+
+		if s.BackupVerifier != nil {
+			s.runnerWG.Add(1)
+			go s.BackupVerifier.Run(ctx, &s.runnerWG)
+		}
 		if s.MetricReporter != nil {
 			s.runnerWG.Add(1)
 			go s.MetricReporter.Run(ctx, &s.runnerWG)
@@ -1,12 +1,14 @@
 package server
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -23,11 +25,15 @@ import (
 	advisorDB "github.com/bytebase/bytebase/plugin/advisor/db"
 	"github.com/bytebase/bytebase/plugin/db"
 	"github.com/bytebase/bytebase/plugin/db/util"
-	"github.com/bytebase/bytebase/plugin/parser"
-	"github.com/bytebase/bytebase/plugin/parser/ast"
 	"github.com/bytebase/bytebase/store"
 )
 
+const (
+	// maxSQLResultBytes is the maximum size of the row data returned by a SQL editor query.
+	// The rows beyond the limit are not read from the database.
+	maxSQLResultBytes = 16 * 1024 * 1024
+)
+
 func (s *Server) registerSQLRoutes(g *echo.Group) {
 	g.POST("/sql/ping", func(c echo.Context) error {
 		ctx := c.Request().Context()
@@ -73,6 +79,27 @@ func (s *Server) registerSQLRoutes(g *echo.Group) {
 				return echo.NewHTTPError(http.StatusBadRequest, "TLS/SSL suite must all be set or not be set")
 			}
 		}
+		sshConfig := db.SSHConfig{
+			Host:       connectionInfo.SSHHost,
+			Port:       connectionInfo.SSHPort,
+			User:       connectionInfo.SSHUser,
+			Password:   connectionInfo.SSHPassword,
+			PrivateKey: connectionInfo.SSHPrivateKey,
+			HostKey:    connectionInfo.SSHHostKey,
+		}
+		// Like the password, the SSH password and private key are not transferred back to client, so we use the existing ones if they are not specified.
+		if sshConfig.Enabled() && sshConfig.Password == "" && sshConfig.PrivateKey == "" && connectionInfo.InstanceID != nil {
+			instance, err := s.store.GetInstanceByID(ctx, *connectionInfo.InstanceID)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to retrieve instance: %d", *connectionInfo.InstanceID)).SetInternal(err)
+			}
+			if instance != nil {
+				if adminDataSource := api.DataSourceFromInstanceWithType(instance, api.Admin); adminDataSource != nil {
+					sshConfig.Password = adminDataSource.SSHPassword
+					sshConfig.PrivateKey = adminDataSource.SSHPrivateKey
+				}
+			}
+		}
 		db, err := db.Open(
 			ctx,
 			connectionInfo.Engine,
@@ -83,6 +110,7 @@ func (s *Server) registerSQLRoutes(g *echo.Group) {
 				Host:      connectionInfo.Host,
 				Port:      connectionInfo.Port,
 				TLSConfig: tlsConfig,
+				SSHConfig: sshConfig,
 			},
 			db.ConnectionContext{},
 		)
@@ -181,6 +209,13 @@ func (s *Server) registerSQLRoutes(g *echo.Group) {
 			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Instance ID not found: %d", exec.InstanceID))
 		}
 
+		// The driver is leased once and shared by the SQL review, the query and the query plan check of the request,
+		// so that concurrent requests don't exhaust the drivers of the instance while each holding more than one.
+		driver, driverErr := s.tryGetReadOnlyDatabaseDriver(ctx, instance, exec.DatabaseName)
+		if driverErr == nil {
+			defer driver.Close(ctx)
+		}
+
 		adviceLevel := advisor.Success
 		adviceList := []advisor.Advice{}
 
@@ -211,11 +246,9 @@ func (s *Server) registerSQLRoutes(g *echo.Group) {
 				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create a catalog")
 			}
 
-			driver, err := tryGetReadOnlyDatabaseDriver(ctx, instance, exec.DatabaseName)
-			if err != nil {
-				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get database driver").SetInternal(err)
+			if driverErr != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get database driver").SetInternal(driverErr)
 			}
-			defer driver.Close(ctx)
 			connection, err := driver.GetDBConnection(ctx, exec.DatabaseName)
 			if err != nil {
 				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to get database connection").SetInternal(err)
@@ -259,38 +292,41 @@ func (s *Server) registerSQLRoutes(g *echo.Group) {
 			}
 		}
 
+		timeoutPolicy, err := s.store.GetSQLQueryTimeoutPolicyByEnvID(ctx, instance.EnvironmentID)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to get SQL query timeout policy for environment %d", instance.EnvironmentID)).SetInternal(err)
+		}
+		timeout := time.Duration(timeoutPolicy.TimeoutSec) * time.Second
+
 		start := time.Now().UnixNano()
 
-		bytes, queryErr := func() ([]byte, error) {
-			driver, err := tryGetReadOnlyDatabaseDriver(ctx, instance, exec.DatabaseName)
-			if err != nil {
-				return nil, err
+		bytes, truncated, queryErr := s.runSQLEditorQuery(ctx, c, exec, timeout, func(ctx context.Context) ([]byte, bool, error) {
+			if driverErr != nil {
+				return nil, false, driverErr
 			}
-			defer driver.Close(ctx)
-
-			rowSet, err := driver.Query(ctx, exec.Statement, exec.Limit, true /* readOnly */)
+			rows, err := driver.QueryRows(ctx, exec.Statement, getQueryRowLimit(exec.Limit), true /* readOnly */)
 			if err != nil {
-				return nil, err
+				return nil, false, err
 			}
+			defer rows.Close()
 
-			return json.Marshal(rowSet)
-		}()
+			return marshalQueryRows(rows, exec.Limit, maxSQLResultBytes)
+		})
 
-		if instance.Engine == db.Postgres {
-			stmts, err := parser.Parse(parser.Postgres, parser.ParseContext{}, exec.Statement)
+		// Check the query plan if the statement explains a query, so that users know whether the query uses indexes.
+		if explainedStatement, ok := getExplainedStatement(exec.Statement); ok && queryErr == nil && isExplainSupported(instance.Engine) {
+			_, planAdviceList, err := explainStatement(ctx, driver, explainedStatement)
 			if err != nil {
-				return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to parse: %s", exec.Statement)).SetInternal(err)
+				log.Warn("Failed to check the query plan", zap.String("statement", exec.Statement), zap.Error(err))
 			}
-			if len(stmts) != 1 {
-				return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Expected one statement, but found %d, statement: %s", len(stmts), exec.Statement))
-			}
-			if _, ok := stmts[0].(*ast.ExplainStmt); ok {
-				indexAdvice := checkPostgreSQLIndexHit(exec.Statement, string(bytes))
-				if len(indexAdvice) > 0 {
+			for _, advice := range planAdviceList {
+				if advice.Status == advisor.Error {
 					adviceLevel = advisor.Error
-					adviceList = append(adviceList, indexAdvice...)
+				} else if advice.Status == advisor.Warn && adviceLevel != advisor.Error {
+					adviceLevel = advisor.Warn
 				}
 			}
+			adviceList = append(adviceList, planAdviceList...)
 		}
 
 		if len(adviceList) == 0 {
@@ -328,6 +364,7 @@ func (s *Server) registerSQLRoutes(g *echo.Group) {
 		resultSet := &api.SQLResultSet{AdviceList: adviceList}
 		if queryErr == nil {
 			resultSet.Data = string(bytes)
+			resultSet.Truncated = truncated
 			log.Debug("Query result advice",
 				zap.String("statement", exec.Statement),
 				zap.Array("advice", advisor.ZapAdviceArray(resultSet.AdviceList)),
@@ -381,20 +418,22 @@ func (s *Server) registerSQLRoutes(g *echo.Group) {
 		exec.Readonly = true
 		start := time.Now().UnixNano()
 
-		bytes, queryErr := func() ([]byte, error) {
+		// Admin queries are not timed out by the SQL query timeout policy, since they may be maintenance operations such as creating indexes.
+		bytes, truncated, queryErr := s.runSQLEditorQuery(ctx, c, exec, 0 /* timeout */, func(ctx context.Context) ([]byte, bool, error) {
 			driver, err := s.getAdminDatabaseDriver(ctx, instance, exec.DatabaseName)
 			if err != nil {
-				return nil, err
+				return nil, false, err
 			}
 			defer driver.Close(ctx)
 
-			rowSet, err := driver.Query(ctx, exec.Statement, exec.Limit, false /* readOnly */)
+			rows, err := driver.QueryRows(ctx, exec.Statement, getQueryRowLimit(exec.Limit), false /* readOnly */)
 			if err != nil {
-				return nil, err
+				return nil, false, err
 			}
+			defer rows.Close()
 
-			return json.Marshal(rowSet)
-		}()
+			return marshalQueryRows(rows, exec.Limit, maxSQLResultBytes)
+		})
 
 		level := api.ActivityInfo
 		errMessage := ""
@@ -417,6 +456,7 @@ func (s *Server) registerSQLRoutes(g *echo.Group) {
 		}
 		if queryErr == nil {
 			resultSet.Data = string(bytes)
+			resultSet.Truncated = truncated
 			log.Debug("Query result advice",
 				zap.String("statement", exec.Statement),
 			)
@@ -441,10 +481,157 @@ func (s *Server) registerSQLRoutes(g *echo.Group) {
 		}
 		return nil
 	})
+
+	g.POST("/sql/explain", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		explain := &api.SQLExplain{}
+		if err := jsonapi.UnmarshalPayload(c.Request().Body, explain); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Malformed sql explain request").SetInternal(err)
+		}
+
+		if explain.InstanceID == 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "Malformed sql explain request, missing instanceId")
+		}
+		if len(explain.Statement) == 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "Malformed sql explain request, missing sql statement")
+		}
+		if !validateSQLSelectStatement(explain.Statement) {
+			return echo.NewHTTPError(http.StatusBadRequest, "Malformed sql explain request, only support SELECT sql statement")
+		}
+		statement := explain.Statement
+		if explainedStatement, ok := getExplainedStatement(statement); ok {
+			statement = explainedStatement
+		}
+
+		instance, err := s.store.GetInstanceByID(ctx, explain.InstanceID)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, fmt.Sprintf("Failed to fetch instance ID: %v", explain.InstanceID)).SetInternal(err)
+		}
+		if instance == nil {
+			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Instance ID not found: %d", explain.InstanceID))
+		}
+		if !isExplainSupported(instance.Engine) {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Explain is not supported for %s", instance.Engine))
+		}
+
+		result := &api.SQLExplainResult{
+			AdviceList: []advisor.Advice{},
+		}
+		plan, adviceList, err := s.explainDatabaseStatement(ctx, instance, explain.DatabaseName, statement)
+		if err != nil {
+			result.Error = err.Error()
+		} else {
+			bytes, err := json.Marshal(plan)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to marshal query plan").SetInternal(err)
+			}
+			result.Plan = string(bytes)
+			result.AdviceList = append(result.AdviceList, adviceList...)
+		}
+
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+		if err := jsonapi.MarshalPayload(c.Response().Writer, result); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to marshal sql explain result response").SetInternal(err)
+		}
+		return nil
+	})
+
+	g.GET("/sql/query", func(c echo.Context) error {
+		queryList := s.SQLQueryManager.List(c.Get(getPrincipalIDContextKey()).(int))
+
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+		if err := jsonapi.MarshalPayload(c.Response().Writer, queryList); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, "Failed to marshal running sql query list response").SetInternal(err)
+		}
+		return nil
+	})
+
+	g.DELETE("/sql/query/:queryID", func(c echo.Context) error {
+		id, err := strconv.Atoi(c.Param("queryID"))
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("ID is not a number: %s", c.Param("queryID"))).SetInternal(err)
+		}
+
+		// Users can only cancel their own queries.
+		if !s.SQLQueryManager.Cancel(c.Get(getPrincipalIDContextKey()).(int), id) {
+			return echo.NewHTTPError(http.StatusNotFound, fmt.Sprintf("Running sql query ID not found: %d", id))
+		}
+
+		c.Response().Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSONCharsetUTF8)
+		c.Response().WriteHeader(http.StatusOK)
+		return nil
+	})
+}
+
+// getQueryRowLimit returns the row limit to query with, which is one more than the limit so that marshalQueryRows can tell whether the result is truncated.
+func getQueryRowLimit(limit int) int {
+	if limit <= 0 {
+		return limit
+	}
+	return limit + 1
+}
+
+// marshalQueryRows reads the rows incrementally and marshals them into the JSON format of [columnNames, columnTypeNames, rows].
+// It stops reading once limit rows or maxBytes bytes of row data have been read, and returns true for the truncated result.
+// No row limit is enforced if limit <= 0.
+func marshalQueryRows(rows db.Rows, limit int, maxBytes int) ([]byte, bool, error) {
+	columnNames := []string{}
+	columnTypeNames := []string{}
+	for _, column := range rows.Columns() {
+		columnNames = append(columnNames, column.Name)
+		columnTypeNames = append(columnTypeNames, column.TypeName)
+	}
+	columnNamesBytes, err := json.Marshal(columnNames)
+	if err != nil {
+		return nil, false, err
+	}
+	columnTypeNamesBytes, err := json.Marshal(columnTypeNames)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var buf bytes.Buffer
+	_, _ = buf.WriteString("[")
+	_, _ = buf.Write(columnNamesBytes)
+	_, _ = buf.WriteString(",")
+	_, _ = buf.Write(columnTypeNamesBytes)
+	_, _ = buf.WriteString(",[")
+	truncated := false
+	rowCount, rowBytes := 0, 0
+	for rows.Next() {
+		if limit > 0 && rowCount >= limit {
+			truncated = true
+			break
+		}
+		values, err := rows.Values()
+		if err != nil {
+			return nil, false, err
+		}
+		rowData, err := json.Marshal(values)
+		if err != nil {
+			return nil, false, err
+		}
+		if rowBytes+len(rowData) > maxBytes {
+			truncated = true
+			break
+		}
+		if rowCount > 0 {
+			_, _ = buf.WriteString(",")
+		}
+		_, _ = buf.Write(rowData)
+		rowCount++
+		rowBytes += len(rowData)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, false, err
+	}
+	_, _ = buf.WriteString("]]")
+
+	return buf.Bytes(), truncated, nil
 }
 
 func (s *Server) syncInstance(ctx context.Context, instance *api.Instance) ([]string, error) {
-	driver, err := tryGetReadOnlyDatabaseDriver(ctx, instance, "")
+	driver, err := s.tryGetReadOnlyDatabaseDriver(ctx, instance, "")
 	if err != nil {
 		return nil, err
 	}
@@ -601,7 +788,7 @@ func (s *Server) syncInstanceSchema(ctx context.Context, instance *api.Instance,
 }
 
 func (s *Server) syncDatabaseSchema(ctx context.Context, instance *api.Instance, databaseName string) error {
-	driver, err := tryGetReadOnlyDatabaseDriver(ctx, instance, "")
+	driver, err := s.tryGetReadOnlyDatabaseDriver(ctx, instance, "")
 	if err != nil {
 		return err
 	}
@@ -675,7 +862,16 @@ func (s *Server) syncDatabaseSchema(ctx context.Context, instance *api.Instance,
 	if err := syncViewSchema(ctx, s.store, database, schema); err != nil {
 		return err
 	}
-	return syncDBExtensionSchema(ctx, s.store, database, schema)
+	if err := syncDBExtensionSchema(ctx, s.store, database, schema); err != nil {
+		return err
+	}
+	if err := syncDBFunctionSchema(ctx, s.store, database, schema); err != nil {
+		return err
+	}
+	if err := syncDBSequenceSchema(ctx, s.store, database, schema); err != nil {
+		return err
+	}
+	return syncDBTriggerSchema(ctx, s.store, database, schema)
 }
 
 func syncTableSchema(ctx context.Context, store *store.Store, database *api.Database, schema *db.Schema) error {
@@ -690,6 +886,18 @@ func syncDBExtensionSchema(ctx context.Context, store *store.Store, database *ap
 	return store.SetDBExtensionList(ctx, schema, database.ID)
 }
 
+func syncDBFunctionSchema(ctx context.Context, store *store.Store, database *api.Database, schema *db.Schema) error {
+	return store.SetDBFunctionList(ctx, schema, database.ID)
+}
+
+func syncDBSequenceSchema(ctx context.Context, store *store.Store, database *api.Database, schema *db.Schema) error {
+	return store.SetDBSequenceList(ctx, schema, database.ID)
+}
+
+func syncDBTriggerSchema(ctx context.Context, store *store.Store, database *api.Database, schema *db.Schema) error {
+	return store.SetDBTriggerList(ctx, schema, database.ID)
+}
+
 func getLatestSchemaVersion(ctx context.Context, driver db.Driver, databaseName string) (string, error) {
 	// TODO(d): support semantic versioning.
 	limit := 1
@@ -732,6 +940,30 @@ func validateSQLSelectStatement(sqlStatement string) bool {
 	return false
 }
 
+// runSQLEditorQuery runs the SQL editor query, the query is canceled once it runs longer than timeout if timeout is greater than 0.
+// The query is registered to the SQL query manager while it's running, so that the user can cancel it.
+func (s *Server) runSQLEditorQuery(ctx context.Context, c echo.Context, exec *api.SQLExecute, timeout time.Duration, query func(ctx context.Context) ([]byte, bool, error)) ([]byte, bool, error) {
+	queryCtx, unregister := s.SQLQueryManager.Register(ctx, &api.SQLQuery{
+		CreatorID:    c.Get(getPrincipalIDContextKey()).(int),
+		InstanceID:   exec.InstanceID,
+		DatabaseName: exec.DatabaseName,
+		Statement:    exec.Statement,
+	}, timeout)
+	defer unregister()
+
+	bytes, truncated, err := query(queryCtx)
+	if err != nil {
+		// Report the reason instead of the error from the driver, which is usually a closed connection once the query is canceled.
+		switch queryCtx.Err() {
+		case context.DeadlineExceeded:
+			return nil, false, errors.Errorf("query timed out after %v", timeout)
+		case context.Canceled:
+			return nil, false, errors.New("query canceled")
+		}
+	}
+	return bytes, truncated, err
+}
+
 func (s *Server) createSQLEditorQueryActivity(ctx context.Context, c echo.Context, level api.ActivityLevel, containerID int, payload api.ActivitySQLEditorQueryPayload) error {
 	activityBytes, err := json.Marshal(payload)
 	if err != nil {
@@ -822,29 +1054,72 @@ func (s *Server) sqlCheck(
 	return adviceLevel, adviceList, nil
 }
 
-func checkPostgreSQLIndexHit(statement string, plan string) []advisor.Advice {
-	plans := strings.Split(plan, "\n")
-	haveSeqScan := false
-	haveIndexScan := false
-	for _, row := range plans {
-		if strings.Contains(row, "Seq Scan") {
-			haveSeqScan = true
-			continue
+// explainedStatementRegexp matches the EXPLAIN statements, the group is the explained query.
+// The options are skipped, e.g. "EXPLAIN ANALYZE", "EXPLAIN (ANALYZE, FORMAT JSON)" in PostgreSQL and "EXPLAIN FORMAT=JSON" in MySQL.
+var explainedStatementRegexp = regexp.MustCompile(`(?is)^\s*EXPLAIN\b(?:\s*\([^)]*\)|\s+(?:ANALYZE|ANALYSE|VERBOSE|EXTENDED|PARTITIONS|FORMAT\s*=\s*\w+)\b)*\s*((?:SELECT|WITH)\b.*)$`)
+
+// getExplainedStatement returns the query explained by the statement without the EXPLAIN options, and false if the statement doesn't explain a query.
+func getExplainedStatement(statement string) (string, bool) {
+	matches := explainedStatementRegexp.FindStringSubmatch(statement)
+	if matches == nil {
+		return "", false
+	}
+	return matches[1], true
+}
+
+// isExplainSupported returns true if the structured query plan is supported for the engine.
+func isExplainSupported(engine db.Type) bool {
+	return engine == db.MySQL || engine == db.Postgres
+}
+
+// explainDatabaseStatement returns the query plan tree of the statement in the database and the advice on it.
+func (s *Server) explainDatabaseStatement(ctx context.Context, instance *api.Instance, databaseName string, statement string) (*db.ExplainNode, []advisor.Advice, error) {
+	driver, err := s.tryGetReadOnlyDatabaseDriver(ctx, instance, databaseName)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer driver.Close(ctx)
+
+	return explainStatement(ctx, driver, statement)
+}
+
+// explainStatement returns the query plan tree of the statement and the advice on it.
+func explainStatement(ctx context.Context, driver db.Driver, statement string) (*db.ExplainNode, []advisor.Advice, error) {
+	plan, err := driver.Explain(ctx, statement)
+	if err != nil {
+		return nil, nil, err
+	}
+	return plan, checkExplainPlan(statement, plan), nil
+}
+
+// checkExplainPlan checks the query plan tree of the statement.
+// It warns the full table scans, and returns an error advice if the query scans tables without using any index.
+func checkExplainPlan(statement string, plan *db.ExplainNode) []advisor.Advice {
+	var adviceList []advisor.Advice
+	useIndex, fullScan := false, false
+	plan.Walk(func(node *db.ExplainNode) {
+		if node.Index != "" {
+			useIndex = true
 		}
-		if strings.Contains(row, "Index Scan") {
-			haveIndexScan = true
-			continue
+		if node.FullScan {
+			fullScan = true
+			adviceList = append(adviceList, advisor.Advice{
+				Status:  advisor.Warn,
+				Code:    advisor.StatementFullTableScan,
+				Title:   "Full table scan",
+				Content: fmt.Sprintf("statement %q scans all rows of table %q, estimated rows: %.0f", statement, node.Relation, node.EstimatedRows),
+			})
 		}
-	}
-	if haveSeqScan && !haveIndexScan {
-		return []advisor.Advice{
+	})
+	if fullScan && !useIndex {
+		adviceList = append([]advisor.Advice{
 			{
 				Status:  advisor.Error,
 				Code:    advisor.NotUseIndex,
 				Title:   "Query does not use index",
 				Content: fmt.Sprintf("statement %q does not use any index", statement),
 			},
-		}
+		}, adviceList...)
 	}
-	return nil
+	return adviceList
 }
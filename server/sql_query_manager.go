@@ -0,0 +1,92 @@
+package server
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/plugin/db/util"
+)
+
+// NewSQLQueryManager creates a SQL query manager.
+func NewSQLQueryManager() *SQLQueryManager {
+	return &SQLQueryManager{
+		queries: make(map[int]*runningSQLQuery),
+	}
+}
+
+// SQLQueryManager tracks the running SQL editor queries, so that users can list and cancel their queries.
+// Canceling a query cancels its context, and the database driver cancels the query on the database side.
+type SQLQueryManager struct {
+	mu      sync.Mutex
+	nextID  int
+	queries map[int]*runningSQLQuery
+}
+
+type runningSQLQuery struct {
+	query  *api.SQLQuery
+	cancel context.CancelFunc
+}
+
+// Register registers a running query, and returns the context to run the query with.
+// The context times out after timeout if timeout is greater than 0, and the query is canceled on the database side once the context is done.
+// The caller must call the returned function to unregister the query once it finishes.
+func (m *SQLQueryManager) Register(ctx context.Context, query *api.SQLQuery, timeout time.Duration) (context.Context, func()) {
+	var cancel context.CancelFunc
+	now := time.Now()
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		query.TimeoutTs = now.Add(timeout).Unix()
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	query.CreatedTs = now.Unix()
+	// Cancel the query on the database side as well once it times out or is canceled.
+	ctx = util.WithQueryCancel(ctx)
+
+	m.mu.Lock()
+	m.nextID++
+	query.ID = m.nextID
+	m.queries[query.ID] = &runningSQLQuery{
+		query:  query,
+		cancel: cancel,
+	}
+	m.mu.Unlock()
+
+	return ctx, func() {
+		m.mu.Lock()
+		delete(m.queries, query.ID)
+		m.mu.Unlock()
+		cancel()
+	}
+}
+
+// List lists the running queries of the principal, ordered by the creation time.
+func (m *SQLQueryManager) List(principalID int) []*api.SQLQuery {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	queryList := []*api.SQLQuery{}
+	for _, q := range m.queries {
+		if q.query.CreatorID == principalID {
+			queryList = append(queryList, q.query)
+		}
+	}
+	sort.Slice(queryList, func(i, j int) bool {
+		return queryList[i].ID < queryList[j].ID
+	})
+	return queryList
+}
+
+// Cancel cancels the running query of the principal, and returns false if there is no such query.
+func (m *SQLQueryManager) Cancel(principalID int, id int) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	q, ok := m.queries[id]
+	if !ok || q.query.CreatorID != principalID {
+		return false
+	}
+	q.cancel()
+	return true
+}
@@ -0,0 +1,44 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/api"
+)
+
+func TestSQLQueryManager(t *testing.T) {
+	m := NewSQLQueryManager()
+	aliceCtx, unregisterAlice := m.Register(context.Background(), &api.SQLQuery{CreatorID: 101, Statement: "SELECT 1"}, 0 /* timeout */)
+	bobCtx, unregisterBob := m.Register(context.Background(), &api.SQLQuery{CreatorID: 102, Statement: "SELECT 2"}, time.Hour)
+
+	aliceList := m.List(101)
+	require.Len(t, aliceList, 1)
+	require.Equal(t, "SELECT 1", aliceList[0].Statement)
+	require.Equal(t, int64(0), aliceList[0].TimeoutTs)
+	bobList := m.List(102)
+	require.Len(t, bobList, 1)
+	require.NotEqual(t, int64(0), bobList[0].TimeoutTs)
+
+	// Users can only cancel their own queries.
+	require.False(t, m.Cancel(101, bobList[0].ID))
+	require.NoError(t, bobCtx.Err())
+	require.True(t, m.Cancel(101, aliceList[0].ID))
+	require.Equal(t, context.Canceled, aliceCtx.Err())
+
+	// The queries are removed once they are unregistered.
+	unregisterAlice()
+	unregisterBob()
+	require.Empty(t, m.List(101))
+	require.Empty(t, m.List(102))
+	require.False(t, m.Cancel(102, bobList[0].ID))
+	require.Equal(t, context.Canceled, bobCtx.Err())
+
+	timeoutCtx, unregister := m.Register(context.Background(), &api.SQLQuery{CreatorID: 101}, time.Millisecond)
+	defer unregister()
+	<-timeoutCtx.Done()
+	require.Equal(t, context.DeadlineExceeded, timeoutCtx.Err())
+}
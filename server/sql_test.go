@@ -2,6 +2,11 @@ package server
 
 import (
 	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/plugin/advisor"
+	"github.com/bytebase/bytebase/plugin/db"
 )
 
 func TestValidateSQLSelectStatement(t *testing.T) {
@@ -94,3 +99,157 @@ func TestValidateSQLSelectStatement(t *testing.T) {
 		}
 	}
 }
+
+type fakeRows struct {
+	columns []db.QueryColumn
+	data    [][]interface{}
+	index   int
+}
+
+func (r *fakeRows) Columns() []db.QueryColumn {
+	return r.columns
+}
+
+func (r *fakeRows) Next() bool {
+	r.index++
+	return r.index <= len(r.data)
+}
+
+func (r *fakeRows) Values() ([]interface{}, error) {
+	return r.data[r.index-1], nil
+}
+
+func (*fakeRows) Err() error {
+	return nil
+}
+
+func (*fakeRows) Close() error {
+	return nil
+}
+
+func TestMarshalQueryRows(t *testing.T) {
+	columns := []db.QueryColumn{
+		{Name: "id", TypeName: "INT"},
+		{Name: "name", TypeName: "VARCHAR"},
+	}
+	data := [][]interface{}{
+		{int64(1), "alice"},
+		{int64(2), "bob"},
+		{int64(3), nil},
+	}
+	tests := []struct {
+		limit         int
+		maxBytes      int
+		want          string
+		wantTruncated bool
+	}{
+		{
+			limit:         0,
+			maxBytes:      1024,
+			want:          `[["id","name"],["INT","VARCHAR"],[[1,"alice"],[2,"bob"],[3,null]]]`,
+			wantTruncated: false,
+		},
+		{
+			limit:         2,
+			maxBytes:      1024,
+			want:          `[["id","name"],["INT","VARCHAR"],[[1,"alice"],[2,"bob"]]]`,
+			wantTruncated: true,
+		},
+		{
+			limit:         3,
+			maxBytes:      1024,
+			want:          `[["id","name"],["INT","VARCHAR"],[[1,"alice"],[2,"bob"],[3,null]]]`,
+			wantTruncated: false,
+		},
+		{
+			// [1,"alice"] and [2,"bob"] take 20 bytes.
+			limit:         0,
+			maxBytes:      20,
+			want:          `[["id","name"],["INT","VARCHAR"],[[1,"alice"],[2,"bob"]]]`,
+			wantTruncated: true,
+		},
+		{
+			limit:         0,
+			maxBytes:      1,
+			want:          `[["id","name"],["INT","VARCHAR"],[]]`,
+			wantTruncated: true,
+		},
+	}
+
+	for _, test := range tests {
+		got, truncated, err := marshalQueryRows(&fakeRows{columns: columns, data: data}, test.limit, test.maxBytes)
+		require.NoError(t, err)
+		require.Equal(t, test.want, string(got))
+		require.Equal(t, test.wantTruncated, truncated)
+	}
+
+	// The drivers limit the rows with the statement, so the truncation is detected by querying one more row than the limit.
+	require.Equal(t, 0, getQueryRowLimit(0))
+	limit := 2
+	_, truncated, err := marshalQueryRows(&fakeRows{columns: columns, data: data[:getQueryRowLimit(limit)]}, limit, 1024)
+	require.NoError(t, err)
+	require.True(t, truncated)
+}
+
+func TestGetExplainedStatement(t *testing.T) {
+	statement, ok := getExplainedStatement("explain \n SELECT * FROM t")
+	require.True(t, ok)
+	require.Equal(t, "SELECT * FROM t", statement)
+	statement, ok = getExplainedStatement("EXPLAIN WITH a AS (SELECT 1) SELECT * FROM a")
+	require.True(t, ok)
+	require.Equal(t, "WITH a AS (SELECT 1) SELECT * FROM a", statement)
+	// The EXPLAIN options are stripped.
+	for _, explain := range []string{
+		"EXPLAIN ANALYZE SELECT * FROM t",
+		"explain analyze verbose SELECT * FROM t",
+		"EXPLAIN (ANALYZE, FORMAT JSON) SELECT * FROM t",
+		"EXPLAIN(COSTS false)SELECT * FROM t",
+		"EXPLAIN FORMAT=JSON SELECT * FROM t",
+		"EXPLAIN FORMAT = TREE SELECT * FROM t",
+	} {
+		statement, ok = getExplainedStatement(explain)
+		require.True(t, ok, explain)
+		require.Equal(t, "SELECT * FROM t", statement, explain)
+	}
+	_, ok = getExplainedStatement("EXPLAIN ANALYZE UPDATE t SET a = 1")
+	require.False(t, ok)
+	_, ok = getExplainedStatement("EXPLAINSELECT * FROM t")
+	require.False(t, ok)
+	_, ok = getExplainedStatement("SELECT * FROM t")
+	require.False(t, ok)
+}
+
+func TestCheckExplainPlan(t *testing.T) {
+	statement := "SELECT * FROM t"
+	// Scanning all rows without any index.
+	adviceList := checkExplainPlan(statement, &db.ExplainNode{
+		NodeType:      "Seq Scan",
+		Relation:      "t",
+		FullScan:      true,
+		EstimatedRows: 1000,
+	})
+	require.Len(t, adviceList, 2)
+	require.Equal(t, advisor.Error, adviceList[0].Status)
+	require.Equal(t, advisor.NotUseIndex, adviceList[0].Code)
+	require.Equal(t, advisor.Warn, adviceList[1].Status)
+	require.Equal(t, advisor.StatementFullTableScan, adviceList[1].Code)
+	require.Equal(t, `statement "SELECT * FROM t" scans all rows of table "t", estimated rows: 1000`, adviceList[1].Content)
+
+	// The full table scan is still warned if other tables are read by index.
+	adviceList = checkExplainPlan(statement, &db.ExplainNode{
+		NodeType: "nested_loop",
+		Children: []*db.ExplainNode{
+			{NodeType: "ALL", Relation: "t", FullScan: true, EstimatedRows: 5},
+			{NodeType: "eq_ref", Relation: "s", Index: "PRIMARY", EstimatedRows: 1},
+		},
+	})
+	require.Len(t, adviceList, 1)
+	require.Equal(t, advisor.StatementFullTableScan, adviceList[0].Code)
+
+	adviceList = checkExplainPlan(statement, &db.ExplainNode{
+		NodeType: "Index Only Scan",
+		Relation: "t",
+		Index:    "t_pkey",
+	})
+	require.Empty(t, adviceList)
+}
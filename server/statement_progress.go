@@ -0,0 +1,77 @@
+package server
+
+import (
+	"encoding/json"
+	"sync/atomic"
+	"time"
+
+	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/plugin/db"
+)
+
+// maxProgressStatementLength is the max length of the statement kept in the task progress and the task run result.
+const maxProgressStatementLength = 512
+
+var (
+	_ db.StatementProgressReporter = (*statementProgressTracker)(nil)
+)
+
+// statementProgressTracker tracks the progress of the migration executing the statements one by one.
+// The completed and total units of the progress are the statement counts.
+type statementProgressTracker struct {
+	progress atomic.Value // api.Progress
+	// currentStatement and resultList are only accessed by the goroutine executing the migration.
+	currentStatement string
+	resultList       []api.TaskRunStatementResult
+}
+
+// OnStatementStart implements the db.StatementProgressReporter interface.
+func (t *statementProgressTracker) OnStatementStart(index, total int, statement string) {
+	t.currentStatement = truncateProgressStatement(statement)
+	// Marshaling the plain struct never fails.
+	payload, _ := json.Marshal(api.ProgressStatementPayload{
+		CurrentStatement: t.currentStatement,
+	})
+	now := time.Now().Unix()
+	createdTs := now
+	if progress, ok := t.progress.Load().(api.Progress); ok {
+		createdTs = progress.CreatedTs
+	}
+	t.progress.Store(api.Progress{
+		TotalUnit:     int64(total),
+		CompletedUnit: int64(index),
+		CreatedTs:     createdTs,
+		UpdatedTs:     now,
+		Payload:       string(payload),
+	})
+}
+
+// OnStatementDone implements the db.StatementProgressReporter interface.
+func (t *statementProgressTracker) OnStatementDone(index int, duration time.Duration) {
+	t.resultList = append(t.resultList, api.TaskRunStatementResult{
+		Statement:  t.currentStatement,
+		DurationMs: duration.Milliseconds(),
+	})
+	progress := t.get()
+	progress.CompletedUnit = int64(index + 1)
+	progress.UpdatedTs = time.Now().Unix()
+	progress.Payload = ""
+	t.progress.Store(progress)
+}
+
+// get returns the latest progress.
+func (t *statementProgressTracker) get() api.Progress {
+	progress, ok := t.progress.Load().(api.Progress)
+	if !ok {
+		return api.Progress{}
+	}
+	return progress
+}
+
+func truncateProgressStatement(statement string) string {
+	runes := []rune(statement)
+	if len(runes) <= maxProgressStatementLength {
+		return statement
+	}
+	return string(runes[:maxProgressStatementLength]) + "..."
+}
@@ -0,0 +1,38 @@
+package server
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/api"
+)
+
+func TestStatementProgressTracker(t *testing.T) {
+	tracker := &statementProgressTracker{}
+	require.Equal(t, api.Progress{}, tracker.get())
+
+	tracker.OnStatementStart(0, 2, "CREATE TABLE t(a int);")
+	progress := tracker.get()
+	require.Equal(t, int64(2), progress.TotalUnit)
+	require.Equal(t, int64(0), progress.CompletedUnit)
+	payload := api.ProgressStatementPayload{}
+	require.NoError(t, json.Unmarshal([]byte(progress.Payload), &payload))
+	require.Equal(t, "CREATE TABLE t(a int);", payload.CurrentStatement)
+
+	tracker.OnStatementDone(0, 1500*time.Millisecond)
+	longStatement := "INSERT INTO t VALUES " + strings.Repeat("(1),", 1000) + "(1);"
+	tracker.OnStatementStart(1, 2, longStatement)
+	tracker.OnStatementDone(1, 10*time.Millisecond)
+	progress = tracker.get()
+	require.Equal(t, int64(2), progress.CompletedUnit)
+	require.Equal(t, "", progress.Payload)
+
+	require.Equal(t, []api.TaskRunStatementResult{
+		{Statement: "CREATE TABLE t(a int);", DurationMs: 1500},
+		{Statement: longStatement[:maxProgressStatementLength] + "...", DurationMs: 10},
+	}, tracker.resultList)
+}
@@ -81,6 +81,14 @@ func (*TaskCheckGhostSyncExecutor) Run(ctx context.Context, server *Server, task
 
 	config := getGhostConfig(task, adminDataSource, instanceUserList, tableName, payload.Statement, true, 20000000)
 
+	sshTunnel, err := openGhostSSHTunnel(&config, adminDataSource)
+	if err != nil {
+		return nil, common.Wrapf(err, common.DbConnectionFailure, "failed to open SSH tunnel")
+	}
+	if sshTunnel != nil {
+		defer sshTunnel.Close()
+	}
+
 	migrationContext, err := newMigrationContext(config)
 	if err != nil {
 		return nil, common.Wrapf(err, common.Internal, "failed to create migration context")
@@ -64,7 +64,7 @@ func (*TaskCheckPITRMySQLExecutor) Run(ctx context.Context, server *Server, task
 		return nil, err
 	}
 	defer driver.Close(ctx)
-	mysqlDriver, ok := driver.(*mysql.Driver)
+	mysqlDriver, ok := unwrapDriver(driver).(*mysql.Driver)
 	if !ok {
 		return nil, errors.Errorf("Failed to cast driver to mysql.Driver")
 	}
@@ -0,0 +1,276 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	pgquery "github.com/pganalyze/pg_query_go/v2"
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/plugin/advisor"
+	"github.com/bytebase/bytebase/plugin/db"
+	"github.com/bytebase/bytebase/plugin/parser"
+	"github.com/bytebase/bytebase/plugin/parser/ast"
+)
+
+const (
+	// dryRunLockTimeout is the maximum time to wait for a lock in the dry run, so that it doesn't queue behind the workload for long.
+	dryRunLockTimeout = 3 * time.Second
+	// dryRunStatementTimeout is the maximum time of a statement in the dry run.
+	dryRunStatementTimeout = 30 * time.Second
+	// dryRunTimeout is the maximum time of the whole dry run. The locks acquired by the statements are held till the transaction
+	// is rolled back, so it limits how long the locks are held regardless of the number of the statements.
+	dryRunTimeout = 60 * time.Second
+	// dryRunLockNote is appended to the check results, because the dry run blocks the workload on the tables it locks.
+	dryRunLockNote = "The dry run takes the same locks as the statements on the database, and holds them for up to %v till it's rolled back."
+)
+
+// NewTaskCheckStatementDryRunExecutor creates a task check statement dry run executor.
+func NewTaskCheckStatementDryRunExecutor() TaskCheckExecutor {
+	return &TaskCheckStatementDryRunExecutor{}
+}
+
+// TaskCheckStatementDryRunExecutor is the task check statement dry run executor.
+// It runs the statement against the target database in a transaction which is always rolled back.
+type TaskCheckStatementDryRunExecutor struct {
+}
+
+// Run will run the task check statement dry run executor once.
+func (*TaskCheckStatementDryRunExecutor) Run(ctx context.Context, server *Server, taskCheckRun *api.TaskCheckRun) (result []api.TaskCheckResult, err error) {
+	task, err := server.store.GetTaskByID(ctx, taskCheckRun.TaskID)
+	if err != nil {
+		return []api.TaskCheckResult{}, common.Wrap(err, common.Internal)
+	}
+	if task == nil {
+		return []api.TaskCheckResult{
+			{
+				Status:    api.TaskCheckStatusError,
+				Namespace: api.BBNamespace,
+				Code:      common.Internal.Int(),
+				Title:     fmt.Sprintf("Failed to find task %v", taskCheckRun.TaskID),
+				Content:   fmt.Sprintf("Task %v not found", taskCheckRun.TaskID),
+			},
+		}, nil
+	}
+
+	payload := &api.TaskCheckDatabaseStatementDryRunPayload{}
+	if err := json.Unmarshal([]byte(taskCheckRun.Payload), payload); err != nil {
+		return nil, common.Wrapf(err, common.Invalid, "invalid check statement dry run payload")
+	}
+	if payload.DbType != db.Postgres {
+		return nil, common.Errorf(common.Invalid, "invalid check statement dry run database type: %s", payload.DbType)
+	}
+
+	nodeList, err := parser.Parse(parser.Postgres, parser.ParseContext{}, payload.Statement)
+	if err != nil {
+		//nolint:nilerr
+		return []api.TaskCheckResult{
+			{
+				Status:    api.TaskCheckStatusError,
+				Namespace: api.AdvisorNamespace,
+				Code:      advisor.StatementSyntaxError.Int(),
+				Title:     "Syntax error",
+				Content:   err.Error(),
+			},
+		}, nil
+	}
+	if node, reason := findNonTransactionalStatement(nodeList); node != nil {
+		return []api.TaskCheckResult{
+			{
+				Status:    api.TaskCheckStatusError,
+				Namespace: api.BBNamespace,
+				Code:      common.DryRunNotTransactional.Int(),
+				Title:     "Statement cannot run in a transaction",
+				Content:   fmt.Sprintf("%q %s, so the statement cannot be dry run", node.Text(), reason),
+			},
+		}, nil
+	}
+
+	database, err := server.store.GetDatabase(ctx, &api.DatabaseFind{ID: task.DatabaseID})
+	if err != nil {
+		return []api.TaskCheckResult{}, common.Wrap(err, common.Internal)
+	}
+	if database == nil {
+		return []api.TaskCheckResult{}, common.Errorf(common.Internal, "database ID not found %v", task.DatabaseID)
+	}
+	driver, err := server.getAdminDatabaseDriver(ctx, database.Instance, database.Name)
+	if err != nil {
+		return []api.TaskCheckResult{
+			{
+				Status:    api.TaskCheckStatusError,
+				Namespace: api.BBNamespace,
+				Code:      common.DbConnectionFailure.Int(),
+				Title:     fmt.Sprintf("Failed to connect %q", database.Name),
+				Content:   err.Error(),
+			},
+		}, nil
+	}
+	defer driver.Close(ctx)
+	sqlDB, err := driver.GetDBConnection(ctx, database.Name)
+	if err != nil {
+		return []api.TaskCheckResult{}, common.Wrap(err, common.Internal)
+	}
+
+	failure, err := dryRunStatementList(ctx, sqlDB, nodeList)
+	if err != nil {
+		return []api.TaskCheckResult{}, common.Wrap(err, common.DbExecutionError)
+	}
+	if failure != nil {
+		return []api.TaskCheckResult{getDryRunFailureResult(failure)}, nil
+	}
+
+	return []api.TaskCheckResult{
+		{
+			Status:    api.TaskCheckStatusSuccess,
+			Namespace: api.BBNamespace,
+			Code:      common.Ok.Int(),
+			Title:     "OK",
+			Content:   fmt.Sprintf("Successfully dry run %d statement(s) in database %q. %s", len(nodeList), database.Name, fmt.Sprintf(dryRunLockNote, dryRunTimeout)),
+		},
+	}, nil
+}
+
+// getDryRunFailureResult returns the check result of the failing statement of the dry run.
+// The statements blocked by the workload or too slow to finish in the dry run are not necessarily wrong, so we only warn about them.
+func getDryRunFailureResult(failure *dryRunFailure) api.TaskCheckResult {
+	lockNote := fmt.Sprintf(dryRunLockNote, dryRunTimeout)
+	if isLockTimeoutError(failure.err) {
+		return api.TaskCheckResult{
+			Status:    api.TaskCheckStatusWarn,
+			Namespace: api.BBNamespace,
+			Code:      common.DryRunLockTimeout.Int(),
+			Title:     fmt.Sprintf("Dry run timed out waiting for a lock at line %d", failure.node.LastLine()),
+			Content:   fmt.Sprintf("%q could not acquire the lock within %v: %v. %s", failure.node.Text(), dryRunLockTimeout, failure.err, lockNote),
+		}
+	}
+	if errors.Is(failure.err, context.DeadlineExceeded) {
+		return api.TaskCheckResult{
+			Status:    api.TaskCheckStatusWarn,
+			Namespace: api.BBNamespace,
+			Code:      common.DryRunTimeout.Int(),
+			Title:     fmt.Sprintf("Dry run stopped after %v at line %d", dryRunTimeout, failure.node.LastLine()),
+			Content:   fmt.Sprintf("The dry run was rolled back while running %q, so that it doesn't hold the locks for too long. %s", failure.node.Text(), lockNote),
+		}
+	}
+	return api.TaskCheckResult{
+		Status:    api.TaskCheckStatusError,
+		Namespace: api.BBNamespace,
+		Code:      common.DryRunFailed.Int(),
+		Title:     fmt.Sprintf("Dry run failed at line %d", failure.node.LastLine()),
+		Content:   fmt.Sprintf("%q failed: %v. %s", failure.node.Text(), failure.err, lockNote),
+	}
+}
+
+// findNonTransactionalStatement returns the first statement which cannot run in a transaction block and the reason.
+// The transaction control statements are also refused, because they would end the transaction of the dry run.
+func findNonTransactionalStatement(nodeList []ast.Node) (ast.Node, string) {
+	for _, node := range nodeList {
+		switch n := node.(type) {
+		case *ast.CreateIndexStmt:
+			if n.Concurrently {
+				return node, "creates the index concurrently"
+			}
+		case *ast.DropIndexStmt:
+			if n.Concurrently {
+				return node, "drops the index concurrently"
+			}
+		case *ast.CreateDatabaseStmt:
+			return node, "creates a database"
+		case *ast.DropDatabaseStmt:
+			return node, "drops a database"
+		case *ast.TransactionStmt:
+			return node, "controls the transaction"
+		case *ast.UnconvertedStmt:
+			if reason := getNonTransactionalReason(node.Text()); reason != "" {
+				return node, reason
+			}
+		}
+	}
+	return nil, ""
+}
+
+// getNonTransactionalReason returns the reason why the statement not converted to the AST cannot run in a transaction block,
+// and empty if it can.
+func getNonTransactionalReason(statement string) string {
+	res, err := pgquery.Parse(statement)
+	if err != nil {
+		// The statements have been parsed successfully before, so it never happens.
+		return ""
+	}
+	for _, stmt := range res.Stmts {
+		switch n := stmt.Stmt.Node.(type) {
+		case *pgquery.Node_VacuumStmt:
+			// ANALYZE without VACUUM can run in a transaction block.
+			if n.VacuumStmt.IsVacuumcmd {
+				return "vacuums the tables"
+			}
+		case *pgquery.Node_ReindexStmt:
+			if n.ReindexStmt.Concurrent {
+				return "reindexes concurrently"
+			}
+			if n.ReindexStmt.Kind == pgquery.ReindexObjectType_REINDEX_OBJECT_SYSTEM || n.ReindexStmt.Kind == pgquery.ReindexObjectType_REINDEX_OBJECT_DATABASE {
+				return "reindexes the database"
+			}
+		case *pgquery.Node_CreateTableSpaceStmt:
+			return "creates a tablespace"
+		case *pgquery.Node_DropTableSpaceStmt:
+			return "drops a tablespace"
+		case *pgquery.Node_AlterSystemStmt:
+			return "alters the server configuration"
+		case *pgquery.Node_AlterEnumStmt:
+			// ALTER TYPE ... ADD VALUE has the new value without the old value, and ALTER TYPE ... RENAME VALUE has both.
+			if n.AlterEnumStmt.OldVal == "" {
+				return "adds the enum value"
+			}
+		}
+	}
+	return ""
+}
+
+// dryRunFailure is the first failing statement of the dry run.
+type dryRunFailure struct {
+	node ast.Node
+	err  error
+}
+
+// isLockTimeoutError returns true if the error is the PostgreSQL lock_not_available error caused by the lock timeout.
+func isLockTimeoutError(err error) bool {
+	var sqlStateErr interface{ SQLState() string }
+	return errors.As(err, &sqlStateErr) && sqlStateErr.SQLState() == "55P03"
+}
+
+// dryRunStatementList runs the statements one by one in a transaction which is always rolled back.
+// It returns the first failing statement, or an error if the dry run cannot be set up.
+func dryRunStatementList(ctx context.Context, sqlDB *sql.DB, nodeList []ast.Node) (*dryRunFailure, error) {
+	// The transaction is rolled back once the deadline is exceeded, which releases the locks.
+	ctx, cancel := context.WithTimeout(ctx, dryRunTimeout)
+	defer cancel()
+	tx, err := sqlDB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin the dry run transaction")
+	}
+	defer tx.Rollback()
+
+	// SET LOCAL only takes effect in the transaction.
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("SET LOCAL lock_timeout = %d", dryRunLockTimeout.Milliseconds())); err != nil {
+		return nil, errors.Wrap(err, "failed to set the lock timeout")
+	}
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("SET LOCAL statement_timeout = %d", dryRunStatementTimeout.Milliseconds())); err != nil {
+		return nil, errors.Wrap(err, "failed to set the statement timeout")
+	}
+	for _, node := range nodeList {
+		if _, err := tx.ExecContext(ctx, node.Text()); err != nil {
+			// The driver may return the error of the canceled query instead of the context error.
+			if ctx.Err() == context.DeadlineExceeded {
+				err = errors.Wrap(context.DeadlineExceeded, err.Error())
+			}
+			return &dryRunFailure{node: node, err: err}, nil
+		}
+	}
+	return nil, nil
+}
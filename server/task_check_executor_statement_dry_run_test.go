@@ -0,0 +1,143 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/plugin/parser"
+)
+
+func TestFindNonTransactionalStatement(t *testing.T) {
+	tests := []struct {
+		stmt string
+		// want is the text of the statement which cannot run in a transaction, empty if there isn't any.
+		want string
+	}{
+		{
+			stmt: "CREATE TABLE t(a int);\nCREATE INDEX idx_a ON t(a);\nINSERT INTO t VALUES (1);",
+			want: "",
+		},
+		{
+			stmt: "CREATE TABLE t(a int);\nCREATE INDEX CONCURRENTLY idx_a ON t(a);",
+			want: "CREATE INDEX CONCURRENTLY idx_a ON t(a);",
+		},
+		{
+			stmt: "DROP INDEX CONCURRENTLY idx_a;",
+			want: "DROP INDEX CONCURRENTLY idx_a;",
+		},
+		{
+			stmt: "CREATE DATABASE db1;",
+			want: "CREATE DATABASE db1;",
+		},
+		{
+			stmt: "DROP DATABASE db1;",
+			want: "DROP DATABASE db1;",
+		},
+		{
+			// COMMIT would end the transaction of the dry run.
+			stmt: "UPDATE t SET a = 1;\nCOMMIT;",
+			want: "COMMIT;",
+		},
+		{
+			stmt: "VACUUM t;",
+			want: "VACUUM t;",
+		},
+		{
+			// ANALYZE can run in a transaction.
+			stmt: "ANALYZE t;",
+			want: "",
+		},
+		{
+			stmt: "REINDEX TABLE t;\nREINDEX INDEX CONCURRENTLY idx_a;",
+			want: "REINDEX INDEX CONCURRENTLY idx_a;",
+		},
+		{
+			stmt: "CREATE TABLESPACE ts LOCATION '/data';",
+			want: "CREATE TABLESPACE ts LOCATION '/data';",
+		},
+		{
+			stmt: "DROP TABLESPACE ts;",
+			want: "DROP TABLESPACE ts;",
+		},
+		{
+			stmt: "ALTER SYSTEM SET work_mem = '64MB';",
+			want: "ALTER SYSTEM SET work_mem = '64MB';",
+		},
+		{
+			stmt: "ALTER TYPE mood RENAME VALUE 'sad' TO 'unhappy';\nALTER TYPE mood ADD VALUE 'happy';",
+			want: "ALTER TYPE mood ADD VALUE 'happy';",
+		},
+	}
+
+	for _, test := range tests {
+		nodeList, err := parser.Parse(parser.Postgres, parser.ParseContext{}, test.stmt)
+		require.NoError(t, err, test.stmt)
+		node, _ := findNonTransactionalStatement(nodeList)
+		if test.want == "" {
+			require.Nil(t, node, test.stmt)
+			continue
+		}
+		require.NotNil(t, node, test.stmt)
+		require.Equal(t, test.want, node.Text(), test.stmt)
+	}
+}
+
+type fakeSQLStateError struct {
+	sqlState string
+}
+
+func (e *fakeSQLStateError) Error() string {
+	return "fake error " + e.sqlState
+}
+
+func (e *fakeSQLStateError) SQLState() string {
+	return e.sqlState
+}
+
+func TestIsLockTimeoutError(t *testing.T) {
+	require.True(t, isLockTimeoutError(&fakeSQLStateError{sqlState: "55P03"}))
+	require.True(t, isLockTimeoutError(errors.Wrap(&fakeSQLStateError{sqlState: "55P03"}, "failed")))
+	require.False(t, isLockTimeoutError(&fakeSQLStateError{sqlState: "42P01"}))
+	require.False(t, isLockTimeoutError(errors.New("lock timeout")))
+}
+
+func TestGetDryRunFailureResult(t *testing.T) {
+	nodeList, err := parser.Parse(parser.Postgres, parser.ParseContext{}, "ALTER TABLE t ADD COLUMN b int;")
+	require.NoError(t, err)
+	node := nodeList[0]
+
+	tests := []struct {
+		err        error
+		wantStatus api.TaskCheckStatus
+		wantCode   common.Code
+	}{
+		{
+			err:        &fakeSQLStateError{sqlState: "55P03"},
+			wantStatus: api.TaskCheckStatusWarn,
+			wantCode:   common.DryRunLockTimeout,
+		},
+		{
+			err:        errors.Wrap(context.DeadlineExceeded, "canceling statement due to user request"),
+			wantStatus: api.TaskCheckStatusWarn,
+			wantCode:   common.DryRunTimeout,
+		},
+		{
+			err:        &fakeSQLStateError{sqlState: "42P01"},
+			wantStatus: api.TaskCheckStatusError,
+			wantCode:   common.DryRunFailed,
+		},
+	}
+
+	for _, test := range tests {
+		result := getDryRunFailureResult(&dryRunFailure{node: node, err: test.err})
+		require.Equal(t, test.wantStatus, result.Status, test.err)
+		require.Equal(t, test.wantCode.Int(), result.Code, test.err)
+		// The results remind that the dry run takes the locks on the database.
+		require.Contains(t, result.Content, "The dry run takes the same locks as the statements", test.err)
+	}
+}
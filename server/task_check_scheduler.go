@@ -239,6 +239,12 @@ func (s *TaskCheckScheduler) getTaskCheck(ctx context.Context, task *api.Task, c
 	}
 	createList = append(createList, create...)
 
+	create, err = s.getDryRunTaskCheck(ctx, task, creatorID, database, statement)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to schedule statement dry run task check")
+	}
+	createList = append(createList, create...)
+
 	return createList, nil
 }
 
@@ -310,6 +316,27 @@ func (*TaskCheckScheduler) getStmtTypeTaskCheck(_ context.Context, task *api.Tas
 	}, nil
 }
 
+func (*TaskCheckScheduler) getDryRunTaskCheck(_ context.Context, task *api.Task, creatorID int, database *api.Database, statement string) ([]*api.TaskCheckRunCreate, error) {
+	if !api.IsStatementDryRunSupported(database.Instance.Engine) {
+		return nil, nil
+	}
+	payload, err := json.Marshal(api.TaskCheckDatabaseStatementDryRunPayload{
+		Statement: statement,
+		DbType:    database.Instance.Engine,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal statement dry run payload: %v", task.Name)
+	}
+	return []*api.TaskCheckRunCreate{
+		{
+			CreatorID: creatorID,
+			TaskID:    task.ID,
+			Type:      api.TaskCheckDatabaseStatementDryRun,
+			Payload:   string(payload),
+		},
+	}, nil
+}
+
 func (s *TaskCheckScheduler) getSQLReviewTaskCheck(ctx context.Context, task *api.Task, creatorID int, database *api.Database, statement string) ([]*api.TaskCheckRunCreate, error) {
 	if !api.IsSQLReviewSupported(database.Instance.Engine, s.server.profile.Mode) {
 		return nil, nil
@@ -56,7 +56,7 @@ func RunTaskExecutorOnce(ctx context.Context, exec TaskExecutor, server *Server,
 	return exec.RunOnce(ctx, server, task)
 }
 
-func preMigration(ctx context.Context, server *Server, task *api.Task, migrationType db.MigrationType, statement, schemaVersion string, vcsPushEvent *vcsPlugin.PushEvent) (*db.MigrationInfo, error) {
+func preMigration(ctx context.Context, server *Server, task *api.Task, migrationType db.MigrationType, statement, schemaVersion string, vcsPushEvent *vcsPlugin.PushEvent, undoStatement string) (*db.MigrationInfo, error) {
 	if task.Database == nil {
 		msg := "missing database when updating schema"
 		if migrationType == db.Data {
@@ -73,6 +73,7 @@ func preMigration(ctx context.Context, server *Server, task *api.Task, migration
 		Version:     schemaVersion,
 		Description: task.Name,
 		Environment: task.Instance.Environment.Name,
+		LockTimeout: server.profile.MigrationLockTimeout,
 	}
 	if vcsPushEvent == nil {
 		mi.Source = db.UI
@@ -90,12 +91,15 @@ func preMigration(ctx context.Context, server *Server, task *api.Task, migration
 	} else {
 		mi.Source = db.VCS
 		mi.Creator = vcsPushEvent.AuthorName
+	}
+	if vcsPushEvent != nil || undoStatement != "" {
 		miPayload := &db.MigrationInfoPayload{
-			VCSPushEvent: vcsPushEvent,
+			VCSPushEvent:  vcsPushEvent,
+			UndoStatement: undoStatement,
 		}
 		bytes, err := json.Marshal(miPayload)
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to prepare for database migration, unable to marshal vcs push event payload")
+			return nil, errors.Wrap(err, "failed to prepare for database migration, unable to marshal migration info payload")
 		}
 		mi.Payload = string(bytes)
 	}
@@ -119,7 +123,7 @@ func preMigration(ctx context.Context, server *Server, task *api.Task, migration
 	// We will force migration for baseline, migrate and data type of migrations.
 	// This usually happens when the previous attempt fails and the client retries the migration.
 	// We also force migration for VCS migrations, which is usually a modified file to correct a former wrong migration commit.
-	if mi.Type == db.Baseline || mi.Type == db.Migrate || mi.Type == db.Data {
+	if mi.Type == db.Baseline || mi.Type == db.Migrate || mi.Type == db.Data || mi.Type == db.Undo {
 		mi.Force = true
 	}
 
@@ -173,7 +177,7 @@ func executeMigration(ctx context.Context, server *Server, task *api.Task, state
 }
 
 func setThreadIDAndStartBinlogCoordinate(ctx context.Context, driver db.Driver, task *api.Task, store *store.Store) error {
-	mysqlDriver, ok := driver.(*mysql.Driver)
+	mysqlDriver, ok := unwrapDriver(driver).(*mysql.Driver)
 	if !ok {
 		return errors.Errorf("failed to cast driver to mysql.Driver")
 	}
@@ -394,8 +398,11 @@ func postMigration(ctx context.Context, server *Server, task *api.Task, vcsPushE
 	}
 
 	detail := fmt.Sprintf("Applied migration version %s to database %q.", mi.Version, databaseName)
-	if mi.Type == db.Baseline {
+	switch mi.Type {
+	case db.Baseline:
 		detail = fmt.Sprintf("Established baseline version %s for database %q.", mi.Version, databaseName)
+	case db.Undo:
+		detail = fmt.Sprintf("Applied undo migration version %s to database %q.", mi.Version, databaseName)
 	}
 
 	return true, &api.TaskRunResultPayload{
@@ -405,16 +412,24 @@ func postMigration(ctx context.Context, server *Server, task *api.Task, vcsPushE
 	}, nil
 }
 
-func runMigration(ctx context.Context, server *Server, task *api.Task, migrationType db.MigrationType, statement, schemaVersion string, vcsPushEvent *vcsPlugin.PushEvent) (terminated bool, result *api.TaskRunResultPayload, err error) {
-	mi, err := preMigration(ctx, server, task, migrationType, statement, schemaVersion, vcsPushEvent)
+// runMigration runs the migration, and tracks the statement-level progress with the tracker if it's not nil.
+func runMigration(ctx context.Context, server *Server, task *api.Task, migrationType db.MigrationType, statement, schemaVersion string, vcsPushEvent *vcsPlugin.PushEvent, undoStatement string, tracker *statementProgressTracker) (terminated bool, result *api.TaskRunResultPayload, err error) {
+	mi, err := preMigration(ctx, server, task, migrationType, statement, schemaVersion, vcsPushEvent, undoStatement)
 	if err != nil {
 		return true, nil, err
 	}
+	if tracker != nil {
+		mi.ProgressReporter = tracker
+	}
 	migrationID, schema, err := executeMigration(ctx, server, task, statement, mi)
 	if err != nil {
 		return true, nil, err
 	}
-	return postMigration(ctx, server, task, vcsPushEvent, mi, migrationID, schema)
+	terminated, result, err = postMigration(ctx, server, task, vcsPushEvent, mi, migrationID, schema)
+	if result != nil && tracker != nil {
+		result.StatementResultList = tracker.resultList
+	}
+	return terminated, result, err
 }
 
 func findIssueByTask(ctx context.Context, server *Server, task *api.Task) (*api.Issue, error) {
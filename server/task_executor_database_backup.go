@@ -4,17 +4,21 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"sync/atomic"
+	"time"
 
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 	"golang.org/x/sys/unix"
 
 	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/common"
 	"github.com/bytebase/bytebase/common/log"
 	"github.com/bytebase/bytebase/plugin/db"
+	"github.com/bytebase/bytebase/plugin/db/mysql"
 )
 
 const (
@@ -30,6 +34,7 @@ func NewDatabaseBackupTaskExecutor() TaskExecutor {
 // DatabaseBackupTaskExecutor is the task executor for database backup.
 type DatabaseBackupTaskExecutor struct {
 	completed int32
+	progress  atomic.Value // api.Progress
 }
 
 // IsCompleted tells the scheduler if the task execution has completed.
@@ -38,8 +43,12 @@ func (exec *DatabaseBackupTaskExecutor) IsCompleted() bool {
 }
 
 // GetProgress returns the task progress.
-func (*DatabaseBackupTaskExecutor) GetProgress() api.Progress {
-	return api.Progress{}
+func (exec *DatabaseBackupTaskExecutor) GetProgress() api.Progress {
+	progress := exec.progress.Load()
+	if progress == nil {
+		return api.Progress{}
+	}
+	return progress.(api.Progress)
 }
 
 // RunOnce will run database backup once.
@@ -127,58 +136,149 @@ func getAvailableFSSpace(path string) (uint64, error) {
 	return stat.Bavail * uint64(stat.Bsize), nil
 }
 
-func dumpBackupFile(ctx context.Context, driver db.Driver, databaseName, backupFilePath string) (string, error) {
+// dumpBackupFile dumps the database to the backup file, compressed with compression and encrypted with encryptionKey if it isn't empty.
+// It returns the backup payload with the compression and encryption recorded.
+func dumpBackupFile(ctx context.Context, driver db.Driver, databaseName, backupFilePath, compression, encryptionKey string) (string, error) {
 	backupFile, err := os.Create(backupFilePath)
 	if err != nil {
 		return "", errors.Errorf("failed to open backup path %q", backupFilePath)
 	}
 	defer backupFile.Close()
-	payload, err := driver.Dump(ctx, databaseName, backupFile, false /* schemaOnly */)
+	backupWriter, err := common.NewBackupWriter(backupFile, compression, encryptionKey)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to create writer for backup file %q", backupFilePath)
+	}
+	payload, err := driver.Dump(ctx, databaseName, backupWriter, false /* schemaOnly */)
 	if err != nil {
 		return "", errors.Wrapf(err, "failed to dump database %q to local backup file %q", databaseName, backupFilePath)
 	}
-	return payload, nil
+	if err := backupWriter.Close(); err != nil {
+		return "", errors.Wrapf(err, "failed to flush backup file %q", backupFilePath)
+	}
+	if compression == common.BackupCompressionNone && encryptionKey == "" {
+		return payload, nil
+	}
+
+	var backupPayload api.BackupPayload
+	if payload != "" {
+		if err := json.Unmarshal([]byte(payload), &backupPayload); err != nil {
+			return "", errors.Wrapf(err, "failed to unmarshal backup payload %q", payload)
+		}
+	}
+	backupPayload.Compression = compression
+	backupPayload.Encrypted = encryptionKey != ""
+	payloadBytes, err := json.Marshal(backupPayload)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal backup payload")
+	}
+	return string(payloadBytes), nil
+}
+
+// openBackupFile opens the backup file for restore. The backup is decrypted and decompressed transparently.
+func openBackupFile(backupFilePath, encryptionKey string) (io.ReadCloser, error) {
+	backupFile, err := os.Open(backupFilePath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open backup file %q", backupFilePath)
+	}
+	backupReader, err := common.NewBackupReader(backupFile, encryptionKey)
+	if err != nil {
+		backupFile.Close()
+		return nil, errors.Wrapf(err, "failed to read backup file %q", backupFilePath)
+	}
+	return &backupFileReader{ReadCloser: backupReader, file: backupFile}, nil
+}
+
+// backupFileReader closes the backup file after closing the backup reader.
+type backupFileReader struct {
+	io.ReadCloser
+	file *os.File
+}
+
+func (r *backupFileReader) Close() error {
+	if err := r.ReadCloser.Close(); err != nil {
+		r.file.Close()
+		return err
+	}
+	return r.file.Close()
 }
 
 // backupDatabase will take a backup of a database.
-func (*DatabaseBackupTaskExecutor) backupDatabase(ctx context.Context, server *Server, instance *api.Instance, databaseName string, backup *api.Backup) (string, error) {
+func (exec *DatabaseBackupTaskExecutor) backupDatabase(ctx context.Context, server *Server, instance *api.Instance, databaseName string, backup *api.Backup) (string, error) {
 	driver, err := server.getAdminDatabaseDriver(ctx, instance, databaseName)
 	if err != nil {
 		return "", err
 	}
 	defer driver.Close(ctx)
 
+	if mysqlDriver, ok := unwrapDriver(driver).(*mysql.Driver); ok {
+		progressCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		exec.updateProgress(progressCtx, mysqlDriver)
+	}
+
+	encryptionKey := ""
+	if server.profile.BackupEncryption {
+		encryptionKey = server.backupEncryptionKey
+	}
 	backupFilePathLocal := filepath.Join(server.profile.DataDir, backup.Path)
-	payload, err := dumpBackupFile(ctx, driver, databaseName, backupFilePathLocal)
+	payload, err := dumpBackupFile(ctx, driver, databaseName, backupFilePathLocal, server.profile.BackupCompression, encryptionKey)
 	if err != nil {
 		return "", errors.Wrapf(err, "failed to dump backup file %q", backupFilePathLocal)
 	}
 
-	switch backup.StorageBackend {
-	case api.BackupStorageBackendLocal:
+	if backup.StorageBackend == api.BackupStorageBackendLocal {
 		return payload, nil
-	case api.BackupStorageBackendS3:
-		log.Debug("Uploading backup to s3 bucket.", zap.String("bucket", server.s3Client.GetBucket()), zap.String("path", backupFilePathLocal))
-		bucketFileToUpload, err := os.Open(backupFilePathLocal)
-		if err != nil {
-			return "", errors.Wrapf(err, "failed to open backup file %q for uploading to s3 bucket", backupFilePathLocal)
-		}
-		defer bucketFileToUpload.Close()
+	}
+	backupStorage, err := server.getBackupStorage(backup.StorageBackend)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get the storage of backup %q", backup.Name)
+	}
+	log.Debug("Uploading backup to the cloud storage.", zap.String("storageBackend", string(backup.StorageBackend)), zap.String("path", backupFilePathLocal))
+	bucketFileToUpload, err := os.Open(backupFilePathLocal)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to open backup file %q for uploading to the cloud storage", backupFilePathLocal)
+	}
+	defer bucketFileToUpload.Close()
 
-		if _, err := server.s3Client.UploadObject(ctx, backup.Path, bucketFileToUpload); err != nil {
-			return "", errors.Wrapf(err, "failed to upload backup to AWS S3")
-		}
-		log.Debug("Successfully uploaded backup to s3 bucket.")
+	if err := backupStorage.Upload(ctx, backup.Path, bucketFileToUpload); err != nil {
+		return "", errors.Wrapf(err, "failed to upload backup to %s", backup.StorageBackend)
+	}
+	log.Debug("Successfully uploaded backup to the cloud storage.")
 
-		if err := os.Remove(backupFilePathLocal); err != nil {
-			log.Warn("Failed to remove the local backup file after uploading to s3 bucket.", zap.String("path", backupFilePathLocal), zap.Error(err))
-		} else {
-			log.Debug("Successfully removed the local backup file after uploading to s3 bucket.", zap.String("path", backupFilePathLocal))
-		}
-		return payload, nil
-	default:
-		return "", errors.Errorf("backup to %s not implemented yet", backup.StorageBackend)
+	if err := os.Remove(backupFilePathLocal); err != nil {
+		log.Warn("Failed to remove the local backup file after uploading to the cloud storage.", zap.String("path", backupFilePathLocal), zap.Error(err))
+	} else {
+		log.Debug("Successfully removed the local backup file after uploading to the cloud storage.", zap.String("path", backupFilePathLocal))
 	}
+	return payload, nil
+}
+
+// updateProgress reports the number of tables whose data has been dumped as the progress until ctx is done.
+func (exec *DatabaseBackupTaskExecutor) updateProgress(ctx context.Context, driver *mysql.Driver) {
+	createdTs := time.Now().Unix()
+	exec.progress.Store(api.Progress{
+		CreatedTs: createdTs,
+		UpdatedTs: createdTs,
+	})
+	go func() {
+		ticker := time.NewTicker(1 * time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				progressPrev := exec.progress.Load().(api.Progress)
+				completed, total := driver.GetDumpProgress()
+				exec.progress.Store(api.Progress{
+					TotalUnit:     total,
+					CompletedUnit: completed,
+					CreatedTs:     progressPrev.CreatedTs,
+					UpdatedTs:     time.Now().Unix(),
+				})
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
 }
 
 // Get backup dir relative to the data dir.
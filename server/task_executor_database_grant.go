@@ -0,0 +1,114 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/common/log"
+)
+
+// NewDatabaseGrantTaskExecutor creates a database grant task executor.
+func NewDatabaseGrantTaskExecutor() TaskExecutor {
+	return &DatabaseGrantTaskExecutor{}
+}
+
+// DatabaseGrantTaskExecutor is the database grant task executor.
+type DatabaseGrantTaskExecutor struct {
+	completed int32
+}
+
+// IsCompleted tells the scheduler if the task execution has completed.
+func (exec *DatabaseGrantTaskExecutor) IsCompleted() bool {
+	return atomic.LoadInt32(&exec.completed) == 1
+}
+
+// GetProgress returns the task progress.
+func (*DatabaseGrantTaskExecutor) GetProgress() api.Progress {
+	return api.Progress{}
+}
+
+// RunOnce will run the database grant task executor once.
+func (exec *DatabaseGrantTaskExecutor) RunOnce(ctx context.Context, server *Server, task *api.Task) (terminated bool, result *api.TaskRunResultPayload, err error) {
+	defer atomic.StoreInt32(&exec.completed, 1)
+	payload := &api.TaskDatabaseGrantPayload{}
+	if err := json.Unmarshal([]byte(task.Payload), payload); err != nil {
+		return true, nil, errors.Wrap(err, "invalid database grant payload")
+	}
+	if task.Database == nil {
+		return true, nil, errors.Errorf("database not found for task %d", task.ID)
+	}
+	instance := task.Instance
+	privilegeList, err := normalizeGrantPrivilegeList(instance.Engine, payload.PrivilegeList)
+	if err != nil {
+		return true, nil, err
+	}
+	// The password is kept out of the task payload and is deleted once the task terminates.
+	password, err := server.store.GetTaskSecret(ctx, task.ID)
+	if err != nil {
+		return true, nil, errors.Wrap(err, "failed to get the password of the database grant task")
+	}
+	grant := &databaseGrant{
+		engine:        instance.Engine,
+		databaseName:  task.Database.Name,
+		userName:      payload.UserName,
+		host:          payload.Host,
+		password:      password,
+		privilegeList: privilegeList,
+	}
+
+	issue, err := server.store.GetIssueByPipelineID(ctx, task.PipelineID)
+	if err != nil {
+		return true, nil, err
+	}
+	if issue == nil {
+		return true, nil, errors.Errorf("issue not found with pipeline ID %v", task.PipelineID)
+	}
+
+	driver, err := server.getAdminDatabaseDriver(ctx, instance, grant.databaseName)
+	if err != nil {
+		return true, nil, err
+	}
+	defer driver.Close(ctx)
+
+	log.Debug("Start granting database privileges...",
+		zap.String("instance", instance.Name),
+		zap.String("database", grant.databaseName),
+		zap.String("user", grant.getInstanceUserName()),
+		zap.Strings("privileges", privilegeList),
+	)
+	if err := executeDatabaseGrant(ctx, driver, grant, false /* revoke */); err != nil {
+		return true, nil, err
+	}
+
+	// Record the grant so that the privileges are revoked once the grant expires.
+	if _, err := server.store.CreateInstanceUserGrant(ctx, &api.InstanceUserGrantCreate{
+		CreatorID:     api.SystemBotID,
+		InstanceID:    instance.ID,
+		IssueID:       issue.ID,
+		DatabaseName:  grant.databaseName,
+		UserName:      grant.userName,
+		Host:          grant.host,
+		PrivilegeList: privilegeList,
+		ExpireTs:      payload.ExpireTs,
+	}); err != nil {
+		return true, nil, errors.Wrap(err, "failed to record the database grant")
+	}
+
+	server.syncInstanceUser(ctx, instance, driver, grant)
+
+	detail := fmt.Sprintf("Granted %s on database %q to %s", strings.Join(privilegeList, ", "), grant.databaseName, grant.getInstanceUserName())
+	if payload.ExpireTs != 0 {
+		detail = fmt.Sprintf("%s, expiring at %s", detail, time.Unix(payload.ExpireTs, 0).UTC().Format(time.RFC3339))
+	}
+	return true, &api.TaskRunResultPayload{
+		Detail: detail,
+	}, nil
+}
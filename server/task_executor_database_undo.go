@@ -0,0 +1,78 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/plugin/db"
+)
+
+// NewDatabaseUndoTaskExecutor creates a database undo task executor.
+func NewDatabaseUndoTaskExecutor() TaskExecutor {
+	return &DatabaseUndoTaskExecutor{}
+}
+
+// DatabaseUndoTaskExecutor is the database undo task executor, which reverses an applied migration.
+type DatabaseUndoTaskExecutor struct {
+	completed int32
+}
+
+// RunOnce will run the database undo task executor once.
+func (exec *DatabaseUndoTaskExecutor) RunOnce(ctx context.Context, server *Server, task *api.Task) (terminated bool, result *api.TaskRunResultPayload, err error) {
+	defer atomic.StoreInt32(&exec.completed, 1)
+	payload := &api.TaskDatabaseUndoPayload{}
+	if err := json.Unmarshal([]byte(task.Payload), payload); err != nil {
+		return true, nil, errors.Wrap(err, "invalid database undo payload")
+	}
+	if task.Database == nil {
+		return true, nil, errors.Errorf("database not found for task %d", task.ID)
+	}
+
+	// Other migrations may have been applied since the issue was created, and reversing the version in between is unsafe.
+	if err := checkDatabaseUndoable(ctx, server, task, payload.UndoVersion); err != nil {
+		return true, nil, err
+	}
+
+	mi, err := preMigration(ctx, server, task, db.Undo, payload.Statement, payload.SchemaVersion, nil /* vcsPushEvent */, "" /* undoStatement */)
+	if err != nil {
+		return true, nil, err
+	}
+	bytes, err := json.Marshal(&db.MigrationInfoPayload{UndoVersion: payload.UndoVersion})
+	if err != nil {
+		return true, nil, errors.Wrap(err, "failed to prepare for database undo, unable to marshal migration info payload")
+	}
+	mi.Payload = string(bytes)
+
+	migrationID, schema, err := executeMigration(ctx, server, task, payload.Statement, mi)
+	if err != nil {
+		return true, nil, err
+	}
+	return postMigration(ctx, server, task, nil /* vcsPushEvent */, mi, migrationID, schema)
+}
+
+// IsCompleted tells the scheduler if the task execution has completed.
+func (exec *DatabaseUndoTaskExecutor) IsCompleted() bool {
+	return atomic.LoadInt32(&exec.completed) == 1
+}
+
+// GetProgress returns the task progress.
+func (*DatabaseUndoTaskExecutor) GetProgress() api.Progress {
+	return api.Progress{}
+}
+
+func checkDatabaseUndoable(ctx context.Context, server *Server, task *api.Task, undoVersion string) error {
+	driver, err := server.getAdminDatabaseDriver(ctx, task.Instance, "" /* databaseName */)
+	if err != nil {
+		return err
+	}
+	defer driver.Close(ctx)
+	historyList, err := driver.FindMigrationHistoryList(ctx, &db.MigrationHistoryFind{Database: &task.Database.Name})
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch the migration history of database %q", task.Database.Name)
+	}
+	return checkMigrationUndoable(historyList, undoVersion)
+}
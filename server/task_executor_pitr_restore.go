@@ -21,7 +21,7 @@ import (
 	"github.com/bytebase/bytebase/plugin/db/mysql"
 	"github.com/bytebase/bytebase/plugin/db/pg"
 	"github.com/bytebase/bytebase/plugin/db/util"
-	bbs3 "github.com/bytebase/bytebase/plugin/storage/s3"
+	"github.com/bytebase/bytebase/plugin/storage"
 	"github.com/bytebase/bytebase/store"
 )
 
@@ -133,7 +133,7 @@ func (exec *PITRRestoreTaskExecutor) doBackupRestore(ctx context.Context, server
 	)
 
 	// Restore the database to the target database.
-	if err := exec.restoreDatabase(ctx, server, targetDatabase.Instance, targetDatabase.Name, backup); err != nil {
+	if err := restoreDatabase(ctx, server, targetDatabase.Instance, targetDatabase.Name, backup); err != nil {
 		return nil, err
 	}
 	// TODO(zp): This should be done in the same transaction as restoreDatabase to guarantee consistency.
@@ -204,21 +204,21 @@ func (exec *PITRRestoreTaskExecutor) doPITRRestore(ctx context.Context, server *
 	}
 	log.Debug("Found backup list", zap.Array("backups", api.ZapBackupArray(backupList)))
 
-	mysqlSourceDriver, sourceOk := sourceDriver.(*mysql.Driver)
-	mysqlTargetDriver, targetOk := targetDriver.(*mysql.Driver)
+	mysqlSourceDriver, sourceOk := unwrapDriver(sourceDriver).(*mysql.Driver)
+	mysqlTargetDriver, targetOk := unwrapDriver(targetDriver).(*mysql.Driver)
 	if (!sourceOk) || (!targetOk) {
 		log.Error("Failed to cast driver to mysql.Driver")
 		return nil, errors.Errorf("[internal] cast driver to mysql.Driver failed")
 	}
 
 	log.Debug("Downloading all binlog files")
-	if err := mysqlSourceDriver.FetchAllBinlogFiles(ctx, true /* downloadLatestBinlogFile */, server.s3Client); err != nil {
+	if err := mysqlSourceDriver.FetchAllBinlogFiles(ctx, true /* downloadLatestBinlogFile */, server.backupStorage); err != nil {
 		return nil, err
 	}
 
 	targetTs := *payload.PointInTimeTs
 	log.Debug("Getting latest backup before or equal to targetTs", zap.Int64("targetTs", targetTs))
-	backup, targetBinlogInfo, err := mysqlSourceDriver.GetLatestBackupBeforeOrEqualTs(ctx, backupList, targetTs, server.s3Client)
+	backup, targetBinlogInfo, err := mysqlSourceDriver.GetLatestBackupBeforeOrEqualTs(ctx, backupList, targetTs, server.backupStorage)
 	if err != nil {
 		targetTsHuman := time.Unix(targetTs, 0).Format(time.RFC822)
 		log.Error("Failed to get backup before or equal to time",
@@ -232,14 +232,14 @@ func (exec *PITRRestoreTaskExecutor) doPITRRestore(ctx context.Context, server *
 	log.Debug("Got latest backup before or equal to targetTs", zap.String("backup", backup.Name))
 
 	backupAbsPathLocal := getBackupAbsFilePath(server.profile.DataDir, backup.DatabaseID, backup.Name)
-	if backup.StorageBackend == api.BackupStorageBackendS3 {
-		if err := downloadBackupFileFromCloud(ctx, server, backup.Path, backupAbsPathLocal); err != nil {
-			return nil, errors.Wrapf(err, "failed to download backup %q from S3", backup.Path)
+	if backup.StorageBackend != api.BackupStorageBackendLocal {
+		if err := downloadBackupFileFromCloud(ctx, server, backup, backupAbsPathLocal); err != nil {
+			return nil, errors.Wrapf(err, "failed to download backup %q from %s", backup.Path, backup.StorageBackend)
 		}
 		defer os.Remove(backupAbsPathLocal)
-		replayBinlogPathList, err := downloadBinlogFilesFromCloud(ctx, server.s3Client, startBinlogInfo, *targetBinlogInfo, binlogDir)
+		replayBinlogPathList, err := downloadBinlogFilesFromCloud(ctx, server.backupStorage, startBinlogInfo, *targetBinlogInfo, binlogDir)
 		if err != nil {
-			return nil, errors.Wrapf(err, "failed to download binlog files from %s to %s from S3", startBinlogInfo.FileName, targetBinlogInfo.FileName)
+			return nil, errors.Wrapf(err, "failed to download binlog files from %s to %s from %s", startBinlogInfo.FileName, targetBinlogInfo.FileName, backup.StorageBackend)
 		}
 		defer func() {
 			for _, binlogPath := range replayBinlogPathList {
@@ -255,6 +255,13 @@ func (exec *PITRRestoreTaskExecutor) doPITRRestore(ctx context.Context, server *
 		return nil, errors.Wrapf(err, "failed to open backup file %q", backupAbsPathLocal)
 	}
 	defer backupFile.Close()
+	// Count the bytes read from the backup file for the progress, since the restored bytes differ if the backup is compressed.
+	backupFileCountingReader := common.NewCountingReader(backupFile)
+	backupReader, err := common.NewBackupReader(backupFileCountingReader, server.backupEncryptionKey)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read backup file %q", backupAbsPathLocal)
+	}
+	defer backupReader.Close()
 	log.Debug("Successfully opened backup file", zap.String("filename", backupAbsPathLocal))
 
 	log.Debug("Start creating and restoring PITR database",
@@ -262,13 +269,13 @@ func (exec *PITRRestoreTaskExecutor) doPITRRestore(ctx context.Context, server *
 		zap.String("database", task.Database.Name),
 	)
 
-	if err := exec.updateProgress(ctx, mysqlTargetDriver, backupFile, startBinlogInfo, *targetBinlogInfo, binlogDir); err != nil {
+	if err := exec.updateProgress(ctx, mysqlTargetDriver, backupFile, backupFileCountingReader, startBinlogInfo, *targetBinlogInfo, binlogDir); err != nil {
 		return nil, errors.Wrap(err, "failed to setup progress update process")
 	}
 
 	if payload.DatabaseName != nil {
 		// case 1: PITR to a new database.
-		if err := mysqlTargetDriver.RestoreBackupToDatabase(ctx, backupFile, *payload.DatabaseName); err != nil {
+		if err := mysqlTargetDriver.RestoreBackupToDatabase(ctx, backupReader, *payload.DatabaseName); err != nil {
 			log.Error("failed to restore full backup in the new database",
 				zap.Int("issueID", issue.ID),
 				zap.String("databaseName", *payload.DatabaseName),
@@ -284,7 +291,7 @@ func (exec *PITRRestoreTaskExecutor) doPITRRestore(ctx context.Context, server *
 		}
 	} else {
 		// case 2: in-place PITR.
-		if err := mysqlTargetDriver.RestoreBackupToPITRDatabase(ctx, backupFile, task.Database.Name, issue.CreatedTs); err != nil {
+		if err := mysqlTargetDriver.RestoreBackupToPITRDatabase(ctx, backupReader, task.Database.Name, issue.CreatedTs); err != nil {
 			log.Error("failed to restore full backup in the PITR database",
 				zap.Int("issueID", issue.ID),
 				zap.String("databaseName", task.Database.Name),
@@ -310,7 +317,7 @@ func (exec *PITRRestoreTaskExecutor) doPITRRestore(ctx context.Context, server *
 	}, nil
 }
 
-func downloadBinlogFilesFromCloud(ctx context.Context, client *bbs3.Client, startBinlogInfo, targetBinlogInfo api.BinlogInfo, binlogDir string) ([]string, error) {
+func downloadBinlogFilesFromCloud(ctx context.Context, client storage.Backend, startBinlogInfo, targetBinlogInfo api.BinlogInfo, binlogDir string) ([]string, error) {
 	replayBinlogPathList, err := mysql.GetBinlogReplayList(startBinlogInfo, targetBinlogInfo, binlogDir)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to get binlog replay list in directory %s", binlogDir)
@@ -318,7 +325,7 @@ func downloadBinlogFilesFromCloud(ctx context.Context, client *bbs3.Client, star
 	for _, binlogFilePath := range replayBinlogPathList {
 		// Use path.Join to compose a path on cloud which always uses / as the separator.
 		filePathOnCloud := path.Join(common.GetBinlogRelativeDir(binlogDir), filepath.Base(binlogFilePath))
-		if err := client.DownloadFileFromCloud(ctx, binlogFilePath, filePathOnCloud); err != nil {
+		if err := storage.DownloadFile(ctx, client, binlogFilePath, filePathOnCloud); err != nil {
 			return nil, errors.Wrapf(err, "failed to download binlog file %s from the cloud storage", binlogFilePath)
 		}
 	}
@@ -338,9 +345,9 @@ func (*PITRRestoreTaskExecutor) doRestoreInPlacePostgres(ctx context.Context, se
 		return nil, errors.Errorf("backup with ID %d not found", *payload.BackupID)
 	}
 	backupFileName := getBackupAbsFilePath(server.profile.DataDir, backup.DatabaseID, backup.Name)
-	backupFile, err := os.Open(backupFileName)
+	backupFile, err := openBackupFile(backupFileName, server.backupEncryptionKey)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to open backup file %q", backupFileName)
+		return nil, err
 	}
 	defer backupFile.Close()
 
@@ -350,7 +357,7 @@ func (*PITRRestoreTaskExecutor) doRestoreInPlacePostgres(ctx context.Context, se
 	}
 	defer driver.Close(ctx)
 
-	pgDriver, ok := driver.(*pg.Driver)
+	pgDriver, ok := unwrapDriver(driver).(*pg.Driver)
 	if !ok {
 		log.Error("Failed to cast driver to pg.Driver")
 		return nil, errors.Errorf("[internal] cast driver to pg.Driver failed")
@@ -386,7 +393,8 @@ func (*PITRRestoreTaskExecutor) doRestoreInPlacePostgres(ctx context.Context, se
 	}, nil
 }
 
-func (exec *PITRRestoreTaskExecutor) updateProgress(ctx context.Context, driver *mysql.Driver, backupFile *os.File, startBinlogInfo, targetBinlogInfo api.BinlogInfo, binlogDir string) error {
+// updateProgress reports the bytes read from the backup file and the replayed binlog bytes as the progress.
+func (exec *PITRRestoreTaskExecutor) updateProgress(ctx context.Context, driver *mysql.Driver, backupFile *os.File, backupFileReader *common.CountingReader, startBinlogInfo, targetBinlogInfo api.BinlogInfo, binlogDir string) error {
 	backupFileInfo, err := backupFile.Stat()
 	if err != nil {
 		return errors.Wrapf(err, "failed to get stat of backup file %q", backupFile.Name())
@@ -417,7 +425,7 @@ func (exec *PITRRestoreTaskExecutor) updateProgress(ctx context.Context, driver
 				progressPrev := exec.progress.Load().(api.Progress)
 				exec.progress.Store(api.Progress{
 					TotalUnit:     progressPrev.TotalUnit,
-					CompletedUnit: driver.GetRestoredBackupBytes() + driver.GetReplayedBinlogBytes(),
+					CompletedUnit: backupFileReader.Count() + driver.GetReplayedBinlogBytes(),
 					CreatedTs:     progressPrev.CreatedTs,
 					UpdatedTs:     time.Now().Unix(),
 				})
@@ -444,7 +452,7 @@ func getIssueByPipelineID(ctx context.Context, store *store.Store, pid int) (*ap
 }
 
 // restoreDatabase will restore the database to the instance from the backup.
-func (*PITRRestoreTaskExecutor) restoreDatabase(ctx context.Context, server *Server, instance *api.Instance, databaseName string, backup *api.Backup) error {
+func restoreDatabase(ctx context.Context, server *Server, instance *api.Instance, databaseName string, backup *api.Backup) error {
 	driver, err := server.getAdminDatabaseDriver(ctx, instance, databaseName)
 	if err != nil {
 		return err
@@ -453,16 +461,16 @@ func (*PITRRestoreTaskExecutor) restoreDatabase(ctx context.Context, server *Ser
 
 	backupAbsPathLocal := filepath.Join(server.profile.DataDir, backup.Path)
 
-	if backup.StorageBackend == api.BackupStorageBackendS3 {
-		if err := downloadBackupFileFromCloud(ctx, server, backup.Path, backupAbsPathLocal); err != nil {
-			return errors.Wrapf(err, "failed to download backup %q from S3", backup.Path)
+	if backup.StorageBackend != api.BackupStorageBackendLocal {
+		if err := downloadBackupFileFromCloud(ctx, server, backup, backupAbsPathLocal); err != nil {
+			return errors.Wrapf(err, "failed to download backup %q from %s", backup.Path, backup.StorageBackend)
 		}
 		defer os.Remove(backupAbsPathLocal)
 	}
 
-	backupFileLocal, err := os.Open(backupAbsPathLocal)
+	backupFileLocal, err := openBackupFile(backupAbsPathLocal, server.backupEncryptionKey)
 	if err != nil {
-		return errors.Wrapf(err, "failed to open backup file at %s", backupAbsPathLocal)
+		return err
 	}
 	defer backupFileLocal.Close()
 
@@ -473,17 +481,16 @@ func (*PITRRestoreTaskExecutor) restoreDatabase(ctx context.Context, server *Ser
 	return nil
 }
 
-func downloadBackupFileFromCloud(ctx context.Context, server *Server, backupPath, backupAbsPathLocal string) error {
-	log.Debug("Downloading backup file from s3 bucket.", zap.String("path", backupPath))
-	backupFileDownload, err := os.Create(backupAbsPathLocal)
+func downloadBackupFileFromCloud(ctx context.Context, server *Server, backup *api.Backup, backupAbsPathLocal string) error {
+	backupStorage, err := server.getBackupStorage(backup.StorageBackend)
 	if err != nil {
-		return errors.Wrapf(err, "failed to create local backup file %q for downloading from s3 bucket", backupAbsPathLocal)
+		return errors.Wrapf(err, "failed to get the storage of backup %q", backup.Name)
 	}
-	defer backupFileDownload.Close()
-	if _, err := server.s3Client.DownloadObject(ctx, backupPath, backupFileDownload); err != nil {
-		return errors.Wrapf(err, "failed to download backup file %q from s3 bucket", backupPath)
+	log.Debug("Downloading backup file from the cloud storage.", zap.String("storageBackend", string(backup.StorageBackend)), zap.String("path", backup.Path))
+	if err := storage.DownloadFile(ctx, backupStorage, backupAbsPathLocal, backup.Path); err != nil {
+		return errors.Wrapf(err, "failed to download backup file %q from the cloud storage", backup.Path)
 	}
-	log.Debug("Successfully downloaded backup file from s3 bucket.")
+	log.Debug("Successfully downloaded backup file from the cloud storage.")
 	return nil
 }
 
@@ -19,6 +19,7 @@ func NewSchemaUpdateTaskExecutor() TaskExecutor {
 // SchemaUpdateTaskExecutor is the schema update (DDL) task executor.
 type SchemaUpdateTaskExecutor struct {
 	completed int32
+	tracker   statementProgressTracker
 }
 
 // RunOnce will run the schema update (DDL) task executor once.
@@ -29,7 +30,7 @@ func (exec *SchemaUpdateTaskExecutor) RunOnce(ctx context.Context, server *Serve
 		return true, nil, errors.Wrap(err, "invalid database schema update payload")
 	}
 
-	return runMigration(ctx, server, task, db.Migrate, payload.Statement, payload.SchemaVersion, payload.VCSPushEvent)
+	return runMigration(ctx, server, task, db.Migrate, payload.Statement, payload.SchemaVersion, payload.VCSPushEvent, payload.UndoStatement, &exec.tracker)
 }
 
 // IsCompleted tells the scheduler if the task execution has completed.
@@ -38,6 +39,6 @@ func (exec *SchemaUpdateTaskExecutor) IsCompleted() bool {
 }
 
 // GetProgress returns the task progress.
-func (*SchemaUpdateTaskExecutor) GetProgress() api.Progress {
-	return api.Progress{}
+func (exec *SchemaUpdateTaskExecutor) GetProgress() api.Progress {
+	return exec.tracker.get()
 }
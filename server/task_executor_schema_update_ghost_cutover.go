@@ -71,76 +71,85 @@ func (exec *SchemaUpdateGhostCutoverTaskExecutor) RunOnce(ctx context.Context, s
 func cutover(ctx context.Context, server *Server, task *api.Task, statement, schemaVersion string, vcsPushEvent *vcsPlugin.PushEvent, postponeFilename string, migrationContext *base.MigrationContext, errCh <-chan error) (terminated bool, result *api.TaskRunResultPayload, err error) {
 	statement = strings.TrimSpace(statement)
 
-	mi, err := preMigration(ctx, server, task, db.Migrate, statement, schemaVersion, vcsPushEvent)
+	mi, err := preMigration(ctx, server, task, db.Migrate, statement, schemaVersion, vcsPushEvent, "" /* undoStatement */)
 	if err != nil {
 		return true, nil, err
 	}
-	migrationID, schema, err := func() (migrationHistoryID int64, updatedSchema string, resErr error) {
+	migrationID, schema, err := func() (int64, string, error) {
 		driver, err := server.getAdminDatabaseDriver(ctx, task.Instance, task.Database.Name)
 		if err != nil {
 			return -1, "", err
 		}
 		defer driver.Close(ctx)
-		needsSetup, err := driver.NeedsSetupMigration(ctx)
-		if err != nil {
-			return -1, "", errors.Wrapf(err, "failed to check migration setup for instance %q", task.Instance.Name)
-		}
-		if needsSetup {
-			return -1, "", common.Errorf(common.MigrationSchemaMissing, "missing migration schema for instance %q", task.Instance.Name)
-		}
+		return executeGhostCutover(ctx, driver, task.Instance.Name, mi, statement, postponeFilename, migrationContext, errCh)
+	}()
+	if err != nil {
+		return true, nil, err
+	}
 
-		executor := driver.(util.MigrationExecutor)
+	return postMigration(ctx, server, task, vcsPushEvent, mi, migrationID, schema)
+}
 
-		var prevSchemaBuf bytes.Buffer
-		if _, err := driver.Dump(ctx, mi.Database, &prevSchemaBuf, true); err != nil {
-			return -1, "", err
-		}
+// executeGhostCutover records the migration history of the gh-ost migration and waits for the gh-ost cutover with the admin database driver.
+func executeGhostCutover(ctx context.Context, driver db.Driver, instanceName string, mi *db.MigrationInfo, statement, postponeFilename string, migrationContext *base.MigrationContext, errCh <-chan error) (migrationHistoryID int64, updatedSchema string, resErr error) {
+	needsSetup, err := driver.NeedsSetupMigration(ctx)
+	if err != nil {
+		return -1, "", errors.Wrapf(err, "failed to check migration setup for instance %q", instanceName)
+	}
+	if needsSetup {
+		return -1, "", common.Errorf(common.MigrationSchemaMissing, "missing migration schema for instance %q", instanceName)
+	}
 
-		// wait for heartbeat lag.
-		// try to make the time gap between the migration history insertion and the actual cutover as close as possible.
-		cancelled := waitForCutover(ctx, migrationContext)
-		if cancelled {
-			return -1, "", errors.Errorf("cutover poller cancelled")
-		}
+	// The driver may be leased from the driver manager, unwrap it to get the engine-specific migration executor.
+	executor, ok := unwrapDriver(driver).(util.MigrationExecutor)
+	if !ok {
+		return -1, "", errors.Errorf("driver of instance %q is not a migration executor", instanceName)
+	}
 
-		insertedID, err := util.BeginMigration(ctx, executor, mi, prevSchemaBuf.String(), statement, db.BytebaseDatabase)
-		if err != nil {
-			if common.ErrorCode(err) == common.MigrationAlreadyApplied {
-				return insertedID, prevSchemaBuf.String(), nil
-			}
-			return -1, "", errors.Wrapf(err, "failed to begin migration for issue %s", mi.IssueID)
-		}
-		startedNs := time.Now().UnixNano()
-
-		defer func() {
-			if err := util.EndMigration(ctx, executor, startedNs, insertedID, updatedSchema, db.BytebaseDatabase, resErr == nil /*isDone*/); err != nil {
-				log.Error("failed to update migration history record",
-					zap.Error(err),
-					zap.Int64("migration_id", migrationHistoryID),
-				)
-			}
-		}()
+	var prevSchemaBuf bytes.Buffer
+	if _, err := driver.Dump(ctx, mi.Database, &prevSchemaBuf, true); err != nil {
+		return -1, "", err
+	}
 
-		if err := os.Remove(postponeFilename); err != nil {
-			return -1, "", errors.Wrap(err, "failed to remove postpone flag file")
-		}
+	// wait for heartbeat lag.
+	// try to make the time gap between the migration history insertion and the actual cutover as close as possible.
+	cancelled := waitForCutover(ctx, migrationContext)
+	if cancelled {
+		return -1, "", errors.Errorf("cutover poller cancelled")
+	}
 
-		if migrationErr := <-errCh; migrationErr != nil {
-			return -1, "", errors.Wrapf(migrationErr, "failed to run gh-ost migration")
+	insertedID, err := util.BeginMigration(ctx, executor, mi, prevSchemaBuf.String(), statement, db.BytebaseDatabase)
+	if err != nil {
+		if common.ErrorCode(err) == common.MigrationAlreadyApplied {
+			return insertedID, prevSchemaBuf.String(), nil
 		}
-
-		var afterSchemaBuf bytes.Buffer
-		if _, err := executor.Dump(ctx, mi.Database, &afterSchemaBuf, true /*schemaOnly*/); err != nil {
-			return -1, "", util.FormatError(err)
+		return -1, "", errors.Wrapf(err, "failed to begin migration for issue %s", mi.IssueID)
+	}
+	startedNs := time.Now().UnixNano()
+
+	defer func() {
+		if err := util.EndMigration(ctx, executor, startedNs, insertedID, updatedSchema, db.BytebaseDatabase, resErr == nil /*isDone*/); err != nil {
+			log.Error("failed to update migration history record",
+				zap.Error(err),
+				zap.Int64("migration_id", migrationHistoryID),
+			)
 		}
-
-		return insertedID, afterSchemaBuf.String(), nil
 	}()
-	if err != nil {
-		return true, nil, err
+
+	if err := os.Remove(postponeFilename); err != nil {
+		return -1, "", errors.Wrap(err, "failed to remove postpone flag file")
 	}
 
-	return postMigration(ctx, server, task, vcsPushEvent, mi, migrationID, schema)
+	if migrationErr := <-errCh; migrationErr != nil {
+		return -1, "", errors.Wrapf(migrationErr, "failed to run gh-ost migration")
+	}
+
+	var afterSchemaBuf bytes.Buffer
+	if _, err := executor.Dump(ctx, mi.Database, &afterSchemaBuf, true /*schemaOnly*/); err != nil {
+		return -1, "", util.FormatError(err)
+	}
+
+	return insertedID, afterSchemaBuf.String(), nil
 }
 
 func waitForCutover(ctx context.Context, migrationContext *base.MigrationContext) bool {
@@ -0,0 +1,137 @@
+package server
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/github/gh-ost/go/base"
+	"github.com/stretchr/testify/require"
+
+	// Register the sqlite3 driver for the in-memory database of the fake migration driver.
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/bytebase/bytebase/plugin/db"
+)
+
+const fakeMigrationDriverType db.Type = "FAKE_MIGRATION"
+
+func init() {
+	db.Register(fakeMigrationDriverType, func(db.DriverConfig) db.Driver {
+		return &fakeMigrationDriver{}
+	})
+}
+
+// fakeMigrationDriver is a fake driver implementing the migration executor, the migration history is kept in memory.
+type fakeMigrationDriver struct {
+	fakeDriver
+	sqldb     *sql.DB
+	schema    string
+	historyID int64
+	doneID    int64
+}
+
+func (d *fakeMigrationDriver) Open(ctx context.Context, dbType db.Type, config db.ConnectionConfig, connCtx db.ConnectionContext) (db.Driver, error) {
+	if _, err := d.fakeDriver.Open(ctx, dbType, config, connCtx); err != nil {
+		return nil, err
+	}
+	sqldb, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		return nil, err
+	}
+	d.sqldb = sqldb
+	return d, nil
+}
+
+func (d *fakeMigrationDriver) Close(ctx context.Context) error {
+	if err := d.sqldb.Close(); err != nil {
+		return err
+	}
+	return d.fakeDriver.Close(ctx)
+}
+
+func (d *fakeMigrationDriver) GetDBConnection(context.Context, string) (*sql.DB, error) {
+	return d.sqldb, nil
+}
+
+func (*fakeMigrationDriver) NeedsSetupMigration(context.Context) (bool, error) {
+	return false, nil
+}
+
+func (d *fakeMigrationDriver) Dump(_ context.Context, _ string, out io.Writer, _ bool) (string, error) {
+	_, err := io.WriteString(out, d.schema)
+	return "", err
+}
+
+func (*fakeMigrationDriver) FindMigrationHistoryList(context.Context, *db.MigrationHistoryFind) ([]*db.MigrationHistory, error) {
+	return nil, nil
+}
+
+func (*fakeMigrationDriver) FindLargestVersionSinceBaseline(context.Context, *sql.Tx, string) (*string, error) {
+	return nil, nil
+}
+
+func (*fakeMigrationDriver) FindLargestSequence(context.Context, *sql.Tx, string, bool) (int, error) {
+	return 0, nil
+}
+
+func (d *fakeMigrationDriver) InsertPendingHistory(context.Context, *sql.Tx, int, string, *db.MigrationInfo, string, string) (int64, error) {
+	d.historyID++
+	return d.historyID, nil
+}
+
+func (d *fakeMigrationDriver) UpdateHistoryAsDone(_ context.Context, _ *sql.Tx, _ int64, _ string, insertedID int64) error {
+	d.doneID = insertedID
+	return nil
+}
+
+func (*fakeMigrationDriver) UpdateHistoryAsFailed(context.Context, *sql.Tx, int64, int64) error {
+	return nil
+}
+
+func (*fakeMigrationDriver) UpdateHistoryPayload(context.Context, *sql.Tx, string, int64) error {
+	return nil
+}
+
+func TestExecuteGhostCutoverWithLeasedDriver(t *testing.T) {
+	a := require.New(t)
+	ctx := context.Background()
+	m := NewDriverManager(1, time.Minute)
+	defer m.close()
+
+	driver, err := m.GetDriver(ctx, 1, fakeMigrationDriverType, db.DriverConfig{}, db.ConnectionConfig{Database: "db"}, db.ConnectionContext{})
+	a.NoError(err)
+	defer driver.Close(ctx)
+	_, ok := driver.(*leasedDriver)
+	a.True(ok)
+	fake := unwrapDriver(driver).(*fakeMigrationDriver)
+	fake.schema = "CREATE TABLE t(id INT);"
+
+	postponeFilename := filepath.Join(t.TempDir(), "postpone")
+	a.NoError(os.WriteFile(postponeFilename, nil, 0644))
+	migrationContext := base.NewMigrationContext()
+	migrationContext.SetLastHeartbeatOnChangelogTime(time.Now())
+	migrationContext.MaxLagMillisecondsThrottleThreshold = time.Minute.Milliseconds()
+	migrationContext.CutOverLockTimeoutSeconds = 60
+	errCh := make(chan error, 1)
+	errCh <- nil
+
+	mi := &db.MigrationInfo{
+		Version:   "0001",
+		Namespace: "db",
+		Database:  "db",
+		Type:      db.Migrate,
+		Source:    db.UI,
+	}
+	migrationHistoryID, updatedSchema, err := executeGhostCutover(ctx, driver, "instance", mi, "ALTER TABLE t ADD COLUMN c INT;", postponeFilename, migrationContext, errCh)
+	a.NoError(err)
+	a.Equal(int64(1), migrationHistoryID)
+	a.Equal("CREATE TABLE t(id INT);", updatedSchema)
+	a.Equal(int64(1), fake.doneID)
+	_, err = os.Stat(postponeFilename)
+	a.True(os.IsNotExist(err))
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/bytebase/bytebase/api"
 	"github.com/bytebase/bytebase/common"
 	"github.com/bytebase/bytebase/common/log"
+	"github.com/bytebase/bytebase/plugin/db"
 )
 
 // NewSchemaUpdateGhostSyncTaskExecutor creates a schema update (gh-ost) sync task executor.
@@ -209,6 +210,22 @@ func getGhostConfig(task *api.Task, dataSource *api.DataSource, userList []*api.
 	}
 }
 
+// openGhostSSHTunnel opens the SSH tunnel of the data source if it's configured, and points gh-ost to the local end of the tunnel.
+// The tunnel is nil if the SSH tunnel isn't configured, otherwise the caller should close it after the migration finishes.
+func openGhostSSHTunnel(config *ghostConfig, dataSource *api.DataSource) (*db.SSHTunnel, error) {
+	connCfg := db.ConnectionConfig{
+		Host:      config.host,
+		Port:      config.port,
+		SSHConfig: getSSHConfig(dataSource),
+	}
+	sshTunnel, connCfg, err := db.OpenSSHTunnelForConnection(connCfg, "3306")
+	if err != nil {
+		return nil, err
+	}
+	config.host, config.port = connCfg.Host, connCfg.Port
+	return sshTunnel, nil
+}
+
 func (exec *SchemaUpdateGhostSyncTaskExecutor) runGhostMigration(ctx context.Context, server *Server, task *api.Task, statement string) (terminated bool, result *api.TaskRunResultPayload, err error) {
 	syncDone := make(chan struct{})
 	// set buffer size to 1 to unblock the sender because there is no listner if the task is canceled.
@@ -234,8 +251,20 @@ func (exec *SchemaUpdateGhostSyncTaskExecutor) runGhostMigration(ctx context.Con
 
 	config := getGhostConfig(task, adminDataSource, instanceUserList, tableName, statement, false, 10000000)
 
+	// The SSH tunnel is closed after the migration finishes, which may be after the cutover task if the sync is done.
+	sshTunnel, err := openGhostSSHTunnel(&config, adminDataSource)
+	if err != nil {
+		return true, nil, err
+	}
+	closeSSHTunnel := func() {
+		if sshTunnel != nil {
+			sshTunnel.Close()
+		}
+	}
+
 	migrationContext, err := newMigrationContext(config)
 	if err != nil {
+		closeSSHTunnel()
 		return true, nil, errors.Wrap(err, "failed to init migrationContext for gh-ost")
 	}
 
@@ -273,6 +302,7 @@ func (exec *SchemaUpdateGhostSyncTaskExecutor) runGhostMigration(ctx context.Con
 	}(childCtx)
 
 	go func() {
+		defer closeSSHTunnel()
 		if err := migrator.Migrate(); err != nil {
 			log.Error("failed to run gh-ost migration", zap.Error(err))
 			migrationError <- err
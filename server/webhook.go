@@ -26,6 +26,7 @@ import (
 	"github.com/bytebase/bytebase/plugin/advisor"
 	advisorDB "github.com/bytebase/bytebase/plugin/advisor/db"
 	"github.com/bytebase/bytebase/plugin/db"
+	"github.com/bytebase/bytebase/plugin/db/util"
 	"github.com/bytebase/bytebase/plugin/vcs"
 	"github.com/bytebase/bytebase/plugin/vcs/github"
 	"github.com/bytebase/bytebase/plugin/vcs/gitlab"
@@ -357,7 +358,7 @@ func (s *Server) sqlAdviceForFile(
 			return nil, errors.Errorf("Failed to get catalog for database %v with error: %v", database.ID, err)
 		}
 
-		driver, err := tryGetReadOnlyDatabaseDriver(ctx, database.Instance, database.Name)
+		driver, err := s.tryGetReadOnlyDatabaseDriver(ctx, database.Instance, database.Name)
 		if err != nil {
 			return nil, err
 		}
@@ -663,8 +664,20 @@ func (s *Server) processFilesInProject(ctx context.Context, pushEvent vcs.PushEv
 	var createdIssueList []string
 	var fileNameList []string
 
+	// The undo files are paired with the migration files of the same version, instead of creating tasks on their own.
+	undoFileMap := make(map[string]string)
+	for _, fileInfo := range fileInfoList {
+		if fileInfo.fType == migrationFileType && fileInfo.migrationInfo.Type == db.Undo {
+			undoFileMap[fileInfo.migrationInfo.Version] = fileInfo.item.FileName
+		}
+	}
+	pairedUndoFileMap := make(map[string]bool)
+
 	creatorID := s.getIssueCreatorID(ctx, pushEvent.CommitList[0].AuthorEmail)
 	for _, fileInfo := range fileInfoList {
+		if fileInfo.fType == migrationFileType && fileInfo.migrationInfo.Type == db.Undo {
+			continue
+		}
 		if fileInfo.fType == schemaFileType {
 			if repo.Project.SchemaChangeType == api.ProjectSchemaChangeTypeSDL {
 				// Create one issue per schema file for SDL project.
@@ -689,7 +702,11 @@ func (s *Server) processFilesInProject(ctx context.Context, pushEvent vcs.PushEv
 			// 1) DML is always migration-based.
 			// 2) We may have a limitation in SDL implementation.
 			// 3) User just wants to break the glass.
-			migrationDetailListForFile, activityCreateListForFile := s.prepareIssueFromFile(ctx, repo, pushEvent, fileInfo.item.FileName, fileInfo.item.ItemType, fileInfo.migrationInfo)
+			undoFileName := undoFileMap[fileInfo.migrationInfo.Version]
+			if undoFileName != "" {
+				pairedUndoFileMap[undoFileName] = true
+			}
+			migrationDetailListForFile, activityCreateListForFile := s.prepareIssueFromFile(ctx, repo, pushEvent, fileInfo.item.FileName, fileInfo.item.ItemType, fileInfo.migrationInfo, undoFileName)
 			activityCreateList = append(activityCreateList, activityCreateListForFile...)
 			migrationDetailList = append(migrationDetailList, migrationDetailListForFile...)
 			if len(migrationDetailListForFile) != 0 {
@@ -697,6 +714,13 @@ func (s *Server) processFilesInProject(ctx context.Context, pushEvent vcs.PushEv
 			}
 		}
 	}
+	for _, fileInfo := range fileInfoList {
+		if fileInfo.fType != migrationFileType || fileInfo.migrationInfo.Type != db.Undo || pairedUndoFileMap[fileInfo.item.FileName] {
+			continue
+		}
+		err := errors.Errorf("undo file must be committed together with the migration file of version %s", fileInfo.migrationInfo.Version)
+		activityCreateList = append(activityCreateList, getIgnoredFileActivityCreate(repo.ProjectID, pushEvent, fileInfo.item.FileName, err))
+	}
 
 	if len(migrationDetailList) == 0 {
 		return "", len(createdIssueList) != 0, activityCreateList, nil
@@ -970,14 +994,22 @@ func (s *Server) prepareIssueFromSDLFile(ctx context.Context, repo *api.Reposito
 	return migrationDetailList, nil
 }
 
-// prepareIssueFromFile returns a list of update schema details derived
-// from the given push event for DDL.
-func (s *Server) prepareIssueFromFile(ctx context.Context, repo *api.Repository, pushEvent vcs.PushEvent, fileName string, fileType vcs.FileItemType, migrationInfo *db.MigrationInfo) ([]*api.MigrationDetail, []*api.ActivityCreate) {
+// prepareIssueFromFile returns a list of update schema details derived from the given push event for DDL,
+// including the undo file of the same version if it's not empty.
+func (s *Server) prepareIssueFromFile(ctx context.Context, repo *api.Repository, pushEvent vcs.PushEvent, fileName string, fileType vcs.FileItemType, migrationInfo *db.MigrationInfo, undoFileName string) ([]*api.MigrationDetail, []*api.ActivityCreate) {
 	statement, err := s.readFileContent(ctx, pushEvent, repo, fileName)
 	if err != nil {
 		activityCreate := getIgnoredFileActivityCreate(repo.ProjectID, pushEvent, fileName, errors.Wrap(err, "Failed to read file content"))
 		return nil, []*api.ActivityCreate{activityCreate}
 	}
+	undoStatement := ""
+	if undoFileName != "" {
+		undoStatement, err = s.readFileContent(ctx, pushEvent, repo, undoFileName)
+		if err != nil {
+			activityCreate := getIgnoredFileActivityCreate(repo.ProjectID, pushEvent, fileName, errors.Wrapf(err, "Failed to read undo file %s content", undoFileName))
+			return nil, []*api.ActivityCreate{activityCreate}
+		}
+	}
 
 	var migrationDetailList []*api.MigrationDetail
 
@@ -993,6 +1025,7 @@ func (s *Server) prepareIssueFromFile(ctx context.Context, repo *api.Repository,
 				DatabaseName:  migrationInfo.Database,
 				Statement:     statement,
 				SchemaVersion: migrationInfo.Version,
+				UndoStatement: undoStatement,
 			},
 		)
 		return migrationDetailList, nil
@@ -1012,6 +1045,7 @@ func (s *Server) prepareIssueFromFile(ctx context.Context, repo *api.Repository,
 					DatabaseID:    database.ID,
 					Statement:     statement,
 					SchemaVersion: migrationInfo.Version,
+					UndoStatement: undoStatement,
 				},
 			)
 		}
@@ -1023,17 +1057,132 @@ func (s *Server) prepareIssueFromFile(ctx context.Context, repo *api.Repository,
 		return nil, []*api.ActivityCreate{activityCreate}
 	}
 
-	return nil, nil
+	return nil, s.flagModifiedAppliedMigration(ctx, repo, pushEvent, databases, fileName, migrationInfo.Version, statement)
+}
+
+// flagModifiedAppliedMigration flags the modified file if its version has been applied to the databases with a different statement.
+// The modification is flagged on the issue applying the version, where it can be accepted.
+// It returns the project activities to create for the versions applied outside of the issues, e.g. by bb.
+func (s *Server) flagModifiedAppliedMigration(ctx context.Context, repo *api.Repository, pushEvent vcs.PushEvent, databases []*api.Database, fileName, schemaVersion, statement string) []*api.ActivityCreate {
+	newChecksum := util.GetStatementChecksum(statement)
+	var activityCreateList []*api.ActivityCreate
+	for _, database := range databases {
+		history, err := s.getAppliedMigrationHistory(ctx, database, schemaVersion)
+		if err != nil {
+			log.Warn("Failed to find the migration history for modified VCS file", zap.String("fileName", fileName), zap.Int("databaseID", database.ID), zap.Error(err))
+			continue
+		}
+		if history == nil {
+			continue
+		}
+		oldChecksum, err := util.GetMigrationHistoryStatementChecksum(history)
+		if err != nil {
+			log.Warn("Failed to get the statement checksum of the migration history", zap.Int("databaseID", database.ID), zap.Int("migrationHistoryID", history.ID), zap.Error(err))
+			continue
+		}
+		if oldChecksum == newChecksum {
+			continue
+		}
+
+		task, err := s.findAppliedMigrationTask(ctx, database.ID, schemaVersion)
+		if err != nil {
+			log.Warn("Failed to find the task applying the modified VCS file", zap.String("fileName", fileName), zap.Int("databaseID", database.ID), zap.Error(err))
+			continue
+		}
+		if task == nil {
+			err := errors.Errorf("version %s has been applied to database %q with a different statement outside of issues", schemaVersion, database.Name)
+			activityCreateList = append(activityCreateList, getIgnoredFileActivityCreate(repo.ProjectID, pushEvent, fileName, err))
+			continue
+		}
+		issue, err := s.store.GetIssueByPipelineID(ctx, task.PipelineID)
+		if err != nil {
+			log.Warn("Failed to find the issue applying the modified VCS file", zap.Int("pipelineID", task.PipelineID), zap.Error(err))
+			continue
+		}
+		if issue == nil {
+			continue
+		}
+
+		filePath := strings.TrimPrefix(fileName, repo.BaseDirectory+"/")
+		payload, err := json.Marshal(api.ActivityPipelineTaskMigrationModifiedPayload{
+			TaskID:               task.ID,
+			InstanceID:           database.InstanceID,
+			MigrationHistoryID:   history.ID,
+			SchemaVersion:        schemaVersion,
+			FilePath:             filePath,
+			OldStatementChecksum: oldChecksum,
+			NewStatementChecksum: newChecksum,
+			NewStatement:         statement,
+			IssueName:            issue.Name,
+			TaskName:             task.Name,
+		})
+		if err != nil {
+			log.Warn("Failed to marshal the activity payload for modified VCS file", zap.Error(err))
+			continue
+		}
+		activityCreate := &api.ActivityCreate{
+			CreatorID:   api.SystemBotID,
+			ContainerID: issue.ID,
+			Type:        api.ActivityPipelineTaskMigrationModified,
+			Level:       api.ActivityWarn,
+			Comment:     fmt.Sprintf("File %q of version %s applied to database %q has been modified, the modification is not applied.", filePath, schemaVersion, database.Name),
+			Payload:     string(payload),
+		}
+		if _, err := s.ActivityManager.CreateActivity(ctx, activityCreate, &ActivityMeta{issue: issue}); err != nil {
+			log.Warn("Failed to create the issue activity for modified VCS file", zap.Int("issueID", issue.ID), zap.Error(err))
+		}
+	}
+	return activityCreateList
+}
+
+// getAppliedMigrationHistory returns the migration history of the version applied to the database, or nil if it hasn't been applied.
+func (s *Server) getAppliedMigrationHistory(ctx context.Context, database *api.Database, schemaVersion string) (*db.MigrationHistory, error) {
+	driver, err := s.getAdminDatabaseDriver(ctx, database.Instance, "" /* databaseName */)
+	if err != nil {
+		return nil, err
+	}
+	defer driver.Close(ctx)
+	historyList, err := driver.FindMigrationHistoryList(ctx, &db.MigrationHistoryFind{
+		Database: &database.Name,
+		Version:  &schemaVersion,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(historyList) == 0 || historyList[0].Status != db.Done {
+		return nil, nil
+	}
+	return historyList[0], nil
+}
+
+// findAppliedMigrationTask returns the latest done task applying the version to the database.
+func (s *Server) findAppliedMigrationTask(ctx context.Context, databaseID int, schemaVersion string) (*api.Task, error) {
+	taskList, err := s.store.FindTask(ctx, &api.TaskFind{
+		DatabaseID:    &databaseID,
+		StatusList:    &[]api.TaskStatus{api.TaskDone},
+		TypeList:      &[]api.TaskType{api.TaskDatabaseSchemaUpdate, api.TaskDatabaseDataUpdate},
+		SchemaVersion: &schemaVersion,
+	}, true)
+	if err != nil {
+		return nil, err
+	}
+	var latest *api.Task
+	for _, task := range taskList {
+		if latest == nil || task.ID > latest.ID {
+			latest = task
+		}
+	}
+	return latest, nil
 }
 
 func (s *Server) tryUpdateTasksFromModifiedFile(ctx context.Context, databases []*api.Database, fileName, schemaVersion, statement string) error {
 	// For modified files, we try to update the existing issue's statement.
 	for _, database := range databases {
 		find := &api.TaskFind{
-			DatabaseID: &database.ID,
-			StatusList: &[]api.TaskStatus{api.TaskPendingApproval, api.TaskFailed},
-			TypeList:   &[]api.TaskType{api.TaskDatabaseSchemaUpdate, api.TaskDatabaseDataUpdate},
-			Payload:    fmt.Sprintf("payload->>'schemaVersion' = '%s'", schemaVersion),
+			DatabaseID:    &database.ID,
+			StatusList:    &[]api.TaskStatus{api.TaskPendingApproval, api.TaskFailed},
+			TypeList:      &[]api.TaskType{api.TaskDatabaseSchemaUpdate, api.TaskDatabaseDataUpdate},
+			SchemaVersion: &schemaVersion,
 		}
 		taskList, err := s.store.FindTask(ctx, find, true)
 		if err != nil {
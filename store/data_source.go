@@ -28,15 +28,21 @@ type dataSourceRaw struct {
 	DatabaseID int
 
 	// Domain specific fields
-	Name         string
-	Type         api.DataSourceType
-	Username     string
-	Password     string
-	SslCa        string
-	SslCert      string
-	SslKey       string
-	HostOverride string
-	PortOverride string
+	Name          string
+	Type          api.DataSourceType
+	Username      string
+	Password      string
+	SslCa         string
+	SslCert       string
+	SslKey        string
+	HostOverride  string
+	PortOverride  string
+	SSHHost       string
+	SSHPort       string
+	SSHUser       string
+	SSHPassword   string
+	SSHPrivateKey string
+	SSHHostKey    string
 }
 
 // toDataSource creates an instance of DataSource based on the dataSourceRaw.
@@ -56,15 +62,21 @@ func (raw *dataSourceRaw) toDataSource() *api.DataSource {
 		DatabaseID: raw.DatabaseID,
 
 		// Domain specific fields
-		Name:         raw.Name,
-		Type:         raw.Type,
-		Username:     raw.Username,
-		Password:     raw.Password,
-		SslCa:        raw.SslCa,
-		SslCert:      raw.SslCert,
-		SslKey:       raw.SslKey,
-		HostOverride: raw.HostOverride,
-		PortOverride: raw.PortOverride,
+		Name:          raw.Name,
+		Type:          raw.Type,
+		Username:      raw.Username,
+		Password:      raw.Password,
+		SslCa:         raw.SslCa,
+		SslCert:       raw.SslCert,
+		SslKey:        raw.SslKey,
+		HostOverride:  raw.HostOverride,
+		PortOverride:  raw.PortOverride,
+		SSHHost:       raw.SSHHost,
+		SSHPort:       raw.SSHPort,
+		SSHUser:       raw.SSHUser,
+		SSHPassword:   raw.SSHPassword,
+		SSHPrivateKey: raw.SSHPrivateKey,
+		SSHHostKey:    raw.SSHHostKey,
 	}
 }
 
@@ -282,29 +294,23 @@ func (s *Store) patchDataSourceRaw(ctx context.Context, patch *api.DataSourcePat
 }
 
 // createDataSourceImpl creates a new dataSource.
-func (*Store) createDataSourceImpl(ctx context.Context, tx *Tx, create *api.DataSourceCreate) (*dataSourceRaw, error) {
-	// Insert row into dataSource.
-	query := `
-		INSERT INTO data_source (
-			creator_id,
-			updater_id,
-			instance_id,
-			database_id,
-			name,
-			type,
-			username,
-			password,
-			ssl_key,
-			ssl_cert,
-			ssl_ca,
-			host_override,
-			port_override
-		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
-		RETURNING id, creator_id, created_ts, updater_id, updated_ts, instance_id, database_id, name, type, username, password, ssl_key, ssl_cert, ssl_ca, host_override, port_override
-	`
-	var dataSourceRaw dataSourceRaw
-	if err := tx.QueryRowContext(ctx, query,
+func (s *Store) createDataSourceImpl(ctx context.Context, tx *Tx, create *api.DataSourceCreate) (*dataSourceRaw, error) {
+	columns := []string{
+		"creator_id",
+		"updater_id",
+		"instance_id",
+		"database_id",
+		"name",
+		"type",
+		"username",
+		"password",
+		"ssl_key",
+		"ssl_cert",
+		"ssl_ca",
+		"host_override",
+		"port_override",
+	}
+	args := []interface{}{
 		create.CreatorID,
 		create.CreatorID,
 		create.InstanceID,
@@ -318,7 +324,24 @@ func (*Store) createDataSourceImpl(ctx context.Context, tx *Tx, create *api.Data
 		create.SslCa,
 		create.HostOverride,
 		create.PortOverride,
-	).Scan(
+	}
+	// The SSH tunnel columns are only available in the dev schema for now.
+	if s.db.mode == common.ReleaseModeDev {
+		columns = append(columns, "ssh_host", "ssh_port", "ssh_user", "ssh_password", "ssh_private_key", "ssh_host_key")
+		args = append(args, create.SSHHost, create.SSHPort, create.SSHUser, create.SSHPassword, create.SSHPrivateKey, create.SSHHostKey)
+	}
+	var placeholders []string
+	for i := range columns {
+		placeholders = append(placeholders, fmt.Sprintf("$%d", i+1))
+	}
+	// Insert row into dataSource.
+	query := `
+		INSERT INTO data_source (` + strings.Join(columns, ", ") + `)
+		VALUES (` + strings.Join(placeholders, ", ") + `)
+		RETURNING id, creator_id, created_ts, updater_id, updated_ts, instance_id, database_id, name, type, username, password, ssl_key, ssl_cert, ssl_ca, host_override, port_override, ` + s.sshColumns() + `
+	`
+	var dataSourceRaw dataSourceRaw
+	if err := tx.QueryRowContext(ctx, query, args...).Scan(
 		&dataSourceRaw.ID,
 		&dataSourceRaw.CreatorID,
 		&dataSourceRaw.CreatedTs,
@@ -335,6 +358,12 @@ func (*Store) createDataSourceImpl(ctx context.Context, tx *Tx, create *api.Data
 		&dataSourceRaw.SslCa,
 		&dataSourceRaw.HostOverride,
 		&dataSourceRaw.PortOverride,
+		&dataSourceRaw.SSHHost,
+		&dataSourceRaw.SSHPort,
+		&dataSourceRaw.SSHUser,
+		&dataSourceRaw.SSHPassword,
+		&dataSourceRaw.SSHPrivateKey,
+		&dataSourceRaw.SSHHostKey,
 	); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, common.FormatDBErrorEmptyRowWithQuery(query)
@@ -344,7 +373,7 @@ func (*Store) createDataSourceImpl(ctx context.Context, tx *Tx, create *api.Data
 	return &dataSourceRaw, nil
 }
 
-func (*Store) findDataSourceImpl(ctx context.Context, tx *Tx, find *api.DataSourceFind) ([]*dataSourceRaw, error) {
+func (s *Store) findDataSourceImpl(ctx context.Context, tx *Tx, find *api.DataSourceFind) ([]*dataSourceRaw, error) {
 	// Build WHERE clause.
 	where, args := []string{"1 = 1"}, []interface{}{}
 	if v := find.ID; v != nil {
@@ -377,7 +406,8 @@ func (*Store) findDataSourceImpl(ctx context.Context, tx *Tx, find *api.DataSour
 			ssl_cert,
 			ssl_ca,
 			host_override,
-			port_override
+			port_override,
+			`+s.sshColumns()+`
 		FROM data_source
 		WHERE `+strings.Join(where, " AND "),
 		args...,
@@ -408,6 +438,12 @@ func (*Store) findDataSourceImpl(ctx context.Context, tx *Tx, find *api.DataSour
 			&dataSourceRaw.SslCa,
 			&dataSourceRaw.HostOverride,
 			&dataSourceRaw.PortOverride,
+			&dataSourceRaw.SSHHost,
+			&dataSourceRaw.SSHPort,
+			&dataSourceRaw.SSHUser,
+			&dataSourceRaw.SSHPassword,
+			&dataSourceRaw.SSHPrivateKey,
+			&dataSourceRaw.SSHHostKey,
 		); err != nil {
 			return nil, FormatError(err)
 		}
@@ -422,7 +458,7 @@ func (*Store) findDataSourceImpl(ctx context.Context, tx *Tx, find *api.DataSour
 }
 
 // patchDataSourceImpl updates a dataSource by ID. Returns the new state of the dataSource after update.
-func (*Store) patchDataSourceImpl(ctx context.Context, tx *Tx, patch *api.DataSourcePatch) (*dataSourceRaw, error) {
+func (s *Store) patchDataSourceImpl(ctx context.Context, tx *Tx, patch *api.DataSourcePatch) (*dataSourceRaw, error) {
 	// Build UPDATE clause.
 	set, args := []string{"updater_id = $1"}, []interface{}{patch.UpdaterID}
 	if v := patch.Username; v != nil {
@@ -446,6 +482,27 @@ func (*Store) patchDataSourceImpl(ctx context.Context, tx *Tx, patch *api.DataSo
 	if v := patch.PortOverride; v != nil {
 		set, args = append(set, fmt.Sprintf("port_override= $%d", len(args)+1)), append(args, *v)
 	}
+	// The SSH tunnel columns are only available in the dev schema for now.
+	if s.db.mode == common.ReleaseModeDev {
+		if v := patch.SSHHost; v != nil {
+			set, args = append(set, fmt.Sprintf("ssh_host = $%d", len(args)+1)), append(args, *v)
+		}
+		if v := patch.SSHPort; v != nil {
+			set, args = append(set, fmt.Sprintf("ssh_port = $%d", len(args)+1)), append(args, *v)
+		}
+		if v := patch.SSHUser; v != nil {
+			set, args = append(set, fmt.Sprintf("ssh_user = $%d", len(args)+1)), append(args, *v)
+		}
+		if v := patch.SSHPassword; v != nil {
+			set, args = append(set, fmt.Sprintf("ssh_password = $%d", len(args)+1)), append(args, *v)
+		}
+		if v := patch.SSHPrivateKey; v != nil {
+			set, args = append(set, fmt.Sprintf("ssh_private_key = $%d", len(args)+1)), append(args, *v)
+		}
+		if v := patch.SSHHostKey; v != nil {
+			set, args = append(set, fmt.Sprintf("ssh_host_key = $%d", len(args)+1)), append(args, *v)
+		}
+	}
 	args = append(args, patch.ID)
 
 	var dataSourceRaw dataSourceRaw
@@ -454,7 +511,7 @@ func (*Store) patchDataSourceImpl(ctx context.Context, tx *Tx, patch *api.DataSo
 			UPDATE data_source
 			SET `+strings.Join(set, ", ")+`
 			WHERE id = $%d
-			RETURNING id, creator_id, created_ts, updater_id, updated_ts, instance_id, database_id, name, type, username, password, ssl_key, ssl_cert, ssl_ca, host_override, port_override
+			RETURNING id, creator_id, created_ts, updater_id, updated_ts, instance_id, database_id, name, type, username, password, ssl_key, ssl_cert, ssl_ca, host_override, port_override, `+s.sshColumns()+`
 		`, len(args)),
 		args...,
 	).Scan(
@@ -474,6 +531,12 @@ func (*Store) patchDataSourceImpl(ctx context.Context, tx *Tx, patch *api.DataSo
 		&dataSourceRaw.SslCa,
 		&dataSourceRaw.HostOverride,
 		&dataSourceRaw.PortOverride,
+		&dataSourceRaw.SSHHost,
+		&dataSourceRaw.SSHPort,
+		&dataSourceRaw.SSHUser,
+		&dataSourceRaw.SSHPassword,
+		&dataSourceRaw.SSHPrivateKey,
+		&dataSourceRaw.SSHHostKey,
 	); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, &common.Error{Code: common.NotFound, Err: errors.Errorf("DataSource not found with ID %d", patch.ID)}
@@ -483,6 +546,15 @@ func (*Store) patchDataSourceImpl(ctx context.Context, tx *Tx, patch *api.DataSo
 	return &dataSourceRaw, nil
 }
 
+// sshColumns returns the select expressions for the data source SSH tunnel columns.
+// The columns only exist in dev mode schema for now, so we fall back to empty values in other modes.
+func (s *Store) sshColumns() string {
+	if s.db.mode == common.ReleaseModeDev {
+		return "ssh_host, ssh_port, ssh_user, ssh_password, ssh_private_key, ssh_host_key"
+	}
+	return "'', '', '', '', '', ''"
+}
+
 // deleteDataSourceImpl permanently deletes a dataSource by ID.
 func (*Store) deleteDataSourceImpl(ctx context.Context, tx *Tx, delete *api.DataSourceDelete) error {
 	if _, err := tx.ExecContext(ctx, `DELETE FROM data_source WHERE id = $1`, delete.ID); err != nil {
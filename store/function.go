@@ -0,0 +1,344 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/plugin/db"
+)
+
+// dbFunctionRaw is the store model for a DBFunction.
+// Fields have exactly the same meaning as DBFunction.
+type dbFunctionRaw struct {
+	ID int
+
+	// Standard fields
+	CreatorID int
+	CreatedTs int64
+	UpdaterID int
+	UpdatedTs int64
+
+	// Related fields
+	DatabaseID int
+
+	// Domain specific fields
+	Name       string
+	Arguments  string
+	ReturnType string
+	Language   string
+	Definition string
+	Comment    string
+}
+
+// toDBFunction creates an instance of DBFunction based on the dbFunctionRaw.
+// This is intended to be called when we need to compose a DBFunction relationship.
+func (raw *dbFunctionRaw) toDBFunction() *api.DBFunction {
+	return &api.DBFunction{
+		ID: raw.ID,
+
+		// Standard fields
+		CreatorID: raw.CreatorID,
+		CreatedTs: raw.CreatedTs,
+		UpdaterID: raw.UpdaterID,
+		UpdatedTs: raw.UpdatedTs,
+
+		// Related fields
+		DatabaseID: raw.DatabaseID,
+
+		// Domain specific fields
+		Name:       raw.Name,
+		Arguments:  raw.Arguments,
+		ReturnType: raw.ReturnType,
+		Language:   raw.Language,
+		Definition: raw.Definition,
+		Comment:    raw.Comment,
+	}
+}
+
+// FindDBFunction finds a list of dbFunction instances.
+func (s *Store) FindDBFunction(ctx context.Context, find *api.DBFunctionFind) ([]*api.DBFunction, error) {
+	// The db_function table is only available in the dev schema for now.
+	if s.db.mode != common.ReleaseModeDev {
+		return nil, nil
+	}
+	dbFunctionRawList, err := s.findDBFunctionRaw(ctx, find)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find dbFunction list with dbFunctionFind[%+v]", find)
+	}
+	var dbFunctionList []*api.DBFunction
+	for _, raw := range dbFunctionRawList {
+		dbFunction, err := s.composeDBFunction(ctx, raw)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to compose dbFunction with dbFunctionRaw[%+v]", raw)
+		}
+		dbFunctionList = append(dbFunctionList, dbFunction)
+	}
+	return dbFunctionList, nil
+}
+
+// functionKey identifies a function since Postgres allows overloading functions by arguments.
+type functionKey struct {
+	name      string
+	arguments string
+}
+
+// SetDBFunctionList sets the functions for a database.
+func (s *Store) SetDBFunctionList(ctx context.Context, schema *db.Schema, databaseID int) error {
+	// The db_function table is only available in the dev schema for now.
+	if s.db.mode != common.ReleaseModeDev {
+		return nil
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return FormatError(err)
+	}
+	defer tx.Rollback()
+
+	oldDBFunctionRawList, err := s.findDBFunctionImpl(ctx, tx, &api.DBFunctionFind{
+		DatabaseID: &databaseID,
+	})
+	if err != nil {
+		return FormatError(err)
+	}
+
+	deletes, creates := generateDBFunctionActions(oldDBFunctionRawList, schema.FunctionList, databaseID)
+	for _, d := range deletes {
+		if err := s.deleteDBFunctionImpl(ctx, tx, d); err != nil {
+			return err
+		}
+	}
+	for _, c := range creates {
+		if _, err := s.createDBFunctionImpl(ctx, tx, c); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return FormatError(err)
+	}
+
+	return nil
+}
+
+// private functions.
+func generateDBFunctionActions(oldDBFunctionRawList []*dbFunctionRaw, functionList []db.Function, databaseID int) ([]*api.DBFunctionDelete, []*api.DBFunctionCreate) {
+	var newDBFunctionList []*api.DBFunctionCreate
+	for _, function := range functionList {
+		newDBFunctionList = append(newDBFunctionList, &api.DBFunctionCreate{
+			CreatorID:  api.SystemBotID,
+			DatabaseID: databaseID,
+			Name:       function.Name,
+			Arguments:  function.Arguments,
+			ReturnType: function.ReturnType,
+			Language:   function.Language,
+			Definition: function.Definition,
+			Comment:    function.Comment,
+		})
+	}
+	oldDBFunctionMap := make(map[functionKey]*dbFunctionRaw)
+	for _, f := range oldDBFunctionRawList {
+		oldDBFunctionMap[functionKey{name: f.Name, arguments: f.Arguments}] = f
+	}
+	newDBFunctionMap := make(map[functionKey]*api.DBFunctionCreate)
+	for _, f := range newDBFunctionList {
+		newDBFunctionMap[functionKey{name: f.Name, arguments: f.Arguments}] = f
+	}
+
+	var deletes []*api.DBFunctionDelete
+	var creates []*api.DBFunctionCreate
+	for _, oldValue := range oldDBFunctionRawList {
+		k := functionKey{name: oldValue.Name, arguments: oldValue.Arguments}
+		newValue, ok := newDBFunctionMap[k]
+		if !ok {
+			deletes = append(deletes, &api.DBFunctionDelete{ID: oldValue.ID})
+		} else if ok &&
+			(oldValue.ReturnType != newValue.ReturnType ||
+				oldValue.Language != newValue.Language ||
+				oldValue.Definition != newValue.Definition ||
+				oldValue.Comment != newValue.Comment) {
+			deletes = append(deletes, &api.DBFunctionDelete{ID: oldValue.ID})
+			creates = append(creates, newValue)
+		}
+	}
+	for _, newValue := range newDBFunctionList {
+		k := functionKey{name: newValue.Name, arguments: newValue.Arguments}
+		if _, ok := oldDBFunctionMap[k]; !ok {
+			creates = append(creates, newValue)
+		}
+	}
+	return deletes, creates
+}
+
+func (s *Store) composeDBFunction(ctx context.Context, raw *dbFunctionRaw) (*api.DBFunction, error) {
+	dbFunction := raw.toDBFunction()
+
+	creator, err := s.GetPrincipalByID(ctx, dbFunction.CreatorID)
+	if err != nil {
+		return nil, err
+	}
+	dbFunction.Creator = creator
+
+	updater, err := s.GetPrincipalByID(ctx, dbFunction.UpdaterID)
+	if err != nil {
+		return nil, err
+	}
+	dbFunction.Updater = updater
+
+	database, err := s.GetDatabase(ctx, &api.DatabaseFind{ID: &dbFunction.DatabaseID})
+	if err != nil {
+		return nil, err
+	}
+	dbFunction.Database = database
+
+	return dbFunction, nil
+}
+
+// findDBFunctionRaw retrieves a list of DBFunctions based on find.
+func (s *Store) findDBFunctionRaw(ctx context.Context, find *api.DBFunctionFind) ([]*dbFunctionRaw, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer tx.Rollback()
+
+	list, err := s.findDBFunctionImpl(ctx, tx, find)
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// createDBFunctionImpl creates a new DBFunction.
+func (*Store) createDBFunctionImpl(ctx context.Context, tx *Tx, create *api.DBFunctionCreate) (*dbFunctionRaw, error) {
+	// Insert row into db_function.
+	query := `
+		INSERT INTO db_function (
+			creator_id,
+			created_ts,
+			updater_id,
+			updated_ts,
+			database_id,
+			name,
+			arguments,
+			return_type,
+			language,
+			definition,
+			comment
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id, creator_id, created_ts, updater_id, updated_ts, database_id, name, arguments, return_type, language, definition, comment
+	`
+	var dbFunctionRaw dbFunctionRaw
+	if err := tx.QueryRowContext(ctx, query,
+		create.CreatorID,
+		create.CreatedTs,
+		create.CreatorID,
+		create.UpdatedTs,
+		create.DatabaseID,
+		create.Name,
+		create.Arguments,
+		create.ReturnType,
+		create.Language,
+		create.Definition,
+		create.Comment,
+	).Scan(
+		&dbFunctionRaw.ID,
+		&dbFunctionRaw.CreatorID,
+		&dbFunctionRaw.CreatedTs,
+		&dbFunctionRaw.UpdaterID,
+		&dbFunctionRaw.UpdatedTs,
+		&dbFunctionRaw.DatabaseID,
+		&dbFunctionRaw.Name,
+		&dbFunctionRaw.Arguments,
+		&dbFunctionRaw.ReturnType,
+		&dbFunctionRaw.Language,
+		&dbFunctionRaw.Definition,
+		&dbFunctionRaw.Comment,
+	); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.FormatDBErrorEmptyRowWithQuery(query)
+		}
+		return nil, FormatError(err)
+	}
+	return &dbFunctionRaw, nil
+}
+
+func (*Store) findDBFunctionImpl(ctx context.Context, tx *Tx, find *api.DBFunctionFind) ([]*dbFunctionRaw, error) {
+	// Build WHERE clause.
+	where, args := []string{"1 = 1"}, []interface{}{}
+	if v := find.ID; v != nil {
+		where, args = append(where, fmt.Sprintf("id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.DatabaseID; v != nil {
+		where, args = append(where, fmt.Sprintf("database_id = $%d", len(args)+1)), append(args, *v)
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT
+			id,
+			creator_id,
+			created_ts,
+			updater_id,
+			updated_ts,
+			database_id,
+			name,
+			arguments,
+			return_type,
+			language,
+			definition,
+			comment
+		FROM db_function
+		WHERE `+strings.Join(where, " AND ")+`
+		ORDER BY database_id, name, arguments ASC`,
+		args...,
+	)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer rows.Close()
+
+	// Iterate over result set and deserialize rows into dbFunctionRawList.
+	var dbFunctionRawList []*dbFunctionRaw
+	for rows.Next() {
+		var dbFunctionRaw dbFunctionRaw
+		if err := rows.Scan(
+			&dbFunctionRaw.ID,
+			&dbFunctionRaw.CreatorID,
+			&dbFunctionRaw.CreatedTs,
+			&dbFunctionRaw.UpdaterID,
+			&dbFunctionRaw.UpdatedTs,
+			&dbFunctionRaw.DatabaseID,
+			&dbFunctionRaw.Name,
+			&dbFunctionRaw.Arguments,
+			&dbFunctionRaw.ReturnType,
+			&dbFunctionRaw.Language,
+			&dbFunctionRaw.Definition,
+			&dbFunctionRaw.Comment,
+		); err != nil {
+			return nil, FormatError(err)
+		}
+
+		dbFunctionRawList = append(dbFunctionRawList, &dbFunctionRaw)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, FormatError(err)
+	}
+
+	return dbFunctionRawList, nil
+}
+
+// deleteDBFunctionImpl permanently deletes DBFunctions from a database.
+func (*Store) deleteDBFunctionImpl(ctx context.Context, tx *Tx, delete *api.DBFunctionDelete) error {
+	// Remove row from database.
+	if _, err := tx.ExecContext(ctx, `DELETE FROM db_function WHERE id = $1`, delete.ID); err != nil {
+		return FormatError(err)
+	}
+	return nil
+}
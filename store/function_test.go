@@ -0,0 +1,65 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/plugin/db"
+)
+
+func TestGenerateDBFunctionActions(t *testing.T) {
+	databaseID := 198
+	tests := []struct {
+		oldDBFunctionRawList []*dbFunctionRaw
+		functionList         []db.Function
+		wantDeletes          []*api.DBFunctionDelete
+		wantCreates          []*api.DBFunctionCreate
+	}{
+		{
+			oldDBFunctionRawList: []*dbFunctionRaw{
+				{ID: 123, Name: "public.add", Arguments: "a integer, b integer", ReturnType: "integer", Language: "sql", Definition: "def1"},
+				{ID: 124, Name: "public.add", Arguments: "a bigint, b bigint", ReturnType: "bigint", Language: "sql", Definition: "def2"},
+			},
+			functionList: []db.Function{
+				{Name: "public.add", Arguments: "a integer, b integer", ReturnType: "integer", Language: "sql", Definition: "def1-change"},
+				{Name: "public.add", Arguments: "a bigint, b bigint", ReturnType: "bigint", Language: "sql", Definition: "def2"},
+				{Name: "public.sub", Arguments: "a integer, b integer", ReturnType: "integer", Language: "plpgsql", Definition: "def3"},
+			},
+			wantDeletes: []*api.DBFunctionDelete{
+				{ID: 123},
+			},
+			wantCreates: []*api.DBFunctionCreate{
+				{Name: "public.add", Arguments: "a integer, b integer", ReturnType: "integer", Language: "sql", Definition: "def1-change", CreatorID: api.SystemBotID, DatabaseID: databaseID},
+				{Name: "public.sub", Arguments: "a integer, b integer", ReturnType: "integer", Language: "plpgsql", Definition: "def3", CreatorID: api.SystemBotID, DatabaseID: databaseID},
+			},
+		},
+		{
+			oldDBFunctionRawList: []*dbFunctionRaw{
+				{ID: 123, Name: "public.add", Arguments: "a integer, b integer", ReturnType: "integer", Language: "sql", Definition: "def1"},
+			},
+			functionList: nil,
+			wantDeletes: []*api.DBFunctionDelete{
+				{ID: 123},
+			},
+			wantCreates: nil,
+		},
+		{
+			oldDBFunctionRawList: []*dbFunctionRaw{
+				{ID: 123, Name: "public.add", Arguments: "a integer, b integer", ReturnType: "integer", Language: "sql", Definition: "def1"},
+			},
+			functionList: []db.Function{
+				{Name: "public.add", Arguments: "a integer, b integer", ReturnType: "integer", Language: "sql", Definition: "def1"},
+			},
+			wantDeletes: nil,
+			wantCreates: nil,
+		},
+	}
+
+	for _, test := range tests {
+		deletes, creates := generateDBFunctionActions(test.oldDBFunctionRawList, test.functionList, databaseID)
+		require.Equal(t, test.wantDeletes, deletes)
+		require.Equal(t, test.wantCreates, creates)
+	}
+}
@@ -357,16 +357,22 @@ func (s *Store) createInstanceRaw(ctx context.Context, create *api.InstanceCreat
 
 	// Create admin data source
 	adminDataSourceCreate := &api.DataSourceCreate{
-		CreatorID:  create.CreatorID,
-		InstanceID: instance.ID,
-		DatabaseID: allDatabase.ID,
-		Name:       api.AdminDataSourceName,
-		Type:       api.Admin,
-		Username:   create.Username,
-		Password:   create.Password,
-		SslKey:     create.SslKey,
-		SslCert:    create.SslCert,
-		SslCa:      create.SslCa,
+		CreatorID:     create.CreatorID,
+		InstanceID:    instance.ID,
+		DatabaseID:    allDatabase.ID,
+		Name:          api.AdminDataSourceName,
+		Type:          api.Admin,
+		Username:      create.Username,
+		Password:      create.Password,
+		SslKey:        create.SslKey,
+		SslCert:       create.SslCert,
+		SslCa:         create.SslCa,
+		SSHHost:       create.SSHHost,
+		SSHPort:       create.SSHPort,
+		SSHUser:       create.SSHUser,
+		SSHPassword:   create.SSHPassword,
+		SSHPrivateKey: create.SSHPrivateKey,
+		SSHHostKey:    create.SSHHostKey,
 	}
 	if err := s.createDataSourceRawTx(ctx, tx, adminDataSourceCreate); err != nil {
 		return nil, err
@@ -188,3 +188,245 @@ func deleteInstanceUser(ctx context.Context, tx *Tx, delete *api.InstanceUserDel
 	}
 	return nil
 }
+
+// CreateInstanceUserGrant creates an instance user grant.
+func (s *Store) CreateInstanceUserGrant(ctx context.Context, create *api.InstanceUserGrantCreate) (*api.InstanceUserGrant, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer tx.Rollback()
+
+	grant, err := createInstanceUserGrantImpl(ctx, tx, create)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, FormatError(err)
+	}
+
+	return grant, nil
+}
+
+// FindInstanceUserGrant retrieves a list of instance user grants based on find.
+func (s *Store) FindInstanceUserGrant(ctx context.Context, find *api.InstanceUserGrantFind) ([]*api.InstanceUserGrant, error) {
+	// The instance_user_grant table only exists in dev mode schema for now.
+	if s.db.mode != common.ReleaseModeDev {
+		return nil, nil
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer tx.Rollback()
+
+	list, err := findInstanceUserGrantImpl(ctx, tx, find)
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// PatchInstanceUserGrant patches an instance user grant.
+func (s *Store) PatchInstanceUserGrant(ctx context.Context, patch *api.InstanceUserGrantPatch) (*api.InstanceUserGrant, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer tx.Rollback()
+
+	grant, err := patchInstanceUserGrantImpl(ctx, tx, patch)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, FormatError(err)
+	}
+
+	return grant, nil
+}
+
+// instanceUserGrantPrivilegeSeparator separates the privileges in the privilege_list column.
+// Privileges such as "CREATE TEMPORARY TABLES" contain spaces but never commas.
+const instanceUserGrantPrivilegeSeparator = ","
+
+// createInstanceUserGrantImpl creates a new instance user grant.
+func createInstanceUserGrantImpl(ctx context.Context, tx *Tx, create *api.InstanceUserGrantCreate) (*api.InstanceUserGrant, error) {
+	// Insert row into database.
+	query := `
+		INSERT INTO instance_user_grant (
+			creator_id,
+			updater_id,
+			instance_id,
+			issue_id,
+			database_name,
+			user_name,
+			host,
+			privilege_list,
+			status,
+			expire_ts
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, creator_id, created_ts, updater_id, updated_ts, instance_id, issue_id, database_name, user_name, host, privilege_list, status, expire_ts
+	`
+	var grant api.InstanceUserGrant
+	var privilegeList string
+	if err := tx.QueryRowContext(ctx, query,
+		create.CreatorID,
+		create.CreatorID,
+		create.InstanceID,
+		create.IssueID,
+		create.DatabaseName,
+		create.UserName,
+		create.Host,
+		strings.Join(create.PrivilegeList, instanceUserGrantPrivilegeSeparator),
+		api.InstanceUserGrantActive,
+		create.ExpireTs,
+	).Scan(
+		&grant.ID,
+		&grant.CreatorID,
+		&grant.CreatedTs,
+		&grant.UpdaterID,
+		&grant.UpdatedTs,
+		&grant.InstanceID,
+		&grant.IssueID,
+		&grant.DatabaseName,
+		&grant.UserName,
+		&grant.Host,
+		&privilegeList,
+		&grant.Status,
+		&grant.ExpireTs,
+	); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.FormatDBErrorEmptyRowWithQuery(query)
+		}
+		return nil, FormatError(err)
+	}
+	grant.PrivilegeList = strings.Split(privilegeList, instanceUserGrantPrivilegeSeparator)
+	return &grant, nil
+}
+
+func findInstanceUserGrantImpl(ctx context.Context, tx *Tx, find *api.InstanceUserGrantFind) ([]*api.InstanceUserGrant, error) {
+	// Build WHERE clause.
+	where, args := []string{"1 = 1"}, []interface{}{}
+
+	if v := find.ID; v != nil {
+		where, args = append(where, fmt.Sprintf("id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.InstanceID; v != nil {
+		where, args = append(where, fmt.Sprintf("instance_id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.IssueID; v != nil {
+		where, args = append(where, fmt.Sprintf("issue_id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.Status; v != nil {
+		where, args = append(where, fmt.Sprintf("status = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.ExpireTsBefore; v != nil {
+		where, args = append(where, fmt.Sprintf("expire_ts > 0 AND expire_ts <= $%d", len(args)+1)), append(args, *v)
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT
+			id,
+			creator_id,
+			created_ts,
+			updater_id,
+			updated_ts,
+			instance_id,
+			issue_id,
+			database_name,
+			user_name,
+			host,
+			privilege_list,
+			status,
+			expire_ts
+		FROM instance_user_grant
+		WHERE `+strings.Join(where, " AND ")+`
+		ORDER BY id ASC
+		`,
+		args...,
+	)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer rows.Close()
+
+	// Iterate over result set and deserialize rows into grantList.
+	var grantList []*api.InstanceUserGrant
+	for rows.Next() {
+		var grant api.InstanceUserGrant
+		var privilegeList string
+		if err := rows.Scan(
+			&grant.ID,
+			&grant.CreatorID,
+			&grant.CreatedTs,
+			&grant.UpdaterID,
+			&grant.UpdatedTs,
+			&grant.InstanceID,
+			&grant.IssueID,
+			&grant.DatabaseName,
+			&grant.UserName,
+			&grant.Host,
+			&privilegeList,
+			&grant.Status,
+			&grant.ExpireTs,
+		); err != nil {
+			return nil, FormatError(err)
+		}
+		grant.PrivilegeList = strings.Split(privilegeList, instanceUserGrantPrivilegeSeparator)
+
+		grantList = append(grantList, &grant)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, FormatError(err)
+	}
+
+	return grantList, nil
+}
+
+// patchInstanceUserGrantImpl updates an instance user grant by ID. Returns the new state of the grant after update.
+func patchInstanceUserGrantImpl(ctx context.Context, tx *Tx, patch *api.InstanceUserGrantPatch) (*api.InstanceUserGrant, error) {
+	// Build UPDATE clause.
+	set, args := []string{"updater_id = $1"}, []interface{}{patch.UpdaterID}
+	if v := patch.Status; v != nil {
+		set, args = append(set, fmt.Sprintf("status = $%d", len(args)+1)), append(args, *v)
+	}
+	args = append(args, patch.ID)
+
+	var grant api.InstanceUserGrant
+	var privilegeList string
+	// Execute update query with RETURNING.
+	if err := tx.QueryRowContext(ctx, fmt.Sprintf(`
+		UPDATE instance_user_grant
+		SET %s
+		WHERE id = $%d
+		RETURNING id, creator_id, created_ts, updater_id, updated_ts, instance_id, issue_id, database_name, user_name, host, privilege_list, status, expire_ts
+	`, strings.Join(set, ", "), len(args)),
+		args...,
+	).Scan(
+		&grant.ID,
+		&grant.CreatorID,
+		&grant.CreatedTs,
+		&grant.UpdaterID,
+		&grant.UpdatedTs,
+		&grant.InstanceID,
+		&grant.IssueID,
+		&grant.DatabaseName,
+		&grant.UserName,
+		&grant.Host,
+		&privilegeList,
+		&grant.Status,
+		&grant.ExpireTs,
+	); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, &common.Error{Code: common.NotFound, Err: errors.Errorf("instance user grant ID not found: %d", patch.ID)}
+		}
+		return nil, FormatError(err)
+	}
+	grant.PrivilegeList = strings.Split(privilegeList, instanceUserGrantPrivilegeSeparator)
+	return &grant, nil
+}
@@ -0,0 +1,97 @@
+ALTER TABLE tbl ADD partition_key TEXT NOT NULL DEFAULT '';
+ALTER TABLE tbl ADD partition_parent TEXT NOT NULL DEFAULT '';
+ALTER TABLE tbl ADD partition_bound TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE vw ADD materialized BOOLEAN NOT NULL DEFAULT false;
+
+-- db_function stores the functions for a particular database.
+-- data is synced periodically from the instance.
+CREATE TABLE db_function (
+    id SERIAL PRIMARY KEY,
+    row_status row_status NOT NULL DEFAULT 'NORMAL',
+    creator_id INTEGER NOT NULL REFERENCES principal (id),
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updater_id INTEGER NOT NULL REFERENCES principal (id),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    database_id INTEGER NOT NULL REFERENCES db (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    arguments TEXT NOT NULL,
+    return_type TEXT NOT NULL,
+    language TEXT NOT NULL,
+    definition TEXT NOT NULL,
+    comment TEXT NOT NULL
+);
+
+CREATE INDEX idx_db_function_database_id ON db_function(database_id);
+
+CREATE UNIQUE INDEX idx_db_function_unique_database_id_name_arguments ON db_function(database_id, name, arguments);
+
+ALTER SEQUENCE db_function_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_db_function_updated_ts
+BEFORE
+UPDATE
+    ON db_function FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
+
+-- db_sequence stores the sequences for a particular database.
+-- data is synced periodically from the instance.
+CREATE TABLE db_sequence (
+    id SERIAL PRIMARY KEY,
+    row_status row_status NOT NULL DEFAULT 'NORMAL',
+    creator_id INTEGER NOT NULL REFERENCES principal (id),
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updater_id INTEGER NOT NULL REFERENCES principal (id),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    database_id INTEGER NOT NULL REFERENCES db (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    data_type TEXT NOT NULL,
+    start_value BIGINT NOT NULL,
+    min_value BIGINT NOT NULL,
+    max_value BIGINT NOT NULL,
+    increment BIGINT NOT NULL,
+    cycle BOOLEAN NOT NULL,
+    owned_by TEXT NOT NULL
+);
+
+CREATE INDEX idx_db_sequence_database_id ON db_sequence(database_id);
+
+CREATE UNIQUE INDEX idx_db_sequence_unique_database_id_name ON db_sequence(database_id, name);
+
+ALTER SEQUENCE db_sequence_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_db_sequence_updated_ts
+BEFORE
+UPDATE
+    ON db_sequence FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
+
+-- db_trigger stores the triggers for a particular database.
+-- data is synced periodically from the instance.
+CREATE TABLE db_trigger (
+    id SERIAL PRIMARY KEY,
+    row_status row_status NOT NULL DEFAULT 'NORMAL',
+    creator_id INTEGER NOT NULL REFERENCES principal (id),
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updater_id INTEGER NOT NULL REFERENCES principal (id),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    database_id INTEGER NOT NULL REFERENCES db (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    table_name TEXT NOT NULL,
+    timing TEXT NOT NULL,
+    event TEXT NOT NULL,
+    definition TEXT NOT NULL,
+    comment TEXT NOT NULL
+);
+
+CREATE INDEX idx_db_trigger_database_id ON db_trigger(database_id);
+
+CREATE UNIQUE INDEX idx_db_trigger_unique_database_id_table_name_name ON db_trigger(database_id, table_name, name);
+
+ALTER SEQUENCE db_trigger_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_db_trigger_updated_ts
+BEFORE
+UPDATE
+    ON db_trigger FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
@@ -0,0 +1,31 @@
+-- fk stores the foreign key for a particular table from a particular database.
+-- data is synced periodically from the instance.
+CREATE TABLE fk (
+    id SERIAL PRIMARY KEY,
+    row_status row_status NOT NULL DEFAULT 'NORMAL',
+    creator_id INTEGER NOT NULL REFERENCES principal (id),
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updater_id INTEGER NOT NULL REFERENCES principal (id),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    database_id INTEGER NOT NULL REFERENCES db (id),
+    table_id INTEGER NOT NULL REFERENCES tbl (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    column_name TEXT NOT NULL,
+    position INTEGER NOT NULL,
+    referenced_table TEXT NOT NULL,
+    referenced_column TEXT NOT NULL,
+    on_update TEXT NOT NULL,
+    on_delete TEXT NOT NULL
+);
+
+CREATE INDEX idx_fk_database_id_table_id ON fk(database_id, table_id);
+
+CREATE UNIQUE INDEX idx_fk_unique_database_id_table_id_name_position ON fk(database_id, table_id, name, position);
+
+ALTER SEQUENCE fk_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_fk_updated_ts
+BEFORE
+UPDATE
+    ON fk FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
@@ -0,0 +1,6 @@
+-- ssh_host, ssh_port, ssh_user, ssh_password and ssh_private_key are used to connect the database through an SSH tunnel.
+ALTER TABLE data_source ADD COLUMN ssh_host TEXT NOT NULL DEFAULT '';
+ALTER TABLE data_source ADD COLUMN ssh_port TEXT NOT NULL DEFAULT '';
+ALTER TABLE data_source ADD COLUMN ssh_user TEXT NOT NULL DEFAULT '';
+ALTER TABLE data_source ADD COLUMN ssh_password TEXT NOT NULL DEFAULT '';
+ALTER TABLE data_source ADD COLUMN ssh_private_key TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,32 @@
+-- instance_user_grant stores the database privileges granted to the instance users by the database grant issues.
+-- The privileges are revoked once the grant expires.
+CREATE TABLE instance_user_grant (
+    id SERIAL PRIMARY KEY,
+    row_status row_status NOT NULL DEFAULT 'NORMAL',
+    creator_id INTEGER NOT NULL REFERENCES principal (id),
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updater_id INTEGER NOT NULL REFERENCES principal (id),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    instance_id INTEGER NOT NULL REFERENCES instance (id),
+    issue_id INTEGER NOT NULL REFERENCES issue (id),
+    database_name TEXT NOT NULL,
+    user_name TEXT NOT NULL,
+    -- host is the host of the MySQL and TiDB user, it's empty for the other engines.
+    host TEXT NOT NULL DEFAULT '',
+    privilege_list TEXT NOT NULL,
+    status TEXT NOT NULL CHECK (status IN ('ACTIVE', 'REVOKED')),
+    -- expire_ts is 0 if the privileges never expire.
+    expire_ts BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX idx_instance_user_grant_instance_id ON instance_user_grant(instance_id);
+
+CREATE INDEX idx_instance_user_grant_issue_id ON instance_user_grant(issue_id);
+
+ALTER SEQUENCE instance_user_grant_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_instance_user_grant_updated_ts
+BEFORE
+UPDATE
+    ON instance_user_grant FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
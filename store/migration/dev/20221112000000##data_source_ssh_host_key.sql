@@ -0,0 +1,2 @@
+-- ssh_host_key is the public key or the SHA256 fingerprint of the SSH host to verify it.
+ALTER TABLE data_source ADD COLUMN ssh_host_key TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,6 @@
+-- task_secret stores the secrets of the tasks such as the password of the database grant task.
+-- The secrets are never returned by the API and are deleted once the task is done, failed or canceled.
+CREATE TABLE task_secret (
+    task_id INTEGER PRIMARY KEY REFERENCES task (id),
+    secret TEXT NOT NULL
+);
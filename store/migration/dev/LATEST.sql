@@ -362,7 +362,10 @@ CREATE TABLE tbl (
     index_size BIGINT NOT NULL,
     data_free BIGINT NOT NULL,
     create_options TEXT NOT NULL,
-    comment TEXT NOT NULL
+    comment TEXT NOT NULL,
+    partition_key TEXT NOT NULL DEFAULT '',
+    partition_parent TEXT NOT NULL DEFAULT '',
+    partition_bound TEXT NOT NULL DEFAULT ''
 );
 
 CREATE INDEX idx_tbl_database_id ON tbl(database_id);
@@ -444,6 +447,38 @@ UPDATE
     ON idx FOR EACH ROW
 EXECUTE FUNCTION trigger_update_updated_ts();
 
+-- fk stores the foreign key for a particular table from a particular database.
+-- data is synced periodically from the instance.
+CREATE TABLE fk (
+    id SERIAL PRIMARY KEY,
+    row_status row_status NOT NULL DEFAULT 'NORMAL',
+    creator_id INTEGER NOT NULL REFERENCES principal (id),
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updater_id INTEGER NOT NULL REFERENCES principal (id),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    database_id INTEGER NOT NULL REFERENCES db (id),
+    table_id INTEGER NOT NULL REFERENCES tbl (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    column_name TEXT NOT NULL,
+    position INTEGER NOT NULL,
+    referenced_table TEXT NOT NULL,
+    referenced_column TEXT NOT NULL,
+    on_update TEXT NOT NULL,
+    on_delete TEXT NOT NULL
+);
+
+CREATE INDEX idx_fk_database_id_table_id ON fk(database_id, table_id);
+
+CREATE UNIQUE INDEX idx_fk_unique_database_id_table_id_name_position ON fk(database_id, table_id, name, position);
+
+ALTER SEQUENCE fk_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_fk_updated_ts
+BEFORE
+UPDATE
+    ON fk FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
+
 -- db_extension stores the extensions for a particular database.
 -- data is synced periodically from the instance.
 CREATE TABLE db_extension (
@@ -484,7 +519,8 @@ CREATE TABLE vw (
     database_id INTEGER NOT NULL REFERENCES db (id) ON DELETE CASCADE,
     name TEXT NOT NULL,
     definition TEXT NOT NULL,
-    comment TEXT NOT NULL
+    comment TEXT NOT NULL,
+    materialized BOOLEAN NOT NULL DEFAULT false
 );
 
 CREATE INDEX idx_vw_database_id ON vw(database_id);
@@ -499,6 +535,98 @@ UPDATE
     ON vw FOR EACH ROW
 EXECUTE FUNCTION trigger_update_updated_ts();
 
+-- db_function stores the functions for a particular database.
+-- data is synced periodically from the instance.
+CREATE TABLE db_function (
+    id SERIAL PRIMARY KEY,
+    row_status row_status NOT NULL DEFAULT 'NORMAL',
+    creator_id INTEGER NOT NULL REFERENCES principal (id),
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updater_id INTEGER NOT NULL REFERENCES principal (id),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    database_id INTEGER NOT NULL REFERENCES db (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    arguments TEXT NOT NULL,
+    return_type TEXT NOT NULL,
+    language TEXT NOT NULL,
+    definition TEXT NOT NULL,
+    comment TEXT NOT NULL
+);
+
+CREATE INDEX idx_db_function_database_id ON db_function(database_id);
+
+CREATE UNIQUE INDEX idx_db_function_unique_database_id_name_arguments ON db_function(database_id, name, arguments);
+
+ALTER SEQUENCE db_function_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_db_function_updated_ts
+BEFORE
+UPDATE
+    ON db_function FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
+
+-- db_sequence stores the sequences for a particular database.
+-- data is synced periodically from the instance.
+CREATE TABLE db_sequence (
+    id SERIAL PRIMARY KEY,
+    row_status row_status NOT NULL DEFAULT 'NORMAL',
+    creator_id INTEGER NOT NULL REFERENCES principal (id),
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updater_id INTEGER NOT NULL REFERENCES principal (id),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    database_id INTEGER NOT NULL REFERENCES db (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    data_type TEXT NOT NULL,
+    start_value BIGINT NOT NULL,
+    min_value BIGINT NOT NULL,
+    max_value BIGINT NOT NULL,
+    increment BIGINT NOT NULL,
+    cycle BOOLEAN NOT NULL,
+    owned_by TEXT NOT NULL
+);
+
+CREATE INDEX idx_db_sequence_database_id ON db_sequence(database_id);
+
+CREATE UNIQUE INDEX idx_db_sequence_unique_database_id_name ON db_sequence(database_id, name);
+
+ALTER SEQUENCE db_sequence_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_db_sequence_updated_ts
+BEFORE
+UPDATE
+    ON db_sequence FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
+
+-- db_trigger stores the triggers for a particular database.
+-- data is synced periodically from the instance.
+CREATE TABLE db_trigger (
+    id SERIAL PRIMARY KEY,
+    row_status row_status NOT NULL DEFAULT 'NORMAL',
+    creator_id INTEGER NOT NULL REFERENCES principal (id),
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updater_id INTEGER NOT NULL REFERENCES principal (id),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    database_id INTEGER NOT NULL REFERENCES db (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    table_name TEXT NOT NULL,
+    timing TEXT NOT NULL,
+    event TEXT NOT NULL,
+    definition TEXT NOT NULL,
+    comment TEXT NOT NULL
+);
+
+CREATE INDEX idx_db_trigger_database_id ON db_trigger(database_id);
+
+CREATE UNIQUE INDEX idx_db_trigger_unique_database_id_table_name_name ON db_trigger(database_id, table_name, name);
+
+ALTER SEQUENCE db_trigger_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_db_trigger_updated_ts
+BEFORE
+UPDATE
+    ON db_trigger FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
+
 -- data_source table stores the data source for a particular database
 CREATE TABLE data_source (
     id SERIAL PRIMARY KEY,
@@ -518,7 +646,15 @@ CREATE TABLE data_source (
     ssl_ca TEXT NOT NULL DEFAULT '',
     -- host_override and port_override are used for read-replicas that have different connection addresses.
     host_override TEXT NOT NULL DEFAULT '',
-    port_override TEXT NOT NULL DEFAULT ''
+    port_override TEXT NOT NULL DEFAULT '',
+    -- ssh_host, ssh_port, ssh_user, ssh_password and ssh_private_key are used to connect the database through an SSH tunnel.
+    ssh_host TEXT NOT NULL DEFAULT '',
+    ssh_port TEXT NOT NULL DEFAULT '',
+    ssh_user TEXT NOT NULL DEFAULT '',
+    ssh_password TEXT NOT NULL DEFAULT '',
+    ssh_private_key TEXT NOT NULL DEFAULT '',
+    -- ssh_host_key is the public key or the SHA256 fingerprint of the SSH host to verify it.
+    ssh_host_key TEXT NOT NULL DEFAULT ''
 );
 
 CREATE INDEX idx_data_source_instance_id ON data_source(instance_id);
@@ -805,6 +941,46 @@ CREATE TABLE issue_subscriber (
 
 CREATE INDEX idx_issue_subscriber_subscriber_id ON issue_subscriber(subscriber_id);
 
+-- instance_user_grant stores the database privileges granted to the instance users by the database grant issues.
+-- The privileges are revoked once the grant expires.
+CREATE TABLE instance_user_grant (
+    id SERIAL PRIMARY KEY,
+    row_status row_status NOT NULL DEFAULT 'NORMAL',
+    creator_id INTEGER NOT NULL REFERENCES principal (id),
+    created_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    updater_id INTEGER NOT NULL REFERENCES principal (id),
+    updated_ts BIGINT NOT NULL DEFAULT extract(epoch from now()),
+    instance_id INTEGER NOT NULL REFERENCES instance (id),
+    issue_id INTEGER NOT NULL REFERENCES issue (id),
+    database_name TEXT NOT NULL,
+    user_name TEXT NOT NULL,
+    -- host is the host of the MySQL and TiDB user, it's empty for the other engines.
+    host TEXT NOT NULL DEFAULT '',
+    privilege_list TEXT NOT NULL,
+    status TEXT NOT NULL CHECK (status IN ('ACTIVE', 'REVOKED')),
+    -- expire_ts is 0 if the privileges never expire.
+    expire_ts BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX idx_instance_user_grant_instance_id ON instance_user_grant(instance_id);
+
+CREATE INDEX idx_instance_user_grant_issue_id ON instance_user_grant(issue_id);
+
+ALTER SEQUENCE instance_user_grant_id_seq RESTART WITH 101;
+
+CREATE TRIGGER update_instance_user_grant_updated_ts
+BEFORE
+UPDATE
+    ON instance_user_grant FOR EACH ROW
+EXECUTE FUNCTION trigger_update_updated_ts();
+
+-- task_secret stores the secrets of the tasks such as the password of the database grant task.
+-- The secrets are never returned by the API and are deleted once the task is done, failed or canceled.
+CREATE TABLE task_secret (
+    task_id INTEGER PRIMARY KEY REFERENCES task (id),
+    secret TEXT NOT NULL
+);
+
 -- activity table stores the activity for the container such as issue
 CREATE TABLE activity (
     id SERIAL PRIMARY KEY,
@@ -247,6 +247,19 @@ func (s *Store) GetEnvironmentTierPolicyByEnvID(ctx context.Context, environment
 	return api.UnmarshalEnvironmentTierPolicy(policy.Payload)
 }
 
+// GetSQLQueryTimeoutPolicyByEnvID will get the SQL query timeout policy for an environment.
+func (s *Store) GetSQLQueryTimeoutPolicyByEnvID(ctx context.Context, environmentID int) (*api.SQLQueryTimeoutPolicy, error) {
+	pType := api.PolicyTypeSQLQueryTimeout
+	policy, err := s.getPolicyRaw(ctx, &api.PolicyFind{
+		EnvironmentID: &environmentID,
+		Type:          &pType,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return api.UnmarshalSQLQueryTimeoutPolicy(policy.Payload)
+}
+
 //
 // private functions
 //
@@ -0,0 +1,355 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/plugin/db"
+)
+
+// dbSequenceRaw is the store model for a DBSequence.
+// Fields have exactly the same meaning as DBSequence.
+type dbSequenceRaw struct {
+	ID int
+
+	// Standard fields
+	CreatorID int
+	CreatedTs int64
+	UpdaterID int
+	UpdatedTs int64
+
+	// Related fields
+	DatabaseID int
+
+	// Domain specific fields
+	Name       string
+	DataType   string
+	StartValue int64
+	MinValue   int64
+	MaxValue   int64
+	Increment  int64
+	Cycle      bool
+	OwnedBy    string
+}
+
+// toDBSequence creates an instance of DBSequence based on the dbSequenceRaw.
+// This is intended to be called when we need to compose a DBSequence relationship.
+func (raw *dbSequenceRaw) toDBSequence() *api.DBSequence {
+	return &api.DBSequence{
+		ID: raw.ID,
+
+		// Standard fields
+		CreatorID: raw.CreatorID,
+		CreatedTs: raw.CreatedTs,
+		UpdaterID: raw.UpdaterID,
+		UpdatedTs: raw.UpdatedTs,
+
+		// Related fields
+		DatabaseID: raw.DatabaseID,
+
+		// Domain specific fields
+		Name:       raw.Name,
+		DataType:   raw.DataType,
+		StartValue: raw.StartValue,
+		MinValue:   raw.MinValue,
+		MaxValue:   raw.MaxValue,
+		Increment:  raw.Increment,
+		Cycle:      raw.Cycle,
+		OwnedBy:    raw.OwnedBy,
+	}
+}
+
+// FindDBSequence finds a list of dbSequence instances.
+func (s *Store) FindDBSequence(ctx context.Context, find *api.DBSequenceFind) ([]*api.DBSequence, error) {
+	// The db_sequence table is only available in the dev schema for now.
+	if s.db.mode != common.ReleaseModeDev {
+		return nil, nil
+	}
+	dbSequenceRawList, err := s.findDBSequenceRaw(ctx, find)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find dbSequence list with dbSequenceFind[%+v]", find)
+	}
+	var dbSequenceList []*api.DBSequence
+	for _, raw := range dbSequenceRawList {
+		dbSequence, err := s.composeDBSequence(ctx, raw)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to compose dbSequence with dbSequenceRaw[%+v]", raw)
+		}
+		dbSequenceList = append(dbSequenceList, dbSequence)
+	}
+	return dbSequenceList, nil
+}
+
+// SetDBSequenceList sets the sequences for a database.
+func (s *Store) SetDBSequenceList(ctx context.Context, schema *db.Schema, databaseID int) error {
+	// The db_sequence table is only available in the dev schema for now.
+	if s.db.mode != common.ReleaseModeDev {
+		return nil
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return FormatError(err)
+	}
+	defer tx.Rollback()
+
+	oldDBSequenceRawList, err := s.findDBSequenceImpl(ctx, tx, &api.DBSequenceFind{
+		DatabaseID: &databaseID,
+	})
+	if err != nil {
+		return FormatError(err)
+	}
+
+	deletes, creates := generateDBSequenceActions(oldDBSequenceRawList, schema.SequenceList, databaseID)
+	for _, d := range deletes {
+		if err := s.deleteDBSequenceImpl(ctx, tx, d); err != nil {
+			return err
+		}
+	}
+	for _, c := range creates {
+		if _, err := s.createDBSequenceImpl(ctx, tx, c); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return FormatError(err)
+	}
+
+	return nil
+}
+
+// private functions.
+func generateDBSequenceActions(oldDBSequenceRawList []*dbSequenceRaw, sequenceList []db.Sequence, databaseID int) ([]*api.DBSequenceDelete, []*api.DBSequenceCreate) {
+	var newDBSequenceList []*api.DBSequenceCreate
+	for _, sequence := range sequenceList {
+		newDBSequenceList = append(newDBSequenceList, &api.DBSequenceCreate{
+			CreatorID:  api.SystemBotID,
+			DatabaseID: databaseID,
+			Name:       sequence.Name,
+			DataType:   sequence.DataType,
+			StartValue: sequence.StartValue,
+			MinValue:   sequence.MinValue,
+			MaxValue:   sequence.MaxValue,
+			Increment:  sequence.Increment,
+			Cycle:      sequence.Cycle,
+			OwnedBy:    sequence.OwnedBy,
+		})
+	}
+	oldDBSequenceMap := make(map[string]*dbSequenceRaw)
+	for _, seq := range oldDBSequenceRawList {
+		oldDBSequenceMap[seq.Name] = seq
+	}
+	newDBSequenceMap := make(map[string]*api.DBSequenceCreate)
+	for _, seq := range newDBSequenceList {
+		newDBSequenceMap[seq.Name] = seq
+	}
+
+	var deletes []*api.DBSequenceDelete
+	var creates []*api.DBSequenceCreate
+	for _, oldValue := range oldDBSequenceRawList {
+		newValue, ok := newDBSequenceMap[oldValue.Name]
+		if !ok {
+			deletes = append(deletes, &api.DBSequenceDelete{ID: oldValue.ID})
+		} else if ok &&
+			(oldValue.DataType != newValue.DataType ||
+				oldValue.StartValue != newValue.StartValue ||
+				oldValue.MinValue != newValue.MinValue ||
+				oldValue.MaxValue != newValue.MaxValue ||
+				oldValue.Increment != newValue.Increment ||
+				oldValue.Cycle != newValue.Cycle ||
+				oldValue.OwnedBy != newValue.OwnedBy) {
+			deletes = append(deletes, &api.DBSequenceDelete{ID: oldValue.ID})
+			creates = append(creates, newValue)
+		}
+	}
+	for _, newValue := range newDBSequenceList {
+		if _, ok := oldDBSequenceMap[newValue.Name]; !ok {
+			creates = append(creates, newValue)
+		}
+	}
+	return deletes, creates
+}
+
+func (s *Store) composeDBSequence(ctx context.Context, raw *dbSequenceRaw) (*api.DBSequence, error) {
+	dbSequence := raw.toDBSequence()
+
+	creator, err := s.GetPrincipalByID(ctx, dbSequence.CreatorID)
+	if err != nil {
+		return nil, err
+	}
+	dbSequence.Creator = creator
+
+	updater, err := s.GetPrincipalByID(ctx, dbSequence.UpdaterID)
+	if err != nil {
+		return nil, err
+	}
+	dbSequence.Updater = updater
+
+	database, err := s.GetDatabase(ctx, &api.DatabaseFind{ID: &dbSequence.DatabaseID})
+	if err != nil {
+		return nil, err
+	}
+	dbSequence.Database = database
+
+	return dbSequence, nil
+}
+
+// findDBSequenceRaw retrieves a list of DBSequences based on find.
+func (s *Store) findDBSequenceRaw(ctx context.Context, find *api.DBSequenceFind) ([]*dbSequenceRaw, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer tx.Rollback()
+
+	list, err := s.findDBSequenceImpl(ctx, tx, find)
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// createDBSequenceImpl creates a new DBSequence.
+func (*Store) createDBSequenceImpl(ctx context.Context, tx *Tx, create *api.DBSequenceCreate) (*dbSequenceRaw, error) {
+	// Insert row into db_sequence.
+	query := `
+		INSERT INTO db_sequence (
+			creator_id,
+			created_ts,
+			updater_id,
+			updated_ts,
+			database_id,
+			name,
+			data_type,
+			start_value,
+			min_value,
+			max_value,
+			increment,
+			cycle,
+			owned_by
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		RETURNING id, creator_id, created_ts, updater_id, updated_ts, database_id, name, data_type, start_value, min_value, max_value, increment, cycle, owned_by
+	`
+	var dbSequenceRaw dbSequenceRaw
+	if err := tx.QueryRowContext(ctx, query,
+		create.CreatorID,
+		create.CreatedTs,
+		create.CreatorID,
+		create.UpdatedTs,
+		create.DatabaseID,
+		create.Name,
+		create.DataType,
+		create.StartValue,
+		create.MinValue,
+		create.MaxValue,
+		create.Increment,
+		create.Cycle,
+		create.OwnedBy,
+	).Scan(
+		&dbSequenceRaw.ID,
+		&dbSequenceRaw.CreatorID,
+		&dbSequenceRaw.CreatedTs,
+		&dbSequenceRaw.UpdaterID,
+		&dbSequenceRaw.UpdatedTs,
+		&dbSequenceRaw.DatabaseID,
+		&dbSequenceRaw.Name,
+		&dbSequenceRaw.DataType,
+		&dbSequenceRaw.StartValue,
+		&dbSequenceRaw.MinValue,
+		&dbSequenceRaw.MaxValue,
+		&dbSequenceRaw.Increment,
+		&dbSequenceRaw.Cycle,
+		&dbSequenceRaw.OwnedBy,
+	); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.FormatDBErrorEmptyRowWithQuery(query)
+		}
+		return nil, FormatError(err)
+	}
+	return &dbSequenceRaw, nil
+}
+
+func (*Store) findDBSequenceImpl(ctx context.Context, tx *Tx, find *api.DBSequenceFind) ([]*dbSequenceRaw, error) {
+	// Build WHERE clause.
+	where, args := []string{"1 = 1"}, []interface{}{}
+	if v := find.ID; v != nil {
+		where, args = append(where, fmt.Sprintf("id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.DatabaseID; v != nil {
+		where, args = append(where, fmt.Sprintf("database_id = $%d", len(args)+1)), append(args, *v)
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT
+			id,
+			creator_id,
+			created_ts,
+			updater_id,
+			updated_ts,
+			database_id,
+			name,
+			data_type,
+			start_value,
+			min_value,
+			max_value,
+			increment,
+			cycle,
+			owned_by
+		FROM db_sequence
+		WHERE `+strings.Join(where, " AND ")+`
+		ORDER BY database_id, name ASC`,
+		args...,
+	)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer rows.Close()
+
+	// Iterate over result set and deserialize rows into dbSequenceRawList.
+	var dbSequenceRawList []*dbSequenceRaw
+	for rows.Next() {
+		var dbSequenceRaw dbSequenceRaw
+		if err := rows.Scan(
+			&dbSequenceRaw.ID,
+			&dbSequenceRaw.CreatorID,
+			&dbSequenceRaw.CreatedTs,
+			&dbSequenceRaw.UpdaterID,
+			&dbSequenceRaw.UpdatedTs,
+			&dbSequenceRaw.DatabaseID,
+			&dbSequenceRaw.Name,
+			&dbSequenceRaw.DataType,
+			&dbSequenceRaw.StartValue,
+			&dbSequenceRaw.MinValue,
+			&dbSequenceRaw.MaxValue,
+			&dbSequenceRaw.Increment,
+			&dbSequenceRaw.Cycle,
+			&dbSequenceRaw.OwnedBy,
+		); err != nil {
+			return nil, FormatError(err)
+		}
+
+		dbSequenceRawList = append(dbSequenceRawList, &dbSequenceRaw)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, FormatError(err)
+	}
+
+	return dbSequenceRawList, nil
+}
+
+// deleteDBSequenceImpl permanently deletes DBSequences from a database.
+func (*Store) deleteDBSequenceImpl(ctx context.Context, tx *Tx, delete *api.DBSequenceDelete) error {
+	// Remove row from database.
+	if _, err := tx.ExecContext(ctx, `DELETE FROM db_sequence WHERE id = $1`, delete.ID); err != nil {
+		return FormatError(err)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/plugin/db"
+)
+
+func TestGenerateDBSequenceActions(t *testing.T) {
+	databaseID := 198
+	tests := []struct {
+		oldDBSequenceRawList []*dbSequenceRaw
+		sequenceList         []db.Sequence
+		wantDeletes          []*api.DBSequenceDelete
+		wantCreates          []*api.DBSequenceCreate
+	}{
+		{
+			oldDBSequenceRawList: []*dbSequenceRaw{
+				{ID: 123, Name: "public.t_id_seq", DataType: "integer", StartValue: 1, MinValue: 1, MaxValue: 2147483647, Increment: 1, OwnedBy: "public.t.id"},
+			},
+			sequenceList: []db.Sequence{
+				{Name: "public.t_id_seq", DataType: "bigint", StartValue: 1, MinValue: 1, MaxValue: 9223372036854775807, Increment: 1, OwnedBy: "public.t.id"},
+				{Name: "public.s", DataType: "bigint", StartValue: 10, MinValue: 1, MaxValue: 100, Increment: 2, Cycle: true},
+			},
+			wantDeletes: []*api.DBSequenceDelete{
+				{ID: 123},
+			},
+			wantCreates: []*api.DBSequenceCreate{
+				{Name: "public.t_id_seq", DataType: "bigint", StartValue: 1, MinValue: 1, MaxValue: 9223372036854775807, Increment: 1, OwnedBy: "public.t.id", CreatorID: api.SystemBotID, DatabaseID: databaseID},
+				{Name: "public.s", DataType: "bigint", StartValue: 10, MinValue: 1, MaxValue: 100, Increment: 2, Cycle: true, CreatorID: api.SystemBotID, DatabaseID: databaseID},
+			},
+		},
+		{
+			oldDBSequenceRawList: []*dbSequenceRaw{
+				{ID: 123, Name: "public.s", DataType: "bigint", StartValue: 1, MinValue: 1, MaxValue: 100, Increment: 1},
+			},
+			sequenceList: nil,
+			wantDeletes: []*api.DBSequenceDelete{
+				{ID: 123},
+			},
+			wantCreates: nil,
+		},
+		{
+			oldDBSequenceRawList: []*dbSequenceRaw{
+				{ID: 123, Name: "public.s", DataType: "bigint", StartValue: 1, MinValue: 1, MaxValue: 100, Increment: 1},
+			},
+			sequenceList: []db.Sequence{
+				{Name: "public.s", DataType: "bigint", StartValue: 1, MinValue: 1, MaxValue: 100, Increment: 1},
+			},
+			wantDeletes: nil,
+			wantCreates: nil,
+		},
+	}
+
+	for _, test := range tests {
+		deletes, creates := generateDBSequenceActions(test.oldDBSequenceRawList, test.sequenceList, databaseID)
+		require.Equal(t, test.wantDeletes, deletes)
+		require.Equal(t, test.wantCreates, creates)
+	}
+}
@@ -29,16 +29,19 @@ type tableRaw struct {
 	DatabaseID int
 
 	// Domain specific fields
-	Name          string
-	Type          string
-	Engine        string
-	Collation     string
-	RowCount      int64
-	DataSize      int64
-	IndexSize     int64
-	DataFree      int64
-	CreateOptions string
-	Comment       string
+	Name            string
+	Type            string
+	Engine          string
+	Collation       string
+	RowCount        int64
+	DataSize        int64
+	IndexSize       int64
+	DataFree        int64
+	CreateOptions   string
+	Comment         string
+	PartitionKey    string
+	PartitionParent string
+	PartitionBound  string
 }
 
 // toTable creates an instance of Table based on the tableRaw.
@@ -57,16 +60,19 @@ func (raw *tableRaw) toTable() *api.Table {
 		DatabaseID: raw.DatabaseID,
 
 		// Domain specific fields
-		Name:          raw.Name,
-		Type:          raw.Type,
-		Engine:        raw.Engine,
-		Collation:     raw.Collation,
-		RowCount:      raw.RowCount,
-		DataSize:      raw.DataSize,
-		IndexSize:     raw.IndexSize,
-		DataFree:      raw.DataFree,
-		CreateOptions: raw.CreateOptions,
-		Comment:       raw.Comment,
+		Name:            raw.Name,
+		Type:            raw.Type,
+		Engine:          raw.Engine,
+		Collation:       raw.Collation,
+		RowCount:        raw.RowCount,
+		DataSize:        raw.DataSize,
+		IndexSize:       raw.IndexSize,
+		DataFree:        raw.DataFree,
+		CreateOptions:   raw.CreateOptions,
+		Comment:         raw.Comment,
+		PartitionKey:    raw.PartitionKey,
+		PartitionParent: raw.PartitionParent,
+		PartitionBound:  raw.PartitionBound,
 	}
 }
 
@@ -186,6 +192,27 @@ func (s *Store) SetTableList(ctx context.Context, schema *db.Schema, databaseID
 				return err
 			}
 		}
+
+		// The fk table is only available in the dev schema for now.
+		if s.db.mode == common.ReleaseModeDev {
+			foreignKeyList, err := s.findForeignKeyImpl(ctx, tx, &api.ForeignKeyFind{
+				TableID: &tableID,
+			})
+			if err != nil {
+				return err
+			}
+			fkDeletes, fkCreates := generateForeignKeyActions(foreignKeyList, table.ForeignKeyList, databaseID, tableID)
+			for _, d := range fkDeletes {
+				if err := s.deleteForeignKeyImpl(ctx, tx, d); err != nil {
+					return err
+				}
+			}
+			for _, c := range fkCreates {
+				if _, err := s.createForeignKeyImpl(ctx, tx, c); err != nil {
+					return err
+				}
+			}
+		}
 	}
 
 	if err := tx.Commit(); err != nil {
@@ -222,20 +249,26 @@ func generateTableActions(oldTableRawList []*tableRaw, tableList []db.Table, dat
 				oldValue.IndexSize != newValue.IndexSize ||
 				oldValue.DataFree != newValue.DataFree ||
 				oldValue.CreateOptions != newValue.CreateOptions ||
-				oldValue.Comment != newValue.Comment) {
+				oldValue.Comment != newValue.Comment ||
+				oldValue.PartitionKey != newValue.PartitionKey ||
+				oldValue.PartitionParent != newValue.PartitionParent ||
+				oldValue.PartitionBound != newValue.PartitionBound) {
 			patches = append(patches,
 				&api.TablePatch{
-					ID:            oldValue.ID,
-					UpdaterID:     api.SystemBotID,
-					Type:          newValue.Type,
-					Engine:        newValue.Engine,
-					Collation:     newValue.Collation,
-					RowCount:      newValue.RowCount,
-					DataSize:      newValue.DataSize,
-					IndexSize:     newValue.IndexSize,
-					DataFree:      newValue.DataFree,
-					CreateOptions: newValue.CreateOptions,
-					Comment:       newValue.Comment,
+					ID:              oldValue.ID,
+					UpdaterID:       api.SystemBotID,
+					Type:            newValue.Type,
+					Engine:          newValue.Engine,
+					Collation:       newValue.Collation,
+					RowCount:        newValue.RowCount,
+					DataSize:        newValue.DataSize,
+					IndexSize:       newValue.IndexSize,
+					DataFree:        newValue.DataFree,
+					CreateOptions:   newValue.CreateOptions,
+					Comment:         newValue.Comment,
+					PartitionKey:    newValue.PartitionKey,
+					PartitionParent: newValue.PartitionParent,
+					PartitionBound:  newValue.PartitionBound,
 				},
 			)
 		}
@@ -244,20 +277,23 @@ func generateTableActions(oldTableRawList []*tableRaw, tableList []db.Table, dat
 		k := newValue.Name
 		if _, ok := oldTableMap[k]; !ok {
 			creates = append(creates, &api.TableCreate{
-				CreatorID:     api.SystemBotID,
-				CreatedTs:     newValue.CreatedTs,
-				UpdatedTs:     newValue.UpdatedTs,
-				DatabaseID:    databaseID,
-				Name:          newValue.Name,
-				Type:          newValue.Type,
-				Engine:        newValue.Engine,
-				Collation:     newValue.Collation,
-				RowCount:      newValue.RowCount,
-				DataSize:      newValue.DataSize,
-				IndexSize:     newValue.IndexSize,
-				DataFree:      newValue.DataFree,
-				CreateOptions: newValue.CreateOptions,
-				Comment:       newValue.Comment,
+				CreatorID:       api.SystemBotID,
+				CreatedTs:       newValue.CreatedTs,
+				UpdatedTs:       newValue.UpdatedTs,
+				DatabaseID:      databaseID,
+				Name:            newValue.Name,
+				Type:            newValue.Type,
+				Engine:          newValue.Engine,
+				Collation:       newValue.Collation,
+				RowCount:        newValue.RowCount,
+				DataSize:        newValue.DataSize,
+				IndexSize:       newValue.IndexSize,
+				DataFree:        newValue.DataFree,
+				CreateOptions:   newValue.CreateOptions,
+				Comment:         newValue.Comment,
+				PartitionKey:    newValue.PartitionKey,
+				PartitionParent: newValue.PartitionParent,
+				PartitionBound:  newValue.PartitionBound,
 			})
 		}
 	}
@@ -268,6 +304,15 @@ func generateTableActions(oldTableRawList []*tableRaw, tableList []db.Table, dat
 // private functions
 //
 
+// partitionColumns returns the select expressions for the table partition columns.
+// The columns only exist in dev mode schema for now, so we fall back to empty values in other modes.
+func (s *Store) partitionColumns() string {
+	if s.db.mode == common.ReleaseModeDev {
+		return "partition_key, partition_parent, partition_bound"
+	}
+	return "'', '', ''"
+}
+
 func (s *Store) composeTable(ctx context.Context, raw *tableRaw) (*api.Table, error) {
 	table := raw.toTable()
 
@@ -331,31 +376,9 @@ func (s *Store) getTableRaw(ctx context.Context, find *api.TableFind) (*tableRaw
 }
 
 // createTableImpl creates a new table.
-func (*Store) createTableImpl(ctx context.Context, tx *Tx, create *api.TableCreate) (*tableRaw, error) {
-	// Insert row into table.
-	query := `
-		INSERT INTO tbl (
-			creator_id,
-			created_ts,
-			updater_id,
-			updated_ts,
-			database_id,
-			name,
-			type,
-			engine,
-			"collation",
-			row_count,
-			data_size,
-			index_size,
-			data_free,
-			create_options,
-			comment
-		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
-		RETURNING id, creator_id, created_ts, updater_id, updated_ts, database_id, name, type, engine, "collation", row_count, data_size, index_size, data_free, create_options, comment
-	`
-	var tableRaw tableRaw
-	if err := tx.QueryRowContext(ctx, query,
+func (s *Store) createTableImpl(ctx context.Context, tx *Tx, create *api.TableCreate) (*tableRaw, error) {
+	columns := []string{"creator_id", "created_ts", "updater_id", "updated_ts", "database_id", "name", "type", "engine", `"collation"`, "row_count", "data_size", "index_size", "data_free", "create_options", "comment"}
+	args := []interface{}{
 		create.CreatorID,
 		create.CreatedTs,
 		create.CreatorID,
@@ -371,7 +394,23 @@ func (*Store) createTableImpl(ctx context.Context, tx *Tx, create *api.TableCrea
 		create.DataFree,
 		create.CreateOptions,
 		create.Comment,
-	).Scan(
+	}
+	if s.db.mode == common.ReleaseModeDev {
+		columns = append(columns, "partition_key", "partition_parent", "partition_bound")
+		args = append(args, create.PartitionKey, create.PartitionParent, create.PartitionBound)
+	}
+	var placeholders []string
+	for i := range args {
+		placeholders = append(placeholders, fmt.Sprintf("$%d", i+1))
+	}
+	// Insert row into table.
+	query := `
+		INSERT INTO tbl (` + strings.Join(columns, ", ") + `)
+		VALUES (` + strings.Join(placeholders, ", ") + `)
+		RETURNING id, creator_id, created_ts, updater_id, updated_ts, database_id, name, type, engine, "collation", row_count, data_size, index_size, data_free, create_options, comment, ` + s.partitionColumns() + `
+	`
+	var tableRaw tableRaw
+	if err := tx.QueryRowContext(ctx, query, args...).Scan(
 		&tableRaw.ID,
 		&tableRaw.CreatorID,
 		&tableRaw.CreatedTs,
@@ -388,6 +427,9 @@ func (*Store) createTableImpl(ctx context.Context, tx *Tx, create *api.TableCrea
 		&tableRaw.DataFree,
 		&tableRaw.CreateOptions,
 		&tableRaw.Comment,
+		&tableRaw.PartitionKey,
+		&tableRaw.PartitionParent,
+		&tableRaw.PartitionBound,
 	); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, common.FormatDBErrorEmptyRowWithQuery(query)
@@ -398,14 +440,9 @@ func (*Store) createTableImpl(ctx context.Context, tx *Tx, create *api.TableCrea
 }
 
 // patchTableImpl patches a table.
-func (*Store) patchTableImpl(ctx context.Context, tx *Tx, patch *api.TablePatch) (*tableRaw, error) {
-	var tableRaw tableRaw
-	// Execute update query with RETURNING.
-	if err := tx.QueryRowContext(ctx, `
-		UPDATE tbl
-		SET	type=$1, engine=$2, "collation"=$3, row_count=$4, data_size=$5, index_size=$6, data_free=$7, create_options=$8, comment=$9
-		WHERE id = $10
-		RETURNING id, creator_id, created_ts, updater_id, updated_ts, database_id, name, type, engine, "collation", row_count, data_size, index_size, data_free, create_options, comment`,
+func (s *Store) patchTableImpl(ctx context.Context, tx *Tx, patch *api.TablePatch) (*tableRaw, error) {
+	set := []string{"type = $1", "engine = $2", `"collation" = $3`, "row_count = $4", "data_size = $5", "index_size = $6", "data_free = $7", "create_options = $8", "comment = $9"}
+	args := []interface{}{
 		patch.Type,
 		patch.Engine,
 		patch.Collation,
@@ -415,7 +452,22 @@ func (*Store) patchTableImpl(ctx context.Context, tx *Tx, patch *api.TablePatch)
 		patch.DataFree,
 		patch.CreateOptions,
 		patch.Comment,
-		patch.ID,
+	}
+	if s.db.mode == common.ReleaseModeDev {
+		set, args = append(set, fmt.Sprintf("partition_key = $%d", len(args)+1)), append(args, patch.PartitionKey)
+		set, args = append(set, fmt.Sprintf("partition_parent = $%d", len(args)+1)), append(args, patch.PartitionParent)
+		set, args = append(set, fmt.Sprintf("partition_bound = $%d", len(args)+1)), append(args, patch.PartitionBound)
+	}
+	args = append(args, patch.ID)
+
+	var tableRaw tableRaw
+	// Execute update query with RETURNING.
+	if err := tx.QueryRowContext(ctx, fmt.Sprintf(`
+		UPDATE tbl
+		SET `+strings.Join(set, ", ")+`
+		WHERE id = $%d
+		RETURNING id, creator_id, created_ts, updater_id, updated_ts, database_id, name, type, engine, "collation", row_count, data_size, index_size, data_free, create_options, comment, `+s.partitionColumns(), len(args)),
+		args...,
 	).Scan(
 		&tableRaw.ID,
 		&tableRaw.CreatorID,
@@ -433,6 +485,9 @@ func (*Store) patchTableImpl(ctx context.Context, tx *Tx, patch *api.TablePatch)
 		&tableRaw.DataFree,
 		&tableRaw.CreateOptions,
 		&tableRaw.Comment,
+		&tableRaw.PartitionKey,
+		&tableRaw.PartitionParent,
+		&tableRaw.PartitionBound,
 	); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, &common.Error{Code: common.NotFound, Err: errors.Errorf("table ID not found: %d", patch.ID)}
@@ -442,7 +497,7 @@ func (*Store) patchTableImpl(ctx context.Context, tx *Tx, patch *api.TablePatch)
 	return &tableRaw, nil
 }
 
-func (*Store) findTableImpl(ctx context.Context, tx *Tx, find *api.TableFind) ([]*tableRaw, error) {
+func (s *Store) findTableImpl(ctx context.Context, tx *Tx, find *api.TableFind) ([]*tableRaw, error) {
 	// Build WHERE clause.
 	where, args := []string{"1 = 1"}, []interface{}{}
 	if v := find.ID; v != nil {
@@ -472,7 +527,8 @@ func (*Store) findTableImpl(ctx context.Context, tx *Tx, find *api.TableFind) ([
 			index_size,
 			data_free,
 			create_options,
-			comment
+			comment,
+			`+s.partitionColumns()+`
 		FROM tbl
 		WHERE `+strings.Join(where, " AND "),
 		args...,
@@ -503,6 +559,9 @@ func (*Store) findTableImpl(ctx context.Context, tx *Tx, find *api.TableFind) ([
 			&tableRaw.DataFree,
 			&tableRaw.CreateOptions,
 			&tableRaw.Comment,
+			&tableRaw.PartitionKey,
+			&tableRaw.PartitionParent,
+			&tableRaw.PartitionBound,
 		); err != nil {
 			return nil, FormatError(err)
 		}
@@ -0,0 +1,230 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/plugin/db"
+)
+
+// FindForeignKey retrieves a list of foreign keys based on find.
+func (s *Store) FindForeignKey(ctx context.Context, find *api.ForeignKeyFind) ([]*api.ForeignKey, error) {
+	// The fk table is only available in the dev schema for now.
+	if s.db.mode != common.ReleaseModeDev {
+		return nil, nil
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer tx.Rollback()
+
+	list, err := s.findForeignKeyImpl(ctx, tx, find)
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+type foreignKeyKey struct {
+	name     string
+	position int
+}
+
+func generateForeignKeyActions(oldForeignKeyList []*api.ForeignKey, foreignKeyList []db.ForeignKey, databaseID, tableID int) ([]*api.ForeignKeyDelete, []*api.ForeignKeyCreate) {
+	var foreignKeyCreateList []*api.ForeignKeyCreate
+	for _, foreignKey := range foreignKeyList {
+		foreignKeyCreateList = append(foreignKeyCreateList, &api.ForeignKeyCreate{
+			CreatorID:        api.SystemBotID,
+			DatabaseID:       databaseID,
+			TableID:          tableID,
+			Name:             foreignKey.Name,
+			Column:           foreignKey.Column,
+			Position:         foreignKey.Position,
+			ReferencedTable:  foreignKey.ReferencedTable,
+			ReferencedColumn: foreignKey.ReferencedColumn,
+			OnUpdate:         foreignKey.OnUpdate,
+			OnDelete:         foreignKey.OnDelete,
+		})
+	}
+	oldForeignKeyMap := make(map[foreignKeyKey]*api.ForeignKey)
+	for _, foreignKey := range oldForeignKeyList {
+		oldForeignKeyMap[foreignKeyKey{foreignKey.Name, foreignKey.Position}] = foreignKey
+	}
+	newForeignKeyMap := make(map[foreignKeyKey]*api.ForeignKeyCreate)
+	for _, foreignKey := range foreignKeyCreateList {
+		newForeignKeyMap[foreignKeyKey{foreignKey.Name, foreignKey.Position}] = foreignKey
+	}
+
+	var deletes []*api.ForeignKeyDelete
+	var creates []*api.ForeignKeyCreate
+	for _, oldValue := range oldForeignKeyList {
+		k := foreignKeyKey{oldValue.Name, oldValue.Position}
+		newValue, ok := newForeignKeyMap[k]
+		if !ok {
+			deletes = append(deletes, &api.ForeignKeyDelete{ID: oldValue.ID})
+		} else if ok && (oldValue.Column != newValue.Column || oldValue.ReferencedTable != newValue.ReferencedTable || oldValue.ReferencedColumn != newValue.ReferencedColumn || oldValue.OnUpdate != newValue.OnUpdate || oldValue.OnDelete != newValue.OnDelete) {
+			deletes = append(deletes, &api.ForeignKeyDelete{ID: oldValue.ID})
+			creates = append(creates, newValue)
+		}
+	}
+	for _, newValue := range foreignKeyCreateList {
+		k := foreignKeyKey{newValue.Name, newValue.Position}
+		if _, ok := oldForeignKeyMap[k]; !ok {
+			creates = append(creates, newValue)
+		}
+	}
+	// The ordering of creates and deletes are not consistently produced because of maps. We need to produce a consistent output
+	// for callers such as testing.
+	sort.Slice(deletes, func(i, j int) bool {
+		return deletes[i].ID < deletes[j].ID
+	})
+	sort.Slice(creates, func(i, j int) bool {
+		return creates[i].Name < creates[j].Name || (creates[i].Name == creates[j].Name && creates[i].Position < creates[j].Position)
+	})
+
+	return deletes, creates
+}
+
+// createForeignKeyImpl creates a new foreign key.
+func (*Store) createForeignKeyImpl(ctx context.Context, tx *Tx, create *api.ForeignKeyCreate) (*api.ForeignKey, error) {
+	// Insert row into fk.
+	query := `
+		INSERT INTO fk (
+			creator_id,
+			updater_id,
+			database_id,
+			table_id,
+			name,
+			column_name,
+			position,
+			referenced_table,
+			referenced_column,
+			on_update,
+			on_delete
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id, creator_id, created_ts, updater_id, updated_ts, database_id, table_id, name, column_name, position, referenced_table, referenced_column, on_update, on_delete
+	`
+	var foreignKey api.ForeignKey
+	if err := tx.QueryRowContext(ctx, query,
+		create.CreatorID,
+		create.CreatorID,
+		create.DatabaseID,
+		create.TableID,
+		create.Name,
+		create.Column,
+		create.Position,
+		create.ReferencedTable,
+		create.ReferencedColumn,
+		create.OnUpdate,
+		create.OnDelete,
+	).Scan(
+		&foreignKey.ID,
+		&foreignKey.CreatorID,
+		&foreignKey.CreatedTs,
+		&foreignKey.UpdaterID,
+		&foreignKey.UpdatedTs,
+		&foreignKey.DatabaseID,
+		&foreignKey.TableID,
+		&foreignKey.Name,
+		&foreignKey.Column,
+		&foreignKey.Position,
+		&foreignKey.ReferencedTable,
+		&foreignKey.ReferencedColumn,
+		&foreignKey.OnUpdate,
+		&foreignKey.OnDelete,
+	); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.FormatDBErrorEmptyRowWithQuery(query)
+		}
+		return nil, FormatError(err)
+	}
+
+	return &foreignKey, nil
+}
+
+func (*Store) findForeignKeyImpl(ctx context.Context, tx *Tx, find *api.ForeignKeyFind) ([]*api.ForeignKey, error) {
+	// Build WHERE clause.
+	where, args := []string{"1 = 1"}, []interface{}{}
+	if v := find.ID; v != nil {
+		where, args = append(where, fmt.Sprintf("id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.DatabaseID; v != nil {
+		where, args = append(where, fmt.Sprintf("database_id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.TableID; v != nil {
+		where, args = append(where, fmt.Sprintf("table_id = $%d", len(args)+1)), append(args, *v)
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+			SELECT
+				id,
+				creator_id,
+				created_ts,
+				updater_id,
+				updated_ts,
+				database_id,
+				table_id,
+				name,
+				column_name,
+				position,
+				referenced_table,
+				referenced_column,
+				on_update,
+				on_delete
+			FROM fk
+			WHERE `+strings.Join(where, " AND ")+`
+			ORDER BY database_id, table_id, name ASC, position ASC`,
+		args...,
+	)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer rows.Close()
+
+	// Iterate over result set and deserialize rows into foreignKeyList.
+	var foreignKeyList []*api.ForeignKey
+	for rows.Next() {
+		var foreignKey api.ForeignKey
+		if err := rows.Scan(
+			&foreignKey.ID,
+			&foreignKey.CreatorID,
+			&foreignKey.CreatedTs,
+			&foreignKey.UpdaterID,
+			&foreignKey.UpdatedTs,
+			&foreignKey.DatabaseID,
+			&foreignKey.TableID,
+			&foreignKey.Name,
+			&foreignKey.Column,
+			&foreignKey.Position,
+			&foreignKey.ReferencedTable,
+			&foreignKey.ReferencedColumn,
+			&foreignKey.OnUpdate,
+			&foreignKey.OnDelete,
+		); err != nil {
+			return nil, FormatError(err)
+		}
+
+		foreignKeyList = append(foreignKeyList, &foreignKey)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, FormatError(err)
+	}
+
+	return foreignKeyList, nil
+}
+
+// deleteForeignKeyImpl deletes a foreign key.
+func (*Store) deleteForeignKeyImpl(ctx context.Context, tx *Tx, delete *api.ForeignKeyDelete) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM fk WHERE id = $1`, delete.ID); err != nil {
+		return FormatError(err)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/plugin/db"
+)
+
+func TestGenerateForeignKeyActions(t *testing.T) {
+	databaseID := 198
+	tableID := 199
+	tests := []struct {
+		oldForeignKeyList []*api.ForeignKey
+		foreignKeyList    []db.ForeignKey
+		wantDeletes       []*api.ForeignKeyDelete
+		wantCreates       []*api.ForeignKeyCreate
+	}{
+		{
+			oldForeignKeyList: []*api.ForeignKey{
+				{ID: 123, Name: "fk1", Column: "a", Position: 1, ReferencedTable: "t1", ReferencedColumn: "id", OnUpdate: "NO ACTION", OnDelete: "NO ACTION"},
+				{ID: 124, Name: "fk2", Column: "b", Position: 1, ReferencedTable: "t2", ReferencedColumn: "x", OnUpdate: "NO ACTION", OnDelete: "NO ACTION"},
+				{ID: 125, Name: "fk2", Column: "c", Position: 2, ReferencedTable: "t2", ReferencedColumn: "y", OnUpdate: "NO ACTION", OnDelete: "NO ACTION"},
+			},
+			foreignKeyList: []db.ForeignKey{
+				{Name: "fk1", Column: "a", Position: 1, ReferencedTable: "t1", ReferencedColumn: "id", OnUpdate: "NO ACTION", OnDelete: "CASCADE"},
+				{Name: "fk2", Column: "b", Position: 1, ReferencedTable: "t2", ReferencedColumn: "x", OnUpdate: "NO ACTION", OnDelete: "NO ACTION"},
+				{Name: "fk3", Column: "d", Position: 1, ReferencedTable: "t3", ReferencedColumn: "id", OnUpdate: "NO ACTION", OnDelete: "NO ACTION"},
+			},
+			wantDeletes: []*api.ForeignKeyDelete{
+				{ID: 123},
+				{ID: 125},
+			},
+			wantCreates: []*api.ForeignKeyCreate{
+				{Name: "fk1", Column: "a", Position: 1, ReferencedTable: "t1", ReferencedColumn: "id", OnUpdate: "NO ACTION", OnDelete: "CASCADE", CreatorID: api.SystemBotID, DatabaseID: databaseID, TableID: tableID},
+				{Name: "fk3", Column: "d", Position: 1, ReferencedTable: "t3", ReferencedColumn: "id", OnUpdate: "NO ACTION", OnDelete: "NO ACTION", CreatorID: api.SystemBotID, DatabaseID: databaseID, TableID: tableID},
+			},
+		},
+		{
+			oldForeignKeyList: []*api.ForeignKey{
+				{ID: 123, Name: "fk1", Column: "a", Position: 1, ReferencedTable: "t1", ReferencedColumn: "id"},
+			},
+			foreignKeyList: []db.ForeignKey{
+				{Name: "fk1", Column: "a", Position: 1, ReferencedTable: "t1", ReferencedColumn: "id"},
+			},
+			wantDeletes: nil,
+			wantCreates: nil,
+		},
+	}
+
+	for _, test := range tests {
+		deletes, creates := generateForeignKeyActions(test.oldForeignKeyList, test.foreignKeyList, databaseID, tableID)
+		require.Equal(t, test.wantDeletes, deletes)
+		require.Equal(t, test.wantCreates, creates)
+	}
+}
@@ -424,7 +424,7 @@ func (s *Store) patchTaskRawStatus(ctx context.Context, patch *api.TaskStatusPat
 }
 
 // createTaskImpl creates tasks.
-func (*Store) createTaskImpl(ctx context.Context, tx *Tx, creates ...*api.TaskCreate) ([]*taskRaw, error) {
+func (s *Store) createTaskImpl(ctx context.Context, tx *Tx, creates ...*api.TaskCreate) ([]*taskRaw, error) {
 	var query strings.Builder
 	var values []interface{}
 	var queryValues []string
@@ -510,6 +510,17 @@ func (*Store) createTaskImpl(ctx context.Context, tx *Tx, creates ...*api.TaskCr
 	if err := rows.Err(); err != nil {
 		return nil, FormatError(err)
 	}
+	rows.Close()
+
+	// The returned rows are in the same order as the creates.
+	for i, create := range creates {
+		if create.Secret == "" {
+			continue
+		}
+		if err := s.createTaskSecretImpl(ctx, tx, taskRawList[i].ID, create.Secret); err != nil {
+			return nil, err
+		}
+	}
 	return taskRawList, nil
 }
 
@@ -544,6 +555,9 @@ func (s *Store) findTaskImpl(ctx context.Context, tx *Tx, find *api.TaskFind) ([
 		}
 		where = append(where, fmt.Sprintf("type in (%s)", strings.Join(list, ",")))
 	}
+	if v := find.SchemaVersion; v != nil {
+		where, args = append(where, fmt.Sprintf("payload->>'schemaVersion' = $%d", len(args)+1)), append(args, *v)
+	}
 	if v := find.Payload; v != "" {
 		where = append(where, v)
 	}
@@ -794,6 +808,15 @@ func (s *Store) patchTaskStatusImpl(ctx context.Context, tx *Tx, patch *api.Task
 	if err := rows.Err(); err != nil {
 		return nil, FormatError(err)
 	}
+	rows.Close()
+
+	// The task secrets are not needed once the tasks terminate.
+	switch patch.Status {
+	case api.TaskDone, api.TaskFailed, api.TaskCanceled:
+		if err := s.deleteTaskSecretImpl(ctx, tx, patch.IDList); err != nil {
+			return nil, err
+		}
+	}
 
 	for _, taskRaw := range taskRawList {
 		taskRunRawList, err := s.findTaskRunImpl(ctx, tx, &api.TaskRunFind{
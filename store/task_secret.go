@@ -0,0 +1,71 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/bytebase/bytebase/common"
+)
+
+// GetTaskSecret gets the secret of the task, returns an empty string if the task has no secret.
+func (s *Store) GetTaskSecret(ctx context.Context, taskID int) (string, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", FormatError(err)
+	}
+	defer tx.Rollback()
+
+	var secret string
+	if err := tx.QueryRowContext(ctx, `
+		SELECT secret
+		FROM task_secret
+		WHERE task_id = $1`,
+		taskID,
+	).Scan(&secret); err != nil {
+		if err == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", FormatError(err)
+	}
+	return secret, nil
+}
+
+// createTaskSecretImpl stores the secret of the task.
+func (*Store) createTaskSecretImpl(ctx context.Context, tx *Tx, taskID int, secret string) error {
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO task_secret (
+			task_id,
+			secret
+		)
+		VALUES ($1, $2)`,
+		taskID,
+		secret,
+	); err != nil {
+		return FormatError(err)
+	}
+	return nil
+}
+
+// deleteTaskSecretImpl deletes the secrets of the tasks.
+func (s *Store) deleteTaskSecretImpl(ctx context.Context, tx *Tx, taskIDList []int) error {
+	// The task_secret table only exists in dev mode schema for now.
+	if s.db.mode != common.ReleaseModeDev || len(taskIDList) == 0 {
+		return nil
+	}
+	var placeholders []string
+	var args []interface{}
+	for i, id := range taskIDList {
+		placeholders = append(placeholders, fmt.Sprintf("$%d", i+1))
+		args = append(args, id)
+	}
+	if _, err := tx.ExecContext(ctx, `
+		DELETE FROM task_secret
+		WHERE task_id IN (`+strings.Join(placeholders, ", ")+`)`,
+		args...,
+	); err != nil {
+		return FormatError(err)
+	}
+	return nil
+}
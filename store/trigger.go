@@ -0,0 +1,344 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/common"
+	"github.com/bytebase/bytebase/plugin/db"
+)
+
+// dbTriggerRaw is the store model for a DBTrigger.
+// Fields have exactly the same meaning as DBTrigger.
+type dbTriggerRaw struct {
+	ID int
+
+	// Standard fields
+	CreatorID int
+	CreatedTs int64
+	UpdaterID int
+	UpdatedTs int64
+
+	// Related fields
+	DatabaseID int
+
+	// Domain specific fields
+	Name       string
+	TableName  string
+	Timing     string
+	Event      string
+	Definition string
+	Comment    string
+}
+
+// toDBTrigger creates an instance of DBTrigger based on the dbTriggerRaw.
+// This is intended to be called when we need to compose a DBTrigger relationship.
+func (raw *dbTriggerRaw) toDBTrigger() *api.DBTrigger {
+	return &api.DBTrigger{
+		ID: raw.ID,
+
+		// Standard fields
+		CreatorID: raw.CreatorID,
+		CreatedTs: raw.CreatedTs,
+		UpdaterID: raw.UpdaterID,
+		UpdatedTs: raw.UpdatedTs,
+
+		// Related fields
+		DatabaseID: raw.DatabaseID,
+
+		// Domain specific fields
+		Name:       raw.Name,
+		TableName:  raw.TableName,
+		Timing:     raw.Timing,
+		Event:      raw.Event,
+		Definition: raw.Definition,
+		Comment:    raw.Comment,
+	}
+}
+
+// FindDBTrigger finds a list of dbTrigger instances.
+func (s *Store) FindDBTrigger(ctx context.Context, find *api.DBTriggerFind) ([]*api.DBTrigger, error) {
+	// The db_trigger table is only available in the dev schema for now.
+	if s.db.mode != common.ReleaseModeDev {
+		return nil, nil
+	}
+	dbTriggerRawList, err := s.findDBTriggerRaw(ctx, find)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find dbTrigger list with dbTriggerFind[%+v]", find)
+	}
+	var dbTriggerList []*api.DBTrigger
+	for _, raw := range dbTriggerRawList {
+		dbTrigger, err := s.composeDBTrigger(ctx, raw)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to compose dbTrigger with dbTriggerRaw[%+v]", raw)
+		}
+		dbTriggerList = append(dbTriggerList, dbTrigger)
+	}
+	return dbTriggerList, nil
+}
+
+// triggerKey identifies a trigger since trigger names are only unique per table.
+type triggerKey struct {
+	tableName string
+	name      string
+}
+
+// SetDBTriggerList sets the triggers for a database.
+func (s *Store) SetDBTriggerList(ctx context.Context, schema *db.Schema, databaseID int) error {
+	// The db_trigger table is only available in the dev schema for now.
+	if s.db.mode != common.ReleaseModeDev {
+		return nil
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return FormatError(err)
+	}
+	defer tx.Rollback()
+
+	oldDBTriggerRawList, err := s.findDBTriggerImpl(ctx, tx, &api.DBTriggerFind{
+		DatabaseID: &databaseID,
+	})
+	if err != nil {
+		return FormatError(err)
+	}
+
+	deletes, creates := generateDBTriggerActions(oldDBTriggerRawList, schema.TriggerList, databaseID)
+	for _, d := range deletes {
+		if err := s.deleteDBTriggerImpl(ctx, tx, d); err != nil {
+			return err
+		}
+	}
+	for _, c := range creates {
+		if _, err := s.createDBTriggerImpl(ctx, tx, c); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return FormatError(err)
+	}
+
+	return nil
+}
+
+// private functions.
+func generateDBTriggerActions(oldDBTriggerRawList []*dbTriggerRaw, triggerList []db.Trigger, databaseID int) ([]*api.DBTriggerDelete, []*api.DBTriggerCreate) {
+	var newDBTriggerList []*api.DBTriggerCreate
+	for _, trigger := range triggerList {
+		newDBTriggerList = append(newDBTriggerList, &api.DBTriggerCreate{
+			CreatorID:  api.SystemBotID,
+			DatabaseID: databaseID,
+			Name:       trigger.Name,
+			TableName:  trigger.TableName,
+			Timing:     trigger.Timing,
+			Event:      trigger.Event,
+			Definition: trigger.Definition,
+			Comment:    trigger.Comment,
+		})
+	}
+	oldDBTriggerMap := make(map[triggerKey]*dbTriggerRaw)
+	for _, t := range oldDBTriggerRawList {
+		oldDBTriggerMap[triggerKey{tableName: t.TableName, name: t.Name}] = t
+	}
+	newDBTriggerMap := make(map[triggerKey]*api.DBTriggerCreate)
+	for _, t := range newDBTriggerList {
+		newDBTriggerMap[triggerKey{tableName: t.TableName, name: t.Name}] = t
+	}
+
+	var deletes []*api.DBTriggerDelete
+	var creates []*api.DBTriggerCreate
+	for _, oldValue := range oldDBTriggerRawList {
+		k := triggerKey{tableName: oldValue.TableName, name: oldValue.Name}
+		newValue, ok := newDBTriggerMap[k]
+		if !ok {
+			deletes = append(deletes, &api.DBTriggerDelete{ID: oldValue.ID})
+		} else if ok &&
+			(oldValue.Timing != newValue.Timing ||
+				oldValue.Event != newValue.Event ||
+				oldValue.Definition != newValue.Definition ||
+				oldValue.Comment != newValue.Comment) {
+			deletes = append(deletes, &api.DBTriggerDelete{ID: oldValue.ID})
+			creates = append(creates, newValue)
+		}
+	}
+	for _, newValue := range newDBTriggerList {
+		k := triggerKey{tableName: newValue.TableName, name: newValue.Name}
+		if _, ok := oldDBTriggerMap[k]; !ok {
+			creates = append(creates, newValue)
+		}
+	}
+	return deletes, creates
+}
+
+func (s *Store) composeDBTrigger(ctx context.Context, raw *dbTriggerRaw) (*api.DBTrigger, error) {
+	dbTrigger := raw.toDBTrigger()
+
+	creator, err := s.GetPrincipalByID(ctx, dbTrigger.CreatorID)
+	if err != nil {
+		return nil, err
+	}
+	dbTrigger.Creator = creator
+
+	updater, err := s.GetPrincipalByID(ctx, dbTrigger.UpdaterID)
+	if err != nil {
+		return nil, err
+	}
+	dbTrigger.Updater = updater
+
+	database, err := s.GetDatabase(ctx, &api.DatabaseFind{ID: &dbTrigger.DatabaseID})
+	if err != nil {
+		return nil, err
+	}
+	dbTrigger.Database = database
+
+	return dbTrigger, nil
+}
+
+// findDBTriggerRaw retrieves a list of DBTriggers based on find.
+func (s *Store) findDBTriggerRaw(ctx context.Context, find *api.DBTriggerFind) ([]*dbTriggerRaw, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer tx.Rollback()
+
+	list, err := s.findDBTriggerImpl(ctx, tx, find)
+	if err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// createDBTriggerImpl creates a new DBTrigger.
+func (*Store) createDBTriggerImpl(ctx context.Context, tx *Tx, create *api.DBTriggerCreate) (*dbTriggerRaw, error) {
+	// Insert row into db_trigger.
+	query := `
+		INSERT INTO db_trigger (
+			creator_id,
+			created_ts,
+			updater_id,
+			updated_ts,
+			database_id,
+			name,
+			table_name,
+			timing,
+			event,
+			definition,
+			comment
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id, creator_id, created_ts, updater_id, updated_ts, database_id, name, table_name, timing, event, definition, comment
+	`
+	var dbTriggerRaw dbTriggerRaw
+	if err := tx.QueryRowContext(ctx, query,
+		create.CreatorID,
+		create.CreatedTs,
+		create.CreatorID,
+		create.UpdatedTs,
+		create.DatabaseID,
+		create.Name,
+		create.TableName,
+		create.Timing,
+		create.Event,
+		create.Definition,
+		create.Comment,
+	).Scan(
+		&dbTriggerRaw.ID,
+		&dbTriggerRaw.CreatorID,
+		&dbTriggerRaw.CreatedTs,
+		&dbTriggerRaw.UpdaterID,
+		&dbTriggerRaw.UpdatedTs,
+		&dbTriggerRaw.DatabaseID,
+		&dbTriggerRaw.Name,
+		&dbTriggerRaw.TableName,
+		&dbTriggerRaw.Timing,
+		&dbTriggerRaw.Event,
+		&dbTriggerRaw.Definition,
+		&dbTriggerRaw.Comment,
+	); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.FormatDBErrorEmptyRowWithQuery(query)
+		}
+		return nil, FormatError(err)
+	}
+	return &dbTriggerRaw, nil
+}
+
+func (*Store) findDBTriggerImpl(ctx context.Context, tx *Tx, find *api.DBTriggerFind) ([]*dbTriggerRaw, error) {
+	// Build WHERE clause.
+	where, args := []string{"1 = 1"}, []interface{}{}
+	if v := find.ID; v != nil {
+		where, args = append(where, fmt.Sprintf("id = $%d", len(args)+1)), append(args, *v)
+	}
+	if v := find.DatabaseID; v != nil {
+		where, args = append(where, fmt.Sprintf("database_id = $%d", len(args)+1)), append(args, *v)
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT
+			id,
+			creator_id,
+			created_ts,
+			updater_id,
+			updated_ts,
+			database_id,
+			name,
+			table_name,
+			timing,
+			event,
+			definition,
+			comment
+		FROM db_trigger
+		WHERE `+strings.Join(where, " AND ")+`
+		ORDER BY database_id, table_name, name ASC`,
+		args...,
+	)
+	if err != nil {
+		return nil, FormatError(err)
+	}
+	defer rows.Close()
+
+	// Iterate over result set and deserialize rows into dbTriggerRawList.
+	var dbTriggerRawList []*dbTriggerRaw
+	for rows.Next() {
+		var dbTriggerRaw dbTriggerRaw
+		if err := rows.Scan(
+			&dbTriggerRaw.ID,
+			&dbTriggerRaw.CreatorID,
+			&dbTriggerRaw.CreatedTs,
+			&dbTriggerRaw.UpdaterID,
+			&dbTriggerRaw.UpdatedTs,
+			&dbTriggerRaw.DatabaseID,
+			&dbTriggerRaw.Name,
+			&dbTriggerRaw.TableName,
+			&dbTriggerRaw.Timing,
+			&dbTriggerRaw.Event,
+			&dbTriggerRaw.Definition,
+			&dbTriggerRaw.Comment,
+		); err != nil {
+			return nil, FormatError(err)
+		}
+
+		dbTriggerRawList = append(dbTriggerRawList, &dbTriggerRaw)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, FormatError(err)
+	}
+
+	return dbTriggerRawList, nil
+}
+
+// deleteDBTriggerImpl permanently deletes DBTriggers from a database.
+func (*Store) deleteDBTriggerImpl(ctx context.Context, tx *Tx, delete *api.DBTriggerDelete) error {
+	// Remove row from database.
+	if _, err := tx.ExecContext(ctx, `DELETE FROM db_trigger WHERE id = $1`, delete.ID); err != nil {
+		return FormatError(err)
+	}
+	return nil
+}
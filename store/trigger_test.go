@@ -0,0 +1,65 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bytebase/bytebase/api"
+	"github.com/bytebase/bytebase/plugin/db"
+)
+
+func TestGenerateDBTriggerActions(t *testing.T) {
+	databaseID := 198
+	tests := []struct {
+		oldDBTriggerRawList []*dbTriggerRaw
+		triggerList         []db.Trigger
+		wantDeletes         []*api.DBTriggerDelete
+		wantCreates         []*api.DBTriggerCreate
+	}{
+		{
+			oldDBTriggerRawList: []*dbTriggerRaw{
+				{ID: 123, Name: "audit", TableName: "public.t1", Timing: "AFTER", Event: "INSERT", Definition: "def1"},
+				{ID: 124, Name: "audit", TableName: "public.t2", Timing: "AFTER", Event: "INSERT", Definition: "def2"},
+			},
+			triggerList: []db.Trigger{
+				{Name: "audit", TableName: "public.t1", Timing: "BEFORE", Event: "INSERT", Definition: "def1"},
+				{Name: "audit", TableName: "public.t2", Timing: "AFTER", Event: "INSERT", Definition: "def2"},
+				{Name: "audit", TableName: "public.t3", Timing: "AFTER", Event: "UPDATE", Definition: "def3"},
+			},
+			wantDeletes: []*api.DBTriggerDelete{
+				{ID: 123},
+			},
+			wantCreates: []*api.DBTriggerCreate{
+				{Name: "audit", TableName: "public.t1", Timing: "BEFORE", Event: "INSERT", Definition: "def1", CreatorID: api.SystemBotID, DatabaseID: databaseID},
+				{Name: "audit", TableName: "public.t3", Timing: "AFTER", Event: "UPDATE", Definition: "def3", CreatorID: api.SystemBotID, DatabaseID: databaseID},
+			},
+		},
+		{
+			oldDBTriggerRawList: []*dbTriggerRaw{
+				{ID: 123, Name: "audit", TableName: "public.t1", Timing: "AFTER", Event: "INSERT", Definition: "def1"},
+			},
+			triggerList: nil,
+			wantDeletes: []*api.DBTriggerDelete{
+				{ID: 123},
+			},
+			wantCreates: nil,
+		},
+		{
+			oldDBTriggerRawList: []*dbTriggerRaw{
+				{ID: 123, Name: "audit", TableName: "public.t1", Timing: "AFTER", Event: "INSERT", Definition: "def1"},
+			},
+			triggerList: []db.Trigger{
+				{Name: "audit", TableName: "public.t1", Timing: "AFTER", Event: "INSERT", Definition: "def1"},
+			},
+			wantDeletes: nil,
+			wantCreates: nil,
+		},
+	}
+
+	for _, test := range tests {
+		deletes, creates := generateDBTriggerActions(test.oldDBTriggerRawList, test.triggerList, databaseID)
+		require.Equal(t, test.wantDeletes, deletes)
+		require.Equal(t, test.wantCreates, creates)
+	}
+}
@@ -28,9 +28,10 @@ type viewRaw struct {
 	DatabaseID int
 
 	// Domain specific fields
-	Name       string
-	Definition string
-	Comment    string
+	Name         string
+	Definition   string
+	Comment      string
+	Materialized bool
 }
 
 // toView creates an instance of View based on the viewRaw.
@@ -49,9 +50,10 @@ func (raw *viewRaw) toView() *api.View {
 		DatabaseID: raw.DatabaseID,
 
 		// Domain specific fields
-		Name:       raw.Name,
-		Definition: raw.Definition,
-		Comment:    raw.Comment,
+		Name:         raw.Name,
+		Definition:   raw.Definition,
+		Comment:      raw.Comment,
+		Materialized: raw.Materialized,
 	}
 }
 
@@ -87,7 +89,12 @@ func (s *Store) SetViewList(ctx context.Context, schema *db.Schema, databaseID i
 		return FormatError(err)
 	}
 
-	deletes, creates := generateViewActions(oldViewRawList, schema.ViewList, databaseID)
+	// Materialized views are only stored in dev mode before the schema change is released.
+	var materializedViewList []db.View
+	if s.db.mode == common.ReleaseModeDev {
+		materializedViewList = schema.MaterializedViewList
+	}
+	deletes, creates := generateViewActions(oldViewRawList, schema.ViewList, materializedViewList, databaseID)
 	for _, d := range deletes {
 		if err := s.deleteViewImpl(ctx, tx, d); err != nil {
 			return err
@@ -107,7 +114,7 @@ func (s *Store) SetViewList(ctx context.Context, schema *db.Schema, databaseID i
 }
 
 // private functions.
-func generateViewActions(oldViewRawList []*viewRaw, viewList []db.View, databaseID int) ([]*api.ViewDelete, []*api.ViewCreate) {
+func generateViewActions(oldViewRawList []*viewRaw, viewList []db.View, materializedViewList []db.View, databaseID int) ([]*api.ViewDelete, []*api.ViewCreate) {
 	var viewCreateList []*api.ViewCreate
 	for _, view := range viewList {
 		viewCreateList = append(viewCreateList, &api.ViewCreate{
@@ -120,6 +127,18 @@ func generateViewActions(oldViewRawList []*viewRaw, viewList []db.View, database
 			Comment:    view.Comment,
 		})
 	}
+	for _, view := range materializedViewList {
+		viewCreateList = append(viewCreateList, &api.ViewCreate{
+			CreatorID:    api.SystemBotID,
+			CreatedTs:    view.CreatedTs,
+			UpdatedTs:    view.UpdatedTs,
+			DatabaseID:   databaseID,
+			Name:         view.Name,
+			Definition:   view.Definition,
+			Comment:      view.Comment,
+			Materialized: true,
+		})
+	}
 	oldViewMap := make(map[string]*viewRaw)
 	for _, v := range oldViewRawList {
 		oldViewMap[v.Name] = v
@@ -136,7 +155,7 @@ func generateViewActions(oldViewRawList []*viewRaw, viewList []db.View, database
 		newValue, ok := newViewMap[k]
 		if !ok {
 			deletes = append(deletes, &api.ViewDelete{ID: oldValue.ID})
-		} else if ok && (oldValue.Definition != newValue.Definition || oldValue.Comment != newValue.Comment) {
+		} else if ok && (oldValue.Definition != newValue.Definition || oldValue.Comment != newValue.Comment || oldValue.Materialized != newValue.Materialized) {
 			deletes = append(deletes, &api.ViewDelete{ID: oldValue.ID})
 			creates = append(creates, newValue)
 		}
@@ -191,7 +210,54 @@ func (s *Store) findViewRaw(ctx context.Context, find *api.ViewFind) ([]*viewRaw
 }
 
 // createViewImpl creates a new view.
-func (*Store) createViewImpl(ctx context.Context, tx *Tx, create *api.ViewCreate) (*viewRaw, error) {
+func (s *Store) createViewImpl(ctx context.Context, tx *Tx, create *api.ViewCreate) (*viewRaw, error) {
+	if s.db.mode == common.ReleaseModeDev {
+		query := `
+			INSERT INTO vw (
+				creator_id,
+				created_ts,
+				updater_id,
+				updated_ts,
+				database_id,
+				name,
+				definition,
+				comment,
+				materialized
+			)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			RETURNING id, creator_id, created_ts, updater_id, updated_ts, database_id, name, definition, comment, materialized
+		`
+		var viewRaw viewRaw
+		if err := tx.QueryRowContext(ctx, query,
+			create.CreatorID,
+			create.CreatedTs,
+			create.CreatorID,
+			create.UpdatedTs,
+			create.DatabaseID,
+			create.Name,
+			create.Definition,
+			create.Comment,
+			create.Materialized,
+		).Scan(
+			&viewRaw.ID,
+			&viewRaw.CreatorID,
+			&viewRaw.CreatedTs,
+			&viewRaw.UpdaterID,
+			&viewRaw.UpdatedTs,
+			&viewRaw.DatabaseID,
+			&viewRaw.Name,
+			&viewRaw.Definition,
+			&viewRaw.Comment,
+			&viewRaw.Materialized,
+		); err != nil {
+			if err == sql.ErrNoRows {
+				return nil, common.FormatDBErrorEmptyRowWithQuery(query)
+			}
+			return nil, FormatError(err)
+		}
+		return &viewRaw, nil
+	}
+
 	// Insert row into view.
 	query := `
 		INSERT INTO vw (
@@ -236,7 +302,7 @@ func (*Store) createViewImpl(ctx context.Context, tx *Tx, create *api.ViewCreate
 	return &viewRaw, nil
 }
 
-func (*Store) findViewImpl(ctx context.Context, tx *Tx, find *api.ViewFind) ([]*viewRaw, error) {
+func (s *Store) findViewImpl(ctx context.Context, tx *Tx, find *api.ViewFind) ([]*viewRaw, error) {
 	// Build WHERE clause.
 	where, args := []string{"1 = 1"}, []interface{}{}
 	if v := find.ID; v != nil {
@@ -249,6 +315,10 @@ func (*Store) findViewImpl(ctx context.Context, tx *Tx, find *api.ViewFind) ([]*
 		where, args = append(where, fmt.Sprintf("name = $%d", len(args)+1)), append(args, *v)
 	}
 
+	materializedColumn := "false"
+	if s.db.mode == common.ReleaseModeDev {
+		materializedColumn = "materialized"
+	}
 	rows, err := tx.QueryContext(ctx, `
 		SELECT
 			id,
@@ -259,7 +329,8 @@ func (*Store) findViewImpl(ctx context.Context, tx *Tx, find *api.ViewFind) ([]*
 			database_id,
 			name,
 			definition,
-			comment
+			comment,
+			`+materializedColumn+`
 		FROM vw
 		WHERE `+strings.Join(where, " AND ")+`
 		ORDER BY database_id, name ASC`,
@@ -284,6 +355,7 @@ func (*Store) findViewImpl(ctx context.Context, tx *Tx, find *api.ViewFind) ([]*
 			&viewRaw.Name,
 			&viewRaw.Definition,
 			&viewRaw.Comment,
+			&viewRaw.Materialized,
 		); err != nil {
 			return nil, FormatError(err)
 		}
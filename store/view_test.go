@@ -12,10 +12,11 @@ import (
 func TestGenerateViewActions(t *testing.T) {
 	databaseID := 198
 	tests := []struct {
-		oldViewRawList []*viewRaw
-		viewList       []db.View
-		wantDeletes    []*api.ViewDelete
-		wantCreates    []*api.ViewCreate
+		oldViewRawList       []*viewRaw
+		viewList             []db.View
+		materializedViewList []db.View
+		wantDeletes          []*api.ViewDelete
+		wantCreates          []*api.ViewCreate
 	}{
 		{
 			oldViewRawList: []*viewRaw{
@@ -69,10 +70,27 @@ func TestGenerateViewActions(t *testing.T) {
 			wantDeletes: nil,
 			wantCreates: nil,
 		},
+		{
+			oldViewRawList: []*viewRaw{
+				{ID: 123, Name: "view1", Definition: "def1", Comment: "comment1"},
+				{ID: 124, Name: "mview1", Definition: "def2", Comment: "comment2", Materialized: true},
+			},
+			viewList: nil,
+			materializedViewList: []db.View{
+				{Name: "view1", Definition: "def1", Comment: "comment1"},
+				{Name: "mview1", Definition: "def2", Comment: "comment2"},
+			},
+			wantDeletes: []*api.ViewDelete{
+				{ID: 123},
+			},
+			wantCreates: []*api.ViewCreate{
+				{Name: "view1", Definition: "def1", Comment: "comment1", Materialized: true, CreatorID: api.SystemBotID, DatabaseID: databaseID},
+			},
+		},
 	}
 
 	for _, test := range tests {
-		deletes, creates := generateViewActions(test.oldViewRawList, test.viewList, databaseID)
+		deletes, creates := generateViewActions(test.oldViewRawList, test.viewList, test.materializedViewList, databaseID)
 		require.Equal(t, test.wantDeletes, deletes)
 		require.Equal(t, test.wantCreates, creates)
 	}